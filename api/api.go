@@ -5,11 +5,15 @@ package api
 import (
 	"context"
 	"net/http"
+	"strings"
 
+	"github.com/diamondburned/arikawa/v3/api/breaker"
 	"github.com/diamondburned/arikawa/v3/api/rate"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/v3/utils/logging"
+	"github.com/diamondburned/arikawa/v3/utils/tracing"
 )
 
 var (
@@ -66,6 +70,20 @@ func (c *Client) WithLocale(language discord.Language) *Client {
 	}
 }
 
+// WithCircuitBreaker creates a copy of Client whose requests go through the
+// given circuit breaker. Once a circuit is open, requests fail fast with an
+// error wrapping a *breaker.OpenError.
+func (c *Client) WithCircuitBreaker(b *breaker.Breaker) *Client {
+	client := c.Client.Copy()
+	client.Client = b.Wrap(client.Client, c.Session.Limiter.Prefix)
+
+	return &Client{
+		Client:         client,
+		Session:        c.Session,
+		AcquireOptions: c.AcquireOptions,
+	}
+}
+
 // WithContext returns a shallow copy of Client with the given context. It's
 // used for method timeouts and such. This method is thread-safe.
 func (c *Client) WithContext(ctx context.Context) *Client {
@@ -82,14 +100,41 @@ func (c *Client) InjectRequest(r httpdriver.Request) error {
 		"User-Agent":    {c.Session.UserAgent},
 	})
 
+	tracing.SpanFromContext(r.GetContext()).SetAttributes(
+		tracing.String(tracing.KeyRoute, c.route(r)),
+	)
+
 	ctx := c.AcquireOptions.Context(r.GetContext())
 	return c.Session.Limiter.Acquire(ctx, r.GetPath())
 }
 
 func (c *Client) OnResponse(r httpdriver.Request, resp httpdriver.Response) error {
+	if resp != nil {
+		bucket := resp.GetHeader().Get("X-RateLimit-Bucket")
+		if bucket != "" {
+			tracing.SpanFromContext(r.GetContext()).SetAttributes(
+				tracing.String(tracing.KeyBucket, bucket),
+			)
+		}
+
+		if status := resp.GetStatus(); status == httputil.StatusTooManyRequests {
+			logging.Logger().Warn("rate limited by Discord",
+				logging.KeyRoute, c.route(r),
+				logging.KeyBucket, bucket,
+				"global", resp.GetHeader().Get("X-RateLimit-Global") == "true",
+			)
+		}
+	}
+
 	return c.Session.Limiter.Release(r.GetPath(), httpdriver.OptHeader(resp))
 }
 
+// route returns the route template of the request, which is safe to expose in
+// traces and logs.
+func (c *Client) route(r httpdriver.Request) string {
+	return rate.RouteTemplate(strings.TrimPrefix(r.GetPath(), c.Session.Limiter.Prefix))
+}
+
 // Session keeps a single session. This is typically wrapped around Client.
 type Session struct {
 	Limiter *rate.Limiter
@@ -1,9 +1,16 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
+	"strings"
 	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/v3/utils/logging"
+	"github.com/diamondburned/arikawa/v3/utils/tracing"
 )
 
 func TestContext(t *testing.T) {
@@ -18,3 +25,90 @@ func TestContext(t *testing.T) {
 		t.Fatal("Unexpected error:", err)
 	}
 }
+
+type routeTracer struct{ span *routeSpan }
+
+func (t *routeTracer) Start(ctx context.Context, _ string, _ ...tracing.Attribute) (context.Context, tracing.Span) {
+	return ctx, t.span
+}
+
+type routeSpan struct{ attrs map[string]interface{} }
+
+func (s *routeSpan) SetAttributes(attrs ...tracing.Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *routeSpan) End(error) {}
+
+func TestInjectRequestRouteRedacted(t *testing.T) {
+	const token = "aW50ZXJhY3Rpb246c2VjcmV0"
+
+	span := &routeSpan{attrs: map[string]interface{}{}}
+	tracing.SetTracer(&routeTracer{span})
+	defer tracing.SetTracer(nil)
+
+	ctx, _ := tracing.Start(context.Background(), "test")
+
+	client := NewClient("")
+	req := httpdriver.NewMockRequestWithContext(ctx, "POST",
+		EndpointInteractions+"123/"+token+"/callback", nil, nil)
+
+	if err := client.InjectRequest(req); err != nil {
+		t.Fatal("failed to inject request:", err)
+	}
+
+	route, _ := span.attrs[tracing.KeyRoute].(string)
+	if strings.Contains(route, token) {
+		t.Fatalf("token found in route %q", route)
+	}
+	if route != "/interactions/:id/:token/callback" {
+		t.Fatalf("unexpected route %q", route)
+	}
+}
+
+func TestRateLimitLogRouteRedacted(t *testing.T) {
+	const token = "aW50ZXJhY3Rpb246c2VjcmV0"
+
+	var buf bytes.Buffer
+	logging.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer logging.SetLogger(nil)
+
+	client := NewClient("")
+	req := httpdriver.NewMockRequest("PATCH",
+		EndpointWebhooks+"123/"+token+"/messages/@original", nil, nil)
+
+	client.OnResponse(req, httpdriver.NewMockResponse(429, nil, nil))
+
+	if strings.Contains(buf.String(), token) {
+		t.Fatalf("token found in log output: %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "route=/webhooks/:id/:token/messages/@original") {
+		t.Fatalf("log output is missing the route: %q", buf.String())
+	}
+}
+
+func TestMessageURL(t *testing.T) {
+	tests := []struct {
+		got, expect string
+	}{
+		{channelMessagesURL(1), EndpointChannels + "1/messages"},
+		{channelMessagesURL(1, "/bulk-delete"), EndpointChannels + "1/messages/bulk-delete"},
+		{messageURL(1, 2), EndpointChannels + "1/messages/2"},
+		{messageURL(1, 2, "/reactions/", "🥺", "/@me"), EndpointChannels + "1/messages/2/reactions/🥺/@me"},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expect {
+			t.Errorf("expected %q, got %q", test.expect, test.got)
+		}
+	}
+}
+
+func BenchmarkMessageURL(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = messageURL(486833611564253186, 540519319814275089, "/reactions/", "🥺", "/@me")
+	}
+}
@@ -0,0 +1,633 @@
+// Package apitest provides a fake Discord REST API server for testing bots
+// without talking to Discord.
+//
+// The Server implements a small set of common routes using an in-memory state
+// of guilds, channels and messages. It also sends realistic rate limit headers,
+// so that the api.Client's rate limiter behaves like it does against Discord.
+//
+// The implemented routes are:
+//
+//	GET    /users/@me
+//	GET    /guilds/{guild.id}
+//	GET    /guilds/{guild.id}/channels
+//	POST   /guilds/{guild.id}/channels
+//	GET    /channels/{channel.id}
+//	PATCH  /channels/{channel.id}
+//	DELETE /channels/{channel.id}
+//	GET    /channels/{channel.id}/messages
+//	POST   /channels/{channel.id}/messages
+//	POST   /channels/{channel.id}/messages/bulk-delete
+//	GET    /channels/{channel.id}/messages/{message.id}
+//	PATCH  /channels/{channel.id}/messages/{message.id}
+//	DELETE /channels/{channel.id}/messages/{message.id}
+//
+// Any other route responds with 404 Not Found.
+package apitest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/rate"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// RateLimit describes the rate limit that the Server applies to each bucket.
+type RateLimit struct {
+	// Limit is the number of requests allowed per Window. If it is 0, then
+	// requests are not rate limited.
+	Limit int
+	// Window is the duration after which a bucket resets.
+	Window time.Duration
+}
+
+// DefaultRateLimit is the default rate limit of a Server. It is generous, so
+// that tests are rarely slowed down by it.
+var DefaultRateLimit = RateLimit{
+	Limit:  50,
+	Window: time.Second,
+}
+
+// Server is a fake Discord REST API server. All its methods are thread-safe.
+type Server struct {
+	*httptest.Server
+
+	// RateLimit is the rate limit applied to each bucket. It must be set
+	// before any request is made.
+	RateLimit RateLimit
+
+	mutex    sync.Mutex
+	me       discord.User
+	guilds   map[discord.GuildID]*discord.Guild
+	channels map[discord.ChannelID]*discord.Channel
+	messages map[discord.ChannelID][]discord.Message
+	buckets  map[string]*bucket
+	nextID   discord.Snowflake
+}
+
+type bucket struct {
+	remaining int
+	reset     time.Time
+}
+
+// NewServer starts a new fake server. The caller should call Close once the
+// server is no longer needed.
+func NewServer() *Server {
+	s := &Server{
+		RateLimit: DefaultRateLimit,
+		guilds:    make(map[discord.GuildID]*discord.Guild),
+		channels:  make(map[discord.ChannelID]*discord.Channel),
+		messages:  make(map[discord.ChannelID][]discord.Message),
+		buckets:   make(map[string]*bucket),
+		nextID:    discord.NewSnowflake(time.Now()),
+	}
+
+	s.me = discord.User{
+		ID:       discord.UserID(s.newID()),
+		Username: "apitest",
+		Bot:      true,
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client creates a new API client with the given token that sends all its
+// requests to the server.
+func (s *Server) Client(token string) *api.Client {
+	target, _ := url.Parse(s.URL)
+
+	client := httputil.NewClient()
+	client.Client = httpdriver.WrapClient(http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &rewriteTransport{target: target, next: s.Server.Client().Transport},
+	})
+
+	return api.NewCustomClient(token, client)
+}
+
+// rewriteTransport rewrites the scheme and host of each request to the
+// target's, so that requests to Discord go to the fake server instead.
+type rewriteTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = t.target.Host
+	return t.next.RoundTrip(r)
+}
+
+// Me returns the user that the server authenticates every client as.
+func (s *Server) Me() discord.User {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.me
+}
+
+// AddGuild adds the guild into the server, along with its channels. If the
+// guild has no ID, then a new one is assigned. The guild with its ID is
+// returned.
+func (s *Server) AddGuild(guild discord.Guild, channels ...discord.Channel) discord.Guild {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !guild.ID.IsValid() {
+		guild.ID = discord.GuildID(s.newID())
+	}
+	s.guilds[guild.ID] = &guild
+
+	for _, ch := range channels {
+		ch.GuildID = guild.ID
+		s.addChannel(ch)
+	}
+
+	return guild
+}
+
+// AddChannel adds the channel into the server. If the channel has no ID, then
+// a new one is assigned. The channel with its ID is returned.
+func (s *Server) AddChannel(ch discord.Channel) discord.Channel {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.addChannel(ch)
+}
+
+func (s *Server) addChannel(ch discord.Channel) discord.Channel {
+	if !ch.ID.IsValid() {
+		ch.ID = discord.ChannelID(s.newID())
+	}
+	s.channels[ch.ID] = &ch
+	return ch
+}
+
+// AddMessage adds the message into its channel. If the message has no ID, then
+// a new one is assigned. A message can be given an old ID, such as one made
+// using discord.NewSnowflake, to test code that handles old messages. The
+// message with its ID is returned.
+func (s *Server) AddMessage(msg discord.Message) discord.Message {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !msg.ID.IsValid() {
+		msg.ID = discord.MessageID(s.newID())
+	}
+
+	s.insertMessage(msg)
+	return msg
+}
+
+// insertMessage inserts the message into its channel, keeping the messages
+// sorted by ID. The caller must hold the mutex.
+func (s *Server) insertMessage(msg discord.Message) {
+	msgs := s.messages[msg.ChannelID]
+	i := sort.Search(len(msgs), func(i int) bool { return msgs[i].ID >= msg.ID })
+
+	msgs = append(msgs, discord.Message{})
+	copy(msgs[i+1:], msgs[i:])
+	msgs[i] = msg
+
+	s.messages[msg.ChannelID] = msgs
+}
+
+// Messages returns the messages in the channel, sorted from oldest to newest.
+func (s *Server) Messages(channelID discord.ChannelID) []discord.Message {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]discord.Message(nil), s.messages[channelID]...)
+}
+
+func (s *Server) newID() discord.Snowflake {
+	s.nextID++
+	return s.nextID
+}
+
+// Error is the JSON error body that the server responds with.
+type Error struct {
+	Code    httputil.ErrorCode `json:"code"`
+	Message string             `json:"message"`
+
+	RetryAfter float64 `json:"retry_after,omitempty"`
+	Global     bool    `json:"global,omitempty"`
+}
+
+func writeError(w http.ResponseWriter, status int, code httputil.ErrorCode, msg string) {
+	writeJSON(w, status, Error{Code: code, Message: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.EncodeStream(w, v)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, api.Path)
+
+	if r.Header.Get("Authorization") == "" {
+		writeError(w, http.StatusUnauthorized, 0, "401: Unauthorized")
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.rateLimit(w, r.Method, path) {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case len(parts) == 2 && parts[0] == "users" && parts[1] == "@me":
+		s.route(w, r, map[string]http.HandlerFunc{
+			"GET": func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusOK, s.me)
+			},
+		})
+
+	case len(parts) >= 2 && parts[0] == "guilds":
+		id, err := discord.ParseSnowflake(parts[1])
+		if err != nil {
+			writeError(w, http.StatusNotFound, httputil.CodeUnknownGuild, "Unknown Guild")
+			return
+		}
+		s.serveGuild(w, r, discord.GuildID(id), parts[2:])
+
+	case len(parts) >= 2 && parts[0] == "channels":
+		id, err := discord.ParseSnowflake(parts[1])
+		if err != nil {
+			writeError(w, http.StatusNotFound, httputil.CodeUnknownChannel, "Unknown Channel")
+			return
+		}
+		s.serveChannel(w, r, discord.ChannelID(id), parts[2:])
+
+	default:
+		writeError(w, http.StatusNotFound, 0, "404: Not Found")
+	}
+}
+
+// route calls the handler for the request's method, or responds with 405
+// Method Not Allowed.
+func (s *Server) route(w http.ResponseWriter, r *http.Request, methods map[string]http.HandlerFunc) {
+	fn, ok := methods[r.Method]
+	if !ok {
+		writeError(w, http.StatusMethodNotAllowed, 0, "405: Method Not Allowed")
+		return
+	}
+	fn(w, r)
+}
+
+// rateLimit applies the rate limit of the request's bucket and writes the rate
+// limit headers. False is returned if the request is rate limited, in which
+// case a 429 response is already written.
+func (s *Server) rateLimit(w http.ResponseWriter, method, path string) bool {
+	if s.RateLimit.Limit < 1 {
+		return true
+	}
+
+	key := method + " " + rate.ParseBucketKey(path)
+	now := time.Now()
+
+	b, ok := s.buckets[key]
+	if !ok || !now.Before(b.reset) {
+		b = &bucket{
+			remaining: s.RateLimit.Limit,
+			reset:     now.Add(s.RateLimit.Window),
+		}
+		s.buckets[key] = b
+	}
+
+	resetAfter := b.reset.Sub(now).Seconds()
+
+	h := w.Header()
+	h.Set("X-RateLimit-Bucket", strconv.FormatUint(uint64(len(key))<<32|uint64(hash(key)), 16))
+	h.Set("X-RateLimit-Limit", strconv.Itoa(s.RateLimit.Limit))
+	h.Set("X-RateLimit-Reset", strconv.FormatFloat(
+		float64(b.reset.UnixNano())/float64(time.Second), 'f', 3, 64))
+	h.Set("X-RateLimit-Reset-After", strconv.FormatFloat(resetAfter, 'f', 3, 64))
+
+	if b.remaining == 0 {
+		retryAfter := int(b.reset.Sub(now)/time.Second) + 1
+
+		h.Set("X-RateLimit-Remaining", "0")
+		h.Set("X-RateLimit-Scope", "user")
+		h.Set("Retry-After", strconv.Itoa(retryAfter))
+
+		writeJSON(w, httputil.StatusTooManyRequests, Error{
+			Message:    "You are being rate limited.",
+			RetryAfter: resetAfter,
+		})
+		return false
+	}
+
+	b.remaining--
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(b.remaining))
+
+	return true
+}
+
+// hash is the FNV-1a hash of s.
+func hash(s string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h
+}
+
+func (s *Server) serveGuild(w http.ResponseWriter, r *http.Request, id discord.GuildID, parts []string) {
+	guild, ok := s.guilds[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, httputil.CodeUnknownGuild, "Unknown Guild")
+		return
+	}
+
+	switch {
+	case len(parts) == 0:
+		s.route(w, r, map[string]http.HandlerFunc{
+			"GET": func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusOK, guild)
+			},
+		})
+
+	case len(parts) == 1 && parts[0] == "channels":
+		s.route(w, r, map[string]http.HandlerFunc{
+			"GET": func(w http.ResponseWriter, r *http.Request) {
+				channels := []discord.Channel{}
+				for _, ch := range s.channels {
+					if ch.GuildID == id {
+						channels = append(channels, *ch)
+					}
+				}
+				sort.Slice(channels, func(i, j int) bool {
+					return channels[i].ID < channels[j].ID
+				})
+				writeJSON(w, http.StatusOK, channels)
+			},
+			"POST": func(w http.ResponseWriter, r *http.Request) {
+				var data api.CreateChannelData
+				if !decodeBody(w, r, &data) {
+					return
+				}
+
+				ch := s.addChannel(discord.Channel{
+					GuildID:  id,
+					Name:     data.Name,
+					Type:     data.Type,
+					Topic:    data.Topic,
+					ParentID: data.CategoryID,
+					NSFW:     data.NSFW,
+				})
+				writeJSON(w, http.StatusCreated, ch)
+			},
+		})
+
+	default:
+		writeError(w, http.StatusNotFound, 0, "404: Not Found")
+	}
+}
+
+func (s *Server) serveChannel(w http.ResponseWriter, r *http.Request, id discord.ChannelID, parts []string) {
+	ch, ok := s.channels[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, httputil.CodeUnknownChannel, "Unknown Channel")
+		return
+	}
+
+	switch {
+	case len(parts) == 0:
+		s.route(w, r, map[string]http.HandlerFunc{
+			"GET": func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusOK, ch)
+			},
+			"PATCH": func(w http.ResponseWriter, r *http.Request) {
+				var data api.ModifyChannelData
+				if !decodeBody(w, r, &data) {
+					return
+				}
+
+				if data.Name != "" {
+					ch.Name = data.Name
+				}
+				if data.Topic != nil {
+					ch.Topic = data.Topic.Val
+				}
+				writeJSON(w, http.StatusOK, ch)
+			},
+			"DELETE": func(w http.ResponseWriter, r *http.Request) {
+				delete(s.channels, id)
+				delete(s.messages, id)
+				writeJSON(w, http.StatusOK, ch)
+			},
+		})
+
+	case len(parts) == 1 && parts[0] == "messages":
+		s.route(w, r, map[string]http.HandlerFunc{
+			"GET":  func(w http.ResponseWriter, r *http.Request) { s.getMessages(w, r, ch) },
+			"POST": func(w http.ResponseWriter, r *http.Request) { s.sendMessage(w, r, ch) },
+		})
+
+	case len(parts) == 2 && parts[0] == "messages" && parts[1] == "bulk-delete":
+		s.route(w, r, map[string]http.HandlerFunc{
+			"POST": func(w http.ResponseWriter, r *http.Request) { s.bulkDeleteMessages(w, r, ch) },
+		})
+
+	case len(parts) == 2 && parts[0] == "messages":
+		msgID, err := discord.ParseSnowflake(parts[1])
+		if err != nil {
+			writeError(w, http.StatusNotFound, httputil.CodeUnknownMessage, "Unknown Message")
+			return
+		}
+		s.serveMessage(w, r, ch, discord.MessageID(msgID))
+
+	default:
+		writeError(w, http.StatusNotFound, 0, "404: Not Found")
+	}
+}
+
+func (s *Server) getMessages(w http.ResponseWriter, r *http.Request, ch *discord.Channel) {
+	query := r.URL.Query()
+
+	limit := 50
+	if v := query.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 || l > 100 {
+			writeError(w, http.StatusBadRequest, httputil.CodeInvalidFormBody, "Invalid Form Body")
+			return
+		}
+		limit = l
+	}
+
+	before, _ := discord.ParseSnowflake(query.Get("before"))
+	after, _ := discord.ParseSnowflake(query.Get("after"))
+
+	all := s.messages[ch.ID]
+	msgs := []discord.Message{}
+
+	// Messages are returned from newest to oldest.
+	for i := len(all) - 1; i >= 0 && len(msgs) < limit; i-- {
+		id := discord.Snowflake(all[i].ID)
+		if before.IsValid() && id >= before {
+			continue
+		}
+		if after.IsValid() && id <= after {
+			continue
+		}
+		msgs = append(msgs, all[i])
+	}
+
+	writeJSON(w, http.StatusOK, msgs)
+}
+
+func (s *Server) sendMessage(w http.ResponseWriter, r *http.Request, ch *discord.Channel) {
+	var data api.SendMessageData
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		if err := json.Unmarshal([]byte(r.FormValue("payload_json")), &data); err != nil {
+			writeError(w, http.StatusBadRequest, httputil.CodeInvalidFormBody, "Invalid Form Body")
+			return
+		}
+	} else if !decodeBody(w, r, &data) {
+		return
+	}
+
+	if data.Content == "" && len(data.Embeds) == 0 {
+		writeError(w, http.StatusBadRequest, httputil.CodeCannotSendEmptyMessage, "Cannot send an empty message")
+		return
+	}
+
+	msg := discord.Message{
+		ID:        discord.MessageID(s.newID()),
+		ChannelID: ch.ID,
+		GuildID:   ch.GuildID,
+		Type:      discord.DefaultMessage,
+		Content:   data.Content,
+		Embeds:    data.Embeds,
+		Author:    s.me,
+		Timestamp: discord.NewTimestamp(time.Now()),
+		Nonce:     data.Nonce,
+		TTS:       data.TTS,
+		Flags:     data.Flags,
+	}
+	if data.Reference != nil {
+		msg.Type = discord.InlinedReplyMessage
+		msg.Reference = data.Reference
+	}
+
+	s.insertMessage(msg)
+	ch.LastMessageID = msg.ID
+
+	writeJSON(w, http.StatusOK, msg)
+}
+
+func (s *Server) serveMessage(
+	w http.ResponseWriter, r *http.Request, ch *discord.Channel, id discord.MessageID) {
+
+	msgs := s.messages[ch.ID]
+
+	i := sort.Search(len(msgs), func(i int) bool { return msgs[i].ID >= id })
+	if i == len(msgs) || msgs[i].ID != id {
+		writeError(w, http.StatusNotFound, httputil.CodeUnknownMessage, "Unknown Message")
+		return
+	}
+
+	msg := &msgs[i]
+
+	s.route(w, r, map[string]http.HandlerFunc{
+		"GET": func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, msg)
+		},
+		"PATCH": func(w http.ResponseWriter, r *http.Request) {
+			if msg.Author.ID != s.me.ID {
+				writeError(w, http.StatusForbidden,
+					httputil.CodeCannotEditOthersMessage, "Cannot edit a message authored by another user")
+				return
+			}
+
+			var data api.EditMessageData
+			if !decodeBody(w, r, &data) {
+				return
+			}
+
+			if data.Content != nil {
+				msg.Content = data.Content.Val
+			}
+			if data.Embeds != nil {
+				msg.Embeds = *data.Embeds
+			}
+			if data.Flags != nil {
+				msg.Flags = *data.Flags
+			}
+			msg.EditedTimestamp = discord.NewTimestamp(time.Now())
+
+			writeJSON(w, http.StatusOK, msg)
+		},
+		"DELETE": func(w http.ResponseWriter, r *http.Request) {
+			s.messages[ch.ID] = append(msgs[:i:i], msgs[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+}
+
+func (s *Server) bulkDeleteMessages(w http.ResponseWriter, r *http.Request, ch *discord.Channel) {
+	var data struct {
+		Messages []discord.MessageID `json:"messages"`
+	}
+
+	if !decodeBody(w, r, &data) {
+		return
+	}
+
+	if len(data.Messages) < 2 || len(data.Messages) > 100 {
+		writeError(w, http.StatusBadRequest, httputil.CodeInvalidFormBody, "Invalid Form Body")
+		return
+	}
+
+	cutoff := time.Now().Add(-api.MaxBulkDeleteAge)
+	remove := make(map[discord.MessageID]bool, len(data.Messages))
+
+	for _, id := range data.Messages {
+		if id.Time().Before(cutoff) {
+			writeError(w, http.StatusBadRequest, httputil.CodeMessageTooOldToDelete,
+				"You can only bulk delete messages that are under 14 days old.")
+			return
+		}
+		remove[id] = true
+	}
+
+	msgs := s.messages[ch.ID][:0]
+	for _, msg := range s.messages[ch.ID] {
+		if !remove[msg.ID] {
+			msgs = append(msgs, msg)
+		}
+	}
+	s.messages[ch.ID] = msgs
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.DecodeStream(r.Body, v); err != nil {
+		writeError(w, http.StatusBadRequest, httputil.CodeInvalidFormBody, "Invalid Form Body")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+package apitest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestServer(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	guild := s.AddGuild(discord.Guild{Name: "arikawa"}, discord.Channel{
+		Name: "general",
+		Type: discord.GuildText,
+	})
+
+	c := s.Client("Bot token")
+
+	me, err := c.Me()
+	if err != nil {
+		t.Fatal("failed to get me:", err)
+	}
+	if me.ID != s.Me().ID {
+		t.Fatalf("unexpected me: %+v", me)
+	}
+
+	channels, err := c.Channels(guild.ID)
+	if err != nil {
+		t.Fatal("failed to get channels:", err)
+	}
+	if len(channels) != 1 || channels[0].Name != "general" {
+		t.Fatalf("unexpected channels: %+v", channels)
+	}
+
+	chID := channels[0].ID
+
+	for _, content := range []string{"hime", "arikawa"} {
+		if _, err := c.SendMessage(chID, content); err != nil {
+			t.Fatal("failed to send message:", err)
+		}
+	}
+
+	msgs, err := c.Messages(chID, 10)
+	if err != nil {
+		t.Fatal("failed to get messages:", err)
+	}
+	if len(msgs) != 2 || msgs[0].Content != "arikawa" || msgs[1].Content != "hime" {
+		t.Fatalf("unexpected messages: %+v", msgs)
+	}
+
+	edited, err := c.EditMessageComplex(chID, msgs[0].ID, api.EditMessageData{
+		Content: option.NewNullableString("hime arikawa"),
+	})
+	if err != nil {
+		t.Fatal("failed to edit message:", err)
+	}
+	if edited.Content != "hime arikawa" || !edited.EditedTimestamp.IsValid() {
+		t.Fatalf("unexpected edited message: %+v", edited)
+	}
+
+	if err := c.DeleteMessage(chID, msgs[1].ID, ""); err != nil {
+		t.Fatal("failed to delete message:", err)
+	}
+
+	if msgs := s.Messages(chID); len(msgs) != 1 || msgs[0].Content != "hime arikawa" {
+		t.Fatalf("unexpected server messages: %+v", msgs)
+	}
+
+	_, err = c.Channel(discord.ChannelID(1))
+	if !errors.Is(err, httputil.CodeUnknownChannel) {
+		t.Fatal("expected unknown channel error, got:", err)
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	s := NewServer()
+	s.RateLimit = RateLimit{Limit: 2, Window: 500 * time.Millisecond}
+	defer s.Close()
+
+	ch := s.AddChannel(discord.Channel{Type: discord.DirectMessage})
+	c := s.Client("Bot token")
+
+	start := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.SendMessage(ch.ID, "hime arikawa"); err != nil {
+			t.Fatal("failed to send message:", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Fatal("client wasn't rate limited, took", elapsed)
+	}
+
+	if msgs := s.Messages(ch.ID); len(msgs) != 3 {
+		t.Fatal("unexpected number of messages:", len(msgs))
+	}
+}
+
+func TestServerMessageOrder(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	ch := s.AddChannel(discord.Channel{Name: "general", Type: discord.GuildText})
+	c := s.Client("Bot token")
+
+	// A message with an ID from the future is newer than the messages that
+	// the server sends.
+	future := s.AddMessage(discord.Message{
+		ID:        discord.MessageID(discord.NewSnowflake(time.Now().Add(time.Hour))),
+		ChannelID: ch.ID,
+		Content:   "future",
+	})
+
+	sent, err := c.SendMessage(ch.ID, "sent")
+	if err != nil {
+		t.Fatal("failed to send message:", err)
+	}
+
+	msgs := s.Messages(ch.ID)
+	if len(msgs) != 2 || msgs[0].ID != sent.ID || msgs[1].ID != future.ID {
+		t.Fatalf("messages are not sorted by ID: %+v", msgs)
+	}
+}
+
+func TestServerDeleteMessages(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	ch := s.AddChannel(discord.Channel{Name: "general", Type: discord.GuildText})
+	c := s.Client("Bot token")
+
+	old := time.Now().Add(-20 * 24 * time.Hour)
+
+	var ids []discord.MessageID
+	for i := 0; i < 3; i++ {
+		msg := s.AddMessage(discord.Message{
+			ID:        discord.MessageID(discord.NewSnowflake(old.Add(time.Duration(i) * time.Second))),
+			ChannelID: ch.ID,
+		})
+		ids = append(ids, msg.ID)
+	}
+	for i := 0; i < 150; i++ {
+		msg := s.AddMessage(discord.Message{ChannelID: ch.ID})
+		ids = append(ids, msg.ID)
+	}
+
+	keep := s.AddMessage(discord.Message{ChannelID: ch.ID, Content: "keep"})
+
+	// Duplicates are ignored, while the old unknown message fails.
+	unknown := discord.MessageID(discord.NewSnowflake(old.Add(-time.Hour)))
+	ids = append(ids, ids[0], ids[10], unknown)
+
+	err := c.DeleteMessages(ch.ID, ids, "")
+
+	var deleteErr *api.DeleteMessagesError
+	if !errors.As(err, &deleteErr) {
+		t.Fatal("expected DeleteMessagesError, got:", err)
+	}
+	if len(deleteErr.Failed) != 1 || !errors.Is(deleteErr.Failed[unknown], httputil.CodeUnknownMessage) {
+		t.Fatalf("unexpected failures: %v", deleteErr.Failed)
+	}
+	if !errors.Is(err, httputil.CodeUnknownMessage) {
+		t.Fatalf("failure isn't unwrapped: %v", err)
+	}
+
+	if msgs := s.Messages(ch.ID); len(msgs) != 1 || msgs[0].ID != keep.ID {
+		t.Fatalf("unexpected server messages: %+v", msgs)
+	}
+}
+
+func TestServerDeleteMessagesCancelled(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	ch := s.AddChannel(discord.Channel{Name: "general", Type: discord.GuildText})
+
+	var ids []discord.MessageID
+	for i := 0; i < 3; i++ {
+		ids = append(ids, s.AddMessage(discord.Message{ChannelID: ch.ID}).ID)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := s.Client("Bot token").WithContext(ctx).DeleteMessages(ch.ID, ids, "")
+
+	var deleteErr *api.DeleteMessagesError
+	if !errors.As(err, &deleteErr) || len(deleteErr.Failed) != len(ids) {
+		t.Fatal("expected all messages to fail, got:", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatal("expected context.Canceled, got:", err)
+	}
+
+	if msgs := s.Messages(ch.ID); len(msgs) != len(ids) {
+		t.Fatalf("messages were deleted after the context was cancelled: %+v", msgs)
+	}
+}
+
+func TestDeleteMessagesErrorOrder(t *testing.T) {
+	err := &api.DeleteMessagesError{
+		Failed: map[discord.MessageID]error{
+			3: errors.New("c"),
+			1: errors.New("a"),
+			2: errors.New("b"),
+		},
+	}
+
+	// The error is the same however the map is iterated.
+	for i := 0; i < 10; i++ {
+		if s := err.Error(); s != "failed to delete 3 messages, including: a" {
+			t.Fatal("unexpected error:", s)
+		}
+	}
+
+	var got string
+	for _, e := range err.Unwrap() {
+		got += e.Error()
+	}
+	if got != "abc" {
+		t.Fatalf("unexpected unwrapped errors %q", got)
+	}
+}
@@ -1,22 +1,22 @@
 package api
 
 import (
-	"encoding/json"
+	"errors"
+	"net/http"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
 var EndpointApplications = Endpoint + "applications/"
 
 // CurrentApplication returns the current bot account's Discord application. It
-// can be used to get the application ID.
+// can be used to get the application ID. It requires a bot token; OAuth2
+// tokens should use the current authorization instead.
 func (c *Client) CurrentApplication() (*discord.Application, error) {
 	var app *discord.Application
-	return app, c.RequestJSON(
-		&app, "GET",
-		Endpoint+"/oauth2/applications/@me",
-	)
+	return app, c.RequestJSON(&app, "GET", EndpointApplications+"@me")
 }
 
 // https://discord.com/developers/docs/interactions/application-commands#create-global-application-command
@@ -30,6 +30,16 @@ type CreateCommandData struct {
 	NoDMPermission           bool                   `json:"-"`
 	NoDefaultPermission      bool                   `json:"-"`
 	Type                     discord.CommandType    `json:"type,omitempty"`
+
+	// IntegrationTypes are the installation contexts where the command is
+	// available, only for globally-scoped commands.
+	IntegrationTypes []discord.ApplicationIntegrationType `json:"integration_types,omitempty"`
+	// Contexts are the interaction contexts where the command can be used,
+	// only for globally-scoped commands.
+	Contexts []discord.InteractionContextType `json:"contexts,omitempty"`
+	// Handler determines whether the interaction is handled by the application
+	// or by Discord. It is only used for EntryPointCommands.
+	Handler discord.EntryPointCommandHandlerType `json:"handler,omitempty"`
 }
 
 func (c CreateCommandData) MarshalJSON() ([]byte, error) {
@@ -122,12 +132,50 @@ func (c *Client) DeleteCommand(appID discord.AppID, commandID discord.CommandID)
 	)
 }
 
+// ErrNoEntryPointCommand is returned by EntryPointCommand and
+// EditEntryPointCommand if the application has no entry point command, which is
+// the case if its Activities aren't enabled.
+var ErrNoEntryPointCommand = errors.New("application has no entry point command")
+
+// EntryPointCommand returns the application's global command of type
+// discord.EntryPointCommand.
+func (c *Client) EntryPointCommand(appID discord.AppID) (*discord.Command, error) {
+	cmds, err := c.Commands(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, cmd := range cmds {
+		if cmd.Type == discord.EntryPointCommand {
+			return &cmds[i], nil
+		}
+	}
+
+	return nil, ErrNoEntryPointCommand
+}
+
+// EditEntryPointCommand edits the application's entry point command, such as to
+// change its Handler, name or description.
+func (c *Client) EditEntryPointCommand(
+	appID discord.AppID, data CreateCommandData) (*discord.Command, error) {
+
+	cmd, err := c.EntryPointCommand(appID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.EditCommand(appID, cmd.ID, data)
+}
+
 // BulkOverwriteCommands takes a slice of application commands, overwriting
 // existing commands that are registered globally for this application. Updates
 // will be available in all guilds after 1 hour.
 //
 // Commands that do not already exist will count toward daily application
 // command create limits.
+//
+// If the application has Activities enabled, its entry point command must be
+// included, otherwise Discord rejects the request.
 func (c *Client) BulkOverwriteCommands(
 	appID discord.AppID, commands []CreateCommandData) ([]discord.Command, error) {
 
@@ -242,16 +290,52 @@ type editCommandPermissionsData struct {
 }
 
 // EditCommandPermissions edits command permissions for a specific command for
-// the application in a guild. Up to 10 permission overwrites can be added for
-// a command.
+// the application in a guild. Up to discord.MaxCommandPermissions permission
+// overwrites can be added for a command. Use the application ID as the command
+// ID to edit the permissions of all commands of the application.
 //
 // Existing permissions for the command will be overwritten in that guild.
 // Deleting or renaming a command will permanently delete all permissions for
 // that command.
+//
+// Discord only accepts this request with an OAuth2 bearer token, so the client
+// must have been created with one. Use EditCommandPermissionsWithBearer to use
+// a bearer token with a bot client.
 func (c *Client) EditCommandPermissions(
 	appID discord.AppID, guildID discord.GuildID, commandID discord.CommandID,
 	permissions []discord.CommandPermissions) (*discord.GuildCommandPermissions, error) {
 
+	return c.editCommandPermissions(appID, guildID, commandID, permissions)
+}
+
+// EditCommandPermissionsWithBearer is like EditCommandPermissions, except the
+// request is authorized using the given OAuth2 access token instead of the
+// client's token. The token must have the
+// applications.commands.permissions.update scope, and belong to a user that
+// can manage the guild's commands.
+func (c *Client) EditCommandPermissionsWithBearer(
+	appID discord.AppID, guildID discord.GuildID, commandID discord.CommandID,
+	accessToken string,
+	permissions []discord.CommandPermissions) (*discord.GuildCommandPermissions, error) {
+
+	return c.editCommandPermissions(appID, guildID, commandID, permissions,
+		httputil.WithHeaders(http.Header{"Authorization": {"Bearer " + accessToken}}),
+	)
+}
+
+func (c *Client) editCommandPermissions(
+	appID discord.AppID, guildID discord.GuildID, commandID discord.CommandID,
+	permissions []discord.CommandPermissions,
+	opts ...httputil.RequestOption) (*discord.GuildCommandPermissions, error) {
+
+	if len(permissions) > discord.MaxCommandPermissions {
+		return nil, &discord.OverboundError{
+			Count: len(permissions),
+			Max:   discord.MaxCommandPermissions,
+			Thing: "command permissions",
+		}
+	}
+
 	data := editCommandPermissionsData{Permissions: permissions}
 
 	var perms *discord.GuildCommandPermissions
@@ -259,7 +343,7 @@ func (c *Client) EditCommandPermissions(
 		&perms, "PUT",
 		EndpointApplications+appID.String()+"/guilds/"+guildID.String()+
 			"/commands/"+commandID.String()+"/permissions",
-		httputil.WithJSONBody(data),
+		append([]httputil.RequestOption{httputil.WithJSONBody(data)}, opts...)...,
 	)
 }
 
@@ -286,3 +370,41 @@ func (c *Client) BatchEditCommandPermissions(
 		httputil.WithJSONBody(data),
 	)
 }
+
+// RoleConnectionMetadata returns the role connection metadata records of the
+// application.
+func (c *Client) RoleConnectionMetadata(
+	appID discord.AppID) ([]discord.ApplicationRoleConnectionMetadata, error) {
+
+	var records []discord.ApplicationRoleConnectionMetadata
+	return records, c.RequestJSON(
+		&records, "GET",
+		EndpointApplications+appID.String()+"/role-connections/metadata",
+	)
+}
+
+// UpdateRoleConnectionMetadata replaces the role connection metadata records
+// of the application. An application can have up to 5 records.
+func (c *Client) UpdateRoleConnectionMetadata(
+	appID discord.AppID,
+	records []discord.ApplicationRoleConnectionMetadata) ([]discord.ApplicationRoleConnectionMetadata, error) {
+
+	if len(records) > discord.MaxRoleConnectionMetadata {
+		return nil, &discord.OverboundError{
+			Count: len(records),
+			Max:   discord.MaxRoleConnectionMetadata,
+			Thing: "role connection metadata",
+		}
+	}
+
+	if records == nil {
+		records = []discord.ApplicationRoleConnectionMetadata{}
+	}
+
+	var updated []discord.ApplicationRoleConnectionMetadata
+	return updated, c.RequestJSON(
+		&updated, "PUT",
+		EndpointApplications+appID.String()+"/role-connections/metadata",
+		httputil.WithJSONBody(records),
+	)
+}
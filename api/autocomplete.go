@@ -0,0 +1,148 @@
+package api
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// MaxAutocompleteChoices is the maximum number of choices that can be sent
+// back as autocomplete results, as imposed by Discord.
+const MaxAutocompleteChoices = 25
+
+// Score tiers, so that a better kind of match always beats a worse one. The
+// scores of each tier are within [tier, tier+scoreTier), so tiers never overlap.
+const (
+	scoreTier      = 2000 // width of each tier
+	scoreFuzzy     = 0 * scoreTier
+	scoreSubstring = 1 * scoreTier
+	scorePrefix    = 2 * scoreTier
+	scoreExact     = 3 * scoreTier
+)
+
+// FuzzyScore scores how well the query matches the candidate, ignoring case.
+// It returns 0 if the candidate doesn't match, and a higher score for a better
+// match. Exact matches score highest, followed by prefix matches, substring
+// matches, then matches where the query's characters appear in order, such as
+// "bnr" for "Banner". Shorter candidates and earlier matches score higher
+// within each kind.
+//
+// An empty query matches every candidate with the same score.
+func FuzzyScore(query, candidate string) int {
+	q := strings.ToLower(query)
+	c := strings.ToLower(candidate)
+
+	if q == "" {
+		return 1
+	}
+
+	switch i := strings.Index(c, q); {
+	case c == q:
+		return scoreExact
+	case i == 0:
+		return tierScore(scorePrefix, len(c)-len(q))
+	case i > 0:
+		return tierScore(scoreSubstring, 10*i+len(c)-len(q))
+	}
+
+	return subsequenceScore([]rune(q), []rune(c))
+}
+
+// subsequenceScore scores q as a subsequence of c, or returns 0 if it isn't
+// one. Consecutive characters and characters at the start of words are
+// rewarded, while characters skipped in between are penalized.
+func subsequenceScore(q, c []rune) int {
+	score := scoreFuzzy + scoreTier/2
+	qi := 0
+	last := -1
+
+	for ci, r := range c {
+		if qi == len(q) {
+			break
+		}
+		if r != q[qi] {
+			continue
+		}
+
+		if last >= 0 && ci == last+1 {
+			score += 10
+		}
+		if ci == 0 || !unicode.IsLetter(c[ci-1]) && !unicode.IsDigit(c[ci-1]) {
+			score += 20
+		}
+		if last >= 0 {
+			score -= ci - last - 1
+		}
+
+		last = ci
+		qi++
+	}
+
+	if qi < len(q) {
+		return 0
+	}
+
+	// Stay within the fuzzy tier.
+	switch {
+	case score >= scoreFuzzy+scoreTier:
+		score = scoreFuzzy + scoreTier - 1
+	case score < scoreFuzzy+1:
+		score = scoreFuzzy + 1
+	}
+
+	return score
+}
+
+// tierScore returns the best score of the tier minus the penalty, staying
+// within the tier.
+func tierScore(tier, penalty int) int {
+	if penalty >= scoreTier {
+		penalty = scoreTier - 1
+	}
+	return tier + scoreTier - 1 - penalty
+}
+
+// FuzzyMatchChoices returns up to MaxAutocompleteChoices choices whose names
+// match the query according to FuzzyScore, with the best matches first.
+// Choices that score the same keep their original order.
+func FuzzyMatchChoices(query string, choices []discord.StringChoice) AutocompleteStringChoices {
+	type scored struct {
+		choice discord.StringChoice
+		score  int
+	}
+
+	matches := make([]scored, 0, len(choices))
+	for _, choice := range choices {
+		if score := FuzzyScore(query, choice.Name); score > 0 {
+			matches = append(matches, scored{choice, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	if len(matches) > MaxAutocompleteChoices {
+		matches = matches[:MaxAutocompleteChoices]
+	}
+
+	results := make(AutocompleteStringChoices, len(matches))
+	for i, match := range matches {
+		results[i] = match.choice
+	}
+
+	return results
+}
+
+// FuzzyMatchStrings is like FuzzyMatchChoices, except each candidate is used
+// as both the name and the value of its choice.
+func FuzzyMatchStrings(query string, candidates []string) AutocompleteStringChoices {
+	choices := make([]discord.StringChoice, len(candidates))
+	for i, candidate := range candidates {
+		choices[i] = discord.StringChoice{Name: candidate, Value: candidate}
+	}
+
+	return FuzzyMatchChoices(query, choices)
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFuzzyMatchStrings(t *testing.T) {
+	candidates := []string{
+		"Unrelated",
+		"Big Banner",
+		"banner",
+		"Banners",
+		"Blue Neon Rain",
+	}
+
+	got := FuzzyMatchStrings("banner", candidates)
+	want := []string{"banner", "Banners", "Big Banner"}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d choices, want %d: %v", len(got), len(want), got)
+	}
+
+	for i, choice := range got {
+		if choice.Name != want[i] || choice.Value != want[i] {
+			t.Errorf("choice %d is %q, want %q", i, choice.Name, want[i])
+		}
+	}
+
+	fuzzy := FuzzyMatchStrings("bnr", candidates)
+	if len(fuzzy) != 4 || fuzzy[len(fuzzy)-1].Name == "Unrelated" {
+		t.Errorf("unexpected fuzzy matches: %v", fuzzy)
+	}
+}
+
+func TestFuzzyMatchStringsLimit(t *testing.T) {
+	candidates := make([]string, 100)
+	for i := range candidates {
+		candidates[i] = "item " + strconv.Itoa(i)
+	}
+
+	got := FuzzyMatchStrings("", candidates)
+	if len(got) != MaxAutocompleteChoices {
+		t.Fatalf("got %d choices, want %d", len(got), MaxAutocompleteChoices)
+	}
+
+	if got[0].Name != "item 0" {
+		t.Errorf("empty query does not keep the order, first is %q", got[0].Name)
+	}
+}
+
+func TestFuzzyScoreTiers(t *testing.T) {
+	const query = "aaaa"
+
+	// The worst match of each kind must still beat the best match of the
+	// next kind.
+	scores := []struct {
+		name  string
+		score int
+	}{
+		{"exact", FuzzyScore(query, "AAAA")},
+		{"prefix", FuzzyScore(query, query+strings.Repeat("x", 5000))},
+		{"substring", FuzzyScore(query, strings.Repeat("x", 5000)+query)},
+		{"fuzzy", FuzzyScore(strings.Repeat("a", 500), strings.Repeat("a ", 500))},
+	}
+
+	for i, s := range scores {
+		if s.score <= 0 {
+			t.Fatalf("%s match scored %d", s.name, s.score)
+		}
+		if i > 0 && s.score >= scores[i-1].score {
+			t.Errorf("%s match scored %d, not below %s match's %d",
+				s.name, s.score, scores[i-1].name, scores[i-1].score)
+		}
+	}
+}
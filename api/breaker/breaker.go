@@ -0,0 +1,283 @@
+// Package breaker provides a circuit breaker for REST requests. It protects
+// bots during Discord outages by failing requests fast once a route, or the
+// API as a whole, keeps failing with 5xx statuses or timeouts.
+//
+// A circuit starts closed, letting every request through. Once it sees
+// Threshold consecutive failures, it opens, and requests fail immediately with
+// an *OpenError for the Cooldown duration. After that, a single probe request
+// is let through: if it succeeds, then the circuit closes again; otherwise, it
+// stays open for another Cooldown.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api/rate"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+)
+
+// ErrOpen matches any *OpenError when used with errors.Is.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// OpenError is returned for requests that are failed fast because their
+// circuit is open.
+type OpenError struct {
+	// Route is the route template of the request.
+	Route string
+	// Global is true if the global circuit is open, rather than the route's.
+	Global bool
+	// RetryAt is the time at which the circuit lets a probe request through.
+	RetryAt time.Time
+}
+
+// Error implements error.
+func (err *OpenError) Error() string {
+	if err.Global {
+		return fmt.Sprintf("circuit breaker is open globally until %s", err.RetryAt.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("circuit breaker is open for %s until %s", err.Route, err.RetryAt.Format(time.RFC3339))
+}
+
+// Is returns true if target is ErrOpen.
+func (err *OpenError) Is(target error) bool {
+	return target == ErrOpen
+}
+
+// Breaker is a circuit breaker keeping one circuit per route and one global
+// circuit. Routes are route templates (see rate.RouteTemplate), so all channels
+// share the circuit of a route such as "/channels/:id/messages". Its fields must
+// not be changed once it's used.
+type Breaker struct {
+	// Threshold is the number of consecutive failures of a route after which
+	// its circuit opens.
+	Threshold int
+	// GlobalThreshold is the number of consecutive failures of all routes
+	// after which the global circuit opens. If it's 0, then there's no global
+	// circuit.
+	GlobalThreshold int
+	// Cooldown is the duration for which an open circuit fails requests before
+	// letting a probe request through.
+	Cooldown time.Duration
+
+	global circuit
+	// routes only holds the circuits of routes that are currently failing;
+	// circuits are removed once a request succeeds.
+	routes sync.Map // string -> *circuit
+
+	now func() time.Time
+}
+
+// New creates a new Breaker with the default thresholds of 5 failures per route
+// and 20 failures globally, and a cooldown of 30 seconds.
+func New() *Breaker {
+	return &Breaker{
+		Threshold:       5,
+		GlobalThreshold: 20,
+		Cooldown:        30 * time.Second,
+	}
+}
+
+func (b *Breaker) time() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
+
+func (b *Breaker) route(route string) *circuit {
+	if c, ok := b.routes.Load(route); ok {
+		return c.(*circuit)
+	}
+
+	c, _ := b.routes.LoadOrStore(route, &circuit{})
+	return c.(*circuit)
+}
+
+// Permit is a request that Allow let through. Its result must be given to
+// Record.
+type Permit struct {
+	route string
+	// probe and globalProbe are true if the request is the probe of the
+	// route's circuit or of the global circuit.
+	probe       bool
+	globalProbe bool
+}
+
+// Allow returns an *OpenError if a request to the given route must fail fast.
+// Otherwise, the caller must call Record with the returned Permit and the
+// result of the request.
+func (b *Breaker) Allow(route string) (Permit, error) {
+	now := b.time()
+	permit := Permit{route: route}
+
+	if b.GlobalThreshold > 0 {
+		retryAt, probe, ok := b.global.allow(now, b.Cooldown)
+		if !ok {
+			return Permit{}, &OpenError{Route: route, Global: true, RetryAt: retryAt}
+		}
+		permit.globalProbe = probe
+	}
+
+	retryAt, probe, ok := b.route(route).allow(now, b.Cooldown)
+	if !ok {
+		if permit.globalProbe {
+			// Give back the global probe, since this request won't be made.
+			b.global.cancelProbe()
+		}
+		return Permit{}, &OpenError{Route: route, RetryAt: retryAt}
+	}
+	permit.probe = probe
+
+	return permit, nil
+}
+
+// Result is the result of a request, as counted by the breaker.
+type Result uint8
+
+const (
+	// Success is a request that Discord responded to without a 5xx status.
+	Success Result = iota
+	// Failure is a request that errored out or timed out, or that Discord
+	// responded to with a 5xx status.
+	Failure
+	// Cancelled is a request that was cancelled by the caller. It says
+	// nothing about the health of the route, so it is neither a success nor
+	// a failure.
+	Cancelled
+)
+
+// ResultOf returns the result of a request from its response and error.
+func ResultOf(resp httpdriver.Response, err error) Result {
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return Cancelled
+		}
+		return Failure
+	}
+	if resp.GetStatus() >= 500 {
+		return Failure
+	}
+	return Success
+}
+
+// Record records the result of a request that was allowed by Allow. Use
+// ResultOf to determine the result of a request. A cancelled request doesn't
+// change the circuits; if it was a probe, then another probe is let through.
+func (b *Breaker) Record(permit Permit, result Result) {
+	if result == Cancelled {
+		if permit.probe {
+			b.route(permit.route).cancelProbe()
+		}
+		if permit.globalProbe {
+			b.global.cancelProbe()
+		}
+		return
+	}
+
+	now := b.time()
+	failed := result == Failure
+
+	c := b.route(permit.route)
+	if c.record(failed, b.Threshold, b.Cooldown, now) {
+		// A healthy circuit holds no state, so drop it to keep the map small.
+		b.routes.CompareAndDelete(permit.route, c)
+	}
+
+	if b.GlobalThreshold > 0 {
+		b.global.record(failed, b.GlobalThreshold, b.Cooldown, now)
+	}
+}
+
+// Wrap wraps the given HTTP client so that its requests go through the
+// breaker. The prefix is trimmed from request paths before they're turned into
+// route templates, similarly to rate.Limiter.
+func (b *Breaker) Wrap(client httpdriver.Client, prefix string) httpdriver.Client {
+	return &breakerClient{
+		Client:  client,
+		breaker: b,
+		prefix:  prefix,
+	}
+}
+
+type breakerClient struct {
+	httpdriver.Client
+	breaker *Breaker
+	prefix  string
+}
+
+func (c *breakerClient) Do(req httpdriver.Request) (httpdriver.Response, error) {
+	route := rate.RouteTemplate(strings.TrimPrefix(req.GetPath(), c.prefix))
+
+	permit, err := c.breaker.Allow(route)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Client.Do(req)
+	c.breaker.Record(permit, ResultOf(resp, err))
+
+	return resp, err
+}
+
+type circuit struct {
+	mut      sync.Mutex
+	failures int
+	open     bool
+	retryAt  time.Time
+	probing  bool
+}
+
+// allow returns whether a request is let through, and if so, whether the
+// request is the probe of the open circuit. Otherwise, it returns the time at
+// which the circuit lets a probe through.
+func (c *circuit) allow(now time.Time, cooldown time.Duration) (retryAt time.Time, probe, ok bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if !c.open {
+		return time.Time{}, false, true
+	}
+
+	// Only let a single probe through once the cooldown is over.
+	if c.probing || now.Before(c.retryAt) {
+		return c.retryAt, false, false
+	}
+
+	c.probing = true
+	return time.Time{}, true, true
+}
+
+func (c *circuit) cancelProbe() {
+	c.mut.Lock()
+	c.probing = false
+	c.mut.Unlock()
+}
+
+// record records the result of a request. It returns true if the circuit is
+// healthy afterwards, that is, closed and without failures.
+func (c *circuit) record(failed bool, threshold int, cooldown time.Duration, now time.Time) bool {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if !failed {
+		c.failures = 0
+		c.open = false
+		c.probing = false
+		return true
+	}
+
+	c.failures++
+
+	if c.probing || c.failures >= threshold {
+		c.open = true
+		c.probing = false
+		c.retryAt = now.Add(cooldown)
+	}
+
+	return false
+}
@@ -0,0 +1,221 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+)
+
+type mockClient struct {
+	status int
+	calls  int
+}
+
+func (c *mockClient) NewRequest(ctx context.Context, method, url string) (httpdriver.Request, error) {
+	return httpdriver.NewMockRequestWithContext(ctx, method, url, nil, nil), nil
+}
+
+func (c *mockClient) Do(req httpdriver.Request) (httpdriver.Response, error) {
+	c.calls++
+	return httpdriver.NewMockResponse(c.status, nil, nil), nil
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+
+	b := New()
+	b.Threshold = 2
+	b.GlobalThreshold = 0
+	b.Cooldown = time.Minute
+	b.now = func() time.Time { return now }
+
+	mock := &mockClient{status: 500}
+	client := b.Wrap(mock, "/api/v9")
+
+	do := func(path string) error {
+		req, _ := client.NewRequest(context.Background(), "GET", "https://discord.com/api/v9"+path)
+		_, err := client.Do(req)
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := do("/channels/1/messages"); err != nil {
+			t.Fatal("unexpected error before the circuit opens:", err)
+		}
+	}
+
+	err := do("/channels/1/messages")
+
+	var openErr *OpenError
+	if !errors.As(err, &openErr) || !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected open circuit, got %v", err)
+	}
+	if openErr.Route != "/channels/:id/messages" || openErr.Global {
+		t.Fatalf("unexpected open error %#v", openErr)
+	}
+	if mock.calls != 2 {
+		t.Fatalf("expected 2 requests to reach the client, got %d", mock.calls)
+	}
+
+	// Other channels share the route's circuit.
+	if err := do("/channels/2/messages"); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected open circuit for another channel, got %v", err)
+	}
+
+	// Other routes aren't affected.
+	if err := do("/channels/1/pins"); err != nil {
+		t.Fatal("unexpected error for another route:", err)
+	}
+
+	// After the cooldown, a failing probe opens the circuit again.
+	now = now.Add(time.Minute)
+	if err := do("/channels/1/messages"); err != nil {
+		t.Fatal("probe request was not let through:", err)
+	}
+	if err := do("/channels/1/messages"); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected open circuit after failed probe, got %v", err)
+	}
+
+	// A successful probe closes the circuit.
+	now = now.Add(time.Minute)
+	mock.status = 200
+
+	for i := 0; i < 3; i++ {
+		if err := do("/channels/1/messages"); err != nil {
+			t.Fatal("unexpected error after the circuit closes:", err)
+		}
+	}
+
+	if _, ok := b.routes.Load("/channels/:id/messages"); ok {
+		t.Fatal("healthy circuit was not removed")
+	}
+}
+
+func TestBreakerRedactsTokens(t *testing.T) {
+	const token = "aW50ZXJhY3Rpb246c2VjcmV0"
+
+	b := New()
+	b.Threshold = 1
+	b.GlobalThreshold = 0
+
+	client := b.Wrap(&mockClient{status: 502}, "/api/v9")
+
+	var err error
+	for i := 0; i < 2; i++ {
+		url := "https://discord.com/api/v9/webhooks/1/" + token + "-" + string(rune('a'+i)) + "/messages/@original"
+		req, _ := client.NewRequest(context.Background(), "PATCH", url)
+		_, err = client.Do(req)
+	}
+
+	if !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected interaction tokens to share a circuit, got %v", err)
+	}
+	if strings.Contains(err.Error(), token) {
+		t.Fatalf("token found in error %q", err)
+	}
+
+	var n int
+	b.routes.Range(func(_, _ interface{}) bool { n++; return true })
+	if n != 1 {
+		t.Fatalf("expected 1 circuit, got %d", n)
+	}
+}
+
+func TestBreakerGlobal(t *testing.T) {
+	b := New()
+	b.Threshold = 10
+	b.GlobalThreshold = 2
+
+	client := b.Wrap(&mockClient{status: 503}, "")
+
+	for i, path := range []string{"/guilds/1", "/guilds/2"} {
+		req, _ := client.NewRequest(context.Background(), "GET", "https://discord.com"+path)
+		if _, err := client.Do(req); err != nil {
+			t.Fatalf("unexpected error before the circuit opens (%d): %v", i, err)
+		}
+	}
+
+	req, _ := client.NewRequest(context.Background(), "GET", "https://discord.com/guilds/3")
+
+	var openErr *OpenError
+	if _, err := client.Do(req); !errors.As(err, &openErr) || !openErr.Global {
+		t.Fatalf("expected global open circuit, got %v", err)
+	}
+}
+
+func TestBreakerCancelledProbe(t *testing.T) {
+	now := time.Now()
+
+	b := New()
+	b.Threshold = 1
+	b.GlobalThreshold = 1
+	b.Cooldown = time.Minute
+	b.now = func() time.Time { return now }
+
+	const route = "/channels/:id/messages"
+
+	permit, err := b.Allow(route)
+	if err != nil {
+		t.Fatal("unexpected error before the circuit opens:", err)
+	}
+	b.Record(permit, Failure)
+
+	if _, err := b.Allow(route); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected open circuit, got %v", err)
+	}
+
+	now = now.Add(time.Minute)
+
+	probe, err := b.Allow(route)
+	if err != nil {
+		t.Fatal("probe request was not let through:", err)
+	}
+	if !probe.probe || !probe.globalProbe {
+		t.Fatalf("expected the request to be the probe, got %+v", probe)
+	}
+
+	// The caller hangs up, which says nothing about the route.
+	b.Record(probe, ResultOf(nil, context.Canceled))
+
+	// The circuit stays open, but lets another probe through.
+	probe, err = b.Allow(route)
+	if err != nil {
+		t.Fatal("second probe request was not let through:", err)
+	}
+	if !probe.probe || !probe.globalProbe {
+		t.Fatalf("cancelled probe closed the circuit: %+v", probe)
+	}
+
+	if _, err := b.Allow(route); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected open circuit while probing, got %v", err)
+	}
+}
+
+func TestResultOf(t *testing.T) {
+	tests := []struct {
+		status int
+		err    error
+		result Result
+	}{
+		{200, nil, Success},
+		{404, nil, Success},
+		{502, nil, Failure},
+		{0, context.DeadlineExceeded, Failure},
+		{0, context.Canceled, Cancelled},
+	}
+
+	for _, test := range tests {
+		var resp httpdriver.Response
+		if test.err == nil {
+			resp = httpdriver.NewMockResponse(test.status, nil, nil)
+		}
+
+		if result := ResultOf(resp, test.err); result != test.result {
+			t.Errorf("status %d, error %v: expected result %d, got %d", test.status, test.err, test.result, result)
+		}
+	}
+}
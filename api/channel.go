@@ -2,6 +2,7 @@ package api
 
 import (
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/internal/intmath"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
 )
@@ -302,12 +303,105 @@ func (c *Client) Typing(channelID discord.ChannelID) error {
 }
 
 // PinnedMessages returns all pinned messages in the channel as an array of
-// message objects.
+// message objects. This uses the legacy pins endpoint, which returns at most
+// 50 pins; use AllChannelPins to fetch more along with their pin times.
 func (c *Client) PinnedMessages(channelID discord.ChannelID) ([]discord.Message, error) {
 	var pinned []discord.Message
 	return pinned, c.RequestJSON(&pinned, "GET", EndpointChannels+channelID.String()+"/pins")
 }
 
+// MaxPinFetchLimit is the maximum number of pinned messages that can be
+// fetched in a single request.
+const MaxPinFetchLimit = 50
+
+// https://discord.com/developers/docs/resources/message#get-channel-pins-response-structure
+type ChannelPins struct {
+	// Items are the pinned messages, ordered by PinnedAt, in descending order.
+	Items []MessagePin `json:"items"`
+	// More specifies whether there are potentially additional pins that could
+	// be returned on a subsequent call.
+	More bool `json:"has_more"`
+}
+
+// https://discord.com/developers/docs/resources/message#message-pin-object
+type MessagePin struct {
+	// PinnedAt is the time the message was pinned.
+	PinnedAt discord.Timestamp `json:"pinned_at"`
+	// Message is the pinned message.
+	Message discord.Message `json:"message"`
+}
+
+// ChannelPins returns a page of pinned messages in the channel along with the
+// time they were pinned. If before is valid, only messages pinned before it
+// are returned. Unlike PinnedMessages, this endpoint is paginated, and limit
+// is clamped to MaxPinFetchLimit.
+//
+// Requires the READ_MESSAGE_HISTORY permission.
+func (c *Client) ChannelPins(
+	channelID discord.ChannelID,
+	before discord.Timestamp, limit uint) (*ChannelPins, error) {
+
+	var param struct {
+		Before string `schema:"before,omitempty"`
+		Limit  uint   `schema:"limit,omitempty"`
+	}
+
+	if before.IsValid() {
+		param.Before = before.Format(discord.TimestampFormat)
+	}
+	param.Limit = uint(intmath.Min(MaxPinFetchLimit, int(limit)))
+
+	var pins *ChannelPins
+	return pins, c.RequestJSON(
+		&pins, "GET",
+		channelMessagesURL(channelID, "/pins"),
+		httputil.WithSchema(c, param),
+	)
+}
+
+// AllChannelPins returns the pinned messages in the channel along with the
+// time they were pinned. This method automatically paginates using
+// ChannelPins until it reaches the passed limit, or, if the limit is set to 0,
+// has fetched all pins in the channel.
+//
+// As the underlying endpoint has a maximum of 50 pins per request, at maximum
+// a total of limit/50 rounded up requests will be made, although they may be
+// less, if no more pins are available.
+func (c *Client) AllChannelPins(channelID discord.ChannelID, limit uint) ([]MessagePin, error) {
+	pins := make([]MessagePin, 0, limit)
+
+	var before discord.Timestamp
+
+	fetch := uint(MaxPinFetchLimit)
+
+	unlimited := limit == 0
+
+	for limit > 0 || unlimited {
+		if limit > 0 {
+			fetch = uint(intmath.Min(MaxPinFetchLimit, int(limit)))
+			limit -= fetch
+		}
+
+		page, err := c.ChannelPins(channelID, before, fetch)
+		if err != nil {
+			return pins, err
+		}
+		pins = append(pins, page.Items...)
+
+		if !page.More || len(page.Items) == 0 {
+			break
+		}
+
+		before = pins[len(pins)-1].PinnedAt
+	}
+
+	if len(pins) == 0 {
+		return nil, nil
+	}
+
+	return pins, nil
+}
+
 // PinMessage pins a message in a channel.
 //
 // Requires the MANAGE_MESSAGES permission.
@@ -332,6 +426,52 @@ func (c *Client) UnpinMessage(
 	)
 }
 
+// FollowAnnouncementChannel follows the announcement channel with the given ID,
+// so that messages crossposted in it are sent to the target channel through a
+// webhook. The returned FollowedChannel contains the ID of the webhook.
+//
+// Requires the MANAGE_WEBHOOKS permission in the target channel.
+//
+// Fires a Webhooks Update Gateway event for the target channel.
+func (c *Client) FollowAnnouncementChannel(
+	channelID, targetChannelID discord.ChannelID,
+	reason AuditLogReason) (*discord.FollowedChannel, error) {
+
+	var param struct {
+		WebhookChannelID discord.ChannelID `json:"webhook_channel_id"`
+	}
+
+	param.WebhookChannelID = targetChannelID
+
+	var followed *discord.FollowedChannel
+	return followed, c.RequestJSON(
+		&followed, "POST",
+		EndpointChannels+channelID.String()+"/followers",
+		httputil.WithJSONBody(param), httputil.WithHeaders(reason.Header()),
+	)
+}
+
+// ModifyGroupDMData is the data for ModifyGroupDM.
+type ModifyGroupDMData struct {
+	// Name is the 1-100 character name of the group DM. Null removes the name.
+	Name option.NullableString `json:"name,omitempty"`
+	// Icon is the icon of the group DM. An empty Image removes the icon.
+	Icon *Image `json:"icon,omitempty"`
+}
+
+// ModifyGroupDM updates the name or the icon of a group direct message.
+//
+// Fires a Channel Update event.
+func (c *Client) ModifyGroupDM(
+	channelID discord.ChannelID, data ModifyGroupDMData) (*discord.Channel, error) {
+
+	var ch *discord.Channel
+	return ch, c.RequestJSON(
+		&ch, "PATCH", EndpointChannels+channelID.String(),
+		httputil.WithJSONBody(data),
+	)
+}
+
 // AddRecipient adds a user to a group direct message. As accessToken is
 // needed, clearly this endpoint should only be used for OAuth. AccessToken can
 // be obtained with the "gdm.join" scope.
@@ -340,7 +480,7 @@ func (c *Client) AddRecipient(
 
 	var params struct {
 		AccessToken string `json:"access_token"`
-		Nickname    string `json:"nickname"`
+		Nickname    string `json:"nick,omitempty"`
 	}
 
 	params.AccessToken = accessToken
@@ -372,7 +512,7 @@ type Ack struct {
 func (c *Client) Ack(channelID discord.ChannelID, messageID discord.MessageID, ack *Ack) error {
 	return c.RequestJSON(
 		ack, "POST",
-		EndpointChannels+channelID.String()+"/messages/"+messageID.String()+"/ack",
+		messageURL(channelID, messageID, "/ack"),
 		httputil.WithJSONBody(ack),
 	)
 }
@@ -419,7 +559,7 @@ func (c *Client) StartThreadWithMessage(
 	var ch *discord.Channel
 	return ch, c.RequestJSON(
 		&ch, "POST",
-		EndpointChannels+channelID.String()+"/messages/"+messageID.String()+"/threads",
+		messageURL(channelID, messageID, "/threads"),
 		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
 	)
 }
@@ -478,6 +618,31 @@ func (c *Client) RemoveThreadMember(threadID discord.ChannelID, userID discord.U
 	)
 }
 
+// MaxThreadMemberFetchLimit is the maximum number of thread members that can
+// be fetched in a single request.
+const MaxThreadMemberFetchLimit = 100
+
+// ThreadMember returns the thread member object for the specified user, if
+// they are a member of the thread. If withMember is true, the Member field of
+// the thread member will be filled.
+func (c *Client) ThreadMember(
+	threadID discord.ChannelID,
+	userID discord.UserID, withMember bool) (*discord.ThreadMember, error) {
+
+	var param struct {
+		WithMember bool `schema:"with_member,omitempty"`
+	}
+
+	param.WithMember = withMember
+
+	var m *discord.ThreadMember
+	return m, c.RequestJSON(
+		&m, "GET",
+		EndpointChannels+threadID.String()+"/thread-members/"+userID.String(),
+		httputil.WithSchema(c, param),
+	)
+}
+
 // ThreadMembers list all members of the thread.
 //
 // This endpoint is restricted according to whether the GUILD_MEMBERS
@@ -487,6 +652,80 @@ func (c *Client) ThreadMembers(threadID discord.ChannelID) ([]discord.ThreadMemb
 	return m, c.RequestJSON(&m, "GET", EndpointChannels+threadID.String()+"/thread-members")
 }
 
+// ThreadMembersAfter returns a list of members of the thread with the passed
+// id, with their Member fields filled. This method automatically paginates
+// until it reaches the passed limit, or, if the limit is set to 0, has fetched
+// all thread members with an id higher than after.
+//
+// As the underlying endpoint has a maximum of 100 thread members per request,
+// at maximum a total of limit/100 rounded up requests will be made, although
+// they may be less, if no more thread members are available.
+//
+// This endpoint is restricted according to whether the GUILD_MEMBERS
+// Privileged Intent is enabled for your application.
+func (c *Client) ThreadMembersAfter(
+	threadID discord.ChannelID,
+	after discord.UserID, limit uint) ([]discord.ThreadMember, error) {
+
+	mems := make([]discord.ThreadMember, 0, limit)
+
+	fetch := uint(MaxThreadMemberFetchLimit)
+
+	unlimited := limit == 0
+
+	for limit > 0 || unlimited {
+		if limit > 0 {
+			fetch = uint(intmath.Min(MaxThreadMemberFetchLimit, int(limit)))
+			limit -= fetch
+		}
+
+		m, err := c.threadMembersAfter(threadID, after, fetch)
+		if err != nil {
+			return mems, err
+		}
+		mems = append(mems, m...)
+
+		if len(m) < MaxThreadMemberFetchLimit {
+			break
+		}
+
+		after = mems[len(mems)-1].UserID
+	}
+
+	if len(mems) == 0 {
+		return nil, nil
+	}
+
+	return mems, nil
+}
+
+func (c *Client) threadMembersAfter(
+	threadID discord.ChannelID,
+	after discord.UserID, limit uint) ([]discord.ThreadMember, error) {
+
+	if limit > MaxThreadMemberFetchLimit {
+		limit = MaxThreadMemberFetchLimit
+	}
+
+	// Paginating is only enabled if with_member is set.
+	var param struct {
+		WithMember bool           `schema:"with_member"`
+		After      discord.UserID `schema:"after,omitempty"`
+		Limit      uint           `schema:"limit,omitempty"`
+	}
+
+	param.WithMember = true
+	param.After = after
+	param.Limit = limit
+
+	var m []discord.ThreadMember
+	return m, c.RequestJSON(
+		&m, "GET",
+		EndpointChannels+threadID.String()+"/thread-members",
+		httputil.WithSchema(c, param),
+	)
+}
+
 // https://discord.com/developers/docs/resources/guild#list-active-threads-response-body
 type ActiveThreads struct {
 	// Threads are the active threads, ordered by descending ID.
@@ -578,21 +817,41 @@ func (c *Client) PrivateArchivedThreads(
 // JoinedPrivateArchivedThreads returns archived threads in the channel that are
 // of type GUILD_PRIVATE_THREAD, and the user has joined.
 //
-// Threads are ordered by their ID, in descending order.
+// Threads are ordered by their ID, in descending order. As Discord paginates
+// this endpoint by thread ID, before is converted to the first possible ID at
+// that time.
 //
 // Requires the READ_MESSAGE_HISTORY permission
+//
+// Deprecated: Use ListJoinedPrivateArchivedThreads instead.
 func (c *Client) JoinedPrivateArchivedThreads(
 	channelID discord.ChannelID,
 	before discord.Timestamp, limit uint) (*ArchivedThreads, error) {
 
-	var param struct {
-		Before string `schema:"before,omitempty"`
-		Limit  uint   `schema:"limit,omitempty"`
+	var beforeID discord.ChannelID
+	if before.IsValid() {
+		beforeID = discord.ChannelID(discord.NewSnowflake(before.Time()))
 	}
 
-	if before.IsValid() {
-		param.Before = before.Format(discord.TimestampFormat)
+	return c.ListJoinedPrivateArchivedThreads(channelID, beforeID, limit)
+}
+
+// ListJoinedPrivateArchivedThreads returns archived threads in the channel
+// that are of type GUILD_PRIVATE_THREAD, and the user has joined. If before is
+// valid, only threads with an ID lower than before are returned.
+//
+// Threads are ordered by their ID, in descending order.
+//
+// Requires the READ_MESSAGE_HISTORY permission
+func (c *Client) ListJoinedPrivateArchivedThreads(
+	channelID, before discord.ChannelID, limit uint) (*ArchivedThreads, error) {
+
+	var param struct {
+		Before discord.ChannelID `schema:"before,omitempty"`
+		Limit  uint              `schema:"limit,omitempty"`
 	}
+
+	param.Before = before
 	param.Limit = limit
 
 	var t *ArchivedThreads
@@ -603,6 +862,121 @@ func (c *Client) JoinedPrivateArchivedThreads(
 	)
 }
 
+// MaxArchivedThreadFetchLimit is the maximum number of archived threads that
+// can be fetched in a single request.
+const MaxArchivedThreadFetchLimit = 100
+
+// AllPublicArchivedThreads returns archived threads in the channel that are
+// public. This method automatically paginates using PublicArchivedThreads
+// until it reaches the passed limit, or, if the limit is set to 0, Discord
+// reports that there are no more threads.
+//
+// The More field of the returned ArchivedThreads is true if the limit was
+// reached before all threads were fetched.
+func (c *Client) AllPublicArchivedThreads(
+	channelID discord.ChannelID, limit uint) (*ArchivedThreads, error) {
+
+	var before discord.Timestamp
+
+	return allArchivedThreads(limit, func(fetch uint) (*ArchivedThreads, error) {
+		t, err := c.PublicArchivedThreads(channelID, before, fetch)
+		if err == nil {
+			before = nextArchiveTimestamp(t, before)
+		}
+		return t, err
+	})
+}
+
+// AllPrivateArchivedThreads returns archived threads in the channel that are of
+// type GUILD_PRIVATE_THREAD. This method automatically paginates using
+// PrivateArchivedThreads until it reaches the passed limit, or, if the limit
+// is set to 0, Discord reports that there are no more threads.
+//
+// The More field of the returned ArchivedThreads is true if the limit was
+// reached before all threads were fetched.
+func (c *Client) AllPrivateArchivedThreads(
+	channelID discord.ChannelID, limit uint) (*ArchivedThreads, error) {
+
+	var before discord.Timestamp
+
+	return allArchivedThreads(limit, func(fetch uint) (*ArchivedThreads, error) {
+		t, err := c.PrivateArchivedThreads(channelID, before, fetch)
+		if err == nil {
+			before = nextArchiveTimestamp(t, before)
+		}
+		return t, err
+	})
+}
+
+// AllJoinedPrivateArchivedThreads returns archived threads in the channel that
+// are of type GUILD_PRIVATE_THREAD, and the user has joined. This method
+// automatically paginates using ListJoinedPrivateArchivedThreads until it
+// reaches the passed limit, or, if the limit is set to 0, Discord reports that
+// there are no more threads.
+//
+// The More field of the returned ArchivedThreads is true if the limit was
+// reached before all threads were fetched.
+func (c *Client) AllJoinedPrivateArchivedThreads(
+	channelID discord.ChannelID, limit uint) (*ArchivedThreads, error) {
+
+	var before discord.ChannelID
+
+	return allArchivedThreads(limit, func(fetch uint) (*ArchivedThreads, error) {
+		t, err := c.ListJoinedPrivateArchivedThreads(channelID, before, fetch)
+		if err == nil && len(t.Threads) > 0 {
+			before = t.Threads[len(t.Threads)-1].ID
+		}
+		return t, err
+	})
+}
+
+// allArchivedThreads calls fetchPage until limit threads are fetched, or until
+// there are no more threads. fetchPage must advance its own cursor.
+func allArchivedThreads(
+	limit uint, fetchPage func(fetch uint) (*ArchivedThreads, error)) (*ArchivedThreads, error) {
+
+	all := ArchivedThreads{More: true}
+
+	fetch := uint(MaxArchivedThreadFetchLimit)
+
+	unlimited := limit == 0
+
+	for all.More && (limit > 0 || unlimited) {
+		if limit > 0 {
+			fetch = uint(intmath.Min(MaxArchivedThreadFetchLimit, int(limit)))
+			limit -= fetch
+		}
+
+		t, err := fetchPage(fetch)
+		if err != nil {
+			return &all, err
+		}
+
+		all.Threads = append(all.Threads, t.Threads...)
+		all.Members = append(all.Members, t.Members...)
+		all.More = t.More && len(t.Threads) > 0
+	}
+
+	return &all, nil
+}
+
+// nextArchiveTimestamp returns the archive timestamp of the last thread in t
+// to paginate from. If there is none, t.More is set to false, so that the
+// pagination stops instead of starting over.
+func nextArchiveTimestamp(t *ArchivedThreads, before discord.Timestamp) discord.Timestamp {
+	if len(t.Threads) == 0 {
+		return before
+	}
+
+	last := t.Threads[len(t.Threads)-1]
+	if last.ThreadMetadata == nil || !last.ThreadMetadata.ArchiveTimestamp.IsValid() {
+		t.More = false
+		return before
+	}
+
+	return last.ThreadMetadata.ArchiveTimestamp
+}
+
 // PublicArchivedThreadsBefore returns archived threads in the channel that are
 // public.
 //
@@ -626,7 +1000,7 @@ func (c *Client) PrivateArchivedThreadsBefore(
 // JoinedPrivateArchivedThreadsBefore returns archived threads in the channel
 // that are of type GUILD_PRIVATE_THREAD, and the user has joined.
 //
-// Deprecated: Use JoinedPrivateArchivedThreads instead.
+// Deprecated: Use ListJoinedPrivateArchivedThreads instead.
 func (c *Client) JoinedPrivateArchivedThreadsBefore(
 	channelID discord.ChannelID,
 	before discord.Timestamp, limit uint) (*ArchivedThreads, error) {
@@ -0,0 +1,257 @@
+// Package cmdroutetest provides a harness for unit testing interaction
+// handlers, such as a cmdroute.Router, without a live session.
+//
+// The Harness synthesizes interaction events as if they were sent by a member
+// in a guild channel, runs them through the handler and returns the response
+// that the handler would have sent:
+//
+//	r := cmdroute.NewRouter()
+//	r.AddFunc("ping", ping)
+//
+//	h := cmdroutetest.NewHarness(r)
+//	resp := h.Command("ping", cmdroutetest.String("text", "hi"))
+package cmdroutetest
+
+import (
+	"strconv"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/webhook"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/discord/discordtest"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// Harness runs synthesized interaction events through an interaction handler.
+type Harness struct {
+	// Handler is the handler that the events are given to.
+	Handler webhook.InteractionHandler
+	// Builder is used to build the events.
+	Builder *discordtest.Builder
+
+	// Guild, Channel and Member are where the events are sent from. They can
+	// be changed freely.
+	Guild   discord.Guild
+	Channel discord.Channel
+	Member  discord.Member
+}
+
+// NewHarness creates a new Harness around the given handler, with a new
+// guild, channel and member.
+func NewHarness(h webhook.InteractionHandler) *Harness {
+	b := discordtest.NewBuilder()
+	guild := b.Guild()
+
+	return &Harness{
+		Handler: h,
+		Builder: b,
+		Guild:   guild,
+		Channel: b.Channel(discordtest.InGuild(guild.ID)),
+		Member:  b.Member(),
+	}
+}
+
+// Event synthesizes an interaction event with the given data, sent by the
+// harness' member in the harness' channel. Options are applied last.
+func (h *Harness) Event(
+	data discord.InteractionData, opts ...discordtest.Option[discord.InteractionEvent]) *discord.InteractionEvent {
+
+	opts = append([]discordtest.Option[discord.InteractionEvent]{
+		discordtest.InteractionData(data),
+		discordtest.InteractionChannel(h.Channel),
+		discordtest.InteractionMember(h.Member),
+		func(ev *discord.InteractionEvent) { ev.GuildLocale = h.Guild.PreferredLocale },
+	}, opts...)
+
+	ev := h.Builder.Interaction(opts...)
+	return &ev
+}
+
+// Run synthesizes an interaction event with the given data and runs it
+// through the handler, returning the response. Nil is returned if the handler
+// doesn't respond.
+func (h *Harness) Run(
+	data discord.InteractionData, opts ...discordtest.Option[discord.InteractionEvent]) *api.InteractionResponse {
+
+	return h.Handler.HandleInteraction(h.Event(data, opts...))
+}
+
+// Command runs a slash command with the given name and options.
+func (h *Harness) Command(name string, opts ...CommandOption) *api.InteractionResponse {
+	return h.Run(h.CommandData(name, opts...))
+}
+
+// CommandData builds the data of a slash command with the given name and
+// options.
+func (h *Harness) CommandData(name string, opts ...CommandOption) *discord.CommandInteraction {
+	data := &discord.CommandInteraction{
+		ID:      discord.CommandID(h.Builder.ID()),
+		Name:    name,
+		GuildID: h.Guild.ID,
+	}
+
+	b := commandBuilder{data: data, options: &data.Options}
+	b.apply(opts)
+
+	return data
+}
+
+// Button runs a button click on the button with the given custom ID.
+func (h *Harness) Button(customID discord.ComponentID) *api.InteractionResponse {
+	return h.Run(&discord.ButtonInteraction{CustomID: customID})
+}
+
+// StringSelect runs a selection of the given values on the string select menu
+// with the given custom ID.
+func (h *Harness) StringSelect(customID discord.ComponentID, values ...string) *api.InteractionResponse {
+	return h.Run(&discord.StringSelectInteraction{CustomID: customID, Values: values})
+}
+
+// Modal runs a submission of the modal with the given custom ID. Each value is
+// put into a text input with the custom ID of its key, in its own action row.
+func (h *Harness) Modal(customID discord.ComponentID, values map[discord.ComponentID]string) *api.InteractionResponse {
+	data := &discord.ModalInteraction{CustomID: customID}
+
+	for id, value := range values {
+		data.Components = append(data.Components, &discord.ActionRowComponent{
+			&discord.TextInputComponent{CustomID: id, Value: value},
+		})
+	}
+
+	return h.Run(data)
+}
+
+// CommandOption is an option of a synthesized slash command. It may also add
+// resolved data to the command.
+type CommandOption func(*commandBuilder)
+
+type commandBuilder struct {
+	data    *discord.CommandInteraction
+	options *discord.CommandInteractionOptions
+}
+
+func (b commandBuilder) apply(opts []CommandOption) {
+	for _, opt := range opts {
+		opt(&b)
+	}
+}
+
+func (b commandBuilder) add(t discord.CommandOptionType, name string, value json.Raw) {
+	*b.options = append(*b.options, discord.CommandInteractionOption{
+		Type:  t,
+		Name:  name,
+		Value: value,
+	})
+}
+
+func mustMarshal(v interface{}) json.Raw {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic("cmdroutetest: cannot marshal option value: " + err.Error())
+	}
+	return b
+}
+
+// Subcommand adds a subcommand with the given options.
+func Subcommand(name string, opts ...CommandOption) CommandOption {
+	return func(b *commandBuilder) {
+		sub := discord.CommandInteractionOption{
+			Type: discord.SubcommandOptionType,
+			Name: name,
+		}
+		commandBuilder{data: b.data, options: &sub.Options}.apply(opts)
+		*b.options = append(*b.options, sub)
+	}
+}
+
+// SubcommandGroup adds a subcommand group with the given subcommands.
+func SubcommandGroup(name string, subcommands ...CommandOption) CommandOption {
+	return func(b *commandBuilder) {
+		group := discord.CommandInteractionOption{
+			Type: discord.SubcommandGroupOptionType,
+			Name: name,
+		}
+		commandBuilder{data: b.data, options: &group.Options}.apply(subcommands)
+		*b.options = append(*b.options, group)
+	}
+}
+
+// String adds a string option.
+func String(name, value string) CommandOption {
+	return func(b *commandBuilder) {
+		b.add(discord.StringOptionType, name, mustMarshal(value))
+	}
+}
+
+// Integer adds an integer option.
+func Integer(name string, value int64) CommandOption {
+	return func(b *commandBuilder) {
+		b.add(discord.IntegerOptionType, name, json.Raw(strconv.FormatInt(value, 10)))
+	}
+}
+
+// Number adds a number option.
+func Number(name string, value float64) CommandOption {
+	return func(b *commandBuilder) {
+		b.add(discord.NumberOptionType, name, mustMarshal(value))
+	}
+}
+
+// Boolean adds a boolean option.
+func Boolean(name string, value bool) CommandOption {
+	return func(b *commandBuilder) {
+		b.add(discord.BooleanOptionType, name, json.Raw(strconv.FormatBool(value)))
+	}
+}
+
+// User adds a user option, resolving the user.
+func User(name string, u discord.User) CommandOption {
+	return func(b *commandBuilder) {
+		b.add(discord.UserOptionType, name, mustMarshal(u.ID))
+		resolveUser(b.data, u)
+	}
+}
+
+// Member adds a user option, resolving both the member and its user.
+func Member(name string, m discord.Member) CommandOption {
+	return func(b *commandBuilder) {
+		b.add(discord.UserOptionType, name, mustMarshal(m.User.ID))
+		resolveUser(b.data, m.User)
+
+		if b.data.Resolved.Members == nil {
+			b.data.Resolved.Members = make(map[discord.UserID]discord.Member)
+		}
+		b.data.Resolved.Members[m.User.ID] = m
+	}
+}
+
+func resolveUser(data *discord.CommandInteraction, u discord.User) {
+	if data.Resolved.Users == nil {
+		data.Resolved.Users = make(map[discord.UserID]discord.User)
+	}
+	data.Resolved.Users[u.ID] = u
+}
+
+// Channel adds a channel option, resolving the channel.
+func Channel(name string, ch discord.Channel) CommandOption {
+	return func(b *commandBuilder) {
+		b.add(discord.ChannelOptionType, name, mustMarshal(ch.ID))
+
+		if b.data.Resolved.Channels == nil {
+			b.data.Resolved.Channels = make(map[discord.ChannelID]discord.Channel)
+		}
+		b.data.Resolved.Channels[ch.ID] = ch
+	}
+}
+
+// Role adds a role option, resolving the role.
+func Role(name string, role discord.Role) CommandOption {
+	return func(b *commandBuilder) {
+		b.add(discord.RoleOptionType, name, mustMarshal(role.ID))
+
+		if b.data.Resolved.Roles == nil {
+			b.data.Resolved.Roles = make(map[discord.RoleID]discord.Role)
+		}
+		b.data.Resolved.Roles[role.ID] = role
+	}
+}
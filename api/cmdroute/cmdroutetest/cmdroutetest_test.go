@@ -0,0 +1,116 @@
+package cmdroutetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/cmdroute"
+	"github.com/diamondburned/arikawa/v3/api/webhook"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestHarnessCommand(t *testing.T) {
+	r := cmdroute.NewRouter()
+	h := NewHarness(r)
+	target := h.Builder.Member()
+
+	r.Sub("mod", func(r *cmdroute.Router) {
+		r.AddFunc("warn", func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+			var opts struct {
+				User   discord.UserID `discord:"user"`
+				Reason string         `discord:"reason"`
+			}
+			if err := data.Options.Unmarshal(&opts); err != nil {
+				t.Fatal("cannot unmarshal options:", err)
+			}
+
+			if opts.User != target.User.ID || opts.Reason != "spam" {
+				t.Errorf("unexpected options %+v", opts)
+			}
+
+			if days, err := data.Options.Find("days").IntValue(); err != nil || days != 3 {
+				t.Errorf("unexpected days %d (error %v)", days, err)
+			}
+
+			if _, ok := data.Data.Resolved.Members[opts.User]; !ok {
+				t.Error("member is not resolved")
+			}
+
+			if data.Event.Member == nil || data.Event.Member.User.ID != h.Member.User.ID {
+				t.Error("event is not from the harness member")
+			}
+
+			if data.Event.GuildID != h.Guild.ID {
+				t.Errorf("event guild is %v, expected %v", data.Event.GuildID, h.Guild.ID)
+			}
+
+			return &api.InteractionResponseData{
+				Content: option.NewNullableString("warned " + target.User.Username),
+			}
+		})
+	})
+
+	resp := h.Command("mod", Subcommand("warn",
+		Member("user", target),
+		String("reason", "spam"),
+		Integer("days", 3),
+	))
+
+	if resp == nil || resp.Data == nil {
+		t.Fatal("unexpected nil response")
+	}
+
+	if content := resp.Data.Content.Val; content != "warned "+target.User.Username {
+		t.Errorf("unexpected response content %q", content)
+	}
+}
+
+func TestHarnessComponent(t *testing.T) {
+	r := cmdroute.NewRouter()
+	r.AddComponentFunc("confirm", func(ctx context.Context, data cmdroute.ComponentData) *api.InteractionResponse {
+		return &api.InteractionResponse{Type: api.DeferredMessageUpdate}
+	})
+
+	h := NewHarness(r)
+
+	resp := h.Button("confirm")
+	if resp == nil || resp.Type != api.DeferredMessageUpdate {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	if resp := h.Button("unknown"); resp != nil {
+		t.Fatalf("unexpected response %+v for unknown button", resp)
+	}
+}
+
+func TestHarnessModal(t *testing.T) {
+	var got map[discord.ComponentID]string
+
+	h := NewHarness(webhook.InteractionHandlerFunc(func(ev *discord.InteractionEvent) *api.InteractionResponse {
+		data, ok := ev.Data.(*discord.ModalInteraction)
+		if !ok {
+			t.Fatalf("unexpected data type %T", ev.Data)
+		}
+
+		got = make(map[discord.ComponentID]string)
+		for _, c := range data.Components {
+			for _, c := range *c.(*discord.ActionRowComponent) {
+				input := c.(*discord.TextInputComponent)
+				got[input.CustomID] = input.Value
+			}
+		}
+
+		return nil
+	}))
+
+	h.Modal("feedback", map[discord.ComponentID]string{
+		"title": "hello",
+		"body":  "world",
+	})
+
+	if got["title"] != "hello" || got["body"] != "world" || len(got) != 2 {
+		t.Fatalf("unexpected modal values %v", got)
+	}
+}
@@ -0,0 +1,115 @@
+package cmdroute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// Command is a slash command whose options are declared by the struct type T.
+// The same struct is used to generate the options when the command is created,
+// using discord.NewCommandOptions, and to read them when the command is
+// invoked, using discord.CommandInteractionOptions.Unmarshal. Refer to both for
+// the supported struct tags.
+//
+//	type BanOptions struct {
+//		User   discord.UserID `discord:"user" description:"The user to ban."`
+//		Reason string         `discord:"reason?" description:"Why the user is banned."`
+//	}
+//
+//	ban := cmdroute.NewCommand("ban", "Ban a user.",
+//		func(ctx context.Context, data cmdroute.CommandData, opts *BanOptions) *api.InteractionResponseData {
+//			...
+//		})
+//
+//	r.Add("ban", ban)
+type Command[T any] struct {
+	// Data is the command data used by CreateCommandData. Its Options are
+	// ignored and replaced with the options generated from T.
+	Data api.CreateCommandData
+	// Handler handles the command with its options.
+	Handler func(ctx context.Context, data CommandData, opts *T) *api.InteractionResponseData
+}
+
+var _ CommandOptioner = (*Command[struct{}])(nil)
+
+// NewCommand creates a new slash command with the given name and description.
+func NewCommand[T any](
+	name, description string,
+	handler func(ctx context.Context, data CommandData, opts *T) *api.InteractionResponseData) *Command[T] {
+
+	return &Command[T]{
+		Data: api.CreateCommandData{
+			Name:        name,
+			Description: description,
+			Type:        discord.ChatInputCommand,
+		},
+		Handler: handler,
+	}
+}
+
+// CommandOptions implements CommandOptioner. It returns the options generated
+// from T.
+func (c *Command[T]) CommandOptions() (discord.CommandOptions, error) {
+	return discord.NewCommandOptions(new(T))
+}
+
+// CreateCommandData returns the command data with the options generated from
+// T. Use it with OverwriteCommands or api.Client.CreateCommand.
+func (c *Command[T]) CreateCommandData() (api.CreateCommandData, error) {
+	options, err := c.CommandOptions()
+	if err != nil {
+		return api.CreateCommandData{}, fmt.Errorf("command %q: %w", c.Data.Name, err)
+	}
+
+	data := c.Data
+	data.Options = options
+	return data, nil
+}
+
+// HandleCommand implements CommandHandler. If the options can't be read into a
+// T, which usually means that the registered command is outdated, then an
+// ephemeral error message is sent instead of calling the handler.
+func (c *Command[T]) HandleCommand(ctx context.Context, data CommandData) *api.InteractionResponseData {
+	var opts T
+	if err := data.Options.Unmarshal(&opts); err != nil {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString("Invalid command options: " + err.Error()),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	return c.Handler(ctx, data, &opts)
+}
+
+// ContextHandlers is a CommandHandler that picks the handler by the context
+// where the command is invoked, such as for user-installed commands that
+// behave differently in guilds and in DMs:
+//
+//	r.Add("note", cmdroute.ContextHandlers{
+//		discord.GuildContext:          handleGuildNote,
+//		discord.BotDMContext:          handlePrivateNote,
+//		discord.PrivateChannelContext: handlePrivateNote,
+//	})
+//
+// The context is given by discord.InteractionEvent.ContextType. If there is no
+// handler for it, then an ephemeral error message is sent.
+type ContextHandlers map[discord.InteractionContextType]CommandHandler
+
+var _ CommandHandler = ContextHandlers(nil)
+
+// HandleCommand implements CommandHandler.
+func (h ContextHandlers) HandleCommand(ctx context.Context, data CommandData) *api.InteractionResponseData {
+	handler, ok := h[data.Event.ContextType()]
+	if !ok {
+		return &api.InteractionResponseData{
+			Content: option.NewNullableString("This command can't be used here."),
+			Flags:   discord.EphemeralMessage,
+		}
+	}
+
+	return handler.HandleCommand(ctx, data)
+}
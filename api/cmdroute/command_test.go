@@ -0,0 +1,110 @@
+package cmdroute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestCommand(t *testing.T) {
+	type echoOptions struct {
+		Text  string `discord:"text" description:"The text to echo."`
+		Times *int   `discord:"times" description:"How many times to echo."`
+	}
+
+	echo := NewCommand("echo", "Echo some text.",
+		func(ctx context.Context, data CommandData, opts *echoOptions) *api.InteractionResponseData {
+			if opts.Times != nil {
+				t.Error("unexpected times option", *opts.Times)
+			}
+			return &api.InteractionResponseData{
+				Content: option.NewNullableString(opts.Text),
+			}
+		})
+
+	data, err := echo.CreateCommandData()
+	if err != nil {
+		t.Fatal("failed to create command data:", err)
+	}
+
+	if data.Name != "echo" || len(data.Options) != 2 {
+		t.Fatalf("unexpected command data %+v", data)
+	}
+
+	r := NewRouter()
+	r.Add("echo", echo)
+
+	t.Run("valid", func(t *testing.T) {
+		resp := r.HandleInteraction(newInteractionEvent(&discord.CommandInteraction{
+			ID:   4,
+			Name: "echo",
+			Options: []discord.CommandInteractionOption{
+				{Type: discord.StringOptionType, Name: "text", Value: json.Raw(`"hi"`)},
+			},
+		}))
+
+		if resp.Data == nil || resp.Data.Content.Val != "hi" {
+			t.Fatalf("unexpected response %s", strInteractionResp(resp))
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := r.HandleInteraction(newInteractionEvent(&discord.CommandInteraction{
+			ID:   4,
+			Name: "echo",
+		}))
+
+		if resp.Data == nil || resp.Data.Flags != discord.EphemeralMessage {
+			t.Fatalf("unexpected response %s", strInteractionResp(resp))
+		}
+	})
+}
+
+func TestContextHandlers(t *testing.T) {
+	respond := func(content string) CommandHandlerFunc {
+		return func(ctx context.Context, data CommandData) *api.InteractionResponseData {
+			return &api.InteractionResponseData{Content: option.NewNullableString(content)}
+		}
+	}
+
+	r := NewRouter()
+	r.Add("note", ContextHandlers{
+		discord.GuildContext: respond("guild"),
+		discord.BotDMContext: respond("dm"),
+	})
+
+	tests := []struct {
+		guildID discord.GuildID
+		context *discord.InteractionContextType
+		expect  string
+	}{
+		{guildID: 1, expect: "guild"},
+		{expect: "dm"},
+		{context: new(discord.InteractionContextType), expect: "guild"},
+	}
+
+	private := discord.PrivateChannelContext
+
+	for _, test := range tests {
+		ev := newInteractionEvent(&discord.CommandInteraction{ID: 4, Name: "note"})
+		ev.GuildID = test.guildID
+		ev.Context = test.context
+
+		resp := r.HandleInteraction(ev)
+		if resp == nil || resp.Data == nil || resp.Data.Content.Val != test.expect {
+			t.Errorf("expected %q, got %s", test.expect, strInteractionResp(resp))
+		}
+	}
+
+	ev := newInteractionEvent(&discord.CommandInteraction{ID: 4, Name: "note"})
+	ev.Context = &private
+
+	resp := r.HandleInteraction(ev)
+	if resp == nil || resp.Data == nil || resp.Data.Flags != discord.EphemeralMessage {
+		t.Errorf("expected an ephemeral error, got %s", strInteractionResp(resp))
+	}
+}
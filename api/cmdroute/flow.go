@@ -0,0 +1,344 @@
+package cmdroute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// FlowSeparator separates the flow ID from the action in the custom IDs of the
+// components and modals of a flow. Flow IDs must not contain it.
+const FlowSeparator = ":"
+
+// DefaultFlowTimeout is the default time that a user has to complete each step
+// of a flow.
+const DefaultFlowTimeout = 5 * time.Minute
+
+// ErrFlowNotFound is returned by a FlowStore if it has no state for the given
+// key.
+var ErrFlowNotFound = errors.New("flow not found")
+
+// FlowKey identifies a user's flow.
+type FlowKey struct {
+	FlowID string
+	UserID discord.UserID
+}
+
+// FlowState is the state of a user's flow that is persisted in a FlowStore.
+type FlowState struct {
+	// Step is the name of the step that handles the next interaction.
+	Step string `json:"step"`
+	// Data is the data of the flow, which is set by its steps.
+	Data json.Raw `json:"data,omitempty"`
+	// Expiry is when the current step expires.
+	Expiry time.Time `json:"expiry"`
+}
+
+// FlowStore persists the state of flows, so that they can survive restarts
+// when it is backed by a database.
+type FlowStore interface {
+	// LoadFlow loads the state of the flow. It returns ErrFlowNotFound if the
+	// flow doesn't exist. It may return an expired flow.
+	LoadFlow(ctx context.Context, key FlowKey) (FlowState, error)
+	// SaveFlow saves the state of the flow.
+	SaveFlow(ctx context.Context, key FlowKey, state FlowState) error
+	// DeleteFlow deletes the flow. Deleting a flow that doesn't exist is not
+	// an error.
+	DeleteFlow(ctx context.Context, key FlowKey) error
+}
+
+// MemoryFlowStore is a FlowStore that keeps the flows in memory. Expired flows
+// are removed when new flows are saved.
+type MemoryFlowStore struct {
+	mu     sync.Mutex
+	states map[FlowKey]FlowState
+}
+
+var _ FlowStore = (*MemoryFlowStore)(nil)
+
+// NewMemoryFlowStore creates a new MemoryFlowStore.
+func NewMemoryFlowStore() *MemoryFlowStore {
+	return &MemoryFlowStore{states: make(map[FlowKey]FlowState)}
+}
+
+// LoadFlow implements FlowStore.
+func (s *MemoryFlowStore) LoadFlow(ctx context.Context, key FlowKey) (FlowState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[key]
+	if !ok {
+		return FlowState{}, ErrFlowNotFound
+	}
+	return state, nil
+}
+
+// SaveFlow implements FlowStore.
+func (s *MemoryFlowStore) SaveFlow(ctx context.Context, key FlowKey, state FlowState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, v := range s.states {
+		if now.After(v.Expiry) {
+			delete(s.states, k)
+		}
+	}
+
+	s.states[key] = state
+	return nil
+}
+
+// DeleteFlow implements FlowStore.
+func (s *MemoryFlowStore) DeleteFlow(ctx context.Context, key FlowKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.states, key)
+	return nil
+}
+
+// FlowStep is a step of a flow.
+type FlowStep struct {
+	// Timeout is the time that the user has to complete the step. If 0, the
+	// Timeout of the flow is used.
+	Timeout time.Duration
+	// Handle handles the interaction of the user at this step. It calls Goto
+	// to move to the next step, or End to end the flow. If it calls neither,
+	// the flow stays at this step, such as when the input is invalid.
+	Handle func(ctx context.Context, s *FlowSession) *api.InteractionResponse
+}
+
+// Flow is a multi-step interaction, such as a command that opens a modal,
+// followed by a confirmation button. A flow is started for a user using Start,
+// usually in a command handler, then each component or modal interaction of
+// the user whose custom ID is made using CustomID is handled by the current
+// step of the flow.
+//
+// Add the flow to a Router using AddFlow:
+//
+//	flow := cmdroute.NewFlow("rename", map[string]cmdroute.FlowStep{
+//		"modal":   {Handle: handleRenameModal},
+//		"confirm": {Handle: handleRenameConfirm, Timeout: time.Minute},
+//	})
+//
+//	r.AddFlow(flow)
+//	r.AddFunc("rename", func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+//		flow.Start(ctx, data.Event.SenderID(), "modal", nil)
+//		...
+//	})
+//
+// Interactions of the same user in the same flow should not be handled
+// concurrently, since each of them loads and saves the state of the flow.
+type Flow struct {
+	// ID is the ID of the flow, which prefixes the custom IDs of its
+	// components and modals.
+	ID string
+	// Steps maps the names of the steps to the steps.
+	Steps map[string]FlowStep
+	// Timeout is the default time that a user has to complete each step.
+	Timeout time.Duration
+	// Store persists the state of the flow.
+	Store FlowStore
+	// OnExpired returns the response to send if the user's flow doesn't exist
+	// or has expired. By default, an ephemeral message is sent.
+	OnExpired func(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse
+	// OnError is called when the state of the flow can't be loaded or saved,
+	// in which case no response is sent. If nil, it does nothing.
+	OnError func(err error)
+}
+
+var _ InteractionHandler = (*Flow)(nil)
+
+// NewFlow creates a new flow with the given ID and steps. It uses a
+// MemoryFlowStore and DefaultFlowTimeout.
+func NewFlow(id string, steps map[string]FlowStep) *Flow {
+	return &Flow{
+		ID:      id,
+		Steps:   steps,
+		Timeout: DefaultFlowTimeout,
+		Store:   NewMemoryFlowStore(),
+	}
+}
+
+// CustomID returns the custom ID of a component or modal in the flow for the
+// given action, which the steps read using FlowSession.Action.
+func (f *Flow) CustomID(action string) discord.ComponentID {
+	return discord.ComponentID(f.ID + FlowSeparator + action)
+}
+
+// Start starts the flow for the user at the given step, replacing the user's
+// current flow if any. The data, if not nil, is marshaled as the data of the
+// flow.
+func (f *Flow) Start(ctx context.Context, userID discord.UserID, step string, data interface{}) error {
+	state := FlowState{Step: step}
+
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal flow data: %w", err)
+		}
+		state.Data = b
+	}
+
+	return f.save(ctx, FlowKey{f.ID, userID}, state)
+}
+
+// Cancel ends the user's flow.
+func (f *Flow) Cancel(ctx context.Context, userID discord.UserID) error {
+	return f.Store.DeleteFlow(ctx, FlowKey{f.ID, userID})
+}
+
+func (f *Flow) save(ctx context.Context, key FlowKey, state FlowState) error {
+	step, ok := f.Steps[state.Step]
+	if !ok {
+		return fmt.Errorf("flow %q has no step %q", f.ID, state.Step)
+	}
+
+	timeout := step.Timeout
+	if timeout == 0 {
+		timeout = f.Timeout
+	}
+	if timeout == 0 {
+		timeout = DefaultFlowTimeout
+	}
+
+	state.Expiry = time.Now().Add(timeout)
+	return f.Store.SaveFlow(ctx, key, state)
+}
+
+// HandleInteraction implements InteractionHandler. It returns nil and does
+// nothing if the interaction isn't a component or modal interaction of the
+// flow.
+func (f *Flow) HandleInteraction(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse {
+	action, ok := f.action(ev)
+	if !ok {
+		return nil
+	}
+
+	key := FlowKey{f.ID, ev.SenderID()}
+
+	state, err := f.Store.LoadFlow(ctx, key)
+	if err != nil && !errors.Is(err, ErrFlowNotFound) {
+		f.error(fmt.Errorf("failed to load flow %q: %w", f.ID, err))
+		return nil
+	}
+
+	step, ok := f.Steps[state.Step]
+	if err != nil || !ok || time.Now().After(state.Expiry) {
+		return f.expired(ctx, ev)
+	}
+
+	session := &FlowSession{
+		Event:  ev,
+		Action: action,
+		state:  state,
+	}
+
+	resp := step.Handle(ctx, session)
+
+	if session.ended {
+		err = f.Store.DeleteFlow(ctx, key)
+	} else {
+		if session.next != "" {
+			session.state.Step = session.next
+		}
+		err = f.save(ctx, key, session.state)
+	}
+
+	if err != nil {
+		f.error(fmt.Errorf("failed to save flow %q: %w", f.ID, err))
+	}
+
+	return resp
+}
+
+// action returns the action of the interaction if it belongs to the flow.
+func (f *Flow) action(ev *discord.InteractionEvent) (string, bool) {
+	var customID discord.ComponentID
+
+	switch data := ev.Data.(type) {
+	case discord.ComponentInteraction:
+		customID = data.ID()
+	case *discord.ModalInteraction:
+		customID = data.CustomID
+	default:
+		return "", false
+	}
+
+	return strings.CutPrefix(string(customID), f.ID+FlowSeparator)
+}
+
+func (f *Flow) expired(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse {
+	if f.OnExpired != nil {
+		return f.OnExpired(ctx, ev)
+	}
+
+	return &api.InteractionResponse{
+		Type: api.MessageInteractionWithSource,
+		Data: &api.InteractionResponseData{
+			Content: option.NewNullableString("This interaction has expired."),
+			Flags:   discord.EphemeralMessage,
+		},
+	}
+}
+
+func (f *Flow) error(err error) {
+	if f.OnError != nil {
+		f.OnError(err)
+	}
+}
+
+// FlowSession is the session of a user's flow while a step handles an
+// interaction.
+type FlowSession struct {
+	// Event is the interaction being handled.
+	Event *discord.InteractionEvent
+	// Action is the action in the custom ID of the component or modal, which
+	// is the part after the flow ID.
+	Action string
+
+	state FlowState
+	next  string
+	ended bool
+}
+
+// Step returns the name of the current step.
+func (s *FlowSession) Step() string { return s.state.Step }
+
+// Data unmarshals the data of the flow into v.
+func (s *FlowSession) Data(v interface{}) error {
+	if len(s.state.Data) == 0 {
+		return nil
+	}
+	return s.state.Data.UnmarshalTo(v)
+}
+
+// SetData sets the data of the flow to v.
+func (s *FlowSession) SetData(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal flow data: %w", err)
+	}
+	s.state.Data = b
+	return nil
+}
+
+// Goto moves the flow to the given step once the current step returns.
+func (s *FlowSession) Goto(step string) {
+	s.next = step
+	s.ended = false
+}
+
+// End ends the flow once the current step returns.
+func (s *FlowSession) End() {
+	s.ended = true
+}
@@ -0,0 +1,128 @@
+package cmdroute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestFlow(t *testing.T) {
+	ctx := context.Background()
+
+	type renameData struct {
+		Name string `json:"name"`
+	}
+
+	reply := func(content string) *api.InteractionResponse {
+		return &api.InteractionResponse{
+			Type: api.MessageInteractionWithSource,
+			Data: &api.InteractionResponseData{Content: option.NewNullableString(content)},
+		}
+	}
+
+	var flow *Flow
+	flow = NewFlow("rename", map[string]FlowStep{
+		"modal": {
+			Handle: func(ctx context.Context, s *FlowSession) *api.InteractionResponse {
+				s.SetData(renameData{Name: s.Action})
+				s.Goto("confirm")
+				return reply("confirm?")
+			},
+		},
+		"confirm": {
+			Timeout: time.Minute,
+			Handle: func(ctx context.Context, s *FlowSession) *api.InteractionResponse {
+				var data renameData
+				if err := s.Data(&data); err != nil {
+					t.Error("failed to get data:", err)
+				}
+				if s.Action != "yes" {
+					return reply("try again")
+				}
+				s.End()
+				return reply("renamed to " + data.Name)
+			},
+		},
+	})
+	flow.OnError = func(err error) { t.Error("flow error:", err) }
+
+	r := NewRouter()
+	r.AddFlow(flow)
+
+	send := func(userID discord.UserID, data discord.InteractionData) string {
+		ev := newInteractionEvent(data)
+		ev.User = &discord.User{ID: userID}
+
+		resp := r.HandleInteraction(ev)
+		if resp == nil || resp.Data == nil {
+			t.Fatal("unexpected nil response")
+		}
+		return resp.Data.Content.Val
+	}
+
+	button := func(action string) discord.InteractionData {
+		return &discord.ButtonInteraction{CustomID: flow.CustomID(action)}
+	}
+
+	if err := flow.Start(ctx, 1, "modal", nil); err != nil {
+		t.Fatal("failed to start flow:", err)
+	}
+
+	steps := []struct {
+		userID discord.UserID
+		data   discord.InteractionData
+		expect string
+	}{
+		{1, &discord.ModalInteraction{CustomID: flow.CustomID("newname")}, "confirm?"},
+		{2, button("yes"), "This interaction has expired."},
+		{1, button("no"), "try again"},
+		{1, button("yes"), "renamed to newname"},
+		{1, button("yes"), "This interaction has expired."},
+	}
+
+	for i, step := range steps {
+		if got := send(step.userID, step.data); got != step.expect {
+			t.Fatalf("step %d: expected %q, got %q", i, step.expect, got)
+		}
+	}
+
+	if resp := r.HandleInteraction(newInteractionEvent(&discord.ButtonInteraction{CustomID: "other"})); resp != nil {
+		t.Fatal("unexpected response for an unrelated component")
+	}
+}
+
+func TestFlowExpired(t *testing.T) {
+	ctx := context.Background()
+
+	flow := NewFlow("expiring", map[string]FlowStep{
+		"step": {
+			Timeout: time.Nanosecond,
+			Handle: func(ctx context.Context, s *FlowSession) *api.InteractionResponse {
+				t.Error("expired step called")
+				return nil
+			},
+		},
+	})
+
+	if err := flow.Start(ctx, 1, "step", nil); err != nil {
+		t.Fatal("failed to start flow:", err)
+	}
+
+	time.Sleep(time.Millisecond)
+
+	ev := newInteractionEvent(&discord.ButtonInteraction{CustomID: flow.CustomID("go")})
+	ev.User = &discord.User{ID: 1}
+
+	resp := flow.HandleInteraction(ctx, ev)
+	if resp == nil || resp.Data == nil || resp.Data.Flags != discord.EphemeralMessage {
+		t.Fatal("expected an ephemeral expired response")
+	}
+
+	if err := flow.Start(ctx, 1, "unknown", nil); err == nil {
+		t.Fatal("expected error starting at an unknown step")
+	}
+}
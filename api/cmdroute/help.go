@@ -0,0 +1,322 @@
+package cmdroute
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// DefaultHelpPerPage is the default number of commands on each page of a help
+// command.
+const DefaultHelpPerPage = 10
+
+// Help is a help command that lists commands in an embed, one page at a time,
+// with buttons to go between the pages. Each command is listed with its
+// options and its description in the language of the user if the command has
+// localizations for it. Commands that the user can't use, per their
+// DefaultMemberPermissions and NoDMPermission, aren't listed.
+//
+// Help is usually given the same commands as OverwriteCommands, then added to
+// the router using AddHelp:
+//
+//	commands := []api.CreateCommandData{...}
+//	help := cmdroute.NewHelp(commands)
+//
+//	r.AddHelp(help)
+//	cmdroute.OverwriteCommands(client, append(commands, help.Data))
+type Help struct {
+	// Data is the data of the help command itself.
+	Data api.CreateCommandData
+	// Commands are the commands to list. Only slash commands are listed.
+	Commands []api.CreateCommandData
+	// ID prefixes the custom IDs of the buttons of the help command. It must
+	// not contain FlowSeparator.
+	ID string
+	// PerPage is the number of commands on each page, up to 25. Each
+	// subcommand counts as a command.
+	PerPage int
+	// Title is the title of the embed.
+	Title string
+	// TitleLocalizations are the localizations of Title.
+	TitleLocalizations discord.StringLocales
+	// Color is the color of the embed.
+	Color discord.Color
+	// Flags are the flags of the response, which is ephemeral by default.
+	Flags discord.MessageFlags
+}
+
+var (
+	_ CommandHandler     = (*Help)(nil)
+	_ InteractionHandler = (*Help)(nil)
+)
+
+// NewHelp creates a new help command named "help" that lists the given
+// commands.
+func NewHelp(commands []api.CreateCommandData) *Help {
+	return &Help{
+		Data: api.CreateCommandData{
+			Name:        "help",
+			Description: "List the commands.",
+			Type:        discord.ChatInputCommand,
+		},
+		Commands: commands,
+		ID:       "help",
+		PerPage:  DefaultHelpPerPage,
+		Title:    "Commands",
+		Color:    discord.DefaultEmbedColor,
+		Flags:    discord.EphemeralMessage,
+	}
+}
+
+// HandleCommand implements CommandHandler. It responds with the first page.
+func (h *Help) HandleCommand(ctx context.Context, data CommandData) *api.InteractionResponseData {
+	return h.Page(data.Event, 1)
+}
+
+// HandleInteraction implements InteractionHandler. It handles the buttons of
+// the pages by updating the message to the page of the button.
+func (h *Help) HandleInteraction(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse {
+	data, ok := ev.Data.(discord.ComponentInteraction)
+	if !ok {
+		return nil
+	}
+
+	page, ok := strings.CutPrefix(string(data.ID()), h.ID+FlowSeparator)
+	if !ok {
+		return nil
+	}
+
+	n, err := strconv.Atoi(page)
+	if err != nil {
+		return nil
+	}
+
+	return &api.InteractionResponse{
+		Type: api.UpdateMessage,
+		Data: h.Page(ev, n),
+	}
+}
+
+// Page returns the given page, starting from 1, of the commands that the
+// sender of the interaction can use. The page is clamped to the existing
+// pages.
+func (h *Help) Page(ev *discord.InteractionEvent, page int) *api.InteractionResponseData {
+	fields := h.fields(ev)
+
+	perPage := h.PerPage
+	if perPage <= 0 || perPage > 25 {
+		perPage = DefaultHelpPerPage
+	}
+
+	pages := (len(fields) + perPage - 1) / perPage
+	if pages == 0 {
+		pages = 1
+	}
+
+	switch {
+	case page < 1:
+		page = 1
+	case page > pages:
+		page = pages
+	}
+
+	start := (page - 1) * perPage
+	end := start + perPage
+	if end > len(fields) {
+		end = len(fields)
+	}
+
+	data := &api.InteractionResponseData{
+		Embeds: &[]discord.Embed{{
+			Title:  localize(ev.Locale, h.Title, h.TitleLocalizations),
+			Color:  h.Color,
+			Fields: fields[start:end],
+			Footer: &discord.EmbedFooter{Text: fmt.Sprintf("%d/%d", page, pages)},
+		}},
+		Flags: h.Flags,
+	}
+
+	if pages > 1 {
+		data.Components = discord.ComponentsPtr(
+			&discord.ButtonComponent{
+				Style:    discord.SecondaryButtonStyle(),
+				CustomID: h.pageID(page - 1),
+				Label:    "◀",
+				Disabled: page == 1,
+			},
+			&discord.ButtonComponent{
+				Style:    discord.SecondaryButtonStyle(),
+				CustomID: h.pageID(page + 1),
+				Label:    "▶",
+				Disabled: page == pages,
+			},
+		)
+	} else {
+		data.Components = &discord.ContainerComponents{}
+	}
+
+	return data
+}
+
+func (h *Help) pageID(page int) discord.ComponentID {
+	return discord.ComponentID(h.ID + FlowSeparator + strconv.Itoa(page))
+}
+
+// fields returns a field for each command and subcommand that the sender of
+// the interaction can use.
+func (h *Help) fields(ev *discord.InteractionEvent) []discord.EmbedField {
+	var fields []discord.EmbedField
+
+	for _, cmd := range h.Commands {
+		if cmd.Type != 0 && cmd.Type != discord.ChatInputCommand {
+			continue
+		}
+		if !canUseCommand(ev, cmd) {
+			continue
+		}
+
+		name := "/" + localize(ev.Locale, cmd.Name, cmd.NameLocalizations)
+		fields = appendHelpFields(fields, ev.Locale, name,
+			localize(ev.Locale, cmd.Description, cmd.DescriptionLocalizations), cmd.Options)
+	}
+
+	return fields
+}
+
+// canUseCommand returns true if the sender of the interaction can use the
+// command by default.
+func canUseCommand(ev *discord.InteractionEvent, cmd api.CreateCommandData) bool {
+	if !ev.GuildID.IsValid() {
+		return !cmd.NoDMPermission
+	}
+
+	if cmd.DefaultMemberPermissions == nil {
+		return true
+	}
+
+	var perms discord.Permissions
+	if ev.Member != nil {
+		perms = ev.Member.Permissions
+	}
+
+	if perms.Has(discord.PermissionAdministrator) {
+		return true
+	}
+
+	// No permissions means that only administrators can use the command.
+	required := *cmd.DefaultMemberPermissions
+	return required != 0 && perms.Has(required)
+}
+
+func appendHelpFields(
+	fields []discord.EmbedField, lang discord.Language,
+	name, description string, options []discord.CommandOption) []discord.EmbedField {
+
+	var values []discord.CommandOption
+
+	for _, opt := range options {
+		switch opt := opt.(type) {
+		case *discord.SubcommandGroupOption:
+			groupName := name + " " + localize(lang, opt.OptionName, opt.OptionNameLocalizations)
+			for _, sub := range opt.Subcommands {
+				fields = appendSubcommandField(fields, lang, groupName, sub)
+			}
+		case *discord.SubcommandOption:
+			fields = appendSubcommandField(fields, lang, name, opt)
+		default:
+			values = append(values, opt)
+		}
+	}
+
+	// Commands with subcommands can't be invoked by themselves.
+	if len(values) == 0 && len(options) > 0 {
+		return fields
+	}
+
+	return append(fields, helpField(lang, name, description, values))
+}
+
+func appendSubcommandField(
+	fields []discord.EmbedField, lang discord.Language,
+	name string, sub *discord.SubcommandOption) []discord.EmbedField {
+
+	values := make([]discord.CommandOption, len(sub.Options))
+	for i, opt := range sub.Options {
+		values[i] = opt
+	}
+
+	return append(fields, helpField(lang,
+		name+" "+localize(lang, sub.OptionName, sub.OptionNameLocalizations),
+		localize(lang, sub.Description, sub.DescriptionLocalizations),
+		values,
+	))
+}
+
+// helpField returns the field of a command, whose name is the usage of the
+// command and whose value is its description followed by the descriptions of
+// its options.
+func helpField(lang discord.Language, name, description string, options []discord.CommandOption) discord.EmbedField {
+	usage := name
+	var value strings.Builder
+	value.WriteString(description)
+
+	for _, opt := range options {
+		optName, optDescription := localizeOption(lang, opt)
+		if discord.OptionRequired(opt) {
+			usage += " <" + optName + ">"
+		} else {
+			usage += " [" + optName + "]"
+		}
+		fmt.Fprintf(&value, "\n`%s`: %s", optName, optDescription)
+	}
+
+	return discord.EmbedField{Name: usage, Value: value.String()}
+}
+
+// localizeOption returns the name and description of the option in the given
+// language.
+func localizeOption(lang discord.Language, opt discord.CommandOption) (name, description string) {
+	switch opt := opt.(type) {
+	case *discord.StringOption:
+		return localizeNames(lang, opt.OptionName, opt.OptionNameLocalizations, opt.Description, opt.DescriptionLocalizations)
+	case *discord.IntegerOption:
+		return localizeNames(lang, opt.OptionName, opt.OptionNameLocalizations, opt.Description, opt.DescriptionLocalizations)
+	case *discord.NumberOption:
+		return localizeNames(lang, opt.OptionName, opt.OptionNameLocalizations, opt.Description, opt.DescriptionLocalizations)
+	case *discord.BooleanOption:
+		return localizeNames(lang, opt.OptionName, opt.OptionNameLocalizations, opt.Description, opt.DescriptionLocalizations)
+	case *discord.UserOption:
+		return localizeNames(lang, opt.OptionName, opt.OptionNameLocalizations, opt.Description, opt.DescriptionLocalizations)
+	case *discord.ChannelOption:
+		return localizeNames(lang, opt.OptionName, opt.OptionNameLocalizations, opt.Description, opt.DescriptionLocalizations)
+	case *discord.RoleOption:
+		return localizeNames(lang, opt.OptionName, opt.OptionNameLocalizations, opt.Description, opt.DescriptionLocalizations)
+	case *discord.MentionableOption:
+		return localizeNames(lang, opt.OptionName, opt.OptionNameLocalizations, opt.Description, opt.DescriptionLocalizations)
+	case *discord.AttachmentOption:
+		return localizeNames(lang, opt.OptionName, opt.OptionNameLocalizations, opt.Description, opt.DescriptionLocalizations)
+	default:
+		return opt.Name(), ""
+	}
+}
+
+func localizeNames(
+	lang discord.Language,
+	name string, nameLocales discord.StringLocales,
+	description string, descriptionLocales discord.StringLocales) (string, string) {
+
+	return localize(lang, name, nameLocales), localize(lang, description, descriptionLocales)
+}
+
+// localize returns the string in the given language if it has a localization,
+// otherwise the string itself.
+func localize(lang discord.Language, s string, locales discord.StringLocales) string {
+	if localized, ok := locales[lang]; ok && localized != "" {
+		return localized
+	}
+	return s
+}
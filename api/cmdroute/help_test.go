@@ -0,0 +1,114 @@
+package cmdroute
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestHelp(t *testing.T) {
+	banPerms := discord.PermissionBanMembers
+
+	help := NewHelp([]api.CreateCommandData{
+		{
+			Name:        "ping",
+			Description: "Ping the bot.",
+			DescriptionLocalizations: discord.StringLocales{
+				discord.French: "Ping le bot.",
+			},
+		},
+		{
+			Name:        "echo",
+			Description: "Echo some text.",
+			Options: discord.CommandOptions{
+				&discord.StringOption{OptionName: "text", Description: "The text.", Required: true},
+				&discord.IntegerOption{OptionName: "times", Description: "How many times."},
+			},
+		},
+		{
+			Name:                     "ban",
+			Description:              "Ban a user.",
+			DefaultMemberPermissions: &banPerms,
+		},
+		{
+			Name:        "role",
+			Description: "Manage roles.",
+			Options: discord.CommandOptions{
+				&discord.SubcommandOption{OptionName: "add", Description: "Add a role."},
+				&discord.SubcommandOption{OptionName: "remove", Description: "Remove a role."},
+			},
+		},
+		{
+			Name: "Report",
+			Type: discord.UserCommand,
+		},
+	})
+	help.PerPage = 2
+
+	r := NewRouter()
+	r.AddHelp(help)
+
+	ev := func(data discord.InteractionData, perms discord.Permissions) *discord.InteractionEvent {
+		ev := newInteractionEvent(data)
+		ev.GuildID = 400
+		ev.Locale = discord.French
+		ev.Member = &discord.Member{User: discord.User{ID: 1}, Permissions: perms}
+		return ev
+	}
+
+	fieldNames := func(data *api.InteractionResponseData) string {
+		var names []string
+		for _, field := range (*data.Embeds)[0].Fields {
+			names = append(names, field.Name)
+		}
+		return strings.Join(names, ", ")
+	}
+
+	resp := r.HandleInteraction(ev(&discord.CommandInteraction{Name: "help"}, 0))
+	if resp == nil || resp.Data == nil {
+		t.Fatal("unexpected nil response")
+	}
+
+	if names := fieldNames(resp.Data); names != "/ping, /echo <text> [times]" {
+		t.Errorf("unexpected first page %q", names)
+	}
+	if field := (*resp.Data.Embeds)[0].Fields[0]; field.Value != "Ping le bot." {
+		t.Errorf("unexpected localized description %q", field.Value)
+	}
+	if footer := (*resp.Data.Embeds)[0].Footer.Text; footer != "1/2" {
+		t.Errorf("unexpected footer %q", footer)
+	}
+
+	resp = r.HandleInteraction(ev(&discord.ButtonInteraction{CustomID: "help:2"}, 0))
+	if resp == nil || resp.Type != api.UpdateMessage {
+		t.Fatalf("unexpected response %s", strInteractionResp(resp))
+	}
+
+	if names := fieldNames(resp.Data); names != "/role add, /role remove" {
+		t.Errorf("unexpected second page %q", names)
+	}
+
+	page := help.Page(ev(&discord.CommandInteraction{Name: "help"}, discord.PermissionBanMembers), 2)
+	if names := fieldNames(page); names != "/ban, /role add" {
+		t.Errorf("unexpected second page with permissions %q", names)
+	}
+}
+
+func TestLocalizeOption(t *testing.T) {
+	opt := &discord.AttachmentOption{
+		OptionName:               "file",
+		OptionNameLocalizations:  discord.StringLocales{discord.French: "fichier"},
+		Description:              "The file.",
+		DescriptionLocalizations: discord.StringLocales{discord.French: "Le fichier."},
+	}
+
+	if name, desc := localizeOption(discord.French, opt); name != "fichier" || desc != "Le fichier." {
+		t.Errorf("unexpected French option %q: %q", name, desc)
+	}
+
+	if name, desc := localizeOption(discord.German, opt); name != "file" || desc != "The file." {
+		t.Errorf("unexpected fallback option %q: %q", name, desc)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/settings"
 )
 
 type ctxKey uint8
@@ -14,6 +15,7 @@ const (
 	_ ctxKey = iota
 	ctxCtx
 	deferTicketCtx
+	settingsCtx
 )
 
 // UseContext returns a middleware that override the handler context to the
@@ -27,6 +29,23 @@ func UseContext(ctx context.Context) Middleware {
 	}
 }
 
+// UseSettings returns a middleware that gives the settings store to the
+// handlers through their context. Handlers get it using SettingsFromContext.
+func UseSettings(store settings.Store) Middleware {
+	return func(next InteractionHandler) InteractionHandler {
+		return InteractionHandlerFunc(func(ctx context.Context, ev *discord.InteractionEvent) *api.InteractionResponse {
+			return next.HandleInteraction(context.WithValue(ctx, settingsCtx, store), ev)
+		})
+	}
+}
+
+// SettingsFromContext returns the settings store given by UseSettings. If
+// there is none, it returns nil.
+func SettingsFromContext(ctx context.Context) settings.Store {
+	store, _ := ctx.Value(settingsCtx).(settings.Store)
+	return store
+}
+
 // FollowUpSender is a type that can send follow-up messages. Usually, anything
 // that extends *api.Client can be used as a FollowUpSender.
 type FollowUpSender interface {
@@ -0,0 +1,25 @@
+package cmdroute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/settings"
+)
+
+func TestUseSettings(t *testing.T) {
+	store := settings.NewMemory()
+
+	r := NewRouter()
+	r.Use(UseSettings(store))
+	r.AddFunc("test", func(ctx context.Context, data CommandData) *api.InteractionResponseData {
+		if SettingsFromContext(ctx) != settings.Store(store) {
+			t.Error("unexpected settings store in context")
+		}
+		return nil
+	})
+
+	r.HandleInteraction(newInteractionEvent(&discord.CommandInteraction{ID: 4, Name: "test"}))
+}
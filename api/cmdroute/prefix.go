@@ -0,0 +1,430 @@
+package cmdroute
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// CommandOptioner is a CommandHandler that declares its options, such as
+// Command. The Router uses the options to parse the arguments of prefix
+// commands. Handlers that aren't CommandOptioners are invoked without options.
+type CommandOptioner interface {
+	CommandHandler
+	// CommandOptions returns the options of the command.
+	CommandOptions() (discord.CommandOptions, error)
+}
+
+// ArgumentError is returned by HandleMessage if the arguments of a prefix
+// command don't match the options of the command.
+type ArgumentError struct {
+	// Command is the full name of the command, such as "role add".
+	Command string
+	// Usage is the usage of the command, such as "!role add <role> [reason]".
+	Usage string
+	// Err is the reason why the arguments are invalid.
+	Err error
+}
+
+// Error implements error.
+func (err *ArgumentError) Error() string {
+	return fmt.Sprintf("invalid arguments for command %q: %v", err.Command, err.Err)
+}
+
+// Unwrap returns the underlying error.
+func (err *ArgumentError) Unwrap() error {
+	return err.Err
+}
+
+// HandleMessage handles the message as a prefix command, which lets the same
+// handlers serve both slash commands and classic message commands for bots
+// that are migrating to slash commands. If the content of the message starts
+// with the prefix, then the words after it are the name of the command and its
+// subcommands, followed by the arguments:
+//
+//	!echo "hello world" 3
+//	!role add @Moderators
+//
+// The arguments are parsed in the order of the options of the command, with
+// the required options first, such that a Command receives them in its typed
+// options struct. Quotes can be used to give an argument with spaces. If the
+// last option is a string option, it takes the rest of the arguments. Users,
+// channels and roles can be given as mentions or IDs.
+//
+// The handlers receive an event made from the message, whose Message field is
+// the message and whose Token is empty. Middlewares are applied as usual, but
+// middlewares that respond to the interaction through the API, such as
+// Deferrable, don't work with prefix commands.
+//
+// HandleMessage returns the response of the handler as a reply to the message,
+// or nil if the message isn't a command of the router or the handler returns
+// no response. If the arguments are invalid, an *ArgumentError is returned:
+//
+//	s.AddHandler(func(ev *gateway.MessageCreateEvent) {
+//		data, err := r.HandleMessage(ev, "!")
+//		var argErr *cmdroute.ArgumentError
+//		if errors.As(err, &argErr) {
+//			data = &api.SendMessageData{Content: "Usage: " + argErr.Usage}
+//		}
+//		if data != nil {
+//			s.SendMessageComplex(ev.ChannelID, *data)
+//		}
+//	})
+//
+// Messages from bots, including the bot's own messages, are ignored, so that
+// bots can't trigger each other's commands or their own. Note that reading the
+// content of messages requires the message content intent.
+func (r *Router) HandleMessage(ev *gateway.MessageCreateEvent, prefix string) (*api.SendMessageData, error) {
+	if ev.Author.Bot {
+		return nil, nil
+	}
+
+	content, ok := strings.CutPrefix(ev.Content, prefix)
+	if !ok {
+		return nil, nil
+	}
+
+	args, err := splitArguments(content)
+	if err != nil {
+		return nil, err
+	}
+
+	found, path, args, ok := r.findPrefixCommand(args, nil)
+	if !ok {
+		return nil, nil
+	}
+
+	var opts discord.CommandOptions
+	if optioner, ok := found.handler.(CommandOptioner); ok {
+		opts, err = optioner.CommandOptions()
+		if err != nil {
+			return nil, fmt.Errorf("command %q: %w", strings.Join(path, " "), err)
+		}
+	}
+
+	options, err := parseArguments(opts, args)
+	if err != nil {
+		return nil, &ArgumentError{
+			Command: strings.Join(path, " "),
+			Usage:   prefixUsage(prefix, path, opts),
+			Err:     err,
+		}
+	}
+
+	// Nest the options into the subcommands, innermost first, as Discord
+	// would send them.
+	found.data = discord.CommandInteractionOption{
+		Type:    discord.SubcommandOptionType,
+		Name:    path[len(path)-1],
+		Options: options,
+	}
+	if len(path) > 1 {
+		options = []discord.CommandInteractionOption{found.data}
+		for i := len(path) - 2; i > 0; i-- {
+			options = []discord.CommandInteractionOption{{
+				Type:    discord.SubcommandGroupOptionType,
+				Name:    path[i],
+				Options: options,
+			}}
+		}
+	}
+
+	resp := found.router.callCommandHandler(messageInteractionEvent(ev, path[0], options), found)
+	return messageResponse(&ev.Message, resp), nil
+}
+
+func (r *Router) findPrefixCommand(args, path []string) (handlerData, []string, []string, bool) {
+	if len(args) == 0 {
+		return handlerData{}, nil, nil, false
+	}
+
+	path = append(path, args[0])
+
+	switch node := r.nodes[args[0]].(type) {
+	case routeNodeSub:
+		return node.findPrefixCommand(args[1:], path)
+	case routeNodeCommand:
+		return handlerData{router: r, handler: node.command}, path, args[1:], true
+	default:
+		return handlerData{}, nil, nil, false
+	}
+}
+
+// messageInteractionEvent returns the event of a command invoked by a message.
+func messageInteractionEvent(
+	msg *gateway.MessageCreateEvent, name string, options []discord.CommandInteractionOption) *discord.InteractionEvent {
+
+	ev := &discord.InteractionEvent{
+		Data: &discord.CommandInteraction{
+			Name:    name,
+			Options: options,
+			GuildID: msg.GuildID,
+		},
+		ChannelID: msg.ChannelID,
+		GuildID:   msg.GuildID,
+		Message:   &msg.Message,
+	}
+
+	if msg.GuildID.IsValid() && msg.Member != nil {
+		member := *msg.Member
+		member.User = msg.Author
+		ev.Member = &member
+	} else {
+		author := msg.Author
+		ev.User = &author
+	}
+
+	return ev
+}
+
+// messageResponse converts the response of a handler into a reply to the
+// message.
+func messageResponse(msg *discord.Message, resp *api.InteractionResponse) *api.SendMessageData {
+	if resp == nil || resp.Data == nil || resp.Type != api.MessageInteractionWithSource {
+		return nil
+	}
+
+	data := &api.SendMessageData{
+		TTS:             resp.Data.TTS,
+		Files:           resp.Data.Files,
+		AllowedMentions: resp.Data.AllowedMentions,
+		Reference:       &discord.MessageReference{MessageID: msg.ID},
+		// Ephemeral messages can't be sent outside interactions.
+		Flags: resp.Data.Flags & discord.SuppressEmbeds,
+	}
+
+	if resp.Data.Content != nil {
+		data.Content = resp.Data.Content.Val
+	}
+	if resp.Data.Embeds != nil {
+		data.Embeds = *resp.Data.Embeds
+	}
+	if resp.Data.Components != nil {
+		data.Components = *resp.Data.Components
+	}
+
+	if data.Content == "" && len(data.Embeds) == 0 && len(data.Components) == 0 && len(data.Files) == 0 {
+		return nil
+	}
+
+	return data
+}
+
+// splitArguments splits the content of a message into words. Words in double
+// quotes are kept together.
+func splitArguments(content string) ([]string, error) {
+	var args []string
+	var word strings.Builder
+	var quoted, inWord bool
+
+	for _, r := range content {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inWord = true
+		case !quoted && (r == ' ' || r == '\n' || r == '\t'):
+			if inWord {
+				args = append(args, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quoted {
+		return nil, errors.New("unclosed quote")
+	}
+	if inWord {
+		args = append(args, word.String())
+	}
+
+	return args, nil
+}
+
+// parseArguments parses the arguments into the options in order.
+func parseArguments(opts discord.CommandOptions, args []string) ([]discord.CommandInteractionOption, error) {
+	options := make([]discord.CommandInteractionOption, 0, len(args))
+
+	for i, opt := range opts {
+		if len(args) == 0 {
+			if discord.OptionRequired(opt) {
+				return nil, fmt.Errorf("missing option %q", opt.Name())
+			}
+			break
+		}
+
+		arg := args[0]
+		args = args[1:]
+
+		// The last string option takes the rest of the arguments.
+		if _, ok := opt.(*discord.StringOption); ok && i == len(opts)-1 && len(args) > 0 {
+			arg = strings.Join(append([]string{arg}, args...), " ")
+			args = nil
+		}
+
+		value, err := parseArgument(opt, arg)
+		if err != nil {
+			return nil, fmt.Errorf("option %q: %w", opt.Name(), err)
+		}
+
+		options = append(options, discord.CommandInteractionOption{
+			Type:  opt.Type(),
+			Name:  opt.Name(),
+			Value: value,
+		})
+	}
+
+	if len(args) > 0 {
+		return nil, fmt.Errorf("too many arguments")
+	}
+
+	return options, nil
+}
+
+// parseArgument parses the argument into the JSON value of the option.
+func parseArgument(opt discord.CommandOption, arg string) (json.Raw, error) {
+	switch opt := opt.(type) {
+	case *discord.StringOption:
+		if len(opt.Choices) > 0 {
+			choice, ok := findChoice(opt.Choices, arg, func(c discord.StringChoice) (string, string) {
+				return c.Name, c.Value
+			})
+			if !ok {
+				return nil, fmt.Errorf("%q is not one of the choices", arg)
+			}
+			arg = choice.Value
+		}
+		if opt.MinLength != nil && len([]rune(arg)) < *opt.MinLength {
+			return nil, fmt.Errorf("must be at least %d characters long", *opt.MinLength)
+		}
+		if opt.MaxLength != nil && len([]rune(arg)) > *opt.MaxLength {
+			return nil, fmt.Errorf("must be at most %d characters long", *opt.MaxLength)
+		}
+		return json.Marshal(arg)
+
+	case *discord.IntegerOption:
+		if len(opt.Choices) > 0 {
+			choice, ok := findChoice(opt.Choices, arg, func(c discord.IntegerChoice) (string, string) {
+				return c.Name, strconv.Itoa(c.Value)
+			})
+			if !ok {
+				return nil, fmt.Errorf("%q is not one of the choices", arg)
+			}
+			return json.Marshal(choice.Value)
+		}
+		i, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", arg)
+		}
+		if opt.Min != nil && i < *opt.Min {
+			return nil, fmt.Errorf("must be at least %d", *opt.Min)
+		}
+		if opt.Max != nil && i > *opt.Max {
+			return nil, fmt.Errorf("must be at most %d", *opt.Max)
+		}
+		return json.Marshal(i)
+
+	case *discord.NumberOption:
+		if len(opt.Choices) > 0 {
+			choice, ok := findChoice(opt.Choices, arg, func(c discord.NumberChoice) (string, string) {
+				return c.Name, strconv.FormatFloat(c.Value, 'f', -1, 64)
+			})
+			if !ok {
+				return nil, fmt.Errorf("%q is not one of the choices", arg)
+			}
+			return json.Marshal(choice.Value)
+		}
+		f, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", arg)
+		}
+		if opt.Min != nil && f < *opt.Min {
+			return nil, fmt.Errorf("must be at least %v", *opt.Min)
+		}
+		if opt.Max != nil && f > *opt.Max {
+			return nil, fmt.Errorf("must be at most %v", *opt.Max)
+		}
+		return json.Marshal(f)
+
+	case *discord.BooleanOption:
+		b, err := strconv.ParseBool(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false", arg)
+		}
+		return json.Marshal(b)
+
+	case *discord.UserOption:
+		return parseMention(arg, "<@!", "<@")
+	case *discord.ChannelOption:
+		return parseMention(arg, "<#")
+	case *discord.RoleOption:
+		return parseMention(arg, "<@&")
+	case *discord.MentionableOption:
+		return parseMention(arg, "<@&", "<@!", "<@")
+
+	default:
+		return nil, fmt.Errorf("%v options can't be given in messages", opt.Type())
+	}
+}
+
+// findChoice finds the choice whose name or value is the argument, ignoring
+// case.
+func findChoice[T any](choices []T, arg string, fn func(T) (name, value string)) (T, bool) {
+	for _, choice := range choices {
+		name, value := fn(choice)
+		if strings.EqualFold(arg, name) || strings.EqualFold(arg, value) {
+			return choice, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// parseMention parses a mention with one of the given prefixes, or a raw ID,
+// into the JSON value of a snowflake option.
+func parseMention(arg string, prefixes ...string) (json.Raw, error) {
+	id := arg
+	if strings.HasSuffix(arg, ">") {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(arg, prefix) {
+				id = strings.TrimSuffix(strings.TrimPrefix(arg, prefix), ">")
+				break
+			}
+		}
+	}
+
+	sf, err := discord.ParseSnowflake(id)
+	if err != nil || !sf.IsValid() {
+		return nil, fmt.Errorf("%q is not a valid mention or ID", arg)
+	}
+
+	return json.Marshal(sf)
+}
+
+// prefixUsage returns the usage of a prefix command, with the required options
+// in angle brackets and the optional options in square brackets.
+func prefixUsage(prefix string, path []string, opts discord.CommandOptions) string {
+	var usage strings.Builder
+	usage.WriteString(prefix)
+	usage.WriteString(strings.Join(path, " "))
+
+	for _, opt := range opts {
+		if discord.OptionRequired(opt) {
+			fmt.Fprintf(&usage, " <%s>", opt.Name())
+		} else {
+			fmt.Fprintf(&usage, " [%s]", opt.Name())
+		}
+	}
+
+	return usage.String()
+}
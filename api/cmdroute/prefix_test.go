@@ -0,0 +1,150 @@
+package cmdroute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestRouterHandleMessage(t *testing.T) {
+	type echoOptions struct {
+		Times int    `discord:"times"`
+		Text  string `discord:"text"`
+	}
+
+	type banOptions struct {
+		User   discord.UserID `discord:"user"`
+		Reason string         `discord:"reason?"`
+	}
+
+	r := NewRouter()
+	r.Add("echo", NewCommand("echo", "Echo some text.",
+		func(ctx context.Context, data CommandData, opts *echoOptions) *api.InteractionResponseData {
+			return &api.InteractionResponseData{
+				Content: option.NewNullableString(fmt.Sprintf("%d %s", opts.Times, opts.Text)),
+				Flags:   discord.EphemeralMessage,
+			}
+		}))
+	r.Sub("mod", func(r *Router) {
+		r.Add("ban", NewCommand("ban", "Ban a user.",
+			func(ctx context.Context, data CommandData, opts *banOptions) *api.InteractionResponseData {
+				if data.Event.Message == nil || data.Event.Sender().ID != 1 {
+					t.Errorf("unexpected event %+v", data.Event)
+				}
+				return &api.InteractionResponseData{
+					Content: option.NewNullableString(fmt.Sprintf("%s: %s", data.Name, opts.User.Mention()+opts.Reason)),
+				}
+			}))
+	})
+
+	message := func(content string) *gateway.MessageCreateEvent {
+		return &gateway.MessageCreateEvent{
+			Message: discord.Message{
+				ID:        10,
+				ChannelID: 20,
+				Author:    discord.User{ID: 1},
+				Content:   content,
+			},
+		}
+	}
+
+	tests := []struct {
+		content string
+		expect  string
+	}{
+		{`!echo 3 "hello world"`, "3 hello world"},
+		{`!echo 3 hello world`, "3 hello world"},
+		{`!mod ban <@!5>`, "ban: <@5>"},
+		{`!mod ban 5 spamming`, "ban: <@5>spamming"},
+		{`echo 3 hi`, ""},
+		{`!unknown`, ""},
+		{`!mod`, ""},
+	}
+
+	for _, test := range tests {
+		data, err := r.HandleMessage(message(test.content), "!")
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.content, err)
+			continue
+		}
+
+		if test.expect == "" {
+			if data != nil {
+				t.Errorf("%q: unexpected response %+v", test.content, data)
+			}
+			continue
+		}
+
+		if data == nil || data.Content != test.expect {
+			t.Errorf("%q: unexpected response %+v", test.content, data)
+			continue
+		}
+
+		if data.Flags != 0 || data.Reference == nil || data.Reference.MessageID != 10 {
+			t.Errorf("%q: unexpected reply %+v", test.content, data)
+		}
+	}
+
+	// Bots, such as the bot itself replying with a command, are ignored.
+	bot := message(`!echo 3 hello`)
+	bot.Author.Bot = true
+	if data, err := r.HandleMessage(bot, "!"); data != nil || err != nil {
+		t.Errorf("unexpected response to a bot: %+v, %v", data, err)
+	}
+
+	invalid := []struct {
+		content string
+		usage   string
+	}{
+		{`!echo three hi`, "!echo <times> <text>"},
+		{`!echo 3`, "!echo <times> <text>"},
+		{`!mod ban @someone`, "!mod ban <user> [reason]"},
+	}
+
+	for _, test := range invalid {
+		_, err := r.HandleMessage(message(test.content), "!")
+
+		var argErr *ArgumentError
+		if !errors.As(err, &argErr) {
+			t.Errorf("%q: expected an argument error, got %v", test.content, err)
+			continue
+		}
+
+		if argErr.Usage != test.usage {
+			t.Errorf("%q: expected usage %q, got %q", test.content, test.usage, argErr.Usage)
+		}
+	}
+}
+
+func TestSplitArguments(t *testing.T) {
+	args, err := splitArguments(`a  "b c" d"e f" ""`)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expect := []string{"a", "b c", "de f", ""}
+	if fmt.Sprint(args) != fmt.Sprint(expect) || len(args) != len(expect) {
+		t.Fatalf("expected %q, got %q", expect, args)
+	}
+
+	if _, err := splitArguments(`"unclosed`); err == nil {
+		t.Fatal("expected an error for an unclosed quote")
+	}
+}
+
+func TestPrefixUsage(t *testing.T) {
+	opts := discord.CommandOptions{
+		&discord.AttachmentOption{OptionName: "file", Required: true},
+		&discord.StringOption{OptionName: "note"},
+	}
+
+	if usage := prefixUsage("!", []string{"upload"}, opts); usage != "!upload <file> [note]" {
+		t.Fatalf("unexpected usage %q", usage)
+	}
+}
@@ -2,6 +2,7 @@ package cmdroute
 
 import (
 	"context"
+	"strings"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/api/webhook"
@@ -30,9 +31,16 @@ type routeNodeComponent struct {
 	component ComponentHandler
 }
 
+// routeNodePrefix handles the component and modal interactions whose custom
+// IDs start with its key, such as flows.
+type routeNodePrefix struct {
+	handler InteractionHandler
+}
+
 func (routeNodeSub) isRouteNode()       {}
 func (routeNodeCommand) isRouteNode()   {}
 func (routeNodeComponent) isRouteNode() {}
+func (routeNodePrefix) isRouteNode()    {}
 
 var _ webhook.InteractionHandler = (*Router)(nil)
 
@@ -102,6 +110,8 @@ func (r *Router) HandleInteraction(ev *discord.InteractionEvent) *api.Interactio
 		return r.HandleAutocompletion(ev, data)
 	case discord.ComponentInteraction:
 		return r.handleComponent(ev, data)
+	case *discord.ModalInteraction:
+		return r.handlePrefix(ev, data.CustomID)
 	default:
 		return nil
 	}
@@ -332,6 +342,32 @@ func (r *Router) handleComponent(ev *discord.InteractionEvent, component discord
 	if ok {
 		return r.callComponentHandler(ev, node.component)
 	}
+	return r.handlePrefix(ev, component.ID())
+}
+
+// AddFlow registers a flow, which handles the component and modal interactions
+// whose custom IDs are made using its CustomID method.
+func (r *Router) AddFlow(f *Flow) {
+	r.add(f.ID+FlowSeparator, routeNodePrefix{f})
+}
+
+// AddHelp registers a help command under the name in its Data, along with the
+// buttons that go between its pages.
+func (r *Router) AddHelp(h *Help) {
+	r.Add(h.Data.Name, h)
+	r.add(h.ID+FlowSeparator, routeNodePrefix{h})
+}
+
+func (r *Router) handlePrefix(ev *discord.InteractionEvent, customID discord.ComponentID) *api.InteractionResponse {
+	prefix, _, ok := strings.Cut(string(customID), FlowSeparator)
+	if !ok {
+		return nil
+	}
+
+	node, ok := r.nodes[prefix+FlowSeparator].(routeNodePrefix)
+	if ok {
+		return r.callHandler(ev, node.handler.HandleInteraction)
+	}
 	return nil
 }
 
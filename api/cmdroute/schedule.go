@@ -0,0 +1,290 @@
+package cmdroute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/clock"
+)
+
+// InteractionTokenLifetime is how long an interaction token can be used to
+// edit the response and send follow-up messages.
+const InteractionTokenLifetime = 15 * time.Minute
+
+// ErrTokenExpired is returned by a Scheduler if the interaction token expires
+// before the scheduled time.
+var ErrTokenExpired = errors.New("interaction token expires before the scheduled time")
+
+// InteractionToken is the token of an interaction, which is needed to edit its
+// response.
+type InteractionToken struct {
+	AppID         discord.AppID
+	InteractionID discord.InteractionID
+	Token         string
+	// Expiry is when the token stops being valid.
+	Expiry time.Time
+}
+
+// NewInteractionToken returns the token of the interaction. Its expiry is
+// computed from when the interaction was created.
+func NewInteractionToken(ev *discord.InteractionEvent) InteractionToken {
+	return InteractionToken{
+		AppID:         ev.AppID,
+		InteractionID: ev.ID,
+		Token:         ev.Token,
+		Expiry:        ev.ID.Time().Add(InteractionTokenLifetime),
+	}
+}
+
+// Valid returns true if the token is still valid at the given time.
+func (t InteractionToken) Valid(now time.Time) bool {
+	return t.Token != "" && now.Before(t.Expiry)
+}
+
+// TokenStore stores interaction tokens until they expire. It is safe for
+// concurrent use. A zero-value TokenStore is a valid store.
+type TokenStore struct {
+	// Clock is the clock used to check if the tokens have expired. It is
+	// clock.System if nil. It must be set before the TokenStore is used.
+	Clock clock.Clock
+
+	mu     sync.Mutex
+	tokens map[discord.InteractionID]InteractionToken
+}
+
+// Add stores the token of the interaction and returns it. Expired tokens are
+// removed from the store.
+func (s *TokenStore) Add(ev *discord.InteractionEvent) InteractionToken {
+	tok := NewInteractionToken(ev)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens == nil {
+		s.tokens = make(map[discord.InteractionID]InteractionToken)
+	}
+
+	now := clock.OrSystem(s.Clock).Now()
+	for id, t := range s.tokens {
+		if !t.Valid(now) {
+			delete(s.tokens, id)
+		}
+	}
+
+	s.tokens[tok.InteractionID] = tok
+	return tok
+}
+
+// Token returns the token of the interaction with the given ID, if it is
+// stored and still valid.
+func (s *TokenStore) Token(id discord.InteractionID) (InteractionToken, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tok, ok := s.tokens[id]
+	if !ok || !tok.Valid(clock.OrSystem(s.Clock).Now()) {
+		return InteractionToken{}, false
+	}
+	return tok, true
+}
+
+// Remove removes the token of the interaction with the given ID.
+func (s *TokenStore) Remove(id discord.InteractionID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tokens, id)
+}
+
+// ResponseEditor is a type that can edit and delete interaction responses.
+// Usually, anything that extends *api.Client can be used as a ResponseEditor.
+type ResponseEditor interface {
+	EditInteractionResponse(appID discord.AppID, token string, data api.EditInteractionResponseData) (*discord.Message, error)
+	DeleteInteractionResponse(appID discord.AppID, token string) error
+}
+
+var _ ResponseEditor = (*api.Client)(nil)
+
+// Scheduler edits and deletes interaction responses later, while their tokens
+// are still valid, such as to disable buttons after a minute:
+//
+//	tok := cmdroute.NewInteractionToken(data.Event)
+//	sched.EditAfter(tok, time.Minute, api.EditInteractionResponseData{
+//		Components: &discord.ContainerComponents{},
+//	})
+type Scheduler struct {
+	// OnError is called when a scheduled edit or deletion fails. If nil, it
+	// does nothing.
+	OnError func(error)
+	// Clock is the clock used to wait for the scheduled times. It is
+	// clock.System if nil. It must be set before the Scheduler is used.
+	Clock clock.Clock
+
+	editor func(context.Context) ResponseEditor
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler that uses the given client. If the
+// client is an *api.Client, then its requests are bound to the Scheduler, so
+// Close cancels the ones in progress. Use NewContextScheduler to do the same
+// for other clients.
+func NewScheduler(client ResponseEditor) *Scheduler {
+	return NewContextScheduler(func(ctx context.Context) ResponseEditor {
+		if c, ok := client.(*api.Client); ok {
+			return c.WithContext(ctx)
+		}
+		return client
+	})
+}
+
+// NewContextScheduler creates a new Scheduler that gets its client for each
+// edit or deletion from the given function. The context given to it is
+// cancelled when the edit or deletion is cancelled or the Scheduler is closed.
+func NewContextScheduler(editor func(context.Context) ResponseEditor) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		editor: editor,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// EditAfter edits the response after the given duration. It returns
+// ErrTokenExpired if the token will have expired by then. The returned
+// function cancels the edit.
+func (s *Scheduler) EditAfter(
+	tok InteractionToken, after time.Duration, data api.EditInteractionResponseData) (func(), error) {
+
+	return s.after(tok, after, func(client ResponseEditor) error {
+		_, err := client.EditInteractionResponse(tok.AppID, tok.Token, data)
+		return err
+	})
+}
+
+// DeleteAfter deletes the response after the given duration. It returns
+// ErrTokenExpired if the token will have expired by then. The returned
+// function cancels the deletion.
+func (s *Scheduler) DeleteAfter(tok InteractionToken, after time.Duration) (func(), error) {
+	return s.after(tok, after, func(client ResponseEditor) error {
+		return client.DeleteInteractionResponse(tok.AppID, tok.Token)
+	})
+}
+
+// Every edits the response every interval, such as to update a progress
+// message. Each time, fn is called with the number of the edit, starting
+// from 1, and returns the data to edit the response with, or false to stop.
+// The edits also stop when the token expires. The returned function stops the
+// edits.
+func (s *Scheduler) Every(
+	tok InteractionToken, interval time.Duration,
+	fn func(n int) (api.EditInteractionResponseData, bool)) (func(), error) {
+
+	clk := clock.OrSystem(s.Clock)
+	now := clk.Now()
+
+	if !tok.Valid(now.Add(interval)) {
+		return nil, ErrTokenExpired
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	client := s.editor(ctx)
+
+	// The timers are made before returning, so that they start now.
+	expiry := clk.NewTimer(tok.Expiry.Sub(now))
+	ticker := clk.NewTicker(interval)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+		defer expiry.Stop()
+		defer ticker.Stop()
+
+		for n := 1; ; n++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-expiry.C():
+				return
+			case <-ticker.C():
+			}
+
+			// Both may be ready, in which case select picks either.
+			if ctx.Err() != nil {
+				return
+			}
+
+			data, ok := fn(n)
+			if !ok {
+				return
+			}
+
+			if _, err := client.EditInteractionResponse(tok.AppID, tok.Token, data); err != nil && ctx.Err() == nil {
+				s.error(fmt.Errorf("failed to edit interaction %v: %w", tok.InteractionID, err))
+			}
+		}
+	}()
+
+	return cancel, nil
+}
+
+func (s *Scheduler) after(
+	tok InteractionToken, after time.Duration, fn func(ResponseEditor) error) (func(), error) {
+
+	clk := clock.OrSystem(s.Clock)
+
+	if !tok.Valid(clk.Now().Add(after)) {
+		return nil, ErrTokenExpired
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	client := s.editor(ctx)
+
+	// The timer is made before returning, so that it starts now.
+	timer := clk.NewTimer(after)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+		}
+
+		// Both may be ready, in which case select picks either.
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := fn(client); err != nil && ctx.Err() == nil {
+			s.error(fmt.Errorf("failed to update interaction %v: %w", tok.InteractionID, err))
+		}
+	}()
+
+	return cancel, nil
+}
+
+func (s *Scheduler) error(err error) {
+	if s.OnError != nil {
+		s.OnError(err)
+	}
+}
+
+// Close cancels all scheduled edits and deletions, including the requests in
+// progress if the client supports it, and waits for them to finish.
+func (s *Scheduler) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
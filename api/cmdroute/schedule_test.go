@@ -0,0 +1,169 @@
+package cmdroute
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/clock"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+type mockedResponseEditor struct {
+	mu    sync.Mutex
+	calls []string
+	done  chan struct{}
+}
+
+func (m *mockedResponseEditor) EditInteractionResponse(
+	appID discord.AppID, token string, data api.EditInteractionResponseData) (*discord.Message, error) {
+
+	m.record("edit " + data.Content.Val)
+	return &discord.Message{}, nil
+}
+
+func (m *mockedResponseEditor) DeleteInteractionResponse(appID discord.AppID, token string) error {
+	m.record("delete")
+	return nil
+}
+
+func (m *mockedResponseEditor) record(call string) {
+	m.mu.Lock()
+	m.calls = append(m.calls, call)
+	m.mu.Unlock()
+	m.done <- struct{}{}
+}
+
+func TestScheduler(t *testing.T) {
+	editor := &mockedResponseEditor{done: make(chan struct{}, 10)}
+	fake := clock.NewFake(time.Now())
+
+	sched := NewScheduler(editor)
+	sched.Clock = fake
+
+	tok := InteractionToken{
+		AppID:  1,
+		Token:  "token",
+		Expiry: fake.Now().Add(time.Minute),
+	}
+
+	if _, err := sched.EditAfter(tok, time.Second, api.EditInteractionResponseData{
+		Content: option.NewNullableString("later"),
+	}); err != nil {
+		t.Fatal("failed to schedule edit:", err)
+	}
+	fake.Advance(time.Second)
+	<-editor.done
+
+	cancel, err := sched.DeleteAfter(tok, time.Hour)
+	if !errors.Is(err, ErrTokenExpired) {
+		t.Fatal("expected ErrTokenExpired, got", err)
+	}
+
+	cancel, err = sched.DeleteAfter(tok, 30*time.Second)
+	if err != nil {
+		t.Fatal("failed to schedule deletion:", err)
+	}
+	cancel()
+
+	if _, err := sched.Every(tok, time.Second, func(n int) (api.EditInteractionResponseData, bool) {
+		return api.EditInteractionResponseData{
+			Content: option.NewNullableString("progress"),
+		}, n <= 2
+	}); err != nil {
+		t.Fatal("failed to schedule edits:", err)
+	}
+	fake.Advance(time.Second)
+	<-editor.done
+	fake.Advance(time.Second)
+	<-editor.done
+
+	// Move past the canceled deletion and the token's expiry.
+	fake.Advance(time.Minute)
+	sched.Close()
+
+	editor.mu.Lock()
+	defer editor.mu.Unlock()
+
+	expected := []string{"edit later", "edit progress", "edit progress"}
+	if len(editor.calls) != len(expected) {
+		t.Fatalf("expected calls %q, got %q", expected, editor.calls)
+	}
+	for i := range expected {
+		if editor.calls[i] != expected[i] {
+			t.Fatalf("expected calls %q, got %q", expected, editor.calls)
+		}
+	}
+}
+
+type blockingResponseEditor struct {
+	ctx     context.Context
+	started chan struct{}
+}
+
+func (b blockingResponseEditor) EditInteractionResponse(
+	appID discord.AppID, token string, data api.EditInteractionResponseData) (*discord.Message, error) {
+
+	close(b.started)
+	<-b.ctx.Done()
+	return nil, b.ctx.Err()
+}
+
+func (b blockingResponseEditor) DeleteInteractionResponse(appID discord.AppID, token string) error {
+	return nil
+}
+
+func TestSchedulerCloseInFlight(t *testing.T) {
+	started := make(chan struct{})
+
+	sched := NewContextScheduler(func(ctx context.Context) ResponseEditor {
+		return blockingResponseEditor{ctx, started}
+	})
+	sched.Clock = clock.NewFake(time.Now())
+	sched.OnError = func(err error) { t.Error("unexpected error:", err) }
+
+	tok := InteractionToken{Token: "token", Expiry: sched.Clock.Now().Add(time.Minute)}
+
+	if _, err := sched.EditAfter(tok, 0, api.EditInteractionResponseData{}); err != nil {
+		t.Fatal("failed to schedule edit:", err)
+	}
+	<-started
+
+	// Close must cancel the edit in progress instead of waiting for it.
+	sched.Close()
+}
+
+func TestTokenStore(t *testing.T) {
+	fake := clock.NewFake(time.Now())
+	store := TokenStore{Clock: fake}
+
+	now := discord.NewSnowflake(fake.Now())
+	old := discord.NewSnowflake(fake.Now().Add(-InteractionTokenLifetime))
+
+	store.Add(&discord.InteractionEvent{ID: discord.InteractionID(now), Token: "new"})
+	store.Add(&discord.InteractionEvent{ID: discord.InteractionID(old), Token: "old"})
+
+	if tok, ok := store.Token(discord.InteractionID(now)); !ok || tok.Token != "new" {
+		t.Errorf("unexpected token %+v (%v)", tok, ok)
+	}
+
+	if _, ok := store.Token(discord.InteractionID(old)); ok {
+		t.Error("expired token returned")
+	}
+
+	store.Remove(discord.InteractionID(now))
+	if _, ok := store.Token(discord.InteractionID(now)); ok {
+		t.Error("removed token returned")
+	}
+
+	store.Add(&discord.InteractionEvent{ID: discord.InteractionID(now), Token: "new"})
+	fake.Advance(InteractionTokenLifetime)
+
+	if _, ok := store.Token(discord.InteractionID(now)); ok {
+		t.Error("token returned after it expired")
+	}
+}
@@ -0,0 +1,92 @@
+package cmdroute
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/webhook"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+// AtomicRouter is an interaction handler whose Router can be replaced while it
+// is in use, such as to reload the commands of a bot from its configuration
+// without reconnecting to the gateway. It is added to the session once, then
+// its router is swapped as needed:
+//
+//	ar := cmdroute.NewAtomicRouter(r)
+//	s.AddInteractionHandler(ar)
+//
+//	// Later, on reload:
+//	if err := ar.Swap(s, newRouter, newCommands); err != nil {
+//		log.Println("cannot reload commands:", err)
+//	}
+//
+// Each interaction is handled entirely by the router that is active when it
+// arrives, so a swap never affects interactions that are being handled.
+type AtomicRouter struct {
+	router atomic.Pointer[Router]
+	swapMu sync.Mutex
+}
+
+var _ webhook.InteractionHandler = (*AtomicRouter)(nil)
+
+// NewAtomicRouter creates a new AtomicRouter with the given active router.
+func NewAtomicRouter(r *Router) *AtomicRouter {
+	a := &AtomicRouter{}
+	a.router.Store(r)
+	return a
+}
+
+// Router returns the active router. It returns nil if there is none.
+func (a *AtomicRouter) Router() *Router {
+	return a.router.Load()
+}
+
+// Store replaces the active router without syncing the commands and returns
+// the previous one. If r is nil, interactions are ignored until another router
+// is stored.
+func (a *AtomicRouter) Store(r *Router) *Router {
+	a.swapMu.Lock()
+	defer a.swapMu.Unlock()
+
+	return a.router.Swap(r)
+}
+
+// Swap overwrites the commands of the current application with cmds, then
+// replaces the active router with r. If the commands can't be overwritten, the
+// active router is kept and the error is returned. Concurrent calls to Swap are
+// applied one at a time, so the active router always matches the last
+// overwritten commands.
+func (a *AtomicRouter) Swap(client BulkCommandsOverwriter, r *Router, cmds []api.CreateCommandData) error {
+	a.swapMu.Lock()
+	defer a.swapMu.Unlock()
+
+	if err := OverwriteCommands(client, cmds); err != nil {
+		return err
+	}
+
+	a.router.Store(r)
+	return nil
+}
+
+// HandleInteraction implements webhook.InteractionHandler. It handles the
+// interaction using the active router.
+func (a *AtomicRouter) HandleInteraction(ev *discord.InteractionEvent) *api.InteractionResponse {
+	r := a.router.Load()
+	if r == nil {
+		return nil
+	}
+	return r.HandleInteraction(ev)
+}
+
+// HandleMessage handles the message as a prefix command using the active
+// router. Refer to Router.HandleMessage.
+func (a *AtomicRouter) HandleMessage(ev *gateway.MessageCreateEvent, prefix string) (*api.SendMessageData, error) {
+	r := a.router.Load()
+	if r == nil {
+		return nil, nil
+	}
+	return r.HandleMessage(ev, prefix)
+}
@@ -0,0 +1,72 @@
+package cmdroute
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+type mockedOverwriter struct {
+	cmds []api.CreateCommandData
+	err  error
+}
+
+func (m *mockedOverwriter) CurrentApplication() (*discord.Application, error) {
+	return &discord.Application{ID: 200}, nil
+}
+
+func (m *mockedOverwriter) BulkOverwriteCommands(appID discord.AppID, cmds []api.CreateCommandData) ([]discord.Command, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.cmds = cmds
+	return nil, nil
+}
+
+func TestAtomicRouter(t *testing.T) {
+	newRouter := func(content string) *Router {
+		r := NewRouter()
+		r.AddFunc("ping", func(ctx context.Context, data CommandData) *api.InteractionResponseData {
+			return &api.InteractionResponseData{Content: option.NewNullableString(content)}
+		})
+		return r
+	}
+
+	assertContent := func(a *AtomicRouter, expect string) {
+		t.Helper()
+
+		resp := a.HandleInteraction(newInteractionEvent(&discord.CommandInteraction{Name: "ping"}))
+		if resp == nil || resp.Data == nil || resp.Data.Content.Val != expect {
+			t.Fatalf("expected %q, got %s", expect, strInteractionResp(resp))
+		}
+	}
+
+	a := NewAtomicRouter(newRouter("old"))
+	assertContent(a, "old")
+
+	client := &mockedOverwriter{}
+	cmds := []api.CreateCommandData{{Name: "ping", Description: "Ping."}}
+
+	if err := a.Swap(client, newRouter("new"), cmds); err != nil {
+		t.Fatal("unexpected swap error:", err)
+	}
+	if len(client.cmds) != 1 {
+		t.Fatalf("unexpected overwritten commands %+v", client.cmds)
+	}
+	assertContent(a, "new")
+
+	client.err = errors.New("mock error")
+	if err := a.Swap(client, newRouter("failed"), cmds); !errors.Is(err, client.err) {
+		t.Fatal("expected swap error, got", err)
+	}
+	assertContent(a, "new")
+
+	a.Store(nil)
+	if resp := a.HandleInteraction(newInteractionEvent(&discord.CommandInteraction{Name: "ping"})); resp != nil {
+		t.Fatalf("unexpected response %s", strInteractionResp(resp))
+	}
+}
@@ -22,9 +22,11 @@ func (c *Client) Emoji(guildID discord.GuildID, emojiID discord.EmojiID) (*disco
 type CreateEmojiData struct {
 	// Name is the name of the emoji.
 	Name string `json:"name"`
-	// Image is the the 128x128 emoji image.
+	// Image is the the 128x128 emoji image. Use ReadImage to read it from a
+	// file.
 	Image Image `json:"image"`
-	// Roles are the roles that can use the emoji.
+	// Roles are the roles that can use the emoji. If empty, everyone can use
+	// it.
 	Roles *[]discord.RoleID `json:"roles,omitempty"`
 
 	AuditLogReason `json:"-"`
@@ -56,7 +58,8 @@ func (c *Client) CreateEmoji(
 type ModifyEmojiData struct {
 	// Name is the name of the emoji.
 	Name string `json:"name,omitempty"`
-	// Roles are the roles that can use the emoji.
+	// Roles are the roles that can use the emoji. Set it to a pointer to an
+	// empty slice to allow everyone to use the emoji.
 	Roles *[]discord.RoleID `json:"roles,omitempty"`
 
 	AuditLogReason `json:"-"`
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// channelMessagesURL returns the URL of the channel's messages, followed by
+// the given path elements. The URL is built in a single allocation, since
+// these are the most frequently requested endpoints.
+func channelMessagesURL(channelID discord.ChannelID, elems ...string) string {
+	var id [20]byte
+
+	var b strings.Builder
+	b.Grow(urlSize(elems, len(EndpointChannels)+len("/messages")+len(id)))
+	b.WriteString(EndpointChannels)
+	b.Write(channelID.Append(id[:0]))
+	b.WriteString("/messages")
+	writeElems(&b, elems)
+
+	return b.String()
+}
+
+// messageURL returns the URL of the message, followed by the given path
+// elements. Like channelMessagesURL, it's built in a single allocation.
+func messageURL(channelID discord.ChannelID, messageID discord.MessageID, elems ...string) string {
+	var id [20]byte
+
+	var b strings.Builder
+	b.Grow(urlSize(elems, len(EndpointChannels)+len("/messages/")+2*len(id)))
+	b.WriteString(EndpointChannels)
+	b.Write(channelID.Append(id[:0]))
+	b.WriteString("/messages/")
+	b.Write(messageID.Append(id[:0]))
+	writeElems(&b, elems)
+
+	return b.String()
+}
+
+func urlSize(elems []string, size int) int {
+	for _, elem := range elems {
+		size += len(elem)
+	}
+	return size
+}
+
+func writeElems(b *strings.Builder, elems []string) {
+	for _, elem := range elems {
+		b.WriteString(elem)
+	}
+}
@@ -219,25 +219,109 @@ func (c *Client) GuildsAfter(after discord.GuildID, limit uint) ([]discord.Guild
 }
 
 func (c *Client) guildsRange(before, after discord.GuildID, limit uint) ([]discord.Guild, error) {
-	var param struct {
-		Before discord.GuildID `schema:"before,omitempty"`
-		After  discord.GuildID `schema:"after,omitempty"`
-
-		Limit uint `schema:"limit"`
-	}
+	return c.GuildsWithData(GuildsData{
+		Before: before,
+		After:  after,
+		Limit:  limit,
+	})
+}
 
-	param.Before = before
-	param.After = after
-	param.Limit = limit
+// https://discord.com/developers/docs/resources/user#get-current-user-guilds-query-string-params
+type GuildsData struct {
+	// Before gets guilds with IDs lower than this ID.
+	Before discord.GuildID `schema:"before,omitempty"`
+	// After gets guilds with IDs higher than this ID.
+	After discord.GuildID `schema:"after,omitempty"`
+	// Limit is the maximum number of guilds to return, from 1 to 200. It
+	// defaults to 200.
+	Limit uint `schema:"limit,omitempty"`
+	// WithCounts specifies whether the guilds should contain their
+	// approximate member and presence counts.
+	WithCounts bool `schema:"with_counts,omitempty"`
+}
 
+// GuildsWithData returns a single page of partial guild objects the current
+// user is a member of. Unlike Guilds, it doesn't paginate.
+//
+// Requires the guilds OAuth2 scope.
+func (c *Client) GuildsWithData(data GuildsData) ([]discord.Guild, error) {
 	var gs []discord.Guild
 	return gs, c.RequestJSON(
 		&gs, "GET",
 		EndpointMe+"/guilds",
-		httputil.WithSchema(c, param),
+		httputil.WithSchema(c, data),
 	)
 }
 
+// GuildPages iterates over the pages of the guilds of the current user. It is
+// created using Client.GuildPages.
+type GuildPages struct {
+	client *Client
+	data   GuildsData
+	done   bool
+}
+
+// GuildPages returns an iterator over the pages of the guilds of the current
+// user, each containing up to data.Limit guilds. The iterator pages from the
+// guilds with the smallest IDs to the largest, unless only data.Before is set,
+// in which case it pages from data.Before to the smallest IDs. Set
+// data.WithCounts to get the approximate member and presence counts of the
+// guilds:
+//
+//	pages := c.GuildPages(api.GuildsData{WithCounts: true})
+//	for {
+//		guilds, err := pages.Next()
+//		if err != nil {
+//			return err
+//		}
+//		if guilds == nil {
+//			break
+//		}
+//		...
+//	}
+//
+// Requires the guilds OAuth2 scope.
+func (c *Client) GuildPages(data GuildsData) *GuildPages {
+	if data.Limit == 0 || data.Limit > 200 {
+		data.Limit = 200
+	}
+
+	return &GuildPages{
+		client: c,
+		data:   data,
+	}
+}
+
+// Next fetches the next page of guilds. It returns nil without an error once
+// there are no more pages.
+func (p *GuildPages) Next() ([]discord.Guild, error) {
+	if p.done {
+		return nil, nil
+	}
+
+	guilds, err := p.client.GuildsWithData(p.data)
+	if err != nil {
+		return nil, err
+	}
+
+	if uint(len(guilds)) < p.data.Limit {
+		p.done = true
+	}
+
+	if len(guilds) == 0 {
+		return nil, nil
+	}
+
+	// Guilds are sorted by ID in ascending order.
+	if p.data.Before.IsValid() && !p.data.After.IsValid() {
+		p.data.Before = guilds[0].ID
+	} else {
+		p.data.After = guilds[len(guilds)-1].ID
+	}
+
+	return guilds, nil
+}
+
 // LeaveGuild leaves a guild.
 func (c *Client) LeaveGuild(id discord.GuildID) error {
 	return c.FastRequest("DELETE", EndpointMe+"/guilds/"+id.String())
@@ -304,6 +388,14 @@ type ModifyGuildData struct {
 	// This defaults to "en-US".
 	PreferredLocale option.NullableString `json:"preferred_locale,omitempty"`
 
+	// Features are the enabled guild features. Only the features in
+	// discord.MutableGuildFeatures can be changed; to enable or disable one,
+	// use the guild's current Features with the With or Without methods.
+	//
+	// Enabling the Community feature requires the RulesChannelID and
+	// PublicUpdatesChannelID to be set.
+	Features discord.GuildFeatures `json:"features,omitempty"`
+
 	AuditLogReason `json:"-"`
 }
 
@@ -344,6 +436,8 @@ type AuditLogData struct {
 	ActionType discord.AuditLogEvent `schema:"action_type,omitempty"`
 	// Before filters the log before a certain entry ID.
 	Before discord.AuditLogEntryID `schema:"before,omitempty"`
+	// After filters the log after a certain entry ID.
+	After discord.AuditLogEntryID `schema:"after,omitempty"`
 	// Limit limits how many entries are returned (default 50, minimum 1,
 	// maximum 100).
 	Limit uint `schema:"limit"`
@@ -369,6 +463,78 @@ func (c *Client) AuditLog(guildID discord.GuildID, data AuditLogData) (*discord.
 	)
 }
 
+// AuditLogPages iterates over the pages of a guild's audit log. It is created
+// using Client.AuditLogPages.
+type AuditLogPages struct {
+	client  *Client
+	guildID discord.GuildID
+	data    AuditLogData
+	done    bool
+}
+
+// AuditLogPages returns an iterator over the pages of the audit log of the
+// guild, each containing up to data.Limit entries. The iterator pages from the
+// newest entries to the oldest, unless only data.After is set, in which case
+// it pages from data.After to the newest entries.
+//
+// Requires the VIEW_AUDIT_LOG permission.
+func (c *Client) AuditLogPages(guildID discord.GuildID, data AuditLogData) *AuditLogPages {
+	switch {
+	case data.Limit == 0:
+		data.Limit = 50
+	case data.Limit > 100:
+		data.Limit = 100
+	}
+
+	return &AuditLogPages{
+		client:  c,
+		guildID: guildID,
+		data:    data,
+	}
+}
+
+// Next fetches the next page of the audit log. It returns nil without an error
+// once there are no more pages.
+func (p *AuditLogPages) Next() (*discord.AuditLog, error) {
+	if p.done {
+		return nil, nil
+	}
+
+	audit, err := p.client.AuditLog(p.guildID, p.data)
+	if err != nil {
+		return nil, err
+	}
+
+	if uint(len(audit.Entries)) < p.data.Limit {
+		p.done = true
+	}
+
+	if len(audit.Entries) == 0 {
+		return nil, nil
+	}
+
+	// Don't rely on the order of the entries.
+	oldest := audit.Entries[0].ID
+	newest := audit.Entries[0].ID
+
+	for _, entry := range audit.Entries[1:] {
+		if entry.ID < oldest {
+			oldest = entry.ID
+		}
+		if entry.ID > newest {
+			newest = entry.ID
+		}
+	}
+
+	if p.data.After.IsValid() && !p.data.Before.IsValid() {
+		p.data.After = newest
+	} else {
+		p.data.Before = oldest
+	}
+
+	return audit, nil
+}
+
 // Integrations returns a list of integration objects for the guild.
 //
 // Requires the MANAGE_GUILD permission.
@@ -432,6 +598,24 @@ func (c *Client) ModifyIntegration(
 	)
 }
 
+// DeleteIntegration deletes the attached integration object for the guild.
+// Deletes any associated webhooks and kicks the associated bot if there is
+// one.
+//
+// Requires the MANAGE_GUILD permission.
+//
+// Fires Guild Integrations Update and Integration Delete Gateway events.
+func (c *Client) DeleteIntegration(
+	guildID discord.GuildID,
+	integrationID discord.IntegrationID, reason AuditLogReason) error {
+
+	return c.FastRequest(
+		"DELETE",
+		EndpointGuilds+guildID.String()+"/integrations/"+integrationID.String(),
+		httputil.WithHeaders(reason.Header()),
+	)
+}
+
 // SyncIntegration syncs an integration.
 //
 // Requires the MANAGE_GUILD permission.
@@ -461,7 +645,8 @@ func (c *Client) GuildWidgetSettings(
 type ModifyGuildWidgetData struct {
 	// Enabled specifies whether the widget is enabled.
 	Enabled option.Bool `json:"enabled,omitempty"`
-	// ChannelID is the widget channel ID.
+	// ChannelID is the widget channel ID. Set it to discord.NullChannelID to
+	// remove the widget channel.
 	ChannelID discord.ChannelID `json:"channel_id,omitempty"`
 
 	AuditLogReason `json:"-"`
@@ -531,11 +716,16 @@ const (
 	GuildBanner4 GuildWidgetImageStyle = "banner4"
 )
 
-// GuildWidgetImageURL returns a link to the PNG image widget for the guild.
+// GuildWidgetImageURL returns a link to the PNG image widget for the guild. If
+// img is empty, Discord defaults to GuildShield.
 //
 // Requires no permissions or authentication.
 func (c *Client) GuildWidgetImageURL(guildID discord.GuildID, img GuildWidgetImageStyle) string {
-	return EndpointGuilds + guildID.String() + "/widget.png?style=" + string(img)
+	u := EndpointGuilds + guildID.String() + "/widget.png"
+	if img != "" {
+		u += "?style=" + url.QueryEscape(string(img))
+	}
+	return u
 }
 
 // GuildWidgetImage returns a PNG image widget for the guild. Requires no permissions
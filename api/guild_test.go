@@ -0,0 +1,157 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestGuildPages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("with_counts") != "true" || q.Get("limit") != "2" {
+			t.Errorf("unexpected query %v", q)
+		}
+
+		after, _ := strconv.Atoi(q.Get("after"))
+
+		// The user is in guilds 1 to 5.
+		var guilds []string
+		for id := after + 1; id <= 5 && len(guilds) < 2; id++ {
+			guilds = append(guilds, fmt.Sprintf(`{"id": "%d", "approximate_member_count": %d}`, id, id*10))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]", strings.Join(guilds, ","))
+	}))
+	defer srv.Close()
+
+	oldMe := EndpointMe
+	EndpointMe = srv.URL + "/users/@me"
+	defer func() { EndpointMe = oldMe }()
+
+	pages := NewClient("token").GuildPages(GuildsData{Limit: 2, WithCounts: true})
+
+	var ids []string
+	for {
+		guilds, err := pages.Next()
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if guilds == nil {
+			break
+		}
+
+		for _, guild := range guilds {
+			if guild.ApproximateMembers != uint64(guild.ID)*10 {
+				t.Errorf("unexpected member count %d for guild %d", guild.ApproximateMembers, guild.ID)
+			}
+			ids = append(ids, guild.ID.String())
+		}
+	}
+
+	if got := strings.Join(ids, ","); got != "1,2,3,4,5" {
+		t.Fatalf("unexpected guilds %s", got)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ReturnCount bool `json:"compute_prune_count"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error("failed to decode prune body:", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if body.ReturnCount {
+			fmt.Fprint(w, `{"pruned": 0}`)
+		} else {
+			fmt.Fprint(w, `{"pruned": null}`)
+		}
+	}))
+	defer srv.Close()
+
+	oldGuilds := EndpointGuilds
+	EndpointGuilds = srv.URL + "/guilds/"
+	defer func() { EndpointGuilds = oldGuilds }()
+
+	client := NewClient("token")
+
+	pruned, err := client.Prune(1, PruneData{ReturnCount: true})
+	if err != nil {
+		t.Fatal("failed to prune:", err)
+	}
+	if pruned != 0 {
+		t.Fatalf("expected 0 pruned members, got %d", pruned)
+	}
+
+	resp, err := client.PruneResult(1, PruneData{ReturnCount: true})
+	if err != nil {
+		t.Fatal("failed to prune:", err)
+	}
+	if resp.Pruned == nil || *resp.Pruned != 0 {
+		t.Fatalf("expected 0 pruned members, got %v", resp.Pruned)
+	}
+
+	resp, err = client.PruneResult(1, PruneData{})
+	if err != nil {
+		t.Fatal("failed to prune:", err)
+	}
+	if resp.Pruned != nil {
+		t.Fatalf("expected no prune count, got %d", *resp.Pruned)
+	}
+
+	// Prune reports a missing count as 0.
+	pruned, err = client.Prune(1, PruneData{})
+	if err != nil {
+		t.Fatal("failed to prune:", err)
+	}
+	if pruned != 0 {
+		t.Fatalf("expected 0 without a prune count, got %d", pruned)
+	}
+}
+
+func TestModifyGuildFeatures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error("failed to decode modify body:", err)
+		}
+
+		if features := string(body["features"]); features != `["NEWS","COMMUNITY"]` {
+			t.Errorf("unexpected features %s", features)
+		}
+		if _, ok := body["name"]; ok {
+			t.Error("unexpected name in modify body")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "1", "features": %s}`, body["features"])
+	}))
+	defer srv.Close()
+
+	oldGuilds := EndpointGuilds
+	EndpointGuilds = srv.URL + "/guilds/"
+	defer func() { EndpointGuilds = oldGuilds }()
+
+	current := discord.GuildFeatures{discord.News}
+
+	g, err := NewClient("token").ModifyGuild(1, ModifyGuildData{
+		Features: current.With(discord.Community),
+	})
+	if err != nil {
+		t.Fatal("failed to modify guild:", err)
+	}
+
+	if !g.HasFeature(discord.Community) {
+		t.Fatalf("unexpected features %v", g.Features)
+	}
+}
@@ -2,12 +2,18 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
 	"net/http"
 
 	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
 )
 
 var ErrInvalidImageCT = errors.New("unknown image content-type")
@@ -56,15 +62,126 @@ func DecodeImage(data []byte) (*Image, error) {
 		Content:     make([]byte, base64.StdEncoding.DecodedLen(len(b64))),
 	}
 
-	base64.StdEncoding.Decode(img.Content, b64)
+	n, err := base64.StdEncoding.Decode(img.Content, b64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %w", err)
+	}
+
+	img.Content = img.Content[:n]
 	return &img, nil
 }
 
+// ReadImage reads the whole image from r and detects its content type. The
+// returned image is not validated.
+func ReadImage(r io.Reader) (*Image, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	return &Image{
+		ContentType: detectImageType(b),
+		Content:     b,
+	}, nil
+}
+
+// NewImageFromImage encodes img as a PNG image. If maxSize is more than 0 and
+// the PNG image is larger than maxSize bytes, then img is encoded as a JPEG
+// image instead, and an ImageTooLargeError is returned if that is still too
+// large. Since JPEG images can't be transparent, images that aren't opaque are
+// never encoded as JPEG. This is useful for images that are generated or
+// resized in memory.
+func NewImageFromImage(img image.Image, maxSize int) (*Image, error) {
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+
+	if maxSize <= 0 || buf.Len() <= maxSize {
+		return &Image{ContentType: "image/png", Content: buf.Bytes()}, nil
+	}
+
+	if !isOpaque(img) {
+		return nil, ImageTooLargeError{buf.Len(), maxSize}
+	}
+
+	buf.Reset()
+
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, fmt.Errorf("failed to encode JPEG: %w", err)
+	}
+
+	if buf.Len() > maxSize {
+		return nil, ImageTooLargeError{buf.Len(), maxSize}
+	}
+
+	return &Image{ContentType: "image/jpeg", Content: buf.Bytes()}, nil
+}
+
+// isOpaque returns true if img is known to be fully opaque.
+func isOpaque(img image.Image) bool {
+	o, ok := img.(interface{ Opaque() bool })
+	return ok && o.Opaque()
+}
+
+// NewImageFromURL downloads the image at the given URL using
+// sendpart.FromURL. The image must be a PNG, JPEG or GIF image. If maxSize is
+// more than 0 and the image is larger than maxSize bytes, then an
+// ImageTooLargeError is returned.
+func NewImageFromURL(ctx context.Context, url string, maxSize int) (*Image, error) {
+	file, err := sendpart.FromURL(ctx, url, int64(maxSize))
+	if err != nil {
+		if errors.Is(err, sendpart.ErrFileTooLarge) {
+			// The download stops past maxSize, so the exact size isn't known.
+			return nil, ImageTooLargeError{maxSize + 1, maxSize}
+		}
+		return nil, err
+	}
+
+	// The content type is detected from the content, since the one sent by
+	// the server may be missing or have parameters.
+	img, err := ReadImage(file.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := img.Validate(maxSize); err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+// DataURI returns the image encoded using the Data URI Scheme, such as
+// "data:image/png;base64,...".
+func (i Image) DataURI() (string, error) {
+	b, err := i.Encode()
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func detectImageType(b []byte) string {
+	if len(b) > 512 {
+		b = b[:512]
+	}
+	return http.DetectContentType(b)
+}
+
+// Validate returns an error if the image is larger than maxSize bytes, or if
+// its content type isn't supported. If ContentType is empty, it is detected
+// from the content. A maxSize of 0 means no size limit.
 func (i Image) Validate(maxSize int) error {
 	if maxSize > 0 && len(i.Content) > maxSize {
 		return ImageTooLargeError{len(i.Content), maxSize}
 	}
 
+	if i.ContentType == "" {
+		i.ContentType = detectImageType(i.Content)
+	}
+
 	switch i.ContentType {
 	case "image/png", "image/jpeg", "image/gif":
 		return nil
@@ -75,11 +192,7 @@ func (i Image) Validate(maxSize int) error {
 
 func (i Image) Encode() ([]byte, error) {
 	if i.ContentType == "" {
-		var max = 512
-		if len(i.Content) < max {
-			max = len(i.Content)
-		}
-		i.ContentType = http.DetectContentType(i.Content[:max])
+		i.ContentType = detectImageType(i.Content)
 	}
 
 	if err := i.Validate(0); err != nil {
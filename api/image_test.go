@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestImageDataURI(t *testing.T) {
+	// PNG signature followed by some arbitrary data.
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	img, err := ReadImage(bytes.NewReader(png))
+	if err != nil {
+		t.Fatal("failed to read image:", err)
+	}
+
+	if img.ContentType != "image/png" {
+		t.Fatalf("unexpected content type %q", img.ContentType)
+	}
+
+	uri, err := img.DataURI()
+	if err != nil {
+		t.Fatal("failed to encode image:", err)
+	}
+
+	if !strings.HasPrefix(uri, "data:image/png;base64,") {
+		t.Fatalf("unexpected data URI %q", uri)
+	}
+
+	decoded, err := DecodeImage([]byte(uri))
+	if err != nil {
+		t.Fatal("failed to decode image:", err)
+	}
+
+	if !bytes.Equal(decoded.Content, png) {
+		t.Fatalf("decoded content %q differs from %q", decoded.Content, png)
+	}
+}
+
+func TestImageValidateDetect(t *testing.T) {
+	img := Image{Content: []byte("GIF89a")}
+
+	if err := img.Validate(256 * 1000); err != nil {
+		t.Fatal("unexpected error validating GIF:", err)
+	}
+
+	if err := img.Validate(1); err == nil {
+		t.Fatal("expected image to be too large")
+	}
+}
+
+func TestNewImageFromImage(t *testing.T) {
+	// Noise compresses poorly as a PNG image, so the JPEG image is smaller.
+	rng := rand.New(rand.NewSource(0))
+	src := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 64; y++ {
+			src.Set(x, y, color.RGBA{uint8(rng.Intn(256)), uint8(rng.Intn(256)), uint8(rng.Intn(256)), 255})
+		}
+	}
+
+	img, err := NewImageFromImage(src, 0)
+	if err != nil {
+		t.Fatal("failed to encode image:", err)
+	}
+
+	if img.ContentType != "image/png" {
+		t.Fatalf("unexpected content type %q", img.ContentType)
+	}
+
+	img, err = NewImageFromImage(src, len(img.Content)-1)
+	if err != nil {
+		t.Fatal("failed to encode image as JPEG:", err)
+	}
+
+	if img.ContentType != "image/jpeg" {
+		t.Fatalf("unexpected content type %q", img.ContentType)
+	}
+
+	var tooLarge ImageTooLargeError
+	if _, err := NewImageFromImage(src, 10); !errors.As(err, &tooLarge) {
+		t.Fatal("expected ImageTooLargeError, got", err)
+	}
+
+	// Transparent images stay PNG images, since JPEG images drop the alpha.
+	src.Set(0, 0, color.RGBA{})
+
+	png, err := NewImageFromImage(src, 0)
+	if err != nil {
+		t.Fatal("failed to encode image:", err)
+	}
+
+	if _, err := NewImageFromImage(src, len(png.Content)-1); !errors.As(err, &tooLarge) {
+		t.Fatal("expected ImageTooLargeError for a transparent image, got", err)
+	}
+}
+
+func TestNewImageFromURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image.gif":
+			w.Write([]byte("GIF89a"))
+		default:
+			w.Write([]byte("not an image"))
+		}
+	}))
+	defer srv.Close()
+
+	img, err := NewImageFromURL(context.Background(), srv.URL+"/image.gif", 0)
+	if err != nil {
+		t.Fatal("failed to download image:", err)
+	}
+
+	if img.ContentType != "image/gif" {
+		t.Fatalf("unexpected content type %q", img.ContentType)
+	}
+
+	var tooLarge ImageTooLargeError
+	if _, err := NewImageFromURL(context.Background(), srv.URL+"/image.gif", 2); !errors.As(err, &tooLarge) {
+		t.Fatal("expected ImageTooLargeError, got", err)
+	}
+
+	if _, err := NewImageFromURL(context.Background(), srv.URL+"/text", 0); !errors.Is(err, ErrInvalidImageCT) {
+		t.Fatal("expected ErrInvalidImageCT, got", err)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"mime/multipart"
 
@@ -24,6 +25,11 @@ const (
 	UpdateMessage
 	AutocompleteResult
 	ModalResponse
+	_
+	_
+	// LaunchActivity launches the application's Activity. It is only
+	// available for applications with Activities enabled.
+	LaunchActivity
 )
 
 // InteractionResponseFlags implements flags for an
@@ -128,11 +134,74 @@ type AutocompleteNumberChoices []discord.NumberChoice
 
 func (c AutocompleteNumberChoices) choices() {}
 
+// ErrInvalidModal is returned by RespondInteraction if a ModalResponse has an
+// invalid modal. The returned error wraps it with the reason.
+var ErrInvalidModal = errors.New("invalid modal")
+
+// validateModal validates the modal in data like Discord would.
+func validateModal(data *InteractionResponseData) error {
+	if data == nil {
+		return fmt.Errorf("%w: missing data", ErrInvalidModal)
+	}
+
+	if data.CustomID == nil || data.CustomID.Val == "" {
+		return fmt.Errorf("%w: missing custom ID", ErrInvalidModal)
+	}
+	if len(data.CustomID.Val) > 100 {
+		return fmt.Errorf("%w: custom ID is longer than 100 characters", ErrInvalidModal)
+	}
+
+	if data.Title == nil || data.Title.Val == "" {
+		return fmt.Errorf("%w: missing title", ErrInvalidModal)
+	}
+	if len([]rune(data.Title.Val)) > 45 {
+		return fmt.Errorf("%w: title is longer than 45 characters", ErrInvalidModal)
+	}
+
+	if data.Components == nil || len(*data.Components) == 0 {
+		return fmt.Errorf("%w: missing components", ErrInvalidModal)
+	}
+	if len(*data.Components) > 5 {
+		return fmt.Errorf("%w: more than 5 component rows", ErrInvalidModal)
+	}
+
+	for i, component := range *data.Components {
+		row, ok := component.(*discord.ActionRowComponent)
+		if !ok {
+			return fmt.Errorf("%w: component %d is a %v, not an action row",
+				ErrInvalidModal, i, component.Type())
+		}
+
+		if len(*row) == 0 {
+			return fmt.Errorf("%w: action row %d is empty", ErrInvalidModal, i)
+		}
+
+		for j, component := range *row {
+			if _, ok := component.(*discord.TextInputComponent); !ok {
+				return fmt.Errorf("%w: component %d in action row %d is a %v, not a text input",
+					ErrInvalidModal, j, i, component.Type())
+			}
+		}
+	}
+
+	return nil
+}
+
 // RespondInteraction responds to an incoming interaction. It is also known as
 // an "interaction callback".
+//
+// If resp is a ModalResponse, the modal must have a custom ID, a title and 1
+// to 5 action rows containing only text inputs, or an error wrapping
+// ErrInvalidModal is returned.
 func (c *Client) RespondInteraction(
 	id discord.InteractionID, token string, resp InteractionResponse) error {
 
+	if resp.Type == ModalResponse {
+		if err := validateModal(resp.Data); err != nil {
+			return err
+		}
+	}
+
 	if resp.Data != nil {
 		switch resp.Type {
 		case MessageInteractionWithSource:
@@ -179,6 +248,75 @@ func (c *Client) RespondInteraction(
 	return sendpart.POST(c.Client, resp, nil, URL)
 }
 
+// RespondText responds to an interaction with a message containing the given
+// content.
+func (c *Client) RespondText(id discord.InteractionID, token, content string) error {
+	return c.RespondInteraction(id, token, InteractionResponse{
+		Type: MessageInteractionWithSource,
+		Data: &InteractionResponseData{
+			Content: option.NewNullableString(content),
+		},
+	})
+}
+
+// RespondEphemeral responds to an interaction with a message containing the
+// given content that only the user who invoked the interaction can see.
+func (c *Client) RespondEphemeral(id discord.InteractionID, token, content string) error {
+	return c.RespondInteraction(id, token, InteractionResponse{
+		Type: MessageInteractionWithSource,
+		Data: &InteractionResponseData{
+			Content: option.NewNullableString(content),
+			Flags:   discord.EphemeralMessage,
+		},
+	})
+}
+
+// DeferResponse acknowledges an interaction and shows a loading state to the
+// user. The response must then be sent using EditInteractionResponse within 15
+// minutes. Flags may be discord.EphemeralMessage to make the response
+// ephemeral.
+func (c *Client) DeferResponse(
+	id discord.InteractionID, token string, flags discord.MessageFlags) error {
+
+	resp := InteractionResponse{Type: DeferredMessageInteractionWithSource}
+	if flags != 0 {
+		resp.Data = &InteractionResponseData{Flags: flags}
+	}
+
+	return c.RespondInteraction(id, token, resp)
+}
+
+// DeferUpdate acknowledges a component interaction without showing a loading
+// state. The message that the component is attached to may then be edited
+// using EditInteractionResponse.
+func (c *Client) DeferUpdate(id discord.InteractionID, token string) error {
+	return c.RespondInteraction(id, token, InteractionResponse{
+		Type: DeferredMessageUpdate,
+	})
+}
+
+// DeferThenEdit defers the response to an interaction, calls fn, then edits
+// the response with the data that fn returns. It is useful for work that may
+// take longer than the 3 seconds Discord gives to respond.
+//
+// If fn returns an error, the error is returned, and the deferred response is
+// left as is, so that the caller can edit it, such as to report the error.
+func (c *Client) DeferThenEdit(
+	id discord.InteractionID, appID discord.AppID, token string,
+	fn func() (EditInteractionResponseData, error)) (*discord.Message, error) {
+
+	if err := c.DeferResponse(id, token, 0); err != nil {
+		return nil, fmt.Errorf("failed to defer response: %w", err)
+	}
+
+	data, err := fn()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.EditInteractionResponse(appID, token, data)
+}
+
 // InteractionResponse returns the initial interaction response.
 func (c *Client) InteractionResponse(
 	appID discord.AppID, token string) (*discord.Message, error) {
@@ -296,6 +434,17 @@ func (c *Client) FollowUpInteraction(
 		c.Client, data, &msg, EndpointWebhooks+appID.String()+"/"+token+"?")
 }
 
+// InteractionFollowup returns a followup message for an interaction.
+func (c *Client) InteractionFollowup(
+	appID discord.AppID, messageID discord.MessageID, token string) (*discord.Message, error) {
+
+	var m *discord.Message
+	return m, c.RequestJSON(
+		&m, "GET",
+		EndpointWebhooks+appID.String()+"/"+token+"/messages/"+messageID.String())
+}
+
+// EditInteractionFollowup edits a followup message for an interaction.
 func (c *Client) EditInteractionFollowup(
 	appID discord.AppID, messageID discord.MessageID,
 	token string, data EditInteractionResponseData) (*discord.Message, error) {
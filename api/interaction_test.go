@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestRespondInteractionModal(t *testing.T) {
+	respond := func(data *InteractionResponseData) (err error) {
+		// A nil client will cause a panic.
+		defer func() {
+			if recover() != nil {
+				err = nil
+			}
+		}()
+
+		// shouldn't matter
+		client := (*Client)(nil)
+		return client.RespondInteraction(0, "", InteractionResponse{
+			Type: ModalResponse,
+			Data: data,
+		})
+	}
+
+	textInput := func() *discord.ActionRowComponent {
+		return &discord.ActionRowComponent{
+			&discord.TextInputComponent{CustomID: "input", Label: "Input"},
+		}
+	}
+
+	modal := func(rows ...discord.ContainerComponent) *InteractionResponseData {
+		components := discord.ContainerComponents(rows)
+		return &InteractionResponseData{
+			CustomID:   option.NewNullableString("modal"),
+			Title:      option.NewNullableString("Modal"),
+			Components: &components,
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		if err := respond(modal(textInput())); err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		noTitle := modal(textInput())
+		noTitle.Title = nil
+
+		button := modal(&discord.ActionRowComponent{
+			&discord.ButtonComponent{CustomID: "button", Label: "Button"},
+		})
+
+		tests := map[string]*InteractionResponseData{
+			"no data":        nil,
+			"no title":       noTitle,
+			"no components":  modal(),
+			"too many rows":  modal(textInput(), textInput(), textInput(), textInput(), textInput(), textInput()),
+			"non-text input": button,
+		}
+
+		for name, data := range tests {
+			if err := respond(data); !errors.Is(err, ErrInvalidModal) {
+				t.Errorf("%s: unexpected error: %v", name, err)
+			}
+		}
+	})
+}
@@ -22,17 +22,27 @@ func (c *Client) Invite(code string) (*discord.Invite, error) {
 // InviteWithCounts returns an invite object for the given code and fills
 // ApproxMembers.
 func (c *Client) InviteWithCounts(code string) (*discord.Invite, error) {
-	var params struct {
-		WithCounts bool `schema:"with_counts,omitempty"`
-	}
+	return c.InviteWithData(code, InviteData{WithCounts: true})
+}
 
-	params.WithCounts = true
+// https://discord.com/developers/docs/resources/invite#get-invite-query-string-params
+type InviteData struct {
+	// WithCounts specifies whether the invite should contain approximate
+	// member counts.
+	WithCounts bool `schema:"with_counts,omitempty"`
+	// GuildScheduledEventID is the guild scheduled event to include with the
+	// invite.
+	GuildScheduledEventID discord.EventID `schema:"guild_scheduled_event_id,omitempty"`
+}
 
+// InviteWithData returns an invite object for the given code with the given
+// options.
+func (c *Client) InviteWithData(code string, data InviteData) (*discord.Invite, error) {
 	var inv *discord.Invite
 	return inv, c.RequestJSON(
 		&inv, "GET",
 		EndpointInvites+code,
-		httputil.WithSchema(c, params),
+		httputil.WithSchema(c, data),
 	)
 }
 
@@ -76,6 +86,17 @@ type CreateInviteData struct {
 	//
 	// Default:	false
 	Unique bool `json:"unique,omitempty"`
+	// TargetType is the type of target for this voice channel invite.
+	TargetType discord.InviteTargetType `json:"target_type,omitempty"`
+	// TargetUserID is the ID of the user whose stream to display for this
+	// invite. It is required if TargetType is discord.InviteTargetStream, and
+	// the user must be streaming in the channel.
+	TargetUserID discord.UserID `json:"target_user_id,omitempty"`
+	// TargetApplicationID is the ID of the embedded application to open for
+	// this invite. It is required if TargetType is
+	// discord.InviteTargetEmbeddedApplication, and the application must have
+	// the EMBEDDED flag.
+	TargetApplicationID discord.AppID `json:"target_application_id,omitempty"`
 
 	AuditLogReason `json:"-"`
 }
@@ -1,6 +1,10 @@
 package api
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/internal/intmath"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
@@ -9,6 +13,10 @@ import (
 
 const MaxMemberFetchLimit = 1000
 
+// MaxTimeoutDuration is the maximum duration that a member can be timed out
+// for.
+const MaxTimeoutDuration = 28 * 24 * time.Hour
+
 // Member returns a guild member object for the specified user.
 func (c *Client) Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
 	var m *discord.Member
@@ -190,12 +198,50 @@ func (c *Client) ModifyMember(
 	)
 }
 
+// TimeoutMember times out the member for the given duration, which must be
+// more than 0 and at most MaxTimeoutDuration. A timed out member can't send
+// messages, react, join voice channels or speak in them.
+//
+// Requires MODERATE_MEMBERS.
+//
+// Fires a Guild Member Update Gateway event.
+func (c *Client) TimeoutMember(
+	guildID discord.GuildID, userID discord.UserID,
+	duration time.Duration, reason AuditLogReason) error {
+
+	if duration <= 0 || duration > MaxTimeoutDuration {
+		return fmt.Errorf("timeout duration %v is not in (0, %v]", duration, MaxTimeoutDuration)
+	}
+
+	until := discord.NewTimestamp(time.Now().Add(duration))
+
+	return c.ModifyMember(guildID, userID, ModifyMemberData{
+		CommunicationDisabledUntil: &until,
+		AuditLogReason:             reason,
+	})
+}
+
+// RemoveTimeout removes the timeout of the member.
+//
+// Requires MODERATE_MEMBERS.
+//
+// Fires a Guild Member Update Gateway event.
+func (c *Client) RemoveTimeout(
+	guildID discord.GuildID, userID discord.UserID, reason AuditLogReason) error {
+
+	// A zero Timestamp is marshaled as null, which clears the timeout.
+	return c.ModifyMember(guildID, userID, ModifyMemberData{
+		CommunicationDisabledUntil: &discord.Timestamp{},
+		AuditLogReason:             reason,
+	})
+}
+
 // https://discord.com/developers/docs/resources/guild#get-guild-prune-count-query-string-params
 type PruneCountData struct {
-	// Days is the number of days to count prune for (1 or more, default 7).
+	// Days is the number of days to count prune for (1-30, default 7).
 	Days uint `schema:"days"`
 	// IncludedRoles are the role(s) to include.
-	IncludedRoles []discord.RoleID `schema:"include_roles,omitempty"`
+	IncludedRoles []discord.RoleID `schema:"-"`
 }
 
 // PruneCount returns the number of members that would be removed in a prune
@@ -212,6 +258,23 @@ func (c *Client) PruneCount(guildID discord.GuildID, data PruneCountData) (uint,
 		data.Days = 7
 	}
 
+	// Discord expects a comma-delimited list of roles instead of repeated
+	// keys.
+	var param struct {
+		PruneCountData
+		IncludedRoles string `schema:"include_roles,omitempty"`
+	}
+
+	param.PruneCountData = data
+
+	if len(data.IncludedRoles) > 0 {
+		roles := make([]string, len(data.IncludedRoles))
+		for i, id := range data.IncludedRoles {
+			roles[i] = id.String()
+		}
+		param.IncludedRoles = strings.Join(roles, ",")
+	}
+
 	var resp struct {
 		Pruned uint `json:"pruned"`
 	}
@@ -219,21 +282,21 @@ func (c *Client) PruneCount(guildID discord.GuildID, data PruneCountData) (uint,
 	return resp.Pruned, c.RequestJSON(
 		&resp, "GET",
 		EndpointGuilds+guildID.String()+"/prune",
-		httputil.WithSchema(c, data),
+		httputil.WithSchema(c, param),
 	)
 }
 
 // https://discord.com/developers/docs/resources/guild#begin-guild-prune-query-string-params
 type PruneData struct {
-	// Days is the number of days to prune (1 or more, default 7).
-	Days uint `schema:"days"`
+	// Days is the number of days to prune (1-30, default 7).
+	Days uint `json:"days"`
 	// ReturnCount specifies whether 'pruned' is returned. Discouraged for
 	// large guilds.
-	ReturnCount bool `schema:"compute_prune_count"`
+	ReturnCount bool `json:"compute_prune_count"`
 	// IncludedRoles are the role(s) to include.
-	IncludedRoles []discord.RoleID `schema:"include_roles,omitempty"`
+	IncludedRoles []discord.RoleID `json:"include_roles,omitempty"`
 
-	AuditLogReason `schema:"-"`
+	AuditLogReason `json:"-"`
 }
 
 // Prune begins a prune. Days must be 1 or more, default 7.
@@ -243,22 +306,43 @@ type PruneData struct {
 // parameter. Any inactive user that has a subset of the provided role(s)
 // will be included in the prune and users with additional roles will not.
 //
+// The number of pruned members is only returned if ReturnCount is true;
+// otherwise, 0 is returned. Use PruneResult to tell the two apart.
+//
 // Requires KICK_MEMBERS.
 //
 // Fires multiple Guild Member Remove Gateway events.
 func (c *Client) Prune(guildID discord.GuildID, data PruneData) (uint, error) {
-	if data.Days == 0 {
-		data.Days = 7
+	resp, err := c.PruneResult(guildID, data)
+	if err != nil || resp.Pruned == nil {
+		return 0, err
 	}
+	return *resp.Pruned, nil
+}
 
-	var resp struct {
-		Pruned uint `json:"pruned"`
+// PruneResponse is the response of a prune.
+type PruneResponse struct {
+	// Pruned is the number of pruned members. It is nil if ReturnCount was
+	// false.
+	Pruned *uint `json:"pruned"`
+}
+
+// PruneResult begins a prune like Prune, except that the number of pruned
+// members is nil instead of 0 if ReturnCount is false.
+//
+// Requires KICK_MEMBERS.
+//
+// Fires multiple Guild Member Remove Gateway events.
+func (c *Client) PruneResult(guildID discord.GuildID, data PruneData) (*PruneResponse, error) {
+	if data.Days == 0 {
+		data.Days = 7
 	}
 
-	return resp.Pruned, c.RequestJSON(
+	var resp *PruneResponse
+	return resp, c.RequestJSON(
 		&resp, "POST",
 		EndpointGuilds+guildID.String()+"/prune",
-		httputil.WithSchema(c, data), httputil.WithHeaders(data.Header()),
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
 	)
 }
 
@@ -3,6 +3,8 @@ package api
 import (
 	"fmt"
 	"mime/multipart"
+	"sort"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/internal/intmath"
@@ -179,7 +181,7 @@ func (c *Client) messagesRange(
 	var msgs []discord.Message
 	return msgs, c.RequestJSON(
 		&msgs, "GET",
-		EndpointChannels+channelID.String()+"/messages",
+		channelMessagesURL(channelID),
 		httputil.WithSchema(c, param),
 	)
 }
@@ -193,7 +195,7 @@ func (c *Client) Message(
 
 	var msg *discord.Message
 	return msg, c.RequestJSON(&msg, "GET",
-		EndpointChannels+channelID.String()+"/messages/"+messageID.String())
+		messageURL(channelID, messageID))
 }
 
 // SendTextReply posts a text-only reply to a message ID in a guild text or DM channel
@@ -389,12 +391,15 @@ func (c *Client) EditMessageComplex(
 
 	var msg *discord.Message
 	return msg, sendpart.PATCH(c.Client, data, &msg,
-		EndpointChannels+channelID.String()+"/messages/"+messageID.String())
+		messageURL(channelID, messageID))
 }
 
 // CrosspostMessage crossposts a message in a news channel to following channels.
 // This endpoint requires the SEND_MESSAGES permission if the current user sent the message,
 // or additionally the MANAGE_MESSAGES permission for all other messages.
+//
+// Use (discord.Message).CanCrosspost to check whether a message can be
+// crossposted.
 func (c *Client) CrosspostMessage(
 	channelID discord.ChannelID, messageID discord.MessageID) (*discord.Message, error) {
 
@@ -403,7 +408,7 @@ func (c *Client) CrosspostMessage(
 	return msg, c.RequestJSON(
 		&msg,
 		"POST",
-		EndpointChannels+channelID.String()+"/messages/"+messageID.String()+"/crosspost",
+		messageURL(channelID, messageID, "/crosspost"),
 	)
 }
 
@@ -414,45 +419,143 @@ func (c *Client) DeleteMessage(
 	channelID discord.ChannelID, messageID discord.MessageID, reason AuditLogReason) error {
 
 	return c.FastRequest(
-		"DELETE", EndpointChannels+channelID.String()+"/messages/"+messageID.String(),
+		"DELETE", messageURL(channelID, messageID),
 		httputil.WithHeaders(reason.Header()))
 }
 
-// DeleteMessages deletes multiple messages in a single request. This endpoint
-// can only be used on guild channels and requires the MANAGE_MESSAGES
-// permission. This endpoint only works for bots.
+// MaxBulkDeleteAge is the maximum age of a message that can be deleted using
+// the bulk delete endpoint, as imposed by Discord.
+const MaxBulkDeleteAge = 14 * 24 * time.Hour
+
+// bulkDeleteMargin is subtracted from MaxBulkDeleteAge, so that messages close
+// to the limit aren't rejected by the time the request is made.
+const bulkDeleteMargin = time.Minute
+
+// DeleteMessagesError is returned by DeleteMessages if some of the messages
+// couldn't be deleted. The other messages were deleted.
+type DeleteMessagesError struct {
+	// Failed maps the IDs of the messages that couldn't be deleted to the
+	// errors that occurred.
+	Failed map[discord.MessageID]error
+}
+
+// Error implements error. It includes the error of the oldest message.
+func (err *DeleteMessagesError) Error() string {
+	ids := err.failedIDs()
+	if len(ids) == 0 {
+		return "failed to delete messages"
+	}
+	return fmt.Sprintf("failed to delete %d messages, including: %v", len(ids), err.Failed[ids[0]])
+}
+
+// Unwrap returns the errors of the failed messages, oldest first, so that
+// errors.Is and errors.As can be used on them.
+func (err *DeleteMessagesError) Unwrap() []error {
+	ids := err.failedIDs()
+
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		errs[i] = err.Failed[id]
+	}
+
+	return errs
+}
+
+// failedIDs returns the IDs of the failed messages, oldest first.
+func (err *DeleteMessagesError) failedIDs() []discord.MessageID {
+	ids := make([]discord.MessageID, 0, len(err.Failed))
+	for id := range err.Failed {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// DeleteMessages deletes multiple messages. This endpoint can only be used on
+// guild channels and requires the MANAGE_MESSAGES permission. This endpoint
+// only works for bots.
 //
-// This endpoint will not delete messages older than 2 weeks, and will fail if
-// any message provided is older than that or if any duplicate message IDs are
-// provided.
+// The messages are deleted using the bulk delete endpoint in batches of up to
+// 100 messages. Since that endpoint rejects messages older than 2 weeks, such
+// messages are deleted one by one instead, which is subject to a much stricter
+// rate limit. Duplicate message IDs are ignored.
 //
-// Because the underlying endpoint only supports a maximum of 100 message IDs
-// per request, DeleteMessages will make a total of messageIDs/100 rounded up
-// requests.
+// A failure to delete some messages doesn't stop the other messages from being
+// deleted. Instead, a *DeleteMessagesError is returned with the messages that
+// failed. If the context of the client is done, then the remaining messages
+// aren't deleted, and they fail with the context's error.
 //
-// Fires a Message Delete Bulk Gateway event.
+// Fires a Message Delete Bulk Gateway event, as well as a Message Delete
+// Gateway event for each message deleted one by one.
 func (c *Client) DeleteMessages(
 	channelID discord.ChannelID, messageIDs []discord.MessageID, reason AuditLogReason) error {
 
-	switch {
-	case len(messageIDs) == 0:
-		return nil
-	case len(messageIDs) == 1:
-		return c.DeleteMessage(channelID, messageIDs[0], reason)
-	case len(messageIDs) <= maxMessageDeleteLimit: // Fast path
-		return c.deleteMessages(channelID, messageIDs, reason)
+	cutoff := time.Now().Add(-MaxBulkDeleteAge + bulkDeleteMargin)
+	seen := make(map[discord.MessageID]struct{}, len(messageIDs))
+
+	var bulk, single []discord.MessageID
+
+	for _, id := range messageIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if id.Time().Before(cutoff) {
+			single = append(single, id)
+		} else {
+			bulk = append(bulk, id)
+		}
 	}
 
-	// If the number of messages to be deleted exceeds the amount discord is willing
-	// to accept at one time then batches of messages will be deleted
-	for start := 0; start < len(messageIDs); start += maxMessageDeleteLimit {
-		end := intmath.Min(len(messageIDs), start+maxMessageDeleteLimit)
-		err := c.deleteMessages(channelID, messageIDs[start:end], reason)
-		if err != nil {
-			return err
+	failed := make(map[discord.MessageID]error)
+	ctx := c.Context()
+
+	// cancel fails the given messages that weren't attempted.
+	cancel := func(ids []discord.MessageID) {
+		for _, id := range ids {
+			failed[id] = ctx.Err()
 		}
 	}
 
+	for start := 0; start < len(bulk); start += maxMessageDeleteLimit {
+		if ctx.Err() != nil {
+			cancel(bulk[start:])
+			break
+		}
+
+		end := intmath.Min(len(bulk), start+maxMessageDeleteLimit)
+		batch := bulk[start:end]
+
+		// The bulk delete endpoint requires at least 2 messages.
+		if len(batch) == 1 {
+			single = append(single, batch[0])
+			continue
+		}
+
+		if err := c.deleteMessages(channelID, batch, reason); err != nil {
+			for _, id := range batch {
+				failed[id] = err
+			}
+		}
+	}
+
+	for i, id := range single {
+		if ctx.Err() != nil {
+			cancel(single[i:])
+			break
+		}
+
+		if err := c.DeleteMessage(channelID, id, reason); err != nil {
+			failed[id] = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return &DeleteMessagesError{Failed: failed}
+	}
+
 	return nil
 }
 
@@ -467,7 +570,7 @@ func (c *Client) deleteMessages(
 
 	return c.FastRequest(
 		"POST",
-		EndpointChannels+channelID.String()+"/messages/bulk-delete",
+		channelMessagesURL(channelID, "/bulk-delete"),
 		httputil.WithJSONBody(param), httputil.WithHeaders(reason.Header()),
 	)
 }
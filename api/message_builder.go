@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+)
+
+// Limits of a message enforced by Discord.
+const (
+	MaxMessageContentLength = 2000
+	MaxMessageEmbeds        = 10
+)
+
+// MessageSender is a type that can send messages, such as a *Client or a
+// *state.State.
+type MessageSender interface {
+	SendMessageComplex(discord.ChannelID, SendMessageData) (*discord.Message, error)
+}
+
+// MessageBuilder builds a SendMessageData using chained method calls and sends
+// it. A MessageBuilder must not be used concurrently.
+//
+//	msg, err := client.NewMessage(channelID).
+//		Content("Hello!").
+//		Reply(messageID).
+//		NoMentions().
+//		Send(ctx)
+type MessageBuilder struct {
+	channelID discord.ChannelID
+	sender    func(context.Context) MessageSender
+	data      SendMessageData
+}
+
+// NewMessage creates a new MessageBuilder that sends a message to the given
+// channel using the client.
+func (c *Client) NewMessage(channelID discord.ChannelID) *MessageBuilder {
+	return NewMessageBuilder(channelID, func(ctx context.Context) MessageSender {
+		return c.WithContext(ctx)
+	})
+}
+
+// NewMessageBuilder creates a new MessageBuilder that sends a message to the
+// given channel using the MessageSender returned by sender for the context
+// given to Send.
+func NewMessageBuilder(
+	channelID discord.ChannelID, sender func(context.Context) MessageSender) *MessageBuilder {
+
+	return &MessageBuilder{
+		channelID: channelID,
+		sender:    sender,
+	}
+}
+
+// Content sets the content of the message.
+func (b *MessageBuilder) Content(content string) *MessageBuilder {
+	b.data.Content = content
+	return b
+}
+
+// TTS makes the message a TTS message.
+func (b *MessageBuilder) TTS() *MessageBuilder {
+	b.data.TTS = true
+	return b
+}
+
+// Nonce sets the nonce of the message.
+func (b *MessageBuilder) Nonce(nonce string) *MessageBuilder {
+	b.data.Nonce = nonce
+	return b
+}
+
+// Embed adds the embeds to the message.
+func (b *MessageBuilder) Embed(embeds ...discord.Embed) *MessageBuilder {
+	b.data.Embeds = append(b.data.Embeds, embeds...)
+	return b
+}
+
+// File adds the files to the message. To reference a file in an embed, use
+// (sendpart.File).AttachmentURI().
+func (b *MessageBuilder) File(files ...sendpart.File) *MessageBuilder {
+	b.data.Files = append(b.data.Files, files...)
+	return b
+}
+
+// Components adds the components, such as action rows of buttons, to the
+// message.
+func (b *MessageBuilder) Components(components ...discord.ContainerComponent) *MessageBuilder {
+	b.data.Components = append(b.data.Components, components...)
+	return b
+}
+
+// Reply makes the message a reply to the given message, which must be in the
+// same channel.
+func (b *MessageBuilder) Reply(messageID discord.MessageID) *MessageBuilder {
+	b.data.Reference = &discord.MessageReference{MessageID: messageID}
+	return b
+}
+
+// AllowedMentions sets the allowed mentions of the message. Use NoMentions to
+// disallow all mentions.
+func (b *MessageBuilder) AllowedMentions(mentions *AllowedMentions) *MessageBuilder {
+	b.data.AllowedMentions = mentions
+	return b
+}
+
+// NoMentions disallows all mentions in the message, including the mention of
+// the author of the message being replied to.
+func (b *MessageBuilder) NoMentions() *MessageBuilder {
+	return b.AllowedMentions(&AllowedMentions{
+		Parse:       []AllowedMentionType{},
+		RepliedUser: option.False,
+	})
+}
+
+// Flags sets the flags of the message. Only SuppressEmbeds and
+// SuppressNotifications can be set.
+func (b *MessageBuilder) Flags(flags discord.MessageFlags) *MessageBuilder {
+	b.data.Flags = flags
+	return b
+}
+
+// Data returns the SendMessageData built so far.
+func (b *MessageBuilder) Data() SendMessageData {
+	return b.data
+}
+
+// Validate checks the message like SendMessageData.Validate. Send calls it
+// before sending the message.
+func (b *MessageBuilder) Validate() error {
+	return b.data.Validate()
+}
+
+// Send validates the message and sends it.
+func (b *MessageBuilder) Send(ctx context.Context) (*discord.Message, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	return b.sender(ctx).SendMessageComplex(b.channelID, b.data)
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+type sendRecorder struct {
+	channelID discord.ChannelID
+	data      SendMessageData
+}
+
+func (r *sendRecorder) SendMessageComplex(
+	channelID discord.ChannelID, data SendMessageData) (*discord.Message, error) {
+
+	r.channelID = channelID
+	r.data = data
+	return &discord.Message{ChannelID: channelID, Content: data.Content}, nil
+}
+
+func TestMessageBuilder(t *testing.T) {
+	r := &sendRecorder{}
+	sender := func(context.Context) MessageSender { return r }
+
+	msg, err := NewMessageBuilder(1, sender).
+		Content("hello").
+		Reply(2).
+		Embed(discord.Embed{Title: "embed"}).
+		NoMentions().
+		Send(context.Background())
+	if err != nil {
+		t.Fatal("failed to send:", err)
+	}
+
+	if msg.Content != "hello" || r.channelID != 1 {
+		t.Errorf("unexpected message sent: %+v", msg)
+	}
+
+	if r.data.Reference == nil || r.data.Reference.MessageID != 2 {
+		t.Errorf("unexpected reference %v", r.data.Reference)
+	}
+
+	if len(r.data.Embeds) != 1 {
+		t.Errorf("expected 1 embed, got %d", len(r.data.Embeds))
+	}
+
+	if m := r.data.AllowedMentions; m == nil || m.Parse == nil || len(m.Parse) != 0 {
+		t.Errorf("unexpected allowed mentions %+v", m)
+	}
+}
+
+func TestMessageBuilderValidate(t *testing.T) {
+	sender := func(context.Context) MessageSender {
+		t.Fatal("unexpected send")
+		return nil
+	}
+
+	_, err := NewMessageBuilder(1, sender).Send(context.Background())
+	if !errors.Is(err, ErrEmptyMessage) {
+		t.Errorf("expected ErrEmptyMessage, got %v", err)
+	}
+
+	_, err = NewMessageBuilder(1, sender).
+		Content(strings.Repeat("a", MaxMessageContentLength+1)).
+		Send(context.Background())
+
+	var overbound *discord.OverboundError
+	if !errors.As(err, &overbound) || overbound.Thing != "content" {
+		t.Errorf("expected content OverboundError, got %v", err)
+	}
+}
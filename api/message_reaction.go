@@ -1,6 +1,8 @@
 package api
 
 import (
+	"fmt"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/internal/intmath"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
@@ -19,12 +21,27 @@ func (c *Client) React(
 
 	return c.FastRequest(
 		"PUT",
-		EndpointChannels+channelID.String()+
-			"/messages/"+messageID.String()+
-			"/reactions/"+emoji.PathString()+"/@me",
+		messageURL(channelID, messageID, "/reactions/", emoji.PathString(), "/@me"),
 	)
 }
 
+// ReactAll creates the reactions for the message in the given order, such as
+// for a reaction menu. The reactions are created one after another, waiting
+// for the rate limit between each one.
+//
+// It stops at the first error, which mentions the emoji that failed.
+func (c *Client) ReactAll(
+	channelID discord.ChannelID, messageID discord.MessageID, emojis ...discord.APIEmoji) error {
+
+	for _, emoji := range emojis {
+		if err := c.React(channelID, messageID, emoji); err != nil {
+			return fmt.Errorf("failed to react with %q: %w", emoji, err)
+		}
+	}
+
+	return nil
+}
+
 // Unreact removes a reaction the current user has made for the message.
 func (c *Client) Unreact(
 	channelID discord.ChannelID, messageID discord.MessageID, emoji discord.APIEmoji) error {
@@ -166,9 +183,7 @@ func (c *Client) reactionsRange(
 
 	var users []discord.User
 	return users, c.RequestJSON(
-		&users, "GET", EndpointChannels+channelID.String()+
-			"/messages/"+messageID.String()+
-			"/reactions/"+emoji.PathString(),
+		&users, "GET", messageURL(channelID, messageID, "/reactions/", emoji.PathString()),
 		httputil.WithSchema(c, param),
 	)
 }
@@ -188,12 +203,38 @@ func (c *Client) DeleteUserReaction(
 
 	return c.FastRequest(
 		"DELETE",
-		EndpointChannels+channelID.String()+
-			"/messages/"+messageID.String()+
-			"/reactions/"+emoji.PathString()+"/"+user,
+		messageURL(channelID, messageID, "/reactions/", emoji.PathString(), "/", user),
 	)
 }
 
+// DeleteUserReactions deletes all of a user's reactions on a message, across
+// every emoji the message has been reacted with. If userID is 0, the current
+// user's reactions are deleted.
+//
+// Deleting another user's reactions requires the MANAGE_MESSAGES permission
+// to be present on the current user.
+func (c *Client) DeleteUserReactions(
+	channelID discord.ChannelID, messageID discord.MessageID, userID discord.UserID) error {
+
+	m, err := c.Message(channelID, messageID)
+	if err != nil {
+		return fmt.Errorf("failed to get message: %w", err)
+	}
+
+	for _, reaction := range m.Reactions {
+		if userID == 0 && !reaction.Me {
+			continue
+		}
+
+		emoji := reaction.Emoji.APIString()
+		if err := c.DeleteUserReaction(channelID, messageID, userID, emoji); err != nil {
+			return fmt.Errorf("failed to delete reaction %q: %w", emoji, err)
+		}
+	}
+
+	return nil
+}
+
 // DeleteReactions deletes all the reactions for a given emoji on a message.
 //
 // This endpoint requires the MANAGE_MESSAGES permission to be present on the
@@ -205,9 +246,7 @@ func (c *Client) DeleteReactions(
 
 	return c.FastRequest(
 		"DELETE",
-		EndpointChannels+channelID.String()+
-			"/messages/"+messageID.String()+
-			"/reactions/"+emoji.PathString(),
+		messageURL(channelID, messageID, "/reactions/", emoji.PathString()),
 	)
 }
 
@@ -222,6 +261,6 @@ func (c *Client) DeleteAllReactions(
 
 	return c.FastRequest(
 		"DELETE",
-		EndpointChannels+channelID.String()+"/messages/"+messageID.String()+"/reactions",
+		messageURL(channelID, messageID, "/reactions"),
 	)
 }
@@ -0,0 +1,90 @@
+package oauth2
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// credentialsExpiryDelta is how long before its expiry a token is renewed by
+// CredentialsSource, so that it doesn't expire while a request is made.
+const credentialsExpiryDelta = time.Minute
+
+// CredentialsSource gets client credentials tokens for the owner of the
+// application and caches them until they expire. It's safe to use
+// concurrently.
+//
+// Client credentials tokens are mostly useful for testing and for editing the
+// permissions of the commands of the application, which Discord doesn't allow
+// bot tokens to do:
+//
+//	c := oauth2.NewClient(appID, clientSecret, "")
+//	src := oauth2.NewCredentialsSource(c,
+//		oauth2.ScopeApplicationsCommandsPermissionsUpdate,
+//	)
+//
+//	_, err := src.EditCommandPermissions(guildID, commandID, perms)
+type CredentialsSource struct {
+	// Client is the OAuth2 client of the application.
+	Client *Client
+	// Scopes are the scopes requested for each token.
+	Scopes Scopes
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewCredentialsSource creates a new CredentialsSource that requests tokens
+// with the given scopes.
+func NewCredentialsSource(client *Client, scopes ...Scope) *CredentialsSource {
+	return &CredentialsSource{
+		Client: client,
+		Scopes: scopes,
+	}
+}
+
+// Token returns the cached token, or gets a new one if there is none or if it
+// is about to expire.
+func (s *CredentialsSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.Valid(time.Now().Add(credentialsExpiryDelta)) {
+		return s.token, nil
+	}
+
+	token, err := s.Client.ClientCredentials(s.Scopes...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client credentials token: %w", err)
+	}
+
+	s.token = token
+	return token, nil
+}
+
+// Invalidate drops the cached token, so that the next call to Token gets a new
+// one. It's useful once the token is revoked or the scopes are changed.
+func (s *CredentialsSource) Invalidate() {
+	s.mu.Lock()
+	s.token = nil
+	s.mu.Unlock()
+}
+
+// EditCommandPermissions edits the permissions of a command of the application
+// in a guild that the owner of the application manages. Use the application ID
+// as the command ID to edit the permissions of all commands. The source must
+// have the applications.commands.permissions.update scope.
+func (s *CredentialsSource) EditCommandPermissions(
+	guildID discord.GuildID, commandID discord.CommandID,
+	permissions []discord.CommandPermissions) (*discord.GuildCommandPermissions, error) {
+
+	token, err := s.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	client := token.Client().WithContext(s.Client.Context())
+	return client.EditCommandPermissions(s.Client.ClientID, guildID, commandID, permissions)
+}
@@ -0,0 +1,76 @@
+package oauth2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCredentialsSource(t *testing.T) {
+	var requests int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if err := r.ParseForm(); err != nil {
+			t.Error("invalid form:", err)
+		}
+		if r.PostForm.Get("grant_type") != "client_credentials" ||
+			r.PostForm.Get("scope") != "identify applications.commands.permissions.update" {
+			t.Errorf("unexpected form %v", r.PostForm)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"access_token": "access",
+			"token_type": "Bearer",
+			"expires_in": 604800,
+			"scope": "identify applications.commands.permissions.update"
+		}`))
+	}))
+	defer srv.Close()
+
+	oldToken := EndpointToken
+	EndpointToken = srv.URL + "/token"
+	defer func() { EndpointToken = oldToken }()
+
+	src := NewCredentialsSource(
+		NewClient(100, "secret", ""),
+		ScopeIdentify, ScopeApplicationsCommandsPermissionsUpdate,
+	)
+
+	token, err := src.Token()
+	if err != nil {
+		t.Fatal("failed to get token:", err)
+	}
+	if token.AccessToken != "access" {
+		t.Errorf("unexpected token %+v", token)
+	}
+
+	if _, err := src.Token(); err != nil {
+		t.Fatal("failed to get cached token:", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request for a cached token, got %d", requests)
+	}
+
+	// Tokens about to expire are renewed.
+	token.Expiry = time.Now().Add(time.Second)
+
+	if _, err := src.Token(); err != nil {
+		t.Fatal("failed to renew token:", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests after expiry, got %d", requests)
+	}
+
+	src.Invalidate()
+
+	if _, err := src.Token(); err != nil {
+		t.Fatal("failed to get new token:", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests after invalidation, got %d", requests)
+	}
+}
@@ -0,0 +1,231 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// DefaultStateCookie is the default name of the cookie that holds the state of
+// the OAuth2 flow of LinkedRoles.
+const DefaultStateCookie = "discord_oauth2_state"
+
+// ErrInvalidState is returned if the state given to the redirect URI doesn't
+// match the state of the user, which can happen if the user took too long to
+// authorize the application or if the request was forged.
+var ErrInvalidState = errors.New("invalid OAuth2 state")
+
+// LinkedRoles is an http.Handler that implements the verification flow of
+// linked roles. When a user links their account in Discord, they are sent to
+// the verification URL of the application, which redirects them to authorize
+// the application with the identify and role_connections.write scopes. Discord
+// then sends them back to the redirect URI, which exchanges the code for a
+// token, gets the role connection of the user from UserData and gives it to
+// Discord.
+//
+// The same handler serves both the verification URL and the redirect URI,
+// which may be the same URL:
+//
+//	c := oauth2.NewClient(appID, clientSecret, "https://example.com/linked-roles")
+//	lr := oauth2.NewLinkedRoles(c, func(ctx context.Context, user *discord.User, token *oauth2.Token) (discord.ApplicationRoleConnection, error) {
+//		return discord.ApplicationRoleConnection{
+//			PlatformName: "Example",
+//			Metadata:     map[string]string{"level": strconv.Itoa(levelOf(user.ID))},
+//		}, nil
+//	})
+//	lr.Metadata = []discord.ApplicationRoleConnectionMetadata{{
+//		Type:        discord.IntegerGreaterThanOrEqualMetadata,
+//		Key:         "level",
+//		Name:        "Level",
+//		Description: "Minimum level",
+//	}}
+//
+//	if err := lr.RegisterMetadata(bot); err != nil {
+//		log.Fatalln("cannot register metadata:", err)
+//	}
+//
+//	http.Handle("/linked-roles", lr)
+type LinkedRoles struct {
+	// Client is the OAuth2 client of the application. Its RedirectURI must
+	// point to the handler.
+	Client *Client
+	// Metadata are the role connection metadata records of the application,
+	// which are registered using RegisterMetadata.
+	Metadata []discord.ApplicationRoleConnectionMetadata
+	// UserData returns the role connection of the user who authorized the
+	// application.
+	UserData func(ctx context.Context, user *discord.User, token *Token) (discord.ApplicationRoleConnection, error)
+
+	// OnSuccess writes the response once the role connection of the user is
+	// updated, along with the token, which may be stored to update the role
+	// connection later using Update. By default, a short message is written.
+	OnSuccess func(w http.ResponseWriter, r *http.Request, user *discord.User, token *Token)
+	// OnError writes the response if the flow fails. By default, the error is
+	// written with the given status code.
+	OnError func(w http.ResponseWriter, r *http.Request, status int, err error)
+
+	// StateCookie is the name of the cookie that holds the state of the flow.
+	// It defaults to DefaultStateCookie.
+	StateCookie string
+	// StateTimeout is the time that the user has to authorize the
+	// application. It defaults to 10 minutes.
+	StateTimeout time.Duration
+}
+
+var _ http.Handler = (*LinkedRoles)(nil)
+
+// NewLinkedRoles creates a new LinkedRoles handler.
+func NewLinkedRoles(
+	client *Client,
+	userData func(ctx context.Context, user *discord.User, token *Token) (discord.ApplicationRoleConnection, error)) *LinkedRoles {
+
+	return &LinkedRoles{
+		Client:   client,
+		UserData: userData,
+	}
+}
+
+// RegisterMetadata replaces the role connection metadata records of the
+// application with Metadata. It must be called with a client that has the
+// bot token of the application.
+func (l *LinkedRoles) RegisterMetadata(bot *api.Client) error {
+	if _, err := bot.UpdateRoleConnectionMetadata(l.Client.ClientID, l.Metadata); err != nil {
+		return fmt.Errorf("failed to update role connection metadata: %w", err)
+	}
+	return nil
+}
+
+// Update updates the role connection of the user who owns the token using
+// UserData, such as when the data of the user changes after they linked their
+// account.
+func (l *LinkedRoles) Update(ctx context.Context, token *Token) (*discord.User, error) {
+	client := token.Client().WithContext(ctx)
+
+	user, err := client.Me()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	conn, err := l.UserData(ctx, user, token)
+	if err != nil {
+		return user, fmt.Errorf("failed to get role connection: %w", err)
+	}
+
+	if _, err := client.UpdateRoleConnection(l.Client.ClientID, conn); err != nil {
+		return user, fmt.Errorf("failed to update role connection: %w", err)
+	}
+
+	return user, nil
+}
+
+// ServeHTTP implements http.Handler. Requests with a code or an error are
+// handled as redirects from Discord, and other requests are redirected to
+// authorize the application.
+func (l *LinkedRoles) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	switch {
+	case q.Has("error"):
+		l.clearState(w)
+		l.error(w, r, http.StatusForbidden, fmt.Errorf("authorization denied: %s", q.Get("error")))
+	case q.Has("code"):
+		l.callback(w, r)
+	default:
+		l.authorize(w, r)
+	}
+}
+
+func (l *LinkedRoles) authorize(w http.ResponseWriter, r *http.Request) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		l.error(w, r, http.StatusInternalServerError, fmt.Errorf("failed to generate state: %w", err))
+		return
+	}
+	state := hex.EncodeToString(b[:])
+
+	timeout := l.StateTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Minute
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     l.stateCookie(),
+		Value:    state,
+		Path:     "/",
+		MaxAge:   int(timeout / time.Second),
+		HttpOnly: true,
+		Secure:   r.TLS != nil || strings.HasPrefix(l.Client.RedirectURI, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, l.Client.AuthURL(AuthURLData{
+		Scopes: Scopes{ScopeIdentify, ScopeRoleConnectionsWrite},
+		State:  state,
+		Prompt: PromptConsent,
+	}), http.StatusFound)
+}
+
+func (l *LinkedRoles) callback(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(l.stateCookie())
+	l.clearState(w)
+
+	state := r.URL.Query().Get("state")
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		l.error(w, r, http.StatusBadRequest, ErrInvalidState)
+		return
+	}
+
+	client := l.Client.WithContext(r.Context())
+
+	token, err := client.Exchange(r.URL.Query().Get("code"))
+	if err != nil {
+		l.error(w, r, http.StatusBadGateway, fmt.Errorf("failed to exchange code: %w", err))
+		return
+	}
+
+	user, err := l.Update(r.Context(), token)
+	if err != nil {
+		l.error(w, r, http.StatusBadGateway, err)
+		return
+	}
+
+	if l.OnSuccess != nil {
+		l.OnSuccess(w, r, user, token)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "Your account is linked. You can now close this page and go back to Discord.")
+}
+
+func (l *LinkedRoles) clearState(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:   l.stateCookie(),
+		Path:   "/",
+		MaxAge: -1,
+	})
+}
+
+func (l *LinkedRoles) stateCookie() string {
+	if l.StateCookie != "" {
+		return l.StateCookie
+	}
+	return DefaultStateCookie
+}
+
+func (l *LinkedRoles) error(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if l.OnError != nil {
+		l.OnError(w, r, status, err)
+		return
+	}
+	http.Error(w, err.Error(), status)
+}
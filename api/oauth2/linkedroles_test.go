@@ -0,0 +1,106 @@
+package oauth2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestLinkedRoles(t *testing.T) {
+	var updated discord.ApplicationRoleConnection
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/token":
+			w.Write([]byte(`{"access_token": "access", "token_type": "Bearer", "expires_in": 3600}`))
+		case "/users/@me":
+			if auth := r.Header.Get("Authorization"); auth != "Bearer access" {
+				t.Errorf("unexpected authorization %q", auth)
+			}
+			w.Write([]byte(`{"id": "4", "username": "user"}`))
+		case "/users/@me/applications/100/role-connection":
+			b, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &updated); err != nil {
+				t.Error("invalid role connection:", err)
+			}
+			w.Write(b)
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	oldToken, oldMe := EndpointToken, api.EndpointMe
+	EndpointToken, api.EndpointMe = srv.URL+"/token", srv.URL+"/users/@me"
+	defer func() { EndpointToken, api.EndpointMe = oldToken, oldMe }()
+
+	lr := NewLinkedRoles(
+		NewClient(100, "secret", "https://example.com/linked-roles"),
+		func(ctx context.Context, user *discord.User, token *Token) (discord.ApplicationRoleConnection, error) {
+			return discord.ApplicationRoleConnection{
+				PlatformName: "Example",
+				Metadata:     map[string]string{"level": user.ID.String()},
+			}, nil
+		},
+	)
+
+	w := httptest.NewRecorder()
+	lr.ServeHTTP(w, httptest.NewRequest("GET", "/linked-roles", nil))
+
+	if w.Code != http.StatusFound {
+		t.Fatalf("unexpected status %d", w.Code)
+	}
+
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatal("invalid redirect:", err)
+	}
+
+	state := location.Query().Get("state")
+	if state == "" || location.Query().Get("scope") != "identify role_connections.write" {
+		t.Fatalf("unexpected redirect %v", location)
+	}
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Value != state {
+		t.Fatalf("unexpected cookies %v", cookies)
+	}
+
+	t.Run("invalid state", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/linked-roles?code=code&state=forged", nil)
+		r.AddCookie(cookies[0])
+
+		w := httptest.NewRecorder()
+		lr.ServeHTTP(w, r)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("unexpected status %d", w.Code)
+		}
+	})
+
+	t.Run("callback", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/linked-roles?code=code&state="+state, nil)
+		r.AddCookie(cookies[0])
+
+		w := httptest.NewRecorder()
+		lr.ServeHTTP(w, r)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected status %d: %s", w.Code, w.Body)
+		}
+
+		if updated.PlatformName != "Example" || updated.Metadata["level"] != "4" {
+			t.Fatalf("unexpected role connection %+v", updated)
+		}
+	})
+}
@@ -0,0 +1,527 @@
+// Package oauth2 implements Discord's OAuth2 flows, which let applications such
+// as dashboards and linked roles act on behalf of users.
+//
+// An application typically redirects the user to the URL returned by AuthURL,
+// then exchanges the code given to its redirect URI for a token:
+//
+//	c := oauth2.NewClient(appID, clientSecret, "https://example.com/callback")
+//	http.Redirect(w, r, c.AuthURL(oauth2.AuthURLData{
+//		Scopes: oauth2.Scopes{oauth2.ScopeIdentify, oauth2.ScopeGuilds},
+//		State:  state,
+//	}), http.StatusFound)
+//
+//	// In the callback:
+//	token, err := c.Exchange(r.URL.Query().Get("code"))
+//	me, err := token.Client().Me()
+package oauth2
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/rate"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+)
+
+var (
+	// EndpointAuthorize is the URL that users are sent to to authorize an
+	// application.
+	EndpointAuthorize = api.BaseEndpoint + "/oauth2/authorize"
+	// EndpointToken is the endpoint to exchange codes and refresh tokens.
+	EndpointToken = api.Endpoint + "oauth2/token"
+	// EndpointRevoke is the endpoint to revoke tokens.
+	EndpointRevoke = EndpointToken + "/revoke"
+	// EndpointMe is the endpoint to get the current authorization.
+	EndpointMe = api.Endpoint + "oauth2/@me"
+)
+
+// Scope is an OAuth2 scope, which grants access to a part of the API.
+//
+// https://discord.com/developers/docs/topics/oauth2#shared-resources-oauth2-scopes
+type Scope string
+
+const (
+	// ScopeActivitiesRead allows reading the activities of the user.
+	ScopeActivitiesRead Scope = "activities.read"
+	// ScopeActivitiesWrite allows updating the activities of the user.
+	ScopeActivitiesWrite Scope = "activities.write"
+	// ScopeApplicationsBuildsRead allows reading the build data of the
+	// applications of the user.
+	ScopeApplicationsBuildsRead Scope = "applications.builds.read"
+	// ScopeApplicationsBuildsUpload allows uploading builds for the
+	// applications of the user. It requires approval from Discord.
+	ScopeApplicationsBuildsUpload Scope = "applications.builds.upload"
+	// ScopeApplicationsCommands allows the application to create commands
+	// in the guild or for the user.
+	ScopeApplicationsCommands Scope = "applications.commands"
+	// ScopeApplicationsCommandsUpdate allows updating the commands of the
+	// application using a client credentials token.
+	ScopeApplicationsCommandsUpdate Scope = "applications.commands.update"
+	// ScopeApplicationsCommandsPermissionsUpdate allows updating the
+	// permissions of the commands of the application in the guilds that the
+	// user manages.
+	ScopeApplicationsCommandsPermissionsUpdate Scope = "applications.commands.permissions.update"
+	// ScopeApplicationsEntitlements allows reading the entitlements of the
+	// user for the application.
+	ScopeApplicationsEntitlements Scope = "applications.entitlements"
+	// ScopeApplicationsStoreUpdate allows updating the store listings, SKUs
+	// and achievements of the applications of the user.
+	ScopeApplicationsStoreUpdate Scope = "applications.store.update"
+	// ScopeBot adds the bot of the application to a guild.
+	ScopeBot Scope = "bot"
+	// ScopeConnections allows reading the connections of the user.
+	ScopeConnections Scope = "connections"
+	// ScopeDMChannelsRead allows reading the DM channels of the user.
+	ScopeDMChannelsRead Scope = "dm_channels.read"
+	// ScopeEmail allows reading the email of the user.
+	ScopeEmail Scope = "email"
+	// ScopeGDMJoin allows adding the user to group DMs.
+	ScopeGDMJoin Scope = "gdm.join"
+	// ScopeGuilds allows listing the guilds of the user.
+	ScopeGuilds Scope = "guilds"
+	// ScopeGuildsJoin allows adding the user to guilds.
+	ScopeGuildsJoin Scope = "guilds.join"
+	// ScopeGuildsMembersRead allows reading the member of the user in its
+	// guilds.
+	ScopeGuildsMembersRead Scope = "guilds.members.read"
+	// ScopeIdentify allows reading the user, without its email.
+	ScopeIdentify Scope = "identify"
+	// ScopeMessagesRead allows reading the messages of the user through the
+	// local RPC server.
+	ScopeMessagesRead Scope = "messages.read"
+	// ScopeRelationshipsRead allows reading the friends of the user.
+	ScopeRelationshipsRead Scope = "relationships.read"
+	// ScopeRoleConnectionsWrite allows updating the role connection metadata
+	// of the user for the application, which is used by linked roles.
+	ScopeRoleConnectionsWrite Scope = "role_connections.write"
+	// ScopeRPC allows controlling the Discord client of the user through the
+	// local RPC server.
+	ScopeRPC Scope = "rpc"
+	// ScopeRPCActivitiesWrite allows updating the activity of the user
+	// through the local RPC server.
+	ScopeRPCActivitiesWrite Scope = "rpc.activities.write"
+	// ScopeRPCNotificationsRead allows receiving the notifications of the
+	// user through the local RPC server.
+	ScopeRPCNotificationsRead Scope = "rpc.notifications.read"
+	// ScopeRPCVoiceRead allows reading the voice settings of the user through
+	// the local RPC server.
+	ScopeRPCVoiceRead Scope = "rpc.voice.read"
+	// ScopeRPCVoiceWrite allows updating the voice settings of the user
+	// through the local RPC server.
+	ScopeRPCVoiceWrite Scope = "rpc.voice.write"
+	// ScopeVoice allows connecting to voice for the user.
+	ScopeVoice Scope = "voice"
+	// ScopeWebhookIncoming creates a webhook in a channel chosen by the user,
+	// which is returned with the token.
+	ScopeWebhookIncoming Scope = "webhook.incoming"
+)
+
+// Scopes is an ordered set of scopes. Scopes are joined into a space-separated
+// string when they're sent to Discord, which is what String returns.
+type Scopes []Scope
+
+// ParseScopes parses a space-separated string of scopes, such as the scope of a
+// token, ignoring duplicate scopes.
+func ParseScopes(scopes string) Scopes {
+	fields := strings.Fields(scopes)
+
+	parsed := make(Scopes, 0, len(fields))
+	for _, field := range fields {
+		if scope := Scope(field); !parsed.Has(scope) {
+			parsed = append(parsed, scope)
+		}
+	}
+
+	return parsed
+}
+
+// String returns the scopes as a space-separated string, as used by Discord.
+func (s Scopes) String() string {
+	strs := make([]string, len(s))
+	for i, scope := range s {
+		strs[i] = string(scope)
+	}
+	return strings.Join(strs, " ")
+}
+
+// Has returns true if all the given scopes are in the set.
+func (s Scopes) Has(scopes ...Scope) bool {
+	for _, scope := range scopes {
+		if !s.has(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Scopes) has(scope Scope) bool {
+	for _, has := range s {
+		if has == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns the given scopes that aren't in the set, such as to check
+// which of the requested scopes the user didn't grant.
+func (s Scopes) Missing(scopes ...Scope) Scopes {
+	var missing Scopes
+	for _, scope := range scopes {
+		if !s.has(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// Token is an OAuth2 token.
+//
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant-access-token-response
+type Token struct {
+	// AccessToken is the token used to make requests on behalf of the user.
+	AccessToken string `json:"access_token"`
+	// TokenType is the type of the token, which is always "Bearer".
+	TokenType string `json:"token_type"`
+	// ExpiresIn is the lifetime of the token in seconds.
+	ExpiresIn int `json:"expires_in"`
+	// RefreshToken is the token used to get a new token once this one
+	// expires. It is empty for client credentials tokens.
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// Scope is the space-separated list of the scopes granted to the token.
+	// Use Scopes to get them.
+	Scope string `json:"scope"`
+
+	// Guild is the guild that the bot was added to, if the token was granted
+	// the bot scope.
+	Guild *discord.Guild `json:"guild,omitempty"`
+	// Webhook is the webhook that was created, if the token was granted the
+	// webhook.incoming scope.
+	Webhook *discord.Webhook `json:"webhook,omitempty"`
+
+	// Expiry is when the token expires. It is computed from ExpiresIn when
+	// the token is received, and it is kept when the token is saved as JSON,
+	// since ExpiresIn is relative to when the token was received.
+	Expiry time.Time `json:"expiry"`
+}
+
+// Scopes returns the scopes granted to the token.
+func (t *Token) Scopes() Scopes {
+	return ParseScopes(t.Scope)
+}
+
+// Valid returns true if the token hasn't expired at the given time. A token
+// that expires but whose Expiry is unknown, such as one that was saved without
+// it, is not valid.
+func (t *Token) Valid(now time.Time) bool {
+	if t.AccessToken == "" {
+		return false
+	}
+	if t.Expiry.IsZero() {
+		return t.ExpiresIn <= 0
+	}
+	return now.Before(t.Expiry)
+}
+
+// AuthorizationHeader returns the value of the Authorization header for
+// requests made with the token.
+func (t *Token) AuthorizationHeader() string {
+	tokenType := t.TokenType
+	if tokenType == "" {
+		tokenType = "Bearer"
+	}
+	return tokenType + " " + t.AccessToken
+}
+
+// Client returns a new API client that makes requests on behalf of the user,
+// such as to get the user using Me or its guilds using Guilds.
+func (t *Token) Client() *api.Client {
+	return api.NewClient(t.AuthorizationHeader())
+}
+
+// Authorization is the current authorization of a token.
+//
+// https://discord.com/developers/docs/topics/oauth2#get-current-authorization-information-response-structure
+type Authorization struct {
+	// Application is the application that the token was granted to.
+	Application discord.Application `json:"application"`
+	// Scopes are the scopes granted to the token.
+	Scopes Scopes `json:"scopes"`
+	// Expires is when the token expires.
+	Expires discord.Timestamp `json:"expires"`
+	// User is the user who authorized the application, if the token was
+	// granted the identify scope.
+	User *discord.User `json:"user,omitempty"`
+}
+
+// Client is a client for the OAuth2 endpoints of an application.
+type Client struct {
+	*httputil.Client
+	// Limiter is the rate limiter used for the client. This field should not
+	// be changed, as doing so is potentially racy.
+	Limiter *rate.Limiter
+
+	// ClientID is the ID of the application.
+	ClientID discord.AppID
+	// ClientSecret is the secret of the application.
+	ClientSecret string
+	// RedirectURI is the URI that users are redirected to after authorizing
+	// the application. It must be one of the redirect URIs of the
+	// application. It may be empty for flows without a redirect, such as
+	// adding a bot.
+	RedirectURI string
+}
+
+// NewClient creates a new OAuth2 client for the application. It uses its own
+// rate limiter.
+func NewClient(clientID discord.AppID, clientSecret, redirectURI string) *Client {
+	return NewCustomClient(clientID, clientSecret, redirectURI, httputil.NewClient())
+}
+
+// NewCustomClient creates a new OAuth2 client for the application using a copy
+// of the given httputil.Client. The copy will have a new rate limiter added
+// in.
+func NewCustomClient(
+	clientID discord.AppID, clientSecret, redirectURI string, hcl *httputil.Client) *Client {
+
+	c := &Client{
+		Limiter:      rate.NewLimiter(api.Path),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURI:  redirectURI,
+	}
+
+	c.Client = hcl.Copy()
+	c.Client.OnRequest = append(c.Client.OnRequest, c.onRequest)
+	c.Client.OnResponse = append(c.Client.OnResponse, c.onResponse)
+
+	return c
+}
+
+func (c *Client) onRequest(r httpdriver.Request) error {
+	r.AddHeader(http.Header{
+		"User-Agent": {api.UserAgent},
+	})
+	return c.Limiter.Acquire(r.GetContext(), r.GetPath())
+}
+
+func (c *Client) onResponse(r httpdriver.Request, resp httpdriver.Response) error {
+	return c.Limiter.Release(r.GetPath(), httpdriver.OptHeader(resp))
+}
+
+// WithContext returns a shallow copy of Client with the given context. It's
+// used for method timeouts and such. This method is thread-safe.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cpy := *c
+	cpy.Client = c.Client.WithContext(ctx)
+	return &cpy
+}
+
+// Prompt controls whether the user is asked to authorize the application
+// again if they already did.
+type Prompt string
+
+const (
+	// PromptConsent always asks the user to authorize the application.
+	PromptConsent Prompt = "consent"
+	// PromptNone skips the authorization if the user already authorized the
+	// application with the same scopes.
+	PromptNone Prompt = "none"
+)
+
+// AuthURLData is the data used to build an authorization URL.
+//
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant-authorization-url-example
+type AuthURLData struct {
+	// Scopes are the scopes to request.
+	Scopes Scopes
+	// State is an opaque value that is given back to the redirect URI, which
+	// should be used to prevent CSRF.
+	State string
+	// Prompt controls whether the user is asked to authorize the application
+	// again.
+	Prompt Prompt
+	// ResponseType is the type of response, which defaults to "code".
+	// Use "token" for the implicit grant.
+	ResponseType string
+
+	// Permissions are the permissions requested for the bot, if the bot scope
+	// is requested.
+	Permissions discord.Permissions
+	// GuildID preselects the guild to add the bot or webhook to.
+	GuildID discord.GuildID
+	// DisableGuildSelect prevents the user from choosing another guild than
+	// GuildID.
+	DisableGuildSelect bool
+	// IntegrationType is the installation context of the authorization, if
+	// the application.commands scope is requested.
+	IntegrationType *discord.ApplicationIntegrationType
+}
+
+// AuthURL returns the URL that users are sent to to authorize the
+// application. The redirect URI of the client is included if it isn't empty.
+func (c *Client) AuthURL(data AuthURLData) string {
+	q := url.Values{}
+	q.Set("client_id", c.ClientID.String())
+
+	responseType := data.ResponseType
+	if responseType == "" {
+		responseType = "code"
+	}
+	q.Set("response_type", responseType)
+
+	if len(data.Scopes) > 0 {
+		q.Set("scope", data.Scopes.String())
+	}
+	if c.RedirectURI != "" {
+		q.Set("redirect_uri", c.RedirectURI)
+	}
+	if data.State != "" {
+		q.Set("state", data.State)
+	}
+	if data.Prompt != "" {
+		q.Set("prompt", string(data.Prompt))
+	}
+	if data.Permissions != 0 {
+		q.Set("permissions", strconv.FormatUint(uint64(data.Permissions), 10))
+	}
+	if data.GuildID.IsValid() {
+		q.Set("guild_id", data.GuildID.String())
+	}
+	if data.DisableGuildSelect {
+		q.Set("disable_guild_select", "true")
+	}
+	if data.IntegrationType != nil {
+		q.Set("integration_type", strconv.FormatUint(uint64(*data.IntegrationType), 10))
+	}
+
+	return EndpointAuthorize + "?" + q.Encode()
+}
+
+// BotAuthURL returns the URL that adds the bot of the application to a guild
+// with the given permissions. The applications.commands scope is also
+// requested, so that the bot can create commands in the guild.
+func (c *Client) BotAuthURL(permissions discord.Permissions) string {
+	bot := *c
+	bot.RedirectURI = ""
+
+	return bot.AuthURL(AuthURLData{
+		Scopes:      Scopes{ScopeBot, ScopeApplicationsCommands},
+		Permissions: permissions,
+	})
+}
+
+// Exchange exchanges the code given to the redirect URI for a token.
+//
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant-access-token-exchange-example
+func (c *Client) Exchange(code string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	if c.RedirectURI != "" {
+		form.Set("redirect_uri", c.RedirectURI)
+	}
+
+	return c.requestToken(form)
+}
+
+// Refresh gets a new token using the refresh token of an expired token.
+//
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant-refresh-token-exchange-example
+func (c *Client) Refresh(refreshToken string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+
+	return c.requestToken(form)
+}
+
+// ClientCredentials gets a token for the owner of the application, which is
+// useful for testing. Tokens for teams are only granted the identify and
+// applications.commands.update scopes. Use CredentialsSource to cache the token
+// until it expires.
+//
+// https://discord.com/developers/docs/topics/oauth2#client-credentials-grant
+func (c *Client) ClientCredentials(scopes ...Scope) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", Scopes(scopes).String())
+
+	return c.requestToken(form)
+}
+
+func (c *Client) requestToken(form url.Values) (*Token, error) {
+	var token *Token
+	if err := c.RequestJSON(&token, "POST", EndpointToken, c.withForm(form)...); err != nil {
+		return nil, err
+	}
+
+	if token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// TokenTypeHint tells Discord whether the token given to Revoke is an access
+// token or a refresh token.
+type TokenTypeHint string
+
+const (
+	AccessTokenHint  TokenTypeHint = "access_token"
+	RefreshTokenHint TokenTypeHint = "refresh_token"
+)
+
+// Revoke revokes the access token or refresh token. The hint is optional.
+// Revoking either token of a pair revokes both.
+//
+// https://discord.com/developers/docs/topics/oauth2#authorization-code-grant-token-revocation-example
+func (c *Client) Revoke(token string, hint TokenTypeHint) error {
+	form := url.Values{}
+	form.Set("token", token)
+	if hint != "" {
+		form.Set("token_type_hint", string(hint))
+	}
+
+	return c.FastRequest("POST", EndpointRevoke, c.withForm(form)...)
+}
+
+// withForm returns the options to send the form, authenticated with the
+// credentials of the application.
+func (c *Client) withForm(form url.Values) []httputil.RequestOption {
+	credentials := c.ClientID.String() + ":" + c.ClientSecret
+	body := form.Encode()
+
+	return []httputil.RequestOption{
+		httputil.WithContentType("application/x-www-form-urlencoded"),
+		httputil.WithHeaders(http.Header{
+			"Authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))},
+		}),
+		// The body is made for each attempt, since requests may be retried.
+		func(r httpdriver.Request) error {
+			r.WithBody(io.NopCloser(strings.NewReader(body)))
+			return nil
+		},
+	}
+}
+
+// CurrentAuthorization returns the authorization of the given token.
+//
+// https://discord.com/developers/docs/topics/oauth2#get-current-authorization-information
+func (c *Client) CurrentAuthorization(token *Token) (*Authorization, error) {
+	var auth *Authorization
+	return auth, c.RequestJSON(&auth, "GET", EndpointMe,
+		httputil.WithHeaders(http.Header{
+			"Authorization": {token.AuthorizationHeader()},
+		}),
+	)
+}
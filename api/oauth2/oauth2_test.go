@@ -0,0 +1,186 @@
+package oauth2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestScopes(t *testing.T) {
+	scopes := ParseScopes(" identify  guilds identify email ")
+	if s := scopes.String(); s != "identify guilds email" {
+		t.Errorf("unexpected scopes %q", s)
+	}
+
+	if !scopes.Has(ScopeIdentify, ScopeEmail) || scopes.Has(ScopeIdentify, ScopeBot) {
+		t.Errorf("unexpected containment of %v", scopes)
+	}
+
+	missing := scopes.Missing(ScopeGuilds, ScopeBot, ScopeRPC)
+	if s := missing.String(); s != "bot rpc" {
+		t.Errorf("unexpected missing scopes %q", s)
+	}
+
+	var auth Authorization
+	if err := json.Unmarshal([]byte(`{"scopes": ["identify", "guilds"]}`), &auth); err != nil {
+		t.Fatal("failed to unmarshal authorization:", err)
+	}
+	if !auth.Scopes.Has(ScopeGuilds) {
+		t.Errorf("unexpected authorization scopes %v", auth.Scopes)
+	}
+}
+
+func TestTokenJSON(t *testing.T) {
+	now := time.Now()
+	token := Token{
+		AccessToken: "access",
+		ExpiresIn:   3600,
+		Expiry:      now.Add(time.Hour),
+	}
+
+	b, err := json.Marshal(token)
+	if err != nil {
+		t.Fatal("failed to marshal token:", err)
+	}
+
+	var loaded Token
+	if err := json.Unmarshal(b, &loaded); err != nil {
+		t.Fatal("failed to unmarshal token:", err)
+	}
+
+	if !loaded.Valid(now) || loaded.Valid(now.Add(2*time.Hour)) {
+		t.Errorf("unexpected expiry after reload %v", loaded.Expiry)
+	}
+
+	// A token saved without its expiry can't be trusted.
+	loaded.Expiry = time.Time{}
+	if loaded.Valid(now) {
+		t.Error("token with unknown expiry is valid")
+	}
+
+	// A token that never expires stays valid.
+	loaded.ExpiresIn = 0
+	if !loaded.Valid(now.Add(24 * time.Hour)) {
+		t.Error("token without expiry is invalid")
+	}
+}
+
+func TestAuthURL(t *testing.T) {
+	c := NewClient(100, "secret", "https://example.com/callback")
+
+	u, err := url.Parse(c.AuthURL(AuthURLData{
+		Scopes:  Scopes{ScopeIdentify, ScopeGuilds},
+		State:   "state",
+		Prompt:  PromptNone,
+		GuildID: 200,
+	}))
+	if err != nil {
+		t.Fatal("invalid URL:", err)
+	}
+
+	expect := url.Values{
+		"client_id":     {"100"},
+		"response_type": {"code"},
+		"scope":         {"identify guilds"},
+		"redirect_uri":  {"https://example.com/callback"},
+		"state":         {"state"},
+		"prompt":        {"none"},
+		"guild_id":      {"200"},
+	}
+	if q := u.Query(); q.Encode() != expect.Encode() {
+		t.Errorf("unexpected query %v", q)
+	}
+
+	u, err = url.Parse(c.BotAuthURL(discord.PermissionSendMessages))
+	if err != nil {
+		t.Fatal("invalid URL:", err)
+	}
+
+	if q := u.Query(); q.Get("redirect_uri") != "" || q.Get("scope") != "bot applications.commands" ||
+		q.Get("permissions") != "2048" {
+		t.Errorf("unexpected bot query %v", q)
+	}
+}
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "100" || secret != "secret" {
+			t.Errorf("unexpected credentials %q:%q", id, secret)
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Error("invalid form:", err)
+		}
+
+		switch r.URL.Path {
+		case "/token":
+			switch r.PostForm.Get("grant_type") {
+			case "authorization_code":
+				if r.PostForm.Get("code") != "code" || r.PostForm.Get("redirect_uri") != "https://example.com/callback" {
+					t.Errorf("unexpected exchange form %v", r.PostForm)
+				}
+			case "refresh_token":
+				if r.PostForm.Get("refresh_token") != "refresh" {
+					t.Errorf("unexpected refresh form %v", r.PostForm)
+				}
+			default:
+				t.Errorf("unexpected grant type %q", r.PostForm.Get("grant_type"))
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{
+				"access_token": "access",
+				"token_type": "Bearer",
+				"expires_in": 604800,
+				"refresh_token": "refresh",
+				"scope": "identify guilds"
+			}`))
+
+		case "/token/revoke":
+			if r.PostForm.Get("token") != "access" || r.PostForm.Get("token_type_hint") != "access_token" {
+				t.Errorf("unexpected revoke form %v", r.PostForm)
+			}
+			w.WriteHeader(http.StatusOK)
+
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	oldToken, oldRevoke := EndpointToken, EndpointRevoke
+	EndpointToken, EndpointRevoke = srv.URL+"/token", srv.URL+"/token/revoke"
+	defer func() { EndpointToken, EndpointRevoke = oldToken, oldRevoke }()
+
+	c := NewClient(100, "secret", "https://example.com/callback")
+
+	token, err := c.Exchange("code")
+	if err != nil {
+		t.Fatal("failed to exchange code:", err)
+	}
+
+	if token.AccessToken != "access" || len(token.Scopes()) != 2 || token.Scopes()[1] != ScopeGuilds {
+		t.Errorf("unexpected token %+v", token)
+	}
+	if !token.Valid(time.Now()) || token.Valid(time.Now().Add(8*24*time.Hour)) {
+		t.Errorf("unexpected token expiry %v", token.Expiry)
+	}
+	if token.AuthorizationHeader() != "Bearer access" {
+		t.Errorf("unexpected authorization header %q", token.AuthorizationHeader())
+	}
+
+	if _, err := c.Refresh(token.RefreshToken); err != nil {
+		t.Fatal("failed to refresh token:", err)
+	}
+
+	if err := c.Revoke(token.AccessToken, AccessTokenHint); err != nil {
+		t.Fatal("failed to revoke token:", err)
+	}
+}
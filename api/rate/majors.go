@@ -56,3 +56,43 @@ func ParseBucketKey(path string) string {
 	path = strings.Join(parts, "/")
 	return "/" + path
 }
+
+// RouteTemplate returns the path with every snowflake, emoji, token and code
+// replaced by a placeholder, such as "/webhooks/:id/:token/messages/:id".
+// Unlike ParseBucketKey, the returned route contains nothing that identifies a
+// specific resource or grants access to it, so it is safe to use in logs,
+// metric labels and traces.
+func RouteTemplate(path string) string {
+	path = strings.SplitN(path, "?", 2)[0]
+
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if part == "" || i == 0 {
+			continue
+		}
+
+		switch prev := parts[i-1]; {
+		case isSnowflake(part):
+			parts[i] = ":id"
+		case prev == "invites" || prev == "templates":
+			parts[i] = ":code"
+		case i >= 2 && prev == ":id" && isTokenRoot(parts[i-2]):
+			// The segment after /webhooks/{id}/ or /interactions/{id}/ is a
+			// webhook or interaction token.
+			parts[i] = ":token"
+		case StringIsEmojiOnly(part) || StringIsCustomEmoji(part):
+			parts[i] = ":emoji"
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+func isSnowflake(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+func isTokenRoot(s string) bool {
+	return s == "webhooks" || s == "interactions"
+}
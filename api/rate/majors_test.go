@@ -31,3 +31,31 @@ func TestBucketKey(t *testing.T) {
 		}
 	}
 }
+
+func TestRouteTemplate(t *testing.T) {
+	var tests = [][2]string{
+		{"/channels/123123/messages/456456?limit=50",
+			"/channels/:id/messages/:id"},
+		{"/channels/1/messages/2/reactions/🤔/@me",
+			"/channels/:id/messages/:id/reactions/:emoji/@me"},
+		{"/channels/1/messages/2/reactions/thonk:123123/@me",
+			"/channels/:id/messages/:id/reactions/:emoji/@me"},
+		{"/webhooks/123/aBcD-eFgH_token/messages/@original",
+			"/webhooks/:id/:token/messages/@original"},
+		{"/webhooks/123/aBcD-eFgH_token/messages/456",
+			"/webhooks/:id/:token/messages/:id"},
+		{"/interactions/123/aBcD-eFgH_token/callback",
+			"/interactions/:id/:token/callback"},
+		{"/webhooks/123", "/webhooks/:id"},
+		{"/invites/discord-api", "/invites/:code"},
+		{"/guilds/templates/hgM48av5Q69A", "/guilds/templates/:code"},
+		{"/users/@me/guilds", "/users/@me/guilds"},
+	}
+
+	for _, conds := range tests {
+		route := RouteTemplate(conds[0])
+		if route != conds[1] {
+			t.Errorf("Expected/got\n%s\n%s", conds[1], route)
+		}
+	}
+}
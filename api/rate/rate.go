@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/internal/moreatomic"
+	"github.com/diamondburned/arikawa/v3/utils/clock"
+	"github.com/diamondburned/arikawa/v3/utils/logging"
 )
 
 // ExtraDelay because Discord is trash. I've seen this in both litcord and
@@ -34,11 +36,82 @@ type Limiter struct {
 
 	Prefix string
 
+	// OnEvent, if not nil, is called on rate limit pressure: when Acquire
+	// waits for a bucket or the global limit, and when Release sees that
+	// Discord rate limited a request. It is called synchronously, so it must
+	// not block. It must be set before the Limiter is used.
+	OnEvent func(Event)
+
+	// Clock is the clock used to check rate limit resets and to wait for
+	// them. It is clock.System if nil. It must be set before the Limiter is
+	// used.
+	Clock clock.Clock
+
 	// global is a pointer to prevent ARM-compatibility alignment.
 	global *int64 // atomic guarded, unixnano
 
-	bucketMu sync.Mutex
-	buckets  map[string]*bucket
+	// buckets maps bucket keys to *bucket. Buckets are only ever added, so a
+	// sync.Map lets concurrent requests look up their buckets without
+	// contending on a single lock.
+	buckets sync.Map
+}
+
+// EventType is the type of a rate limit Event.
+type EventType uint8
+
+const (
+	// EventWait is emitted when Acquire sleeps until a rate limit resets.
+	EventWait EventType = iota
+	// EventRateLimited is emitted when Discord responds with 429 Too Many
+	// Requests. If the Event's Scope is ScopeGlobal, then the global rate
+	// limit was tripped.
+	EventRateLimited
+)
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case EventWait:
+		return "wait"
+	case EventRateLimited:
+		return "rate_limited"
+	default:
+		return fmt.Sprintf("EventType(%d)", uint8(t))
+	}
+}
+
+// Scope is the scope of a rate limit.
+type Scope uint8
+
+const (
+	// ScopeBucket is a rate limit that only applies to the route's bucket.
+	ScopeBucket Scope = iota
+	// ScopeGlobal is a rate limit that applies to all routes.
+	ScopeGlobal
+)
+
+// String returns the name of the scope.
+func (s Scope) String() string {
+	switch s {
+	case ScopeBucket:
+		return "bucket"
+	case ScopeGlobal:
+		return "global"
+	default:
+		return fmt.Sprintf("Scope(%d)", uint8(s))
+	}
+}
+
+// Event describes rate limit pressure on a route. It is given to
+// Limiter.OnEvent.
+type Event struct {
+	Type EventType
+	// Route is the route template of the request, as returned by
+	// RouteTemplate. It contains no IDs or tokens.
+	Route string
+	Scope Scope
+	// Wait is the duration until the rate limit resets.
+	Wait time.Duration
 }
 
 type CustomRateLimit struct {
@@ -86,7 +159,6 @@ func NewLimiter(prefix string) *Limiter {
 	return &Limiter{
 		Prefix:       prefix,
 		global:       new(int64),
-		buckets:      map[string]*bucket{},
 		CustomLimits: []*CustomRateLimit{},
 	}
 }
@@ -94,29 +166,27 @@ func NewLimiter(prefix string) *Limiter {
 func (l *Limiter) getBucket(path string, store bool) *bucket {
 	path = ParseBucketKey(strings.TrimPrefix(path, l.Prefix))
 
-	l.bucketMu.Lock()
-	defer l.bucketMu.Unlock()
+	if bc, ok := l.buckets.Load(path); ok {
+		return bc.(*bucket)
+	}
 
-	bc, ok := l.buckets[path]
-	if !ok && !store {
+	if !store {
 		return nil
 	}
 
-	if !ok {
-		bc := newBucket()
+	bc := newBucket()
 
-		for _, limit := range l.CustomLimits {
-			if strings.Contains(path, limit.Contains) {
-				bc.custom = limit
-				break
-			}
+	for _, limit := range l.CustomLimits {
+		if strings.Contains(path, limit.Contains) {
+			bc.custom = limit
+			break
 		}
-
-		l.buckets[path] = bc
-		return bc
 	}
 
-	return bc
+	// Another goroutine may have stored the bucket in the meantime, in which
+	// case that one is used.
+	actual, _ := l.buckets.LoadOrStore(path, bc)
+	return actual.(*bucket)
 }
 
 // Acquire acquires the rate limiter for the given URL bucket.
@@ -136,7 +206,8 @@ func (l *Limiter) Acquire(ctx context.Context, path string) error {
 
 	// Deadline until the limiter is released.
 	until := time.Time{}
-	now := time.Now()
+	scope := ScopeBucket
+	now := clock.OrSystem(l.Clock).Now()
 
 	if b.remaining == 0 && b.reset.After(now) {
 		// out of turns, gotta wait
@@ -144,6 +215,7 @@ func (l *Limiter) Acquire(ctx context.Context, path string) error {
 	} else {
 		// maybe global rate limit has it
 		until = time.Unix(0, atomic.LoadInt64(l.global))
+		scope = ScopeGlobal
 	}
 
 	if until.After(now) {
@@ -153,11 +225,27 @@ func (l *Limiter) Acquire(ctx context.Context, path string) error {
 			return ErrTimedOutEarly
 		}
 
+		route := RouteTemplate(strings.TrimPrefix(path, l.Prefix))
+		logging.Logger().Debug("waiting for rate limit",
+			logging.KeyRoute, route, "wait", until.Sub(now))
+
+		if l.OnEvent != nil {
+			l.OnEvent(Event{
+				Type:  EventWait,
+				Route: route,
+				Scope: scope,
+				Wait:  until.Sub(now),
+			})
+		}
+
+		timer := clock.OrSystem(l.Clock).NewTimer(until.Sub(now))
+
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			b.lock.Unlock()
 			return ctx.Err()
-		case <-time.After(until.Sub(now)):
+		case <-timer.C():
 		}
 	}
 
@@ -181,7 +269,7 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 
 	// Check custom limiter
 	if b.custom != nil {
-		now := time.Now()
+		now := clock.OrSystem(l.Clock).Now()
 
 		if now.Sub(b.lastReset) >= b.custom.Reset {
 			b.lastReset = now
@@ -213,14 +301,26 @@ func (l *Limiter) Release(path string, headers http.Header) error {
 			return fmt.Errorf("invalid retryAfter %q: %w", retryAfter, err)
 		}
 
-		at := time.Now().Add(time.Duration(i) * time.Second)
+		wait := time.Duration(i) * time.Second
+		at := clock.OrSystem(l.Clock).Now().Add(wait)
+		scope := ScopeBucket
 
 		if global != "" { // probably "true"
 			atomic.StoreInt64(l.global, at.UnixNano())
+			scope = ScopeGlobal
 		} else {
 			b.reset = at
 		}
 
+		if l.OnEvent != nil {
+			l.OnEvent(Event{
+				Type:  EventRateLimited,
+				Route: RouteTemplate(strings.TrimPrefix(path, l.Prefix)),
+				Scope: scope,
+				Wait:  wait,
+			})
+		}
+
 	case reset != "":
 		unix, err := strconv.ParseFloat(reset, 64)
 		if err != nil {
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"testing"
 	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/clock"
 )
 
 // https://github.com/bwmarrin/discordgo/blob/master/ratelimit_test.go
@@ -78,3 +80,88 @@ func TestRatelimitGlobal(t *testing.T) {
 		t.Error("did not ratelimit correctly, got:", time.Since(sent))
 	}
 }
+
+// This test takes ~1 seconds to run
+func TestRatelimitEvents(t *testing.T) {
+	l := NewLimiter("")
+
+	var events []Event
+	l.OnEvent = func(ev Event) { events = append(events, ev) }
+
+	headers := http.Header{}
+	headers.Set("Retry-After", "1")
+
+	mockRequest(t, l, "/channels/1/messages", headers)
+	mockRequest(t, l, "/channels/1/messages", nil)
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d: %+v", len(events), events)
+	}
+
+	limited := events[0]
+	if limited.Type != EventRateLimited || limited.Scope != ScopeBucket ||
+		limited.Route != "/channels/:id/messages" || limited.Wait != time.Second {
+		t.Errorf("unexpected rate limited event: %+v", limited)
+	}
+
+	wait := events[1]
+	if wait.Type != EventWait || wait.Scope != ScopeBucket ||
+		wait.Route != "/channels/:id/messages" || wait.Wait <= 0 || wait.Wait > time.Second {
+		t.Errorf("unexpected wait event: %+v", wait)
+	}
+}
+
+func BenchmarkGetBucketParallel(b *testing.B) {
+	l := NewLimiter("")
+
+	paths := []string{
+		"/channels/1/messages",
+		"/channels/2/messages",
+		"/guilds/1/members",
+		"/guilds/2/roles",
+	}
+
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			l.getBucket(paths[i%len(paths)], true)
+		}
+	})
+}
+
+func TestRatelimitClock(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1600000000, 0))
+
+	l := NewLimiter("")
+	l.Clock = fake
+
+	headers := http.Header{}
+	headers.Set("Retry-After", "60")
+	mockRequest(t, l, "/channels/1/messages", headers)
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- l.Acquire(context.Background(), "/channels/1/messages") }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := fake.BlockUntil(ctx, 1); err != nil {
+		t.Fatal("limiter did not wait:", err)
+	}
+
+	select {
+	case err := <-acquired:
+		t.Fatal("acquired before the rate limit reset:", err)
+	default:
+	}
+
+	fake.Advance(time.Minute)
+
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Fatal("failed to acquire:", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for acquire after advancing the clock")
+	}
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"sort"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
@@ -126,6 +128,124 @@ func (c *Client) MoveRoles(guildID discord.GuildID, data MoveRolesData) ([]disco
 	)
 }
 
+// ReorderRoles sets the positions of the roles in the guild to the given
+// ordering, from the lowest role to the highest. The @everyone role is always at
+// the bottom and is skipped if it is in roleIDs. Roles not in roleIDs are left
+// as they are, so roleIDs should usually contain all other roles of the guild.
+//
+// Requires the MANAGE_ROLES permission.
+//
+// Fires multiple Guild Role Update Gateway events.
+func (c *Client) ReorderRoles(
+	guildID discord.GuildID,
+	roleIDs []discord.RoleID, reason AuditLogReason) ([]discord.Role, error) {
+
+	data := MoveRolesData{
+		Roles:          make([]MoveRoleData, 0, len(roleIDs)),
+		AuditLogReason: reason,
+	}
+
+	for _, id := range roleIDs {
+		if discord.Snowflake(id) == discord.Snowflake(guildID) {
+			continue
+		}
+
+		data.Roles = append(data.Roles, MoveRoleData{
+			ID:       id,
+			Position: option.NewNullableInt(len(data.Roles) + 1),
+		})
+	}
+
+	return c.MoveRoles(guildID, data)
+}
+
+// MoveRole moves the role to the given position, shifting the roles in between
+// to make room for it. Position 1 is right above the @everyone role; positions
+// out of range are clamped. Only the roles whose position actually changes are
+// sent to Discord, so the positions of other roles aren't clobbered.
+//
+// Requires the MANAGE_ROLES permission.
+//
+// Fires multiple Guild Role Update Gateway events.
+func (c *Client) MoveRole(
+	guildID discord.GuildID,
+	roleID discord.RoleID, position int, reason AuditLogReason) ([]discord.Role, error) {
+
+	roles, err := c.Roles(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := MoveRolesData{
+		Roles:          moveRole(guildID, roles, roleID, position),
+		AuditLogReason: reason,
+	}
+
+	if len(data.Roles) == 0 {
+		return roles, nil
+	}
+
+	return c.MoveRoles(guildID, data)
+}
+
+// moveRole returns the position changes needed to move the role with the given
+// ID to the given position.
+func moveRole(
+	guildID discord.GuildID,
+	roles []discord.Role, roleID discord.RoleID, position int) []MoveRoleData {
+
+	var moved *discord.Role
+	ordered := make([]discord.Role, 0, len(roles))
+
+	for i, r := range roles {
+		switch {
+		case discord.Snowflake(r.ID) == discord.Snowflake(guildID):
+			continue
+		case r.ID == roleID:
+			moved = &roles[i]
+			continue
+		}
+		ordered = append(ordered, r)
+	}
+
+	if moved == nil {
+		return nil
+	}
+
+	// Discord breaks ties between roles with the same position using their
+	// IDs.
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Position != ordered[j].Position {
+			return ordered[i].Position < ordered[j].Position
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	switch {
+	case position < 1:
+		position = 1
+	case position > len(ordered)+1:
+		position = len(ordered) + 1
+	}
+
+	ordered = append(ordered, discord.Role{})
+	copy(ordered[position:], ordered[position-1:])
+	ordered[position-1] = *moved
+
+	var data []MoveRoleData
+
+	for i, r := range ordered {
+		if r.Position != i+1 {
+			data = append(data, MoveRoleData{
+				ID:       r.ID,
+				Position: option.NewNullableInt(i + 1),
+			})
+		}
+	}
+
+	return data
+}
+
 // https://discord.com/developers/docs/resources/guild#modify-guild-role-json-params
 type ModifyRoleData struct {
 	// Name is the 	name of the role.
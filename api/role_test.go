@@ -0,0 +1,51 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestMoveRole(t *testing.T) {
+	const guildID = 1
+
+	roles := []discord.Role{
+		{ID: guildID, Position: 0},
+		{ID: 10, Position: 1},
+		{ID: 11, Position: 2},
+		{ID: 12, Position: 3},
+		{ID: 13, Position: 4},
+	}
+
+	type change struct {
+		ID       discord.RoleID
+		Position int
+	}
+
+	tests := []struct {
+		name     string
+		roleID   discord.RoleID
+		position int
+		changes  []change
+	}{
+		{"up", 10, 3, []change{{11, 1}, {12, 2}, {10, 3}}},
+		{"down", 13, 2, []change{{13, 2}, {11, 3}, {12, 4}}},
+		{"clamped", 11, 100, []change{{12, 2}, {13, 3}, {11, 4}}},
+		{"unchanged", 12, 3, nil},
+		{"unknown", 99, 1, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var changes []change
+			for _, d := range moveRole(guildID, roles, test.roleID, test.position) {
+				changes = append(changes, change{d.ID, d.Position.Val})
+			}
+
+			if !reflect.DeepEqual(changes, test.changes) {
+				t.Errorf("expected changes %v, got %v", test.changes, changes)
+			}
+		})
+	}
+}
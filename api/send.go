@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"unicode/utf8"
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
@@ -90,6 +91,31 @@ func (am AllowedMentions) Verify() error {
 // ExecuteWebhookData is missing content, embeds, and files.
 var ErrEmptyMessage = errors.New("message is empty")
 
+// UploadTooLargeError is returned if the files attached to a message are
+// larger than the upload limit.
+type UploadTooLargeError struct {
+	// Size is the total size of the files in bytes.
+	Size int64
+	// Limit is the upload limit in bytes.
+	Limit int64
+}
+
+func (err *UploadTooLargeError) Error() string {
+	return fmt.Sprintf("files are %.02fMiB, larger than the upload limit of %.02fMiB",
+		float64(err.Size)/(1024*1024), float64(err.Limit)/(1024*1024))
+}
+
+// ValidateUploadSize returns an *UploadTooLargeError if the total size of the
+// files is larger than limit bytes, such as a guild's
+// discord.NitroBoost.UploadLimit. Files whose sizes can't be known without
+// reading them are not counted.
+func ValidateUploadSize(files []sendpart.File, limit int64) error {
+	if size := sendpart.TotalSize(files); size > limit {
+		return &UploadTooLargeError{Size: size, Limit: limit}
+	}
+	return nil
+}
+
 // SendMessageData is the full structure to send a new message to Discord with.
 type SendMessageData struct {
 	// Content are the message contents (up to 2000 characters).
@@ -132,6 +158,44 @@ func (data SendMessageData) WriteMultipart(body *multipart.Writer) error {
 	return sendpart.Write(body, data, data.Files)
 }
 
+// Validate checks the message against Discord's limits. ErrEmptyMessage is
+// returned if the message has no content, embeds or files. The embeds are
+// validated using discord.Embed.Validate, which may change their fields.
+func (data SendMessageData) Validate() error {
+	if data.Content == "" && len(data.Embeds) == 0 && len(data.Files) == 0 {
+		return ErrEmptyMessage
+	}
+
+	if n := utf8.RuneCountInString(data.Content); n > MaxMessageContentLength {
+		return &discord.OverboundError{Count: n, Max: MaxMessageContentLength, Thing: "content"}
+	}
+
+	if n := len(data.Embeds); n > MaxMessageEmbeds {
+		return &discord.OverboundError{Count: n, Max: MaxMessageEmbeds, Thing: "embeds"}
+	}
+
+	if data.AllowedMentions != nil {
+		if err := data.AllowedMentions.Verify(); err != nil {
+			return fmt.Errorf("allowedMentions error: %w", err)
+		}
+	}
+
+	sum := 0
+	for i, embed := range data.Embeds {
+		if err := embed.Validate(); err != nil {
+			return fmt.Errorf("embed error at %d: %w", i, err)
+		}
+		sum += embed.Length()
+		if sum > 6000 {
+			return &discord.OverboundError{Count: sum, Max: 6000, Thing: "sum of all text in embeds"}
+		}
+
+		data.Embeds[i] = embed // embed.Validate changes fields
+	}
+
+	return nil
+}
+
 // SendMessageComplex posts a message to a guild text or DM channel. If
 // operating on a guild channel, this endpoint requires the SEND_MESSAGES
 // permission to be present on the current user. If the tts field is set to
@@ -153,30 +217,11 @@ func (data SendMessageData) WriteMultipart(body *multipart.Writer) error {
 // Content-Disposition subpart header MUST contain a filename parameter.
 func (c *Client) SendMessageComplex(
 	channelID discord.ChannelID, data SendMessageData) (*discord.Message, error) {
-	if data.Content == "" && len(data.Embeds) == 0 && len(data.Files) == 0 {
-		return nil, ErrEmptyMessage
-	}
-
-	if data.AllowedMentions != nil {
-		if err := data.AllowedMentions.Verify(); err != nil {
-			return nil, fmt.Errorf("allowedMentions error: %w", err)
-		}
-	}
-
-	sum := 0
-	for i, embed := range data.Embeds {
-		if err := embed.Validate(); err != nil {
-			return nil, fmt.Errorf("embed error at %d: %w", i, err)
-		}
-		sum += embed.Length()
-		if sum > 6000 {
-			return nil, &discord.OverboundError{Count: sum, Max: 6000, Thing: "sum of all text in embeds"}
-		}
-
-		data.Embeds[i] = embed // embed.Validate changes fields
+	if err := data.Validate(); err != nil {
+		return nil, err
 	}
 
-	var URL = EndpointChannels + channelID.String() + "/messages"
+	var URL = channelMessagesURL(channelID)
 	var msg *discord.Message
 	return msg, sendpart.POST(c.Client, data, &msg, URL)
 }
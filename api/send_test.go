@@ -1,7 +1,10 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"strings"
 	"testing"
 
@@ -144,6 +147,30 @@ func TestSendMessage(t *testing.T) {
 	})
 }
 
+func TestValidateUploadSize(t *testing.T) {
+	files := []sendpart.File{
+		{Name: "a.bin", Reader: bytes.NewReader(make([]byte, 6*1024*1024))},
+		{Name: "b.bin", Reader: strings.NewReader(strings.Repeat("b", 6*1024*1024))},
+		// Unknown size, so it must not be counted.
+		{Name: "c.bin", Reader: io.LimitReader(strings.NewReader("c"), 1)},
+	}
+
+	err := ValidateUploadSize(files, discord.NoNitroLevel.UploadLimit())
+
+	var tooLarge *UploadTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if tooLarge.Size != 12*1024*1024 || tooLarge.Limit != discord.DefaultUploadLimit {
+		t.Fatalf("Unexpected error values: %#v", tooLarge)
+	}
+
+	if err := ValidateUploadSize(files, discord.NitroLevel2.UploadLimit()); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+}
+
 func errMustContain(t *testing.T, err error, contains string) {
 	// mark function as helper so line traces are accurate.
 	t.Helper()
@@ -167,3 +194,16 @@ func mustMarshal(t *testing.T, v interface{}) string {
 	}
 	return string(j)
 }
+
+func TestSendMessageComplexValidate(t *testing.T) {
+	client := NewClient("token")
+
+	_, err := client.SendMessageComplex(1, SendMessageData{
+		Content: strings.Repeat("a", MaxMessageContentLength+1),
+	})
+
+	var overbound *discord.OverboundError
+	if !errors.As(err, &overbound) || overbound.Thing != "content" {
+		t.Errorf("expected content OverboundError, got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+)
+
+var (
+	EndpointStickers     = Endpoint + "stickers/"
+	EndpointStickerPacks = Endpoint + "sticker-packs"
+)
+
+// Sticker returns a sticker object for the given sticker ID.
+func (c *Client) Sticker(stickerID discord.StickerID) (*discord.Sticker, error) {
+	var s *discord.Sticker
+	return s, c.RequestJSON(&s, "GET", EndpointStickers+stickerID.String())
+}
+
+// StickerPacks returns the list of sticker packs available to Nitro
+// subscribers.
+func (c *Client) StickerPacks() ([]discord.StickerPack, error) {
+	var resp struct {
+		StickerPacks []discord.StickerPack `json:"sticker_packs"`
+	}
+
+	return resp.StickerPacks, c.RequestJSON(&resp, "GET", EndpointStickerPacks)
+}
+
+// StickerPack returns a sticker pack object for the given sticker pack ID.
+func (c *Client) StickerPack(packID discord.StickerPackID) (*discord.StickerPack, error) {
+	var p *discord.StickerPack
+	return p, c.RequestJSON(&p, "GET", EndpointStickerPacks+"/"+packID.String())
+}
+
+// GuildStickers returns the list of sticker objects for the given guild.
+//
+// The User field of the stickers is only filled if the current user has the
+// MANAGE_EMOJIS_AND_STICKERS permission.
+func (c *Client) GuildStickers(guildID discord.GuildID) ([]discord.Sticker, error) {
+	var s []discord.Sticker
+	return s, c.RequestJSON(&s, "GET", EndpointGuilds+guildID.String()+"/stickers")
+}
+
+// GuildSticker returns a sticker object for the given guild and sticker IDs.
+//
+// The User field of the sticker is only filled if the current user has the
+// MANAGE_EMOJIS_AND_STICKERS permission.
+func (c *Client) GuildSticker(
+	guildID discord.GuildID, stickerID discord.StickerID) (*discord.Sticker, error) {
+
+	var s *discord.Sticker
+	return s, c.RequestJSON(
+		&s, "GET",
+		EndpointGuilds+guildID.String()+"/stickers/"+stickerID.String(),
+	)
+}
+
+// https://discord.com/developers/docs/resources/sticker#create-guild-sticker-form-params
+type CreateStickerData struct {
+	// Name is the name of the sticker (2-30 characters).
+	Name string
+	// Description is the description of the sticker (empty or 2-100
+	// characters).
+	Description string
+	// Tags is the autocomplete/suggestion tags for the sticker (max 200
+	// characters).
+	Tags string
+	// File is the sticker file to upload. It must be a PNG, APNG, GIF or
+	// Lottie JSON file, max 512KB.
+	File sendpart.File
+
+	AuditLogReason
+}
+
+// WriteMultipart writes the sticker form into the multipart writer.
+func (data CreateStickerData) WriteMultipart(body *multipart.Writer) error {
+	fields := [...][2]string{
+		{"name", data.Name},
+		{"description", data.Description},
+		{"tags", data.Tags},
+	}
+
+	for _, field := range fields {
+		if err := body.WriteField(field[0], field[1]); err != nil {
+			return fmt.Errorf("failed to write field %q: %w", field[0], err)
+		}
+	}
+
+	w, err := body.CreateFormFile("file", data.File.Name)
+	if err != nil {
+		return fmt.Errorf("failed to create bodypart for file: %w", err)
+	}
+
+	if _, err := io.Copy(w, data.File.Reader); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// CreateGuildSticker creates a new sticker in the guild.
+//
+// Requires the MANAGE_EMOJIS_AND_STICKERS permission.
+//
+// Fires a Guild Stickers Update Gateway event.
+func (c *Client) CreateGuildSticker(
+	guildID discord.GuildID, data CreateStickerData) (*discord.Sticker, error) {
+
+	if data.File.Reader == nil {
+		return nil, fmt.Errorf("sticker file %q has no reader", data.File.Name)
+	}
+
+	r, err := c.MeanwhileMultipart(
+		data, "POST",
+		EndpointGuilds+guildID.String()+"/stickers",
+		httputil.WithHeaders(data.Header()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	body := r.GetBody()
+	defer body.Close()
+
+	var s *discord.Sticker
+	return s, json.DecodeStream(body, &s)
+}
+
+// https://discord.com/developers/docs/resources/sticker#modify-guild-sticker-json-params
+type ModifyStickerData struct {
+	// Name is the name of the sticker (2-30 characters).
+	Name string `json:"name,omitempty"`
+	// Description is the description of the sticker (empty or 2-100
+	// characters).
+	Description option.NullableString `json:"description,omitempty"`
+	// Tags is the autocomplete/suggestion tags for the sticker (max 200
+	// characters).
+	Tags string `json:"tags,omitempty"`
+
+	AuditLogReason `json:"-"`
+}
+
+// ModifyGuildSticker modifies the given sticker.
+//
+// Requires the MANAGE_EMOJIS_AND_STICKERS permission.
+//
+// Fires a Guild Stickers Update Gateway event.
+func (c *Client) ModifyGuildSticker(
+	guildID discord.GuildID,
+	stickerID discord.StickerID, data ModifyStickerData) (*discord.Sticker, error) {
+
+	var s *discord.Sticker
+	return s, c.RequestJSON(
+		&s, "PATCH",
+		EndpointGuilds+guildID.String()+"/stickers/"+stickerID.String(),
+		httputil.WithJSONBody(data), httputil.WithHeaders(data.Header()),
+	)
+}
+
+// DeleteGuildSticker deletes the given sticker.
+//
+// Requires the MANAGE_EMOJIS_AND_STICKERS permission.
+//
+// Fires a Guild Stickers Update Gateway event.
+func (c *Client) DeleteGuildSticker(
+	guildID discord.GuildID, stickerID discord.StickerID, reason AuditLogReason) error {
+
+	return c.FastRequest(
+		"DELETE",
+		EndpointGuilds+guildID.String()+"/stickers/"+stickerID.String(),
+		httputil.WithHeaders(reason.Header()),
+	)
+}
@@ -85,6 +85,25 @@ func (c *Client) CreatePrivateChannel(recipientID discord.UserID) (*discord.Chan
 	return dm, c.RequestJSON(&dm, "POST", EndpointMe+"/channels", httputil.WithJSONBody(param))
 }
 
+// CreateGroupDMData is the data for CreateGroupDM.
+type CreateGroupDMData struct {
+	// AccessTokens are the access tokens of the users to add to the group DM,
+	// who must have authorized the application with the gdm.join scope.
+	AccessTokens []string `json:"access_tokens"`
+	// Nicks maps the IDs of the users to their nicknames in the group DM.
+	Nicks map[discord.UserID]string `json:"nicks,omitempty"`
+}
+
+// CreateGroupDM creates a new group DM with the users whose access tokens are
+// given. Group DMs created this way are limited to 10 active group DMs and
+// aren't shown in the Discord client.
+//
+// Fires a Channel Create Gateway event.
+func (c *Client) CreateGroupDM(data CreateGroupDMData) (*discord.Channel, error) {
+	var dm *discord.Channel
+	return dm, c.RequestJSON(&dm, "POST", EndpointMe+"/channels", httputil.WithJSONBody(data))
+}
+
 // UserConnections returns a list of connection objects. Requires the
 // connections OAuth2 scope.
 func (c *Client) UserConnections() ([]discord.Connection, error) {
@@ -92,6 +111,31 @@ func (c *Client) UserConnections() ([]discord.Connection, error) {
 	return conn, c.RequestJSON(&conn, "GET", EndpointMe+"/connections")
 }
 
+// RoleConnection returns the role connection of the current user to the
+// application. Requires an OAuth2 access token with the role_connections.write
+// scope.
+func (c *Client) RoleConnection(appID discord.AppID) (*discord.ApplicationRoleConnection, error) {
+	var conn *discord.ApplicationRoleConnection
+	return conn, c.RequestJSON(
+		&conn, "GET",
+		EndpointMe+"/applications/"+appID.String()+"/role-connection",
+	)
+}
+
+// UpdateRoleConnection updates the role connection of the current user to the
+// application, which Discord uses to grant the linked roles of the user.
+// Requires an OAuth2 access token with the role_connections.write scope.
+func (c *Client) UpdateRoleConnection(
+	appID discord.AppID, conn discord.ApplicationRoleConnection) (*discord.ApplicationRoleConnection, error) {
+
+	var updated *discord.ApplicationRoleConnection
+	return updated, c.RequestJSON(
+		&updated, "PUT",
+		EndpointMe+"/applications/"+appID.String()+"/role-connection",
+		httputil.WithJSONBody(conn),
+	)
+}
+
 // Note gets the note for the given user. This endpoint is undocumented and
 // might only work for user accounts.
 func (c *Client) Note(userID discord.UserID) (string, error) {
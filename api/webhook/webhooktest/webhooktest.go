@@ -0,0 +1,124 @@
+// Package webhooktest provides helpers for testing HTTP interaction servers,
+// such as webhook.InteractionServer, with requests signed like Discord signs
+// them.
+package webhooktest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// Signer signs interaction requests with an Ed25519 key pair, acting as
+// Discord. The server under test should be given PublicKeyHex.
+type Signer struct {
+	PublicKey  ed25519.PublicKey
+	PrivateKey ed25519.PrivateKey
+}
+
+// NewSigner creates a new Signer with a random key pair.
+func NewSigner() (*Signer, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+
+	return &Signer{PublicKey: pub, PrivateKey: priv}, nil
+}
+
+// NewSignerFromSeed creates a new Signer with the key pair derived from the
+// given seed, which must be ed25519.SeedSize bytes long. It is useful for
+// tests that need the same key on every run.
+func NewSignerFromSeed(seed []byte) *Signer {
+	priv := ed25519.NewKeyFromSeed(seed)
+	return &Signer{
+		PublicKey:  priv.Public().(ed25519.PublicKey),
+		PrivateKey: priv,
+	}
+}
+
+// PublicKeyHex returns the hex-encoded public key, which is the format that
+// webhook.NewInteractionServer takes.
+func (s *Signer) PublicKeyHex() string {
+	return hex.EncodeToString(s.PublicKey)
+}
+
+// Sign returns the hex-encoded signature of the request body with the given
+// timestamp, which is what Discord sends in the X-Signature-Ed25519 header.
+func (s *Signer) Sign(timestamp string, body []byte) string {
+	msg := make([]byte, 0, len(timestamp)+len(body))
+	msg = append(msg, timestamp...)
+	msg = append(msg, body...)
+
+	return hex.EncodeToString(ed25519.Sign(s.PrivateKey, msg))
+}
+
+// SignRequest signs the request with the current time, setting the
+// X-Signature-Ed25519 and X-Signature-Timestamp headers. The request body is
+// read and replaced.
+func (s *Signer) SignRequest(r *http.Request) error {
+	var body []byte
+
+	if r.Body != nil {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read body: %w", err)
+		}
+		r.Body.Close()
+		body = b
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	r.Header.Set("X-Signature-Ed25519", s.Sign(timestamp, body))
+	r.Header.Set("X-Signature-Timestamp", timestamp)
+
+	return nil
+}
+
+// NewRequest creates a new signed POST request delivering the interaction
+// event to the given URL.
+func (s *Signer) NewRequest(url string, ev *discord.InteractionEvent) (*http.Request, error) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal interaction: %w", err)
+	}
+
+	r, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+
+	if err := s.SignRequest(r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Serve delivers the interaction event to the handler in a signed request and
+// returns the recorded response.
+func (s *Signer) Serve(h http.Handler, ev *discord.InteractionEvent) (*httptest.ResponseRecorder, error) {
+	r, err := s.NewRequest("http://localhost/", ev)
+	if err != nil {
+		return nil, err
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	return w, nil
+}
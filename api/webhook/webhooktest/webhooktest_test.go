@@ -0,0 +1,71 @@
+package webhooktest
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/webhook"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+func TestSigner(t *testing.T) {
+	signer := NewSignerFromSeed(bytes.Repeat([]byte{1}, 32))
+
+	srv, err := webhook.NewInteractionServer(signer.PublicKeyHex(), webhook.InteractionHandlerFunc(
+		func(ev *discord.InteractionEvent) *api.InteractionResponse {
+			return &api.InteractionResponse{
+				Type: api.MessageInteractionWithSource,
+				Data: &api.InteractionResponseData{
+					Content: option.NewNullableString("pong"),
+				},
+			}
+		},
+	))
+	if err != nil {
+		t.Fatal("failed to create server:", err)
+	}
+
+	ev := &discord.InteractionEvent{
+		ID:    1,
+		AppID: 2,
+		Token: "token",
+		Data:  &discord.CommandInteraction{ID: 3, Name: "ping"},
+	}
+
+	w, err := signer.Serve(srv, ev)
+	if err != nil {
+		t.Fatal("failed to serve:", err)
+	}
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d: %s", w.Code, w.Body)
+	}
+
+	var resp api.InteractionResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal("failed to decode response:", err)
+	}
+
+	if resp.Data == nil || resp.Data.Content == nil || resp.Data.Content.Val != "pong" {
+		t.Fatalf("unexpected response %s", w.Body)
+	}
+
+	// A request signed by another key must be rejected.
+	other, err := NewSigner()
+	if err != nil {
+		t.Fatal("failed to create signer:", err)
+	}
+
+	w, err = other.Serve(srv, ev)
+	if err != nil {
+		t.Fatal("failed to serve:", err)
+	}
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("unexpected status %d for a bad signature: %s", w.Code, w.Body)
+	}
+}
@@ -55,6 +55,20 @@ type Application struct {
 	CustomInstallURL string `json:"custom_install_url,omitempty"`
 	// RoleConnectionsVerificationURL is the application's role connection verification entry point, which when configured will render the app as a verification method in the guild role verification configuration.
 	RoleConnectionsVerificationURL string `json:"role_connections_verification_url,omitempty"`
+	// ApproximateGuildCount is the approximate number of guilds that the app
+	// has been added to.
+	ApproximateGuildCount int `json:"approximate_guild_count,omitempty"`
+	// IntegrationTypesConfig is the default settings of the authorization
+	// link of each installation type that the app supports.
+	IntegrationTypesConfig map[ApplicationIntegrationType]ApplicationIntegrationTypeConfig `json:"integration_types_config,omitempty"`
+}
+
+// ApplicationIntegrationTypeConfig is the configuration of an installation
+// type of an application.
+type ApplicationIntegrationTypeConfig struct {
+	// OAuth2InstallParams is the settings of the default authorization link
+	// for the installation type.
+	OAuth2InstallParams *InstallParams `json:"oauth2_install_params,omitempty"`
 }
 
 type ApplicationFlags uint32
@@ -120,10 +134,92 @@ type CommandPermissionType uint8
 
 // https://discord.com/developers/docs/interactions/slash-commands#application-command-permissions-object-application-command-permission-type
 const (
-	RoleCommandPermission = iota + 1
+	RoleCommandPermission CommandPermissionType = iota + 1
 	UserCommandPermission
+	ChannelCommandPermission
 )
 
+// MaxCommandPermissions is the maximum number of permission overwrites that a
+// command can have in a guild.
+const MaxCommandPermissions = 100
+
+// AllChannelsID returns the sentinel channel ID that represents all channels
+// in the guild in command permissions, which is the guild ID minus 1.
+func AllChannelsID(guildID GuildID) ChannelID {
+	return ChannelID(guildID - 1)
+}
+
+// NewRoleCommandPermission returns the command permission that allows or
+// denies the role from using the command. Use the guild ID as the role ID to
+// target the @everyone role, or use EveryoneCommandPermission.
+func NewRoleCommandPermission(roleID RoleID, allow bool) CommandPermissions {
+	return CommandPermissions{ID: Snowflake(roleID), Type: RoleCommandPermission, Permission: allow}
+}
+
+// NewUserCommandPermission returns the command permission that allows or
+// denies the user from using the command.
+func NewUserCommandPermission(userID UserID, allow bool) CommandPermissions {
+	return CommandPermissions{ID: Snowflake(userID), Type: UserCommandPermission, Permission: allow}
+}
+
+// NewChannelCommandPermission returns the command permission that allows or
+// denies the command from being used in the channel. Use
+// AllChannelsCommandPermission to target all channels.
+func NewChannelCommandPermission(channelID ChannelID, allow bool) CommandPermissions {
+	return CommandPermissions{ID: Snowflake(channelID), Type: ChannelCommandPermission, Permission: allow}
+}
+
+// EveryoneCommandPermission returns the command permission that allows or
+// denies everyone in the guild from using the command.
+func EveryoneCommandPermission(guildID GuildID, allow bool) CommandPermissions {
+	return NewRoleCommandPermission(RoleID(guildID), allow)
+}
+
+// AllChannelsCommandPermission returns the command permission that allows or
+// denies the command from being used in all channels of the guild.
+func AllChannelsCommandPermission(guildID GuildID, allow bool) CommandPermissions {
+	return NewChannelCommandPermission(AllChannelsID(guildID), allow)
+}
+
+// CommandPermissionsForRoles returns the command permissions that only allow
+// the roles with the given permissions, such as BanMembers, to use the command.
+// Roles with the Administrator permission are also allowed, and everyone else
+// is denied. The returned list may need to be truncated to
+// MaxCommandPermissions.
+func CommandPermissionsForRoles(guildID GuildID, roles []Role, perms Permissions) []CommandPermissions {
+	permissions := []CommandPermissions{EveryoneCommandPermission(guildID, false)}
+
+	for _, role := range roles {
+		if Snowflake(role.ID) == Snowflake(guildID) {
+			continue
+		}
+		if role.Permissions.Has(perms) || role.Permissions.Has(PermissionAdministrator) {
+			permissions = append(permissions, NewRoleCommandPermission(role.ID, true))
+		}
+	}
+
+	return permissions
+}
+
+// Target returns the command permission's ID as a string describing what it
+// targets, such as "@everyone" or "all channels", for the given guild.
+func (p CommandPermissions) Target(guildID GuildID) string {
+	switch {
+	case p.Type == RoleCommandPermission && p.ID == Snowflake(guildID):
+		return "@everyone"
+	case p.Type == ChannelCommandPermission && p.ID == Snowflake(AllChannelsID(guildID)):
+		return "all channels"
+	case p.Type == RoleCommandPermission:
+		return RoleID(p.ID).Mention()
+	case p.Type == UserCommandPermission:
+		return UserID(p.ID).Mention()
+	case p.Type == ChannelCommandPermission:
+		return ChannelID(p.ID).Mention()
+	default:
+		return p.ID.String()
+	}
+}
+
 // https://discord.com/developers/docs/resources/application#install-params-object
 type InstallParams struct {
 	// Scopes is the scopes to add the application to the server with.
@@ -131,3 +227,78 @@ type InstallParams struct {
 	// Permissions is the permissions to request for the bot role.
 	Permissions Permissions `json:"permissions,string"`
 }
+
+// RoleConnectionMetadataType is the type of a role connection metadata
+// record, which determines how the value of a user is compared to the value
+// configured for a linked role.
+//
+// https://discord.com/developers/docs/resources/application-role-connection-metadata#application-role-connection-metadata-object-application-role-connection-metadata-type
+type RoleConnectionMetadataType uint8
+
+const (
+	// IntegerLessThanOrEqualMetadata requires the value of the user to be
+	// less than or equal to the configured value.
+	IntegerLessThanOrEqualMetadata RoleConnectionMetadataType = iota + 1
+	// IntegerGreaterThanOrEqualMetadata requires the value of the user to be
+	// greater than or equal to the configured value.
+	IntegerGreaterThanOrEqualMetadata
+	// IntegerEqualMetadata requires the value of the user to be equal to the
+	// configured value.
+	IntegerEqualMetadata
+	// IntegerNotEqualMetadata requires the value of the user to not be equal
+	// to the configured value.
+	IntegerNotEqualMetadata
+	// DatetimeLessThanOrEqualMetadata requires the date of the user to be at
+	// most the configured number of days ago.
+	DatetimeLessThanOrEqualMetadata
+	// DatetimeGreaterThanOrEqualMetadata requires the date of the user to be
+	// at least the configured number of days ago.
+	DatetimeGreaterThanOrEqualMetadata
+	// BooleanEqualMetadata requires the value of the user to be equal to the
+	// configured value.
+	BooleanEqualMetadata
+	// BooleanNotEqualMetadata requires the value of the user to not be equal
+	// to the configured value.
+	BooleanNotEqualMetadata
+)
+
+// MaxRoleConnectionMetadata is the maximum number of role connection metadata
+// records of an application.
+const MaxRoleConnectionMetadata = 5
+
+// ApplicationRoleConnectionMetadata is a role connection metadata record of an
+// application, which guilds can require for their linked roles.
+//
+// https://discord.com/developers/docs/resources/application-role-connection-metadata#application-role-connection-metadata-object
+type ApplicationRoleConnectionMetadata struct {
+	// Type is the type of the metadata value.
+	Type RoleConnectionMetadataType `json:"type"`
+	// Key is the key of the metadata in the role connections of the users. It
+	// can only contain a-z, 0-9 and _, up to 50 characters.
+	Key string `json:"key"`
+	// Name is the name of the metadata, up to 100 characters.
+	Name string `json:"name"`
+	// NameLocalizations are the localizations of Name.
+	NameLocalizations StringLocales `json:"name_localizations,omitempty"`
+	// Description is the description of the metadata, up to 200 characters.
+	Description string `json:"description"`
+	// DescriptionLocalizations are the localizations of Description.
+	DescriptionLocalizations StringLocales `json:"description_localizations,omitempty"`
+}
+
+// ApplicationRoleConnection is the role connection of a user to an
+// application.
+//
+// https://discord.com/developers/docs/resources/user#application-role-connection-object
+type ApplicationRoleConnection struct {
+	// PlatformName is the vanity name of the platform that the application
+	// connects to, up to 50 characters.
+	PlatformName string `json:"platform_name,omitempty"`
+	// PlatformUsername is the username of the user on the platform, up to
+	// 100 characters.
+	PlatformUsername string `json:"platform_username,omitempty"`
+	// Metadata maps the keys of the role connection metadata records of the
+	// application to the values of the user. Integers are formatted in base
+	// 10, datetimes in ISO8601 and booleans as "1" or "0".
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
@@ -0,0 +1,53 @@
+package discord
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommandPermissionsForRoles(t *testing.T) {
+	const guildID = 100
+
+	roles := []Role{
+		{ID: guildID, Permissions: PermissionBanMembers},
+		{ID: 1, Permissions: PermissionBanMembers | PermissionKickMembers},
+		{ID: 2, Permissions: PermissionKickMembers},
+		{ID: 3, Permissions: PermissionAdministrator},
+	}
+
+	got := CommandPermissionsForRoles(guildID, roles, PermissionBanMembers)
+	expected := []CommandPermissions{
+		{ID: guildID, Type: RoleCommandPermission, Permission: false},
+		{ID: 1, Type: RoleCommandPermission, Permission: true},
+		{ID: 3, Type: RoleCommandPermission, Permission: true},
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("unexpected permissions %+v", got)
+	}
+}
+
+func TestCommandPermissionsTarget(t *testing.T) {
+	const guildID = 100
+
+	tests := []struct {
+		perm   CommandPermissions
+		target string
+	}{
+		{EveryoneCommandPermission(guildID, true), "@everyone"},
+		{AllChannelsCommandPermission(guildID, false), "all channels"},
+		{NewRoleCommandPermission(5, true), "<@&5>"},
+		{NewUserCommandPermission(6, true), "<@6>"},
+		{NewChannelCommandPermission(7, true), "<#7>"},
+	}
+
+	for _, test := range tests {
+		if target := test.perm.Target(guildID); target != test.target {
+			t.Errorf("expected target %q, got %q", test.target, target)
+		}
+	}
+
+	if id := AllChannelsID(guildID); id != 99 {
+		t.Errorf("unexpected all channels ID %v", id)
+	}
+}
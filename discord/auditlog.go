@@ -87,6 +87,26 @@ const (
 	IntegrationCreate      AuditLogEvent = 80
 	IntegrationUpdate      AuditLogEvent = 81
 	IntegrationDelete      AuditLogEvent = 82
+
+	StageInstanceCreate                     AuditLogEvent = 83
+	StageInstanceUpdate                     AuditLogEvent = 84
+	StageInstanceDelete                     AuditLogEvent = 85
+	StickerCreate                           AuditLogEvent = 90
+	StickerUpdate                           AuditLogEvent = 91
+	StickerDelete                           AuditLogEvent = 92
+	GuildScheduledEventCreate               AuditLogEvent = 100
+	GuildScheduledEventUpdate               AuditLogEvent = 101
+	GuildScheduledEventDelete               AuditLogEvent = 102
+	ThreadCreate                            AuditLogEvent = 110
+	ThreadUpdate                            AuditLogEvent = 111
+	ThreadDelete                            AuditLogEvent = 112
+	ApplicationCommandPermissionUpdate      AuditLogEvent = 121
+	AutoModerationRuleCreate                AuditLogEvent = 140
+	AutoModerationRuleUpdate                AuditLogEvent = 141
+	AutoModerationRuleDelete                AuditLogEvent = 142
+	AutoModerationBlockMessage              AuditLogEvent = 143
+	AutoModerationFlagToChannel             AuditLogEvent = 144
+	AutoModerationUserCommunicationDisabled AuditLogEvent = 145
 )
 
 // https://discord.com/developers/docs/resources/audit-log#audit-log-entry-object-optional-audit-entry-info
@@ -171,6 +191,19 @@ type AuditEntryInfo struct {
 //	}
 //
 //	log.Println("Transferred ownership from user", oldOwnerID, "to", newOwnerID)
+//
+// Alternatively, Values decodes the values into their documented types, which
+// is useful when handling many keys:
+//
+//	old, new, err := change.Values()
+//	if err != nil {
+//	    return err
+//	}
+//
+//	switch new := new.(type) {
+//	case discord.UserID:
+//	    log.Println("Changed", change.Key, "from", old, "to user", new)
+//	}
 type AuditLogChange struct {
 	// Key is the name of audit log change key.
 	Key AuditLogChangeKey `json:"key"`
@@ -192,6 +225,103 @@ func (a AuditLogChange) UnmarshalValues(old, new interface{}) error {
 	return nil
 }
 
+// Values unmarshals the old and new values of the AuditLogChange into the type
+// documented for its key, such as UserID for AuditGuildOwnerID, and returns
+// them as values, not pointers. A value is nil if it's missing. If the type of
+// the key is unknown or ambiguous, such as for AuditAnyType, the values are
+// returned as json.Raw.
+func (a AuditLogChange) Values() (old, new interface{}, err error) {
+	decode, ok := auditLogChangeTypes[a.Key]
+	if !ok {
+		decode = decodeAuditValue[json.Raw]
+	}
+
+	if old, err = decodeAuditRaw(a.OldValue, decode); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal old value: %w", err)
+	}
+	if new, err = decodeAuditRaw(a.NewValue, decode); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal new value: %w", err)
+	}
+
+	return old, new, nil
+}
+
+func decodeAuditRaw(raw json.Raw, decode func(json.Raw) (interface{}, error)) (interface{}, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	return decode(raw)
+}
+
+func decodeAuditValue[T any](raw json.Raw) (interface{}, error) {
+	var v T
+	if err := raw.UnmarshalTo(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func decodeAuditPermissions(raw json.Raw) (interface{}, error) {
+	var v struct {
+		Permissions Permissions `json:"p,string"`
+	}
+	if err := json.Unmarshal([]byte(`{"p":`+string(raw)+`}`), &v); err != nil {
+		return nil, err
+	}
+	return v.Permissions, nil
+}
+
+// auditLogChangeTypes maps the keys to functions that decode their values into
+// the type documented for each key.
+var auditLogChangeTypes = map[AuditLogChangeKey]func(json.Raw) (interface{}, error){
+	AuditGuildName:                    decodeAuditValue[string],
+	AuditGuildIconHash:                decodeAuditValue[Hash],
+	AuditGuildSplashHash:              decodeAuditValue[Hash],
+	AuditGuildOwnerID:                 decodeAuditValue[UserID],
+	AuditGuildRegion:                  decodeAuditValue[string],
+	AuditGuildAFKChannelID:            decodeAuditValue[ChannelID],
+	AuditGuildAFKTimeout:              decodeAuditValue[Seconds],
+	AuditGuildMFA:                     decodeAuditValue[MFALevel],
+	AuditGuildVerification:            decodeAuditValue[Verification],
+	AuditGuildExplicitFilter:          decodeAuditValue[ExplicitFilter],
+	AuditGuildNotification:            decodeAuditValue[Notification],
+	AuditGuildVanityURLCode:           decodeAuditValue[string],
+	AuditGuildRoleAdd:                 decodeAuditValue[[]Role],
+	AuditGuildRoleRemove:              decodeAuditValue[[]Role],
+	AuditGuildPruneDeleteDays:         decodeAuditValue[int],
+	AuditGuildWidgetEnabled:           decodeAuditValue[bool],
+	AuditGuildWidgetChannelID:         decodeAuditValue[ChannelID],
+	AuditGuildSystemChannelID:         decodeAuditValue[ChannelID],
+	AuditChannelPosition:              decodeAuditValue[int],
+	AuditChannelTopic:                 decodeAuditValue[string],
+	AuditChannelBitrate:               decodeAuditValue[uint],
+	AuditChannelPermissionOverwrites:  decodeAuditValue[[]Overwrite],
+	AuditChannelNSFW:                  decodeAuditValue[bool],
+	AuditChannelApplicationID:         decodeAuditValue[AppID],
+	AuditChannelRateLimitPerUser:      decodeAuditValue[Seconds],
+	AuditRolePermissions:              decodeAuditPermissions,
+	AuditRoleColor:                    decodeAuditValue[Color],
+	AuditRoleHoist:                    decodeAuditValue[bool],
+	AuditRoleMentionable:              decodeAuditValue[bool],
+	AuditRoleAllow:                    decodeAuditPermissions,
+	AuditRoleDeny:                     decodeAuditPermissions,
+	AuditInviteCode:                   decodeAuditValue[string],
+	AuditInviteChannelID:              decodeAuditValue[ChannelID],
+	AuditInviteInviterID:              decodeAuditValue[UserID],
+	AuditInviteMaxUses:                decodeAuditValue[int],
+	AuditInviteUses:                   decodeAuditValue[int],
+	AuditInviteMaxAge:                 decodeAuditValue[Seconds],
+	AuditInviteTemporary:              decodeAuditValue[bool],
+	AuditUserDeaf:                     decodeAuditValue[bool],
+	AuditUserMute:                     decodeAuditValue[bool],
+	AuditUserNick:                     decodeAuditValue[string],
+	AuditUserAvatarHash:               decodeAuditValue[Hash],
+	AuditAnyID:                        decodeAuditValue[Snowflake],
+	AuditIntegrationEnableEmoticons:   decodeAuditValue[bool],
+	AuditIntegrationExpireBehavior:    decodeAuditValue[ExpireBehavior],
+	AuditIntegrationExpireGracePeriod: decodeAuditValue[int],
+}
+
 type AuditLogChangeKey string
 
 // https://discord.com/developers/docs/resources/audit-log#audit-log-change-object-audit-log-change-key
@@ -0,0 +1,71 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestAuditLogChangeValues(t *testing.T) {
+	type test struct {
+		name     string
+		change   string
+		old, new interface{}
+	}
+
+	var tests = []test{
+		{
+			name:   "owner_id",
+			change: `{"key": "owner_id", "old_value": "1", "new_value": "2"}`,
+			old:    UserID(1),
+			new:    UserID(2),
+		},
+		{
+			name:   "permissions",
+			change: `{"key": "permissions", "old_value": "8", "new_value": "0"}`,
+			old:    PermissionAdministrator,
+			new:    Permissions(0),
+		},
+		{
+			name:   "created",
+			change: `{"key": "topic", "new_value": "hi"}`,
+			old:    nil,
+			new:    "hi",
+		},
+		{
+			name:   "unknown",
+			change: `{"key": "type", "old_value": 0, "new_value": "x"}`,
+			old:    json.Raw("0"),
+			new:    json.Raw(`"x"`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var change AuditLogChange
+			if err := json.Unmarshal([]byte(test.change), &change); err != nil {
+				t.Fatal("failed to unmarshal change:", err)
+			}
+
+			old, new, err := change.Values()
+			if err != nil {
+				t.Fatal("failed to get values:", err)
+			}
+
+			if !equalAuditValue(old, test.old) {
+				t.Errorf("old value = %#v, want %#v", old, test.old)
+			}
+			if !equalAuditValue(new, test.new) {
+				t.Errorf("new value = %#v, want %#v", new, test.new)
+			}
+		})
+	}
+}
+
+func equalAuditValue(got, want interface{}) bool {
+	if raw, ok := want.(json.Raw); ok {
+		got, ok := got.(json.Raw)
+		return ok && string(got) == string(raw)
+	}
+	return got == want
+}
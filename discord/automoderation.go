@@ -0,0 +1,167 @@
+package discord
+
+// AutoModerationEventType indicates in what event context a rule should be
+// checked.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-event-types
+type AutoModerationEventType int
+
+const (
+	// AutoModerationMessageSend is when a member sends or edits a message in
+	// the guild.
+	AutoModerationMessageSend AutoModerationEventType = iota + 1
+	// AutoModerationMemberUpdate is when a member edits their profile.
+	AutoModerationMemberUpdate
+)
+
+// AutoModerationTriggerType characterizes the type of content which can
+// trigger the rule.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-trigger-types
+type AutoModerationTriggerType int
+
+const (
+	// KeywordTrigger checks if content contains words from a user defined
+	// list of keywords. A guild can have up to 6 of these rules.
+	KeywordTrigger AutoModerationTriggerType = 1
+	// SpamTrigger checks if content represents generic spam. A guild can have
+	// 1 of these rules.
+	SpamTrigger AutoModerationTriggerType = 3
+	// KeywordPresetTrigger checks if content contains words from internal
+	// pre-defined wordsets. A guild can have 1 of these rules.
+	KeywordPresetTrigger AutoModerationTriggerType = 4
+	// MentionSpamTrigger checks if content contains more unique mentions than
+	// allowed. A guild can have 1 of these rules.
+	MentionSpamTrigger AutoModerationTriggerType = 5
+	// MemberProfileTrigger checks if a member's profile contains words from a
+	// user defined list of keywords. A guild can have 1 of these rules.
+	MemberProfileTrigger AutoModerationTriggerType = 6
+)
+
+// AutoModerationKeywordPreset is an internally pre-defined wordset which will
+// be searched for in content.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-keyword-preset-types
+type AutoModerationKeywordPreset int
+
+const (
+	// ProfanityPreset contains words that may be considered forms of swearing
+	// or cursing.
+	ProfanityPreset AutoModerationKeywordPreset = iota + 1
+	// SexualContentPreset contains words that refer to sexually explicit
+	// behavior or activity.
+	SexualContentPreset
+	// SlursPreset contains personal insults or words that may be considered
+	// hate speech.
+	SlursPreset
+)
+
+// AutoModerationTriggerMetadata is additional data used to determine whether a
+// rule should be triggered. Which fields are used depends on the trigger type
+// of the rule.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object-trigger-metadata
+type AutoModerationTriggerMetadata struct {
+	// KeywordFilter is the list of substrings which will be searched for in
+	// content (maximum of 1000). It is used by KeywordTrigger and
+	// MemberProfileTrigger.
+	KeywordFilter []string `json:"keyword_filter,omitempty"`
+	// RegexPatterns is the list of Rust-flavored regular expressions which
+	// will be matched against content (maximum of 10). It is used by
+	// KeywordTrigger and MemberProfileTrigger.
+	RegexPatterns []string `json:"regex_patterns,omitempty"`
+	// Presets is the list of internally pre-defined wordsets which will be
+	// searched for in content. It is used by KeywordPresetTrigger.
+	Presets []AutoModerationKeywordPreset `json:"presets,omitempty"`
+	// AllowList is the list of substrings which should not trigger the rule.
+	// It is used by KeywordTrigger, KeywordPresetTrigger and
+	// MemberProfileTrigger.
+	AllowList []string `json:"allow_list,omitempty"`
+	// MentionTotalLimit is the total number of unique role and user mentions
+	// allowed per message (maximum of 50). It is used by MentionSpamTrigger.
+	MentionTotalLimit int `json:"mention_total_limit,omitempty"`
+	// MentionRaidProtectionEnabled is whether to automatically detect mention
+	// raids. It is used by MentionSpamTrigger.
+	MentionRaidProtectionEnabled bool `json:"mention_raid_protection_enabled,omitempty"`
+}
+
+// AutoModerationActionType is the type of action to take when a rule is
+// triggered.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object-action-types
+type AutoModerationActionType int
+
+const (
+	// BlockMessageAction blocks a member's message and prevents it from being
+	// posted. A custom explanation can be specified and shown to members
+	// whenever their message is blocked.
+	BlockMessageAction AutoModerationActionType = iota + 1
+	// SendAlertMessageAction logs the user content to a specified channel.
+	SendAlertMessageAction
+	// TimeoutAction times out the user for a specified duration. It can only
+	// be set up for KeywordTrigger and MentionSpamTrigger rules, and requires
+	// the MODERATE_MEMBERS permission.
+	TimeoutAction
+	// BlockMemberInteractionAction prevents a member from using text, voice,
+	// or other interactions.
+	BlockMemberInteractionAction
+)
+
+// AutoModerationAction is an action which will execute whenever a rule is
+// triggered.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object
+type AutoModerationAction struct {
+	// Type is the type of action.
+	Type AutoModerationActionType `json:"type"`
+	// Metadata is the additional metadata needed during execution for this
+	// specific action type.
+	Metadata *AutoModerationActionMetadata `json:"metadata,omitempty"`
+}
+
+// AutoModerationActionMetadata is additional data used when an action is
+// executed. Which fields are used depends on the action type.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-action-object-action-metadata
+type AutoModerationActionMetadata struct {
+	// ChannelID is the channel to which user content should be logged. It is
+	// used by SendAlertMessageAction.
+	ChannelID ChannelID `json:"channel_id,omitempty"`
+	// DurationSeconds is the timeout duration in seconds (maximum of 2419200
+	// seconds, or 4 weeks). It is used by TimeoutAction.
+	DurationSeconds int `json:"duration_seconds,omitempty"`
+	// CustomMessage is the additional explanation that will be shown to
+	// members whenever their message is blocked (maximum of 150 characters).
+	// It is used by BlockMessageAction.
+	CustomMessage string `json:"custom_message,omitempty"`
+}
+
+// AutoModerationRule is a rule that is checked against content in a guild.
+//
+// https://discord.com/developers/docs/resources/auto-moderation#auto-moderation-rule-object
+type AutoModerationRule struct {
+	// ID is the id of this rule.
+	ID AutoModerationRuleID `json:"id"`
+	// GuildID is the id of the guild which this rule belongs to.
+	GuildID GuildID `json:"guild_id"`
+	// Name is the rule name.
+	Name string `json:"name"`
+	// CreatorID is the user which first created this rule.
+	CreatorID UserID `json:"creator_id"`
+	// EventType is the rule event type.
+	EventType AutoModerationEventType `json:"event_type"`
+	// TriggerType is the rule trigger type.
+	TriggerType AutoModerationTriggerType `json:"trigger_type"`
+	// TriggerMetadata is the rule trigger metadata.
+	TriggerMetadata AutoModerationTriggerMetadata `json:"trigger_metadata"`
+	// Actions are the actions which will execute when the rule is triggered.
+	Actions []AutoModerationAction `json:"actions"`
+	// Enabled is whether the rule is enabled.
+	Enabled bool `json:"enabled"`
+	// ExemptRoles are the role ids that should not be affected by the rule
+	// (maximum of 20).
+	ExemptRoles []RoleID `json:"exempt_roles"`
+	// ExemptChannels are the channel ids that should not be affected by the
+	// rule (maximum of 50).
+	ExemptChannels []ChannelID `json:"exempt_channels"`
+}
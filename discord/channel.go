@@ -233,6 +233,16 @@ const GuildNews = GuildAnnouncement
 // Deprecated: use GuildAnnouncementThread instead.
 const GuildNewsThread = GuildAnnouncementThread
 
+// IsThread returns true if the channel type is a thread type.
+func (t ChannelType) IsThread() bool {
+	switch t {
+	case GuildAnnouncementThread, GuildPublicThread, GuildPrivateThread:
+		return true
+	default:
+		return false
+	}
+}
+
 // https://discord.com/developers/docs/resources/channel#overwrite-object
 type Overwrite struct {
 	// ID is the role or user id.
@@ -283,6 +293,17 @@ func (otype *OverwriteType) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// FollowedChannel is an announcement channel that another channel follows
+// through a webhook.
+//
+// https://discord.com/developers/docs/resources/channel#followed-channel-object
+type FollowedChannel struct {
+	// ChannelID is the source channel id.
+	ChannelID ChannelID `json:"channel_id"`
+	// WebhookID is the id of the created target webhook.
+	WebhookID WebhookID `json:"webhook_id"`
+}
+
 type VideoQualityMode uint8
 
 // https://discord.com/developers/docs/resources/channel#channel-object-video-quality-modes
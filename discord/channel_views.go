@@ -0,0 +1,246 @@
+package discord
+
+import "time"
+
+// guildChannel contains the methods shared by all typed channel views.
+type guildChannel struct {
+	ch Channel
+}
+
+// Channel returns a copy of the underlying channel.
+func (c guildChannel) Channel() Channel { return c.ch }
+
+// ID returns the ID of the channel.
+func (c guildChannel) ID() ChannelID { return c.ch.ID }
+
+// Type returns the type of the channel.
+func (c guildChannel) Type() ChannelType { return c.ch.Type }
+
+// GuildID returns the ID of the guild that the channel is in.
+func (c guildChannel) GuildID() GuildID { return c.ch.GuildID }
+
+// Name returns the name of the channel.
+func (c guildChannel) Name() string { return c.ch.Name }
+
+// ParentID returns the ID of the parent category of the channel, or the ID of
+// the channel that a thread was created in.
+func (c guildChannel) ParentID() ChannelID { return c.ch.ParentID }
+
+// Flags returns the flags of the channel.
+func (c guildChannel) Flags() ChannelFlags { return c.ch.Flags }
+
+// CreatedAt returns a time object representing when the channel was created.
+func (c guildChannel) CreatedAt() time.Time { return c.ch.CreatedAt() }
+
+// Mention returns a mention of the channel.
+func (c guildChannel) Mention() string { return c.ch.Mention() }
+
+// TextChannel is a view of a guild text or announcement channel.
+type TextChannel struct{ guildChannel }
+
+// AsTextChannel returns the channel as a TextChannel if it is a GuildText or
+// GuildAnnouncement channel.
+func (ch Channel) AsTextChannel() (TextChannel, bool) {
+	switch ch.Type {
+	case GuildText, GuildAnnouncement:
+		return TextChannel{guildChannel{ch}}, true
+	default:
+		return TextChannel{}, false
+	}
+}
+
+// IsAnnouncement returns true if the channel is an announcement channel.
+func (c TextChannel) IsAnnouncement() bool { return c.ch.Type == GuildAnnouncement }
+
+// Position returns the sorting position of the channel.
+func (c TextChannel) Position() int { return c.ch.Position }
+
+// Overwrites returns the explicit permission overrides of the channel.
+func (c TextChannel) Overwrites() []Overwrite { return c.ch.Overwrites }
+
+// Topic returns the topic of the channel.
+func (c TextChannel) Topic() string { return c.ch.Topic }
+
+// NSFW returns whether the channel is NSFW.
+func (c TextChannel) NSFW() bool { return c.ch.NSFW }
+
+// LastMessageID returns the ID of the last message sent in the channel.
+func (c TextChannel) LastMessageID() MessageID { return c.ch.LastMessageID }
+
+// LastPinTime returns when the last message was pinned in the channel.
+func (c TextChannel) LastPinTime() Timestamp { return c.ch.LastPinTime }
+
+// UserRateLimit returns the slowmode of the channel.
+func (c TextChannel) UserRateLimit() Seconds { return c.ch.UserRateLimit }
+
+// DefaultAutoArchiveDuration returns the default duration after which new
+// threads in the channel are archived.
+func (c TextChannel) DefaultAutoArchiveDuration() ArchiveDuration {
+	return c.ch.DefaultAutoArchiveDuration
+}
+
+// VoiceChannel is a view of a guild voice or stage channel.
+type VoiceChannel struct{ guildChannel }
+
+// AsVoiceChannel returns the channel as a VoiceChannel if it is a GuildVoice or
+// GuildStageVoice channel.
+func (ch Channel) AsVoiceChannel() (VoiceChannel, bool) {
+	switch ch.Type {
+	case GuildVoice, GuildStageVoice:
+		return VoiceChannel{guildChannel{ch}}, true
+	default:
+		return VoiceChannel{}, false
+	}
+}
+
+// IsStage returns true if the channel is a stage channel.
+func (c VoiceChannel) IsStage() bool { return c.ch.Type == GuildStageVoice }
+
+// Position returns the sorting position of the channel.
+func (c VoiceChannel) Position() int { return c.ch.Position }
+
+// Overwrites returns the explicit permission overrides of the channel.
+func (c VoiceChannel) Overwrites() []Overwrite { return c.ch.Overwrites }
+
+// NSFW returns whether the channel is NSFW.
+func (c VoiceChannel) NSFW() bool { return c.ch.NSFW }
+
+// Bitrate returns the bitrate of the channel in bits.
+func (c VoiceChannel) Bitrate() uint { return c.ch.VoiceBitrate }
+
+// UserLimit returns the maximum number of users in the channel, or 0 if there
+// is no limit.
+func (c VoiceChannel) UserLimit() uint { return c.ch.VoiceUserLimit }
+
+// RTCRegionID returns the voice region ID of the channel, or an empty string
+// if it is automatic.
+func (c VoiceChannel) RTCRegionID() string { return c.ch.RTCRegionID }
+
+// VideoQualityMode returns the camera video quality mode of the channel.
+func (c VoiceChannel) VideoQualityMode() VideoQualityMode { return c.ch.VideoQualityMode }
+
+// LastMessageID returns the ID of the last message sent in the channel's text
+// chat.
+func (c VoiceChannel) LastMessageID() MessageID { return c.ch.LastMessageID }
+
+// UserRateLimit returns the slowmode of the channel's text chat.
+func (c VoiceChannel) UserRateLimit() Seconds { return c.ch.UserRateLimit }
+
+// ForumChannel is a view of a guild forum channel.
+type ForumChannel struct{ guildChannel }
+
+// AsForumChannel returns the channel as a ForumChannel if it is a GuildForum
+// channel.
+func (ch Channel) AsForumChannel() (ForumChannel, bool) {
+	if ch.Type != GuildForum {
+		return ForumChannel{}, false
+	}
+	return ForumChannel{guildChannel{ch}}, true
+}
+
+// Position returns the sorting position of the channel.
+func (c ForumChannel) Position() int { return c.ch.Position }
+
+// Overwrites returns the explicit permission overrides of the channel.
+func (c ForumChannel) Overwrites() []Overwrite { return c.ch.Overwrites }
+
+// Topic returns the guidelines of the forum.
+func (c ForumChannel) Topic() string { return c.ch.Topic }
+
+// NSFW returns whether the channel is NSFW.
+func (c ForumChannel) NSFW() bool { return c.ch.NSFW }
+
+// LastMessageID returns the ID of the last thread created in the forum.
+func (c ForumChannel) LastMessageID() MessageID { return c.ch.LastMessageID }
+
+// UserRateLimit returns the slowmode for creating posts in the forum.
+func (c ForumChannel) UserRateLimit() Seconds { return c.ch.UserRateLimit }
+
+// AvailableTags returns the tags that can be applied to posts in the forum.
+func (c ForumChannel) AvailableTags() []Tag { return c.ch.AvailableTags }
+
+// Tag returns the available tag with the given ID, if any.
+func (c ForumChannel) Tag(id TagID) (Tag, bool) {
+	for _, tag := range c.ch.AvailableTags {
+		if tag.ID == id {
+			return tag, true
+		}
+	}
+	return Tag{}, false
+}
+
+// DefaultReactionEmoji returns the emoji shown on posts by default, or nil.
+func (c ForumChannel) DefaultReactionEmoji() *ForumReaction { return c.ch.DefaultReactionEmoji }
+
+// DefaultThreadRateLimitPerUser returns the slowmode that is copied into new
+// posts in the forum.
+func (c ForumChannel) DefaultThreadRateLimitPerUser() int {
+	return c.ch.DefaultThreadRateLimitPerUser
+}
+
+// DefaultAutoArchiveDuration returns the default duration after which new
+// posts in the forum are archived.
+func (c ForumChannel) DefaultAutoArchiveDuration() ArchiveDuration {
+	return c.ch.DefaultAutoArchiveDuration
+}
+
+// DefaultSortOrder returns the default sort order of posts, or nil if it isn't
+// set.
+func (c ForumChannel) DefaultSortOrder() *SortOrderType { return c.ch.DefaultSoftOrder }
+
+// DefaultForumLayout returns the default layout of the forum.
+func (c ForumChannel) DefaultForumLayout() ForumLayoutType { return c.ch.DefaultForumLayout }
+
+// ThreadChannel is a view of a thread, including forum posts.
+type ThreadChannel struct{ guildChannel }
+
+// AsThreadChannel returns the channel as a ThreadChannel if it is a thread.
+func (ch Channel) AsThreadChannel() (ThreadChannel, bool) {
+	if !ch.Type.IsThread() {
+		return ThreadChannel{}, false
+	}
+	return ThreadChannel{guildChannel{ch}}, true
+}
+
+// IsPrivate returns true if the thread is a private thread.
+func (c ThreadChannel) IsPrivate() bool { return c.ch.Type == GuildPrivateThread }
+
+// OwnerID returns the ID of the user who created the thread.
+func (c ThreadChannel) OwnerID() UserID { return c.ch.OwnerID }
+
+// LastMessageID returns the ID of the last message sent in the thread.
+func (c ThreadChannel) LastMessageID() MessageID { return c.ch.LastMessageID }
+
+// UserRateLimit returns the slowmode of the thread.
+func (c ThreadChannel) UserRateLimit() Seconds { return c.ch.UserRateLimit }
+
+// MessageCount returns the approximate number of messages in the thread.
+func (c ThreadChannel) MessageCount() int { return c.ch.MessageCount }
+
+// MemberCount returns the approximate number of users in the thread, stopping
+// at 50.
+func (c ThreadChannel) MemberCount() int { return c.ch.MemberCount }
+
+// Metadata returns the thread-specific fields of the thread. It is the zero
+// value if Discord didn't send them.
+func (c ThreadChannel) Metadata() ThreadMetadata {
+	if c.ch.ThreadMetadata == nil {
+		return ThreadMetadata{}
+	}
+	return *c.ch.ThreadMetadata
+}
+
+// Archived returns whether the thread is archived.
+func (c ThreadChannel) Archived() bool { return c.Metadata().Archived }
+
+// Locked returns whether the thread is locked, in which case only users with
+// the MANAGE_THREADS permission can unarchive it.
+func (c ThreadChannel) Locked() bool { return c.Metadata().Locked }
+
+// Member returns the current user's thread member, or nil if the current user
+// hasn't joined the thread or Discord didn't send it.
+func (c ThreadChannel) Member() *ThreadMember { return c.ch.ThreadMember }
+
+// AppliedTags returns the IDs of the forum tags applied to the thread, if it
+// is a forum post.
+func (c ThreadChannel) AppliedTags() []TagID { return c.ch.AppliedTags }
@@ -0,0 +1,41 @@
+package discord
+
+import "testing"
+
+func TestChannelViews(t *testing.T) {
+	text := Channel{ID: 1, Type: GuildAnnouncement, Topic: "news"}
+
+	tc, ok := text.AsTextChannel()
+	if !ok || !tc.IsAnnouncement() || tc.Topic() != "news" || tc.ID() != 1 {
+		t.Errorf("unexpected text channel %+v (%v)", tc, ok)
+	}
+
+	if _, ok := text.AsVoiceChannel(); ok {
+		t.Error("announcement channel converted to voice channel")
+	}
+
+	thread := Channel{
+		Type:           GuildPrivateThread,
+		ThreadMetadata: &ThreadMetadata{Archived: true},
+	}
+
+	th, ok := thread.AsThreadChannel()
+	if !ok || !th.IsPrivate() || !th.Archived() || th.Locked() {
+		t.Errorf("unexpected thread channel %+v (%v)", th, ok)
+	}
+
+	forum := Channel{Type: GuildForum, AvailableTags: []Tag{{ID: 5, Name: "help"}}}
+
+	fc, ok := forum.AsForumChannel()
+	if !ok {
+		t.Fatal("forum channel not converted")
+	}
+
+	if tag, ok := fc.Tag(5); !ok || tag.Name != "help" {
+		t.Errorf("unexpected tag %+v (%v)", tag, ok)
+	}
+
+	if _, ok := (Channel{Type: DirectMessage}).AsThreadChannel(); ok {
+		t.Error("DM converted to thread channel")
+	}
+}
@@ -16,6 +16,55 @@ const (
 	ChatInputCommand CommandType = iota + 1
 	UserCommand
 	MessageCommand
+	// EntryPointCommand is the command that launches an application's
+	// Activity. An application can only have one, which Discord creates when
+	// Activities are enabled.
+	EntryPointCommand
+)
+
+// EntryPointCommandHandlerType determines how an EntryPointCommand is handled.
+//
+// https://discord.com/developers/docs/interactions/application-commands#application-command-object-entry-point-command-handler-types
+type EntryPointCommandHandlerType uint
+
+const (
+	// AppHandler means that the application handles the interaction using an
+	// interaction token.
+	AppHandler EntryPointCommandHandlerType = iota + 1
+	// DiscordLaunchActivity means that Discord handles the interaction by
+	// launching the application's Activity and sending a follow-up message
+	// without coordinating with the application.
+	DiscordLaunchActivity
+)
+
+// ApplicationIntegrationType is the type of installation that an application
+// can be installed as.
+//
+// https://discord.com/developers/docs/resources/application#application-object-application-integration-types
+type ApplicationIntegrationType uint
+
+const (
+	// GuildInstall means that the application is installed to a guild.
+	GuildInstall ApplicationIntegrationType = iota
+	// UserInstall means that the application is installed to a user.
+	UserInstall
+)
+
+// InteractionContextType is the type of context an interaction can be used
+// in.
+//
+// https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-interaction-context-types
+type InteractionContextType uint
+
+const (
+	// GuildContext means that the interaction can be used within guilds.
+	GuildContext InteractionContextType = iota
+	// BotDMContext means that the interaction can be used within the DM
+	// channel with the application's bot user.
+	BotDMContext
+	// PrivateChannelContext means that the interaction can be used within
+	// group DMs and DMs other than the application's bot user.
+	PrivateChannelContext
 )
 
 // Command is the base "command" model that belongs to an application. This is
@@ -59,6 +108,17 @@ type Command struct {
 	// NoDefaultPermissions defines whether the command is NOT enabled by
 	// default when the app is added to a guild.
 	NoDefaultPermission bool `json:"-"`
+	// IntegrationTypes are the installation contexts where the command is
+	// available, only for globally-scoped commands. It defaults to the
+	// application's configured contexts.
+	IntegrationTypes []ApplicationIntegrationType `json:"integration_types,omitempty"`
+	// Contexts are the interaction contexts where the command can be used,
+	// only for globally-scoped commands. By default, all interaction context
+	// types are included for new commands.
+	Contexts []InteractionContextType `json:"contexts,omitempty"`
+	// Handler determines whether the interaction is handled by the application
+	// or by Discord. It is only present on EntryPointCommands.
+	Handler EntryPointCommandHandlerType `json:"handler,omitempty"`
 	// Version is an autoincrementing version identifier updated during
 	// substantial record changes
 	Version Snowflake `json:"version,omitempty"`
@@ -334,6 +394,33 @@ type CommandOption interface {
 	Type() CommandOptionType
 }
 
+// OptionRequired returns true if the option is a value option that is
+// required. Subcommands and subcommand groups are never required.
+func OptionRequired(option CommandOption) bool {
+	switch option := option.(type) {
+	case *StringOption:
+		return option.Required
+	case *IntegerOption:
+		return option.Required
+	case *NumberOption:
+		return option.Required
+	case *BooleanOption:
+		return option.Required
+	case *UserOption:
+		return option.Required
+	case *ChannelOption:
+		return option.Required
+	case *RoleOption:
+		return option.Required
+	case *MentionableOption:
+		return option.Required
+	case *AttachmentOption:
+		return option.Required
+	default:
+		return false
+	}
+}
+
 // Maintaining these structs is quite an effort. If a new field is added into
 // the generic CommandOption type, you MUST update ALL CommandOption structs.
 // This means copy-pasting, yes.
@@ -0,0 +1,227 @@
+package discord
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/internal/rfutil"
+)
+
+// channelTypeNames maps the names accepted by the "channel_types" struct tag to
+// their channel types.
+var channelTypeNames = map[string]ChannelType{
+	"text":                GuildText,
+	"dm":                  DirectMessage,
+	"voice":               GuildVoice,
+	"group_dm":            GroupDM,
+	"category":            GuildCategory,
+	"announcement":        GuildAnnouncement,
+	"announcement_thread": GuildAnnouncementThread,
+	"public_thread":       GuildPublicThread,
+	"private_thread":      GuildPrivateThread,
+	"stage":               GuildStageVoice,
+	"forum":               GuildForum,
+}
+
+// NewCommandOptions generates the options of a command from the struct pointer
+// v, so that the same struct can be used to create the command and, using
+// CommandInteractionOptions.Unmarshal, to read the options when the command is
+// invoked. Fields are handled like in Unmarshal: the "discord" struct tag sets
+// the name of the option, and a "?" suffix or a pointer type makes the option
+// optional. Fields without a "discord" tag use their lowercased name.
+//
+// The following struct tags are also read:
+//
+//   - description: the description of the option, which defaults to its name.
+//   - choices: a comma-separated list of choices for string, integer and
+//     number options. Each choice is either a value or a "name=value" pair.
+//   - channel_types: a comma-separated list of the channel types allowed for a
+//     ChannelID option, such as "text,announcement". The names are text, dm,
+//     voice, group_dm, category, announcement, announcement_thread,
+//     public_thread, private_thread, stage and forum.
+//
+// Integer fields generate integer options, and float fields generate number
+// options. Struct fields are not supported. Since Discord requires required
+// options to come first, they are moved before the optional options.
+//
+// For example, the following struct generates a required "user" option and an
+// optional "reason" option:
+//
+//	type BanOptions struct {
+//		User   discord.UserID `discord:"user" description:"The user to ban."`
+//		Reason string         `discord:"reason?" description:"Why the user is banned."`
+//	}
+func NewCommandOptions(v interface{}) (CommandOptions, error) {
+	_, rt, err := rfutil.StructValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var options CommandOptions
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Tag.Get("discord")
+		switch name {
+		case "-":
+			continue
+		case "", "?":
+			name = strings.ToLower(field.Name) + name
+		}
+
+		required := !strings.HasSuffix(name, "?")
+		name = strings.TrimSuffix(name, "?")
+
+		t := field.Type
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+			required = false
+		}
+
+		option, err := newCommandOption(name, required, t, field.Tag)
+		if err != nil {
+			return nil, fmt.Errorf("option %q: %w", name, err)
+		}
+
+		options = append(options, option)
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		return OptionRequired(options[i]) && !OptionRequired(options[j])
+	})
+
+	return options, nil
+}
+
+func newCommandOption(
+	name string, required bool, t reflect.Type, tag reflect.StructTag) (CommandOptionValue, error) {
+
+	description := tag.Get("description")
+	if description == "" {
+		description = name
+	}
+
+	choices := parseChoices(tag.Get("choices"))
+
+	if _, ok := tag.Lookup("channel_types"); ok && t != reflect.TypeOf(ChannelID(0)) {
+		return nil, fmt.Errorf("channel_types is only supported for ChannelID, not %s", t)
+	}
+
+	if len(choices) > 0 {
+		switch t.Kind() {
+		case reflect.String, reflect.Float32, reflect.Float64,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			return nil, fmt.Errorf("choices are not supported for %s", t)
+		}
+	}
+
+	if optionType, ok := optionSupportedSnowflakeTypes[t]; ok {
+		switch optionType {
+		case ChannelOptionType:
+			channelTypes, err := parseChannelTypes(tag.Get("channel_types"))
+			if err != nil {
+				return nil, err
+			}
+			return &ChannelOption{
+				OptionName:   name,
+				Description:  description,
+				Required:     required,
+				ChannelTypes: channelTypes,
+			}, nil
+		case UserOptionType:
+			return &UserOption{OptionName: name, Description: description, Required: required}, nil
+		case RoleOptionType:
+			return &RoleOption{OptionName: name, Description: description, Required: required}, nil
+		default:
+			return &MentionableOption{OptionName: name, Description: description, Required: required}, nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &BooleanOption{OptionName: name, Description: description, Required: required}, nil
+
+	case reflect.String:
+		option := &StringOption{OptionName: name, Description: description, Required: required}
+		for _, choice := range choices {
+			option.Choices = append(option.Choices, StringChoice{Name: choice[0], Value: choice[1]})
+		}
+		return option, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+
+		option := &IntegerOption{OptionName: name, Description: description, Required: required}
+		for _, choice := range choices {
+			i, err := strconv.Atoi(choice[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer choice %q: %w", choice[1], err)
+			}
+			option.Choices = append(option.Choices, IntegerChoice{Name: choice[0], Value: i})
+		}
+		return option, nil
+
+	case reflect.Float32, reflect.Float64:
+		option := &NumberOption{OptionName: name, Description: description, Required: required}
+		for _, choice := range choices {
+			f, err := strconv.ParseFloat(choice[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number choice %q: %w", choice[1], err)
+			}
+			option.Choices = append(option.Choices, NumberChoice{Name: choice[0], Value: f})
+		}
+		return option, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// parseChoices parses the "choices" struct tag into name and value pairs.
+func parseChoices(tag string) [][2]string {
+	if tag == "" {
+		return nil
+	}
+
+	parts := strings.Split(tag, ",")
+	choices := make([][2]string, len(parts))
+
+	for i, part := range parts {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			value = name
+		}
+		choices[i] = [2]string{strings.TrimSpace(name), strings.TrimSpace(value)}
+	}
+
+	return choices
+}
+
+// parseChannelTypes parses the "channel_types" struct tag.
+func parseChannelTypes(tag string) ([]ChannelType, error) {
+	if tag == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(tag, ",")
+	types := make([]ChannelType, len(parts))
+
+	for i, part := range parts {
+		t, ok := channelTypeNames[strings.TrimSpace(part)]
+		if !ok {
+			return nil, fmt.Errorf("unknown channel type %q", part)
+		}
+		types[i] = t
+	}
+
+	return types, nil
+}
@@ -0,0 +1,115 @@
+package discord
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestNewCommandOptions(t *testing.T) {
+	type options struct {
+		Reason  *string   `discord:"reason" description:"The reason."`
+		Channel ChannelID `discord:"channel" description:"The channel." channel_types:"text,announcement"`
+		Color   string    `discord:"color" choices:"Red=red,blue"`
+		Days    int       `discord:"days?" choices:"1,7"`
+		Silent  bool
+		Ignored int `discord:"-"`
+	}
+
+	got, err := NewCommandOptions(&options{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expected := CommandOptions{
+		&ChannelOption{
+			OptionName:   "channel",
+			Description:  "The channel.",
+			Required:     true,
+			ChannelTypes: []ChannelType{GuildText, GuildAnnouncement},
+		},
+		&StringOption{
+			OptionName:  "color",
+			Description: "color",
+			Required:    true,
+			Choices:     []StringChoice{{Name: "Red", Value: "red"}, {Name: "blue", Value: "blue"}},
+		},
+		&BooleanOption{OptionName: "silent", Description: "silent", Required: true},
+		&StringOption{OptionName: "reason", Description: "The reason."},
+		&IntegerOption{
+			OptionName:  "days",
+			Description: "days",
+			Choices:     []IntegerChoice{{Name: "1", Value: 1}, {Name: "7", Value: 7}},
+		},
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("unexpected options:\n%#v\nexpected:\n%#v", got, expected)
+	}
+}
+
+func TestNewCommandOptionsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"struct field", &struct{ Sub struct{} }{}},
+		{"channel types on a string", &struct {
+			S string `channel_types:"text"`
+		}{}},
+		{"unknown channel type", &struct {
+			C ChannelID `channel_types:"closet"`
+		}{}},
+		{"invalid integer choice", &struct {
+			I int `choices:"one"`
+		}{}},
+		{"not a pointer", struct{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewCommandOptions(test.v); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestNewCommandOptionsUnmarshal(t *testing.T) {
+	type options struct {
+		Days  int     `discord:"days"`
+		Ratio float64 `discord:"ratio"`
+	}
+
+	generated, err := NewCommandOptions(&options{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// Discord sends the options with the types that were generated.
+	var received CommandInteractionOptions
+	for _, opt := range generated {
+		received = append(received, CommandInteractionOption{
+			Type:  opt.Type(),
+			Name:  opt.Name(),
+			Value: json.Raw("3"),
+		})
+	}
+
+	var got options
+	if err := received.Unmarshal(&got); err != nil {
+		t.Fatal("failed to unmarshal generated options:", err)
+	}
+
+	if got != (options{Days: 3, Ratio: 3}) {
+		t.Fatalf("unexpected options %+v", got)
+	}
+
+	// Integer options aren't numbers.
+	received[0], received[1] = received[1], received[0]
+	received[0].Name, received[1].Name = "days", "ratio"
+	if err := received.Unmarshal(&got); err == nil {
+		t.Fatal("expected error reading an integer option into a float")
+	}
+}
@@ -0,0 +1,280 @@
+// Package discordtest provides builders for Discord objects with sensible
+// defaults, for use in tests.
+//
+// A Builder assigns deterministic IDs and timestamps to the objects that it
+// builds, so two Builders that build the same objects in the same order give
+// the same results. The IDs come from a Generator, whose clock can be moved to
+// control the time of the built objects. Fields other than the defaults are
+// set using options:
+//
+//	b := discordtest.NewBuilder()
+//	guild := b.Guild(discordtest.GuildName("arikawa"))
+//	channel := b.Channel(discordtest.InGuild(guild.ID))
+//	msg := b.Message(discordtest.InChannel(channel), discordtest.Content("hi"))
+//
+// Any function taking a pointer to the object can be used as an option, so
+// fields without an option can be set inline.
+package discordtest
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+// DefaultTime is the time that the IDs of a new Builder start from.
+var DefaultTime = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Builder builds Discord objects with deterministic IDs and timestamps. A
+// Builder is not thread-safe.
+type Builder struct {
+	gen *Generator
+}
+
+// NewBuilder creates a new Builder whose IDs start from DefaultTime.
+func NewBuilder() *Builder {
+	return NewBuilderWithGenerator(NewGenerator(0))
+}
+
+// NewBuilderWithGenerator creates a new Builder that takes its IDs and
+// timestamps from the given Generator. The time of the built objects can then
+// be controlled using the Generator.
+func NewBuilderWithGenerator(g *Generator) *Builder {
+	return &Builder{gen: g}
+}
+
+// ID returns a new ID. IDs are monotonically increasing.
+func (b *Builder) ID() discord.Snowflake {
+	if b.gen == nil {
+		b.gen = NewGenerator(0)
+	}
+	return b.gen.Next()
+}
+
+// Option is an option for building an object of type T.
+type Option[T any] func(*T)
+
+func apply[T any](v *T, opts []Option[T]) {
+	for _, opt := range opts {
+		opt(v)
+	}
+}
+
+// User builds a new user named after its ID.
+func (b *Builder) User(opts ...Option[discord.User]) discord.User {
+	id := discord.UserID(b.ID())
+
+	u := discord.User{
+		ID:            id,
+		Username:      "user" + strconv.FormatUint(uint64(id)%10000, 10),
+		Discriminator: "0",
+	}
+
+	apply(&u, opts)
+	return u
+}
+
+// Username sets the user's username.
+func Username(name string) Option[discord.User] {
+	return func(u *discord.User) { u.Username = name }
+}
+
+// Bot marks the user as a bot.
+func Bot() Option[discord.User] {
+	return func(u *discord.User) { u.Bot = true }
+}
+
+// Guild builds a new guild with a new owner and an @everyone role.
+func (b *Builder) Guild(opts ...Option[discord.Guild]) discord.Guild {
+	id := discord.GuildID(b.ID())
+
+	g := discord.Guild{
+		ID:      id,
+		Name:    "guild" + strconv.FormatUint(uint64(id)%10000, 10),
+		OwnerID: discord.UserID(b.ID()),
+		Roles: []discord.Role{{
+			ID:          discord.RoleID(id),
+			Name:        "@everyone",
+			Permissions: discord.PermissionViewChannel | discord.PermissionSendMessages,
+		}},
+		Verification:    discord.NoVerification,
+		Notification:    discord.OnlyMentions,
+		PreferredLocale: "en-US",
+	}
+
+	apply(&g, opts)
+	return g
+}
+
+// GuildName sets the guild's name.
+func GuildName(name string) Option[discord.Guild] {
+	return func(g *discord.Guild) { g.Name = name }
+}
+
+// GuildOwner sets the guild's owner.
+func GuildOwner(id discord.UserID) Option[discord.Guild] {
+	return func(g *discord.Guild) { g.OwnerID = id }
+}
+
+// GuildRoles adds the roles to the guild.
+func GuildRoles(roles ...discord.Role) Option[discord.Guild] {
+	return func(g *discord.Guild) { g.Roles = append(g.Roles, roles...) }
+}
+
+// Channel builds a new text channel. Without InGuild, the channel is a direct
+// message channel with a new user.
+func (b *Builder) Channel(opts ...Option[discord.Channel]) discord.Channel {
+	id := discord.ChannelID(b.ID())
+
+	ch := discord.Channel{
+		ID:           id,
+		Type:         discord.DirectMessage,
+		DMRecipients: []discord.User{b.User()},
+	}
+
+	apply(&ch, opts)
+	return ch
+}
+
+// ChannelName sets the channel's name.
+func ChannelName(name string) Option[discord.Channel] {
+	return func(ch *discord.Channel) { ch.Name = name }
+}
+
+// ChannelType sets the channel's type.
+func ChannelType(t discord.ChannelType) Option[discord.Channel] {
+	return func(ch *discord.Channel) { ch.Type = t }
+}
+
+// InGuild makes the channel a text channel in the given guild, named after its
+// ID.
+func InGuild(id discord.GuildID) Option[discord.Channel] {
+	return func(ch *discord.Channel) {
+		ch.GuildID = id
+		ch.DMRecipients = nil
+		if ch.Type == discord.DirectMessage {
+			ch.Type = discord.GuildText
+		}
+		if ch.Name == "" {
+			ch.Name = "channel" + strconv.FormatUint(uint64(ch.ID)%10000, 10)
+		}
+	}
+}
+
+// ChannelParent sets the channel's parent category or thread parent.
+func ChannelParent(id discord.ChannelID) Option[discord.Channel] {
+	return func(ch *discord.Channel) { ch.ParentID = id }
+}
+
+// Member builds a new guild member with a new user that joined at the time of
+// its ID.
+func (b *Builder) Member(opts ...Option[discord.Member]) discord.Member {
+	u := b.User()
+
+	m := discord.Member{
+		User:    u,
+		RoleIDs: []discord.RoleID{},
+		Joined:  discord.NewTimestamp(discord.Snowflake(u.ID).Time()),
+	}
+
+	apply(&m, opts)
+	return m
+}
+
+// MemberUser sets the member's user.
+func MemberUser(u discord.User) Option[discord.Member] {
+	return func(m *discord.Member) { m.User = u }
+}
+
+// MemberRoles adds the roles to the member.
+func MemberRoles(ids ...discord.RoleID) Option[discord.Member] {
+	return func(m *discord.Member) { m.RoleIDs = append(m.RoleIDs, ids...) }
+}
+
+// Nick sets the member's nickname.
+func Nick(nick string) Option[discord.Member] {
+	return func(m *discord.Member) { m.Nick = nick }
+}
+
+// Message builds a new message from a new author that was sent at the time
+// of its ID.
+func (b *Builder) Message(opts ...Option[discord.Message]) discord.Message {
+	author := b.User()
+	id := discord.MessageID(b.ID())
+
+	msg := discord.Message{
+		ID:        id,
+		ChannelID: discord.ChannelID(b.ID()),
+		Type:      discord.DefaultMessage,
+		Author:    author,
+		Content:   "message " + id.String(),
+		Timestamp: discord.NewTimestamp(id.Time()),
+	}
+
+	apply(&msg, opts)
+	return msg
+}
+
+// Content sets the message's content.
+func Content(content string) Option[discord.Message] {
+	return func(m *discord.Message) { m.Content = content }
+}
+
+// Author sets the message's author.
+func Author(u discord.User) Option[discord.Message] {
+	return func(m *discord.Message) { m.Author = u }
+}
+
+// InChannel sets the message's channel and guild.
+func InChannel(ch discord.Channel) Option[discord.Message] {
+	return func(m *discord.Message) {
+		m.ChannelID = ch.ID
+		m.GuildID = ch.GuildID
+	}
+}
+
+// Interaction builds a new ping interaction from a new user in a direct
+// message channel.
+func (b *Builder) Interaction(opts ...Option[discord.InteractionEvent]) discord.InteractionEvent {
+	u := b.User()
+	id := discord.InteractionID(b.ID())
+
+	ev := discord.InteractionEvent{
+		ID:        id,
+		Data:      &discord.PingInteraction{},
+		AppID:     discord.AppID(b.ID()),
+		ChannelID: discord.ChannelID(b.ID()),
+		Token:     "token" + id.String(),
+		Version:   1,
+		User:      &u,
+		Locale:    discord.EnglishUS,
+	}
+
+	apply(&ev, opts)
+	return ev
+}
+
+// InteractionData sets the interaction's data, such as a
+// *discord.CommandInteraction.
+func InteractionData(data discord.InteractionData) Option[discord.InteractionEvent] {
+	return func(ev *discord.InteractionEvent) { ev.Data = data }
+}
+
+// InteractionChannel sets the interaction's channel and guild.
+func InteractionChannel(ch discord.Channel) Option[discord.InteractionEvent] {
+	return func(ev *discord.InteractionEvent) {
+		ev.ChannelID = ch.ID
+		ev.GuildID = ch.GuildID
+		ev.Channel = &ch
+	}
+}
+
+// InteractionMember makes the interaction come from the given guild member.
+// The guild is set using InteractionChannel.
+func InteractionMember(m discord.Member) Option[discord.InteractionEvent] {
+	return func(ev *discord.InteractionEvent) {
+		ev.Member = &m
+		ev.User = nil
+	}
+}
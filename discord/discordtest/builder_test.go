@@ -0,0 +1,77 @@
+package discordtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
+)
+
+func TestBuilder(t *testing.T) {
+	build := func() (discord.Guild, discord.Channel, discord.Message) {
+		b := NewBuilder()
+		guild := b.Guild(GuildName("arikawa"))
+		channel := b.Channel(InGuild(guild.ID))
+		msg := b.Message(InChannel(channel), Content("hime arikawa"), func(m *discord.Message) {
+			m.Pinned = true
+		})
+		return guild, channel, msg
+	}
+
+	guild, channel, msg := build()
+
+	if guild.Name != "arikawa" || len(guild.Roles) != 1 || guild.Roles[0].ID != discord.RoleID(guild.ID) {
+		t.Fatalf("unexpected guild: %+v", guild)
+	}
+
+	if channel.GuildID != guild.ID || channel.Type != discord.GuildText || channel.Name == "" {
+		t.Fatalf("unexpected channel: %+v", channel)
+	}
+
+	if msg.ChannelID != channel.ID || msg.GuildID != guild.ID ||
+		msg.Content != "hime arikawa" || !msg.Pinned || !msg.Author.ID.IsValid() {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+
+	if !msg.Timestamp.Time().Equal(msg.ID.Time()) {
+		t.Fatal("message timestamp doesn't match its ID:", msg.Timestamp)
+	}
+
+	guild2, channel2, msg2 := build()
+	if !reflect.DeepEqual(guild, guild2) ||
+		!reflect.DeepEqual(channel, channel2) ||
+		!reflect.DeepEqual(msg, msg2) {
+		t.Fatal("builders aren't deterministic")
+	}
+}
+
+func TestBuilderInteraction(t *testing.T) {
+	b := NewBuilder()
+
+	member := b.Member(Nick("hime"))
+	channel := b.Channel(InGuild(b.Guild().ID))
+
+	ev := b.Interaction(InteractionChannel(channel), InteractionMember(member))
+
+	if ev.GuildID != channel.GuildID || ev.SenderID() != member.User.ID || ev.User != nil {
+		t.Fatalf("unexpected interaction: %+v", ev)
+	}
+}
+
+func TestBuilderDirectMessage(t *testing.T) {
+	b := NewBuilder()
+
+	dm := b.Channel()
+	if dm.Type != discord.DirectMessage || len(dm.DMRecipients) != 1 {
+		t.Fatalf("unexpected direct message channel: %+v", dm)
+	}
+
+	if err := defaultstore.NewChannel().ChannelSet(&dm, false); err != nil {
+		t.Fatal("store rejected the direct message channel:", err)
+	}
+
+	if ch := b.Channel(InGuild(b.Guild().ID)); len(ch.DMRecipients) > 0 {
+		t.Fatalf("guild channel has recipients: %+v", ch)
+	}
+}
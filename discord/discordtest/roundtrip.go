@@ -0,0 +1,233 @@
+package discordtest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// DiffKind is the kind of a Diff.
+type DiffKind uint8
+
+const (
+	// DiffDropped means that a field in the sample is missing from the
+	// marshaled output, usually because the type has no field for it.
+	DiffDropped DiffKind = iota
+	// DiffMistyped means that a field has a different JSON type in the
+	// marshaled output, such as a number that became a string.
+	DiffMistyped
+	// DiffChanged means that a field has the same JSON type but a different
+	// value in the marshaled output.
+	DiffChanged
+	// DiffAdded means that the marshaled output has a non-zero field that the
+	// sample doesn't have.
+	DiffAdded
+)
+
+// String formats DiffKind to a human-readable string.
+func (k DiffKind) String() string {
+	switch k {
+	case DiffDropped:
+		return "dropped"
+	case DiffMistyped:
+		return "mistyped"
+	case DiffChanged:
+		return "changed"
+	case DiffAdded:
+		return "added"
+	default:
+		return fmt.Sprintf("DiffKind(%d)", uint8(k))
+	}
+}
+
+// Diff is a difference between a JSON sample and the JSON that its decoded
+// value marshals back to.
+type Diff struct {
+	// Path is the path to the field, such as "$.embeds[0].title".
+	Path string
+	Kind DiffKind
+	// Sample and Output are the values of the field in the sample and in the
+	// marshaled output. They are nil if the field is missing.
+	Sample interface{}
+	Output interface{}
+}
+
+// String formats the Diff into a human-readable string.
+func (d Diff) String() string {
+	switch d.Kind {
+	case DiffDropped:
+		return fmt.Sprintf("%s: dropped %s", d.Path, formatJSON(d.Sample))
+	case DiffAdded:
+		return fmt.Sprintf("%s: added %s", d.Path, formatJSON(d.Output))
+	default:
+		return fmt.Sprintf("%s: %s from %s to %s",
+			d.Path, d.Kind, formatJSON(d.Sample), formatJSON(d.Output))
+	}
+}
+
+func formatJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// RoundTrip unmarshals the JSON sample into v, which must be a pointer, then
+// marshals v back and returns how the output differs from the sample.
+//
+// The comparison is semantic: key order and formatting don't matter, and a
+// null field is the same as a missing one. Fields that are only in either the
+// sample or the output are reported only if they're not zero, since types
+// commonly omit or add optional fields with zero values.
+func RoundTrip(sample []byte, v interface{}) ([]Diff, error) {
+	if err := json.Unmarshal(sample, v); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sample into %T: %w", v, err)
+	}
+
+	output, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T: %w", v, err)
+	}
+
+	var want, got interface{}
+
+	if err := json.Unmarshal(sample, &want); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sample: %w", err)
+	}
+
+	if err := json.Unmarshal(output, &got); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal output: %w", err)
+	}
+
+	var diffs []Diff
+	compareJSON(&diffs, "$", want, got)
+	return diffs, nil
+}
+
+// AssertRoundTrip calls RoundTrip with a new value of type T and fails the
+// test for each difference.
+func AssertRoundTrip[T any](t testing.TB, sample []byte) {
+	t.Helper()
+
+	diffs, err := RoundTrip(sample, new(T))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, diff := range diffs {
+		t.Errorf("%T does not round-trip: %s", *new(T), diff)
+	}
+}
+
+func compareJSON(diffs *[]Diff, path string, want, got interface{}) {
+	if jsonType(want) != jsonType(got) {
+		switch {
+		case want == nil:
+			if !isZeroJSON(got) {
+				*diffs = append(*diffs, Diff{Path: path, Kind: DiffAdded, Output: got})
+			}
+		case got == nil:
+			if isZeroJSON(want) {
+				// Probably omitted using omitempty.
+				return
+			}
+			*diffs = append(*diffs, Diff{Path: path, Kind: DiffDropped, Sample: want})
+		default:
+			*diffs = append(*diffs, Diff{Path: path, Kind: DiffMistyped, Sample: want, Output: got})
+		}
+		return
+	}
+
+	switch want := want.(type) {
+	case map[string]interface{}:
+		got := got.(map[string]interface{})
+
+		keys := make([]string, 0, len(want)+len(got))
+		for k := range want {
+			keys = append(keys, k)
+		}
+		for k := range got {
+			if _, ok := want[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			compareJSON(diffs, path+"."+k, want[k], got[k])
+		}
+
+	case []interface{}:
+		got := got.([]interface{})
+
+		n := len(want)
+		if len(got) > n {
+			n = len(got)
+		}
+
+		for i := 0; i < n; i++ {
+			var w, g interface{}
+			if i < len(want) {
+				w = want[i]
+			}
+			if i < len(got) {
+				g = got[i]
+			}
+			compareJSON(diffs, path+"["+strconv.Itoa(i)+"]", w, g)
+		}
+
+	default:
+		if !reflect.DeepEqual(want, got) {
+			*diffs = append(*diffs, Diff{Path: path, Kind: DiffChanged, Sample: want, Output: got})
+		}
+	}
+}
+
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return strings.ToLower(fmt.Sprintf("%T", v))
+	}
+}
+
+func isZeroJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		for _, v := range v {
+			if !isZeroJSON(v) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,85 @@
+package discordtest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestRoundTrip(t *testing.T) {
+	AssertRoundTrip[discord.User](t, []byte(`{
+		"id": "80351110224678912",
+		"username": "Nelly",
+		"discriminator": "1337",
+		"avatar": "8342729096ea3675442027381ff50dfe",
+		"bot": false,
+		"public_flags": 64
+	}`))
+
+	diffs, err := RoundTrip([]byte(`{
+		"id": "80351110224678912",
+		"username": "Nelly",
+		"avatar": null,
+		"pronouns": "she/her",
+		"tags": ["a", "b"]
+	}`), &struct {
+		ID       discord.UserID `json:"id"`
+		Username string         `json:"username"`
+		Tags     []string       `json:"tags"`
+		Bot      bool           `json:"bot"`
+		Flags    int            `json:"flags"`
+	}{Flags: 1})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expect := []Diff{
+		{Path: "$.flags", Kind: DiffAdded, Output: float64(1)},
+		{Path: "$.pronouns", Kind: DiffDropped, Sample: "she/her"},
+	}
+
+	if len(diffs) != len(expect) {
+		t.Fatalf("unexpected diffs %v", diffs)
+	}
+
+	for i := range expect {
+		if diffs[i] != expect[i] {
+			t.Errorf("diff %d is %v, expected %v", i, diffs[i], expect[i])
+		}
+	}
+}
+
+// stringifiedInt unmarshals from a number but marshals into a string.
+type stringifiedInt int
+
+func (i stringifiedInt) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.Itoa(int(i)))), nil
+}
+
+func TestRoundTripMistyped(t *testing.T) {
+	diffs, err := RoundTrip([]byte(`{"count": 42, "items": [{"name": "a"}]}`), &struct {
+		Count stringifiedInt `json:"count"`
+		Items []struct {
+			Name string `json:"title"`
+		} `json:"items"`
+	}{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expect := []string{
+		`$.count: mistyped from 42 to "42"`,
+		`$.items[0].name: dropped "a"`,
+	}
+
+	if len(diffs) != len(expect) {
+		t.Fatalf("unexpected diffs %v", diffs)
+	}
+
+	for i := range expect {
+		if s := diffs[i].String(); s != expect[i] {
+			t.Errorf("diff %d is %q, expected %q", i, s, expect[i])
+		}
+	}
+}
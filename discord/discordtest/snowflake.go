@@ -0,0 +1,82 @@
+package discordtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+const (
+	incrementBits = 12
+	timestampMask = ^discord.Snowflake(1<<22 - 1)
+)
+
+// Generator generates deterministic snowflakes. Its clock doesn't move on its
+// own; it starts at DefaultTime and is only changed using SetTime and Advance,
+// so the same calls always give the same IDs. A Generator is thread-safe.
+type Generator struct {
+	mutex     sync.Mutex
+	now       time.Time
+	worker    discord.Snowflake
+	process   discord.Snowflake
+	increment discord.Snowflake
+	last      discord.Snowflake
+}
+
+// NewGenerator creates a new Generator. The seed picks the worker and process
+// IDs of the snowflakes, so generators with different seeds below 1024 never
+// generate the same ID.
+func NewGenerator(seed int64) *Generator {
+	return &Generator{
+		now:     DefaultTime,
+		worker:  discord.Snowflake(seed>>5) & 0x1F,
+		process: discord.Snowflake(seed) & 0x1F,
+	}
+}
+
+// Next returns a new snowflake with the current time of the generator.
+// Snowflakes are strictly increasing, even if the time is set backwards or
+// more than 4096 snowflakes are generated within the same millisecond; in
+// those cases, the timestamp is moved forward instead.
+func (g *Generator) Next() discord.Snowflake {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	s := g.snowflake(discord.NewSnowflake(g.now))
+	if s <= g.last {
+		s = g.snowflake(g.last&timestampMask + 1<<22)
+	}
+
+	g.increment = (g.increment + 1) & (1<<incrementBits - 1)
+	g.last = s
+	return s
+}
+
+func (g *Generator) snowflake(timestamp discord.Snowflake) discord.Snowflake {
+	return timestamp | g.worker<<17 | g.process<<incrementBits | g.increment
+}
+
+// Time returns the current time of the generator.
+func (g *Generator) Time() time.Time {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.now
+}
+
+// SetTime sets the current time of the generator.
+func (g *Generator) SetTime(t time.Time) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.now = t
+}
+
+// Advance moves the current time of the generator forward by d.
+func (g *Generator) Advance(d time.Duration) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.now = g.now.Add(d)
+}
@@ -0,0 +1,63 @@
+package discordtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestGenerator(t *testing.T) {
+	g := NewGenerator(42)
+
+	first := g.Next()
+	if !first.Time().Equal(DefaultTime) {
+		t.Fatalf("first snowflake has time %v, expected %v", first.Time(), DefaultTime)
+	}
+	if first.Worker() != 1 || first.PID() != 10 {
+		t.Fatalf("unexpected worker %d and process %d", first.Worker(), first.PID())
+	}
+
+	if other := NewGenerator(42).Next(); other != first {
+		t.Fatalf("generators with the same seed differ: %v != %v", other, first)
+	}
+	if other := NewGenerator(43).Next(); other == first {
+		t.Fatal("generators with different seeds give the same snowflake")
+	}
+
+	g.Advance(time.Hour)
+	if s := g.Next(); !s.Time().Equal(DefaultTime.Add(time.Hour)) {
+		t.Fatalf("snowflake has time %v after advancing", s.Time())
+	}
+
+	last := g.Next()
+	g.SetTime(DefaultTime)
+
+	for i := 0; i < 5000; i++ {
+		s := g.Next()
+		if s <= last {
+			t.Fatalf("snowflake %d (%v) is not after %v", i, s, last)
+		}
+		if s.Worker() != 1 || s.PID() != 10 {
+			t.Fatalf("snowflake %d has worker %d and process %d", i, s.Worker(), s.PID())
+		}
+		last = s
+	}
+}
+
+func TestBuilderWithGenerator(t *testing.T) {
+	g := NewGenerator(0)
+	b := NewBuilderWithGenerator(g)
+
+	g.SetTime(DefaultTime.Add(24 * time.Hour))
+
+	msg := b.Message()
+	if !msg.Timestamp.Time().Equal(g.Time()) {
+		t.Fatalf("message has timestamp %v, expected %v", msg.Timestamp.Time(), g.Time())
+	}
+
+	var zero Builder
+	if id := zero.ID(); id != discord.NewSnowflake(DefaultTime) {
+		t.Fatalf("zero Builder gave ID %v", id)
+	}
+}
@@ -20,7 +20,9 @@ type Emoji struct {
 	RoleIDs []RoleID `json:"roles,omitempty"`
 	// User is the user that created the emoji.
 	//
-	// This field is only available for custom emojis.
+	// This field is only available for custom emojis fetched using the API,
+	// and only if the current user has the MANAGE_EMOJIS_AND_STICKERS
+	// permission.
 	User User `json:"user,omitempty"`
 
 	// RequireColons specifies whether the emoji must be wrapped in colons.
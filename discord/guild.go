@@ -1,6 +1,10 @@
 package discord
 
-import "time"
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
 
 // https://discord.com/developers/docs/resources/guild#guild-object
 type Guild struct {
@@ -62,7 +66,7 @@ type Guild struct {
 	// Emojis are the custom guild emojis.
 	Emojis []Emoji `json:"emojis"`
 	// Features are the enabled guild features.
-	Features []GuildFeature `json:"features"`
+	Features GuildFeatures `json:"features"`
 
 	// AppID is the application id of the guild creator if it is bot-created.
 	//
@@ -120,6 +124,11 @@ func (g Guild) CreatedAt() time.Time {
 	return g.ID.Time()
 }
 
+// HasFeature returns true if the guild has the given feature enabled.
+func (g Guild) HasFeature(feature GuildFeature) bool {
+	return g.Features.Has(feature)
+}
+
 // IconURL returns the URL to the guild icon and auto detects a suitable type.
 // An empty string is returned if there's no icon.
 func (g Guild) IconURL() string {
@@ -222,7 +231,7 @@ type GuildPreview struct {
 	// Emojis are the custom guild emojis.
 	Emojis []Emoji `json:"emojis"`
 	// Features are the enabled guild features.
-	Features []GuildFeature `json:"guild_features"`
+	Features GuildFeatures `json:"guild_features"`
 
 	// ApproximateMembers is the approximate number of members in this guild.
 	ApproximateMembers uint64 `json:"approximate_member_count"`
@@ -376,6 +385,10 @@ type Member struct {
 	RoleIDs []RoleID `json:"roles"`
 	// Avatar is this member's guild avatar.
 	Avatar Hash `json:"avatar,omitempty"`
+	// Banner is this member's guild banner.
+	Banner Hash `json:"banner,omitempty"`
+	// AvatarDecoration is the data for the member's guild avatar decoration.
+	AvatarDecoration *AvatarDecoration `json:"avatar_decoration_data,omitempty"`
 
 	// Joined specifies when the user joined the guild.
 	Joined Timestamp `json:"joined_at"`
@@ -394,6 +407,10 @@ type Member struct {
 
 	// IsPending specifies whether the user has not yet passed the guild's Membership Screening requirements
 	IsPending bool `json:"pending"`
+
+	// Permissions are the total permissions of the member in the channel,
+	// including overwrites. It is only present in interactions.
+	Permissions Permissions `json:"permissions,string,omitempty"`
 }
 
 // Mention returns the mention of the role.
@@ -419,6 +436,53 @@ func (m Member) AvatarURLWithType(t ImageType, guildID GuildID) string {
 	return "https://cdn.discordapp.com/guilds/" + guildID.String() + "/users/" + m.User.ID.String() + "/avatars/" + t.format(m.Avatar)
 }
 
+// BannerURL returns the URL of the Banner Image. It automatically detects a
+// suitable type.
+func (m Member) BannerURL(guildID GuildID) string {
+	return m.BannerURLWithType(AutoImage, guildID)
+}
+
+// BannerURLWithType returns the URL of the Banner Image using the passed type.
+// If the member has no Banner, an empty string will be returned.
+//
+// Supported Image Types: PNG, JPEG, WebP, GIF
+func (m Member) BannerURLWithType(t ImageType, guildID GuildID) string {
+	if m.Banner == "" {
+		return ""
+	}
+
+	return "https://cdn.discordapp.com/guilds/" + guildID.String() + "/users/" + m.User.ID.String() + "/banners/" + t.format(m.Banner)
+}
+
+// AvatarDecorationURL returns the URL of the member's guild avatar decoration,
+// falling back to the user's avatar decoration. If neither is set, an empty
+// string will be returned.
+func (m Member) AvatarDecorationURL() string {
+	if m.AvatarDecoration != nil {
+		return m.AvatarDecoration.URL()
+	}
+	return m.User.AvatarDecorationURL()
+}
+
+// IsTimedOut returns true if the member is timed out at the given time.
+func (m Member) IsTimedOut(now time.Time) bool {
+	return m.TimeoutRemaining(now) > 0
+}
+
+// TimeoutRemaining returns how long the member remains timed out at the given
+// time, or 0 if the member isn't timed out.
+func (m Member) TimeoutRemaining(now time.Time) time.Duration {
+	if !m.CommunicationDisabledUntil.IsValid() {
+		return 0
+	}
+
+	if d := m.CommunicationDisabledUntil.Time().Sub(now); d > 0 {
+		return d
+	}
+
+	return 0
+}
+
 type MemberFlags uint8
 
 const (
@@ -485,6 +549,8 @@ type Integration struct {
 	Revoked bool `json:"revoked,omitempty"`
 	// Application is the bot/OAuth2 application for integrations.
 	Application *IntegrationApplication `json:"application,omitempty"`
+	// Scopes are the OAuth2 scopes the application has been authorized for.
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // CreatedAt returns a time object representing when the integration was created.
@@ -529,13 +595,79 @@ type GuildWidget struct {
 	// Name is the name of the guild.
 	Name string `json:"name"`
 	// InviteURl is the url of an instant invite to the guild.
-	InviteURL string    `json:"instant_invite"`
-	Channels  []Channel `json:"channels"`
-	Members   []User    `json:"members"`
+	InviteURL string `json:"instant_invite"`
+	// Channels are the voice and stage channels that are visible to
+	// everyone. Only the ID, Name and Position fields are filled.
+	Channels []Channel `json:"channels"`
+	// Members are the online members, limited to 100. Their IDs and
+	// discriminators are anonymized.
+	Members []User `json:"members"`
+	// WidgetMembers are the same members as Members, but with their status,
+	// avatar URL and activity.
+	WidgetMembers []GuildWidgetMember `json:"-"`
 	// Presence count is the amount of presences in the guild
 	PresenceCount int `json:"presence_count"`
 }
 
+func (w *GuildWidget) UnmarshalJSON(data []byte) error {
+	type rawGuildWidget GuildWidget
+
+	widget := struct {
+		*rawGuildWidget
+		Members []GuildWidgetMember `json:"members"`
+	}{
+		rawGuildWidget: (*rawGuildWidget)(w),
+	}
+
+	if err := json.Unmarshal(data, &widget); err != nil {
+		return err
+	}
+
+	w.WidgetMembers = widget.Members
+	w.Members = nil
+	if widget.Members != nil {
+		w.Members = make([]User, len(widget.Members))
+		for i, m := range widget.Members {
+			w.Members[i] = m.User
+		}
+	}
+
+	return nil
+}
+
+func (w GuildWidget) MarshalJSON() ([]byte, error) {
+	type rawGuildWidget GuildWidget
+
+	// WidgetMembers is preferred, since it has more information.
+	members := w.WidgetMembers
+	if members == nil && w.Members != nil {
+		members = make([]GuildWidgetMember, len(w.Members))
+		for i, u := range w.Members {
+			members[i] = GuildWidgetMember{User: u}
+		}
+	}
+
+	return json.Marshal(struct {
+		rawGuildWidget
+		Members []GuildWidgetMember `json:"members"`
+	}{
+		rawGuildWidget: rawGuildWidget(w),
+		Members:        members,
+	})
+}
+
+// GuildWidgetMember is a member as shown in a guild widget.
+type GuildWidgetMember struct {
+	User
+	// Status is the member's status.
+	Status Status `json:"status"`
+	// AvatarURL is the url of the member's avatar, proxied by the widget.
+	AvatarURL URL `json:"avatar_url,omitempty"`
+	// Activity is the member's current activity, if any. Only the Name field
+	// is filled.
+	Activity *Activity `json:"activity,omitempty"`
+}
+
 // https://discord.com/developers/docs/resources/guild#guild-widget-object
 type GuildWidgetSettings struct {
 	// Enabled specifies whether the widget is enabled.
@@ -559,7 +691,7 @@ func MemberColor(guild Guild, member Member) (Color, bool) {
 				continue
 			}
 
-			if r.Color > 0 && r.Position > pos {
+			if r.Color.IsSet() && r.Position > pos {
 				c = r.Color
 				pos = r.Position
 			}
@@ -586,6 +718,27 @@ type Presence struct {
 	ClientStatus ClientStatus `json:"client_status"`
 }
 
+// Streaming returns the user's streaming activity, or nil if the user isn't
+// streaming.
+func (p *Presence) Streaming() *Activity {
+	return p.findActivity(Activity.IsStreaming)
+}
+
+// Spotify returns the user's Spotify activity, or nil if the user isn't
+// listening to Spotify.
+func (p *Presence) Spotify() *Activity {
+	return p.findActivity(Activity.IsSpotify)
+}
+
+func (p *Presence) findActivity(fn func(Activity) bool) *Activity {
+	for i := range p.Activities {
+		if fn(p.Activities[i]) {
+			return &p.Activities[i]
+		}
+	}
+	return nil
+}
+
 type ClientStatus struct {
 	// Desktop is the user's status set for an active desktop (Windows,
 	// Linux, Mac) application session.
@@ -596,4 +749,92 @@ type ClientStatus struct {
 	// Web is the user's status set for an active web (browser, bot
 	// account) application session.
 	Web Status `json:"web,omitempty"`
+	// Embedded is the user's status set for an active embedded (console,
+	// VR headset) application session.
+	Embedded Status `json:"embedded,omitempty"`
+}
+
+// Platforms returns the platforms in which the user has an active session.
+// The returned platforms are in the order desktop, mobile, web and embedded.
+func (c ClientStatus) Platforms() []string {
+	var platforms []string
+	for _, status := range []struct {
+		name   string
+		status Status
+	}{
+		{"desktop", c.Desktop},
+		{"mobile", c.Mobile},
+		{"web", c.Web},
+		{"embedded", c.Embedded},
+	} {
+		if status.status != "" && status.status != OfflineStatus {
+			platforms = append(platforms, status.name)
+		}
+	}
+	return platforms
+}
+
+// GuildJoinRequestStatus is the status of a request to join a guild that has
+// Membership Screening enabled.
+type GuildJoinRequestStatus string
+
+const (
+	// JoinRequestStarted means that the user has started filling out the
+	// form but hasn't submitted it yet.
+	JoinRequestStarted GuildJoinRequestStatus = "STARTED"
+	// JoinRequestSubmitted means that the request is pending review.
+	JoinRequestSubmitted GuildJoinRequestStatus = "SUBMITTED"
+	// JoinRequestRejected means that the request was rejected by a moderator.
+	JoinRequestRejected GuildJoinRequestStatus = "REJECTED"
+	// JoinRequestApproved means that the request was approved by a moderator.
+	JoinRequestApproved GuildJoinRequestStatus = "APPROVED"
+)
+
+// GuildJoinRequest is a user's request to join a guild that has Membership
+// Screening enabled. This struct is undocumented.
+type GuildJoinRequest struct {
+	// ID is the ID of the join request.
+	ID Snowflake `json:"id"`
+	// GuildID is the ID of the guild that the request is for.
+	GuildID GuildID `json:"guild_id"`
+	// UserID is the ID of the user who made the request.
+	UserID UserID `json:"user_id"`
+	// User is the user who made the request, if any.
+	User *User `json:"user,omitempty"`
+	// Status is the status of the request.
+	Status GuildJoinRequestStatus `json:"application_status"`
+	// CreatedAt is when the request was created.
+	CreatedAt Timestamp `json:"created_at"`
+	// LastSeen is when the user was last seen, if known.
+	LastSeen Timestamp `json:"last_seen,omitempty"`
+	// FormResponses are the user's answers to the guild's screening form.
+	FormResponses []GuildJoinRequestResponse `json:"form_responses"`
+	// RejectionReason is the reason given by the moderator who rejected the
+	// request, if any.
+	RejectionReason string `json:"rejection_reason,omitempty"`
+	// ActionedAt is when the request was approved or rejected, if it was.
+	ActionedAt Timestamp `json:"actioned_at,omitempty"`
+	// ActionedByUser is the moderator who approved or rejected the request,
+	// if any.
+	ActionedByUser *User `json:"actioned_by_user,omitempty"`
+}
+
+// GuildJoinRequestResponse is a field of a guild's screening form along with
+// the user's answer to it. This struct is undocumented.
+type GuildJoinRequestResponse struct {
+	// FieldType is the type of the field, such as "TERMS", "TEXT_INPUT",
+	// "PARAGRAPH" or "MULTIPLE_CHOICE".
+	FieldType string `json:"field_type"`
+	// Label is the title of the field.
+	Label string `json:"label"`
+	// Description is the description of the field, if any.
+	Description string `json:"description,omitempty"`
+	// Values are the rules for TERMS fields or the choices for
+	// MULTIPLE_CHOICE fields.
+	Values []string `json:"values,omitempty"`
+	// Required is whether the field must be answered.
+	Required bool `json:"required"`
+	// Response is the user's answer. It is a boolean for TERMS fields, the
+	// index of the choice for MULTIPLE_CHOICE fields, and a string otherwise.
+	Response json.Raw `json:"response,omitempty"`
 }
@@ -20,6 +20,23 @@ const (
 	NitroLevel3
 )
 
+// DefaultUploadLimit is the default maximum total size of the files attached
+// to a message, in bytes.
+const DefaultUploadLimit = 10 * 1024 * 1024
+
+// UploadLimit returns the maximum total size of the files attached to a
+// message in a guild with this premium tier, in bytes.
+func (b NitroBoost) UploadLimit() int64 {
+	switch {
+	case b >= NitroLevel3:
+		return 100 * 1024 * 1024
+	case b == NitroLevel2:
+		return 50 * 1024 * 1024
+	default:
+		return DefaultUploadLimit
+	}
+}
+
 // MFALevel is the required MFA level for a guild.
 type MFALevel uint8
 
@@ -39,6 +56,7 @@ const (
 	SuppressPremiumSubscriptions
 )
 
+// GuildFeature is a feature that a guild has enabled.
 type GuildFeature string
 
 // https://discord.com/developers/docs/resources/guild#guild-object-guild-features
@@ -72,8 +90,129 @@ const (
 	AnimatedIcon GuildFeature = "ANIMATED_ICON"
 	// Banner is set, if the guild has access to set a guild banner image.
 	Banner GuildFeature = "BANNER"
+	// AnimatedBanner is set, if the guild has access to set an animated guild
+	// banner image.
+	AnimatedBanner GuildFeature = "ANIMATED_BANNER"
+	// Community is set, if the guild can enable welcome screen, Membership
+	// Screening, stage channels and discovery, and receives community updates.
+	//
+	// This feature is mutable through ModifyGuild.
+	Community GuildFeature = "COMMUNITY"
+	// CreatorMonetizableProvisional is set, if the guild has enabled
+	// monetization.
+	CreatorMonetizableProvisional GuildFeature = "CREATOR_MONETIZABLE_PROVISIONAL"
+	// CreatorStorePage is set, if the guild has enabled the role subscription
+	// promo page.
+	CreatorStorePage GuildFeature = "CREATOR_STORE_PAGE"
+	// DeveloperSupportServer is set, if the guild has been set as a support
+	// server on the App Directory.
+	DeveloperSupportServer GuildFeature = "DEVELOPER_SUPPORT_SERVER"
+	// InvitesDisabled is set, if the guild has paused invites, preventing new
+	// users from joining.
+	//
+	// This feature is mutable through ModifyGuild.
+	InvitesDisabled GuildFeature = "INVITES_DISABLED"
+	// MemberVerificationGateEnabled is set, if the guild has enabled
+	// Membership Screening.
+	MemberVerificationGateEnabled GuildFeature = "MEMBER_VERIFICATION_GATE_ENABLED"
+	// MoreSoundboard is set, if the guild has increased custom soundboard
+	// sound slots.
+	MoreSoundboard GuildFeature = "MORE_SOUNDBOARD"
+	// MoreStickers is set, if the guild has increased custom sticker slots.
+	MoreStickers GuildFeature = "MORE_STICKERS"
+	// PreviewEnabled is set, if the guild can be previewed before joining via
+	// Membership Screening or the directory.
+	PreviewEnabled GuildFeature = "PREVIEW_ENABLED"
+	// RaidAlertsDisabled is set, if the guild has disabled alerts for join
+	// raids in the configured safety alerts channel.
+	//
+	// This feature is mutable through ModifyGuild.
+	RaidAlertsDisabled GuildFeature = "RAID_ALERTS_DISABLED"
+	// RoleIcons is set, if the guild is able to set role icons.
+	RoleIcons GuildFeature = "ROLE_ICONS"
+	// RoleSubscriptionsAvailableForPurchase is set, if the guild has role
+	// subscriptions that can be purchased.
+	RoleSubscriptionsAvailableForPurchase GuildFeature = "ROLE_SUBSCRIPTIONS_AVAILABLE_FOR_PURCHASE"
+	// RoleSubscriptionsEnabled is set, if the guild has enabled role
+	// subscriptions.
+	RoleSubscriptionsEnabled GuildFeature = "ROLE_SUBSCRIPTIONS_ENABLED"
+	// Soundboard is set, if the guild has created soundboard sounds.
+	Soundboard GuildFeature = "SOUNDBOARD"
+	// TicketedEventsEnabled is set, if the guild has enabled ticketed events.
+	TicketedEventsEnabled GuildFeature = "TICKETED_EVENTS_ENABLED"
+	// WelcomeScreenEnabled is set, if the guild has enabled the welcome
+	// screen.
+	WelcomeScreenEnabled GuildFeature = "WELCOME_SCREEN_ENABLED"
+	// AutoModerationFeature is set, if the guild has set up auto moderation
+	// rules.
+	AutoModerationFeature GuildFeature = "AUTO_MODERATION"
+	// ApplicationCommandPermissionsV2 is set, if the guild is using the
+	// updated permissions configuration for application commands.
+	ApplicationCommandPermissionsV2 GuildFeature = "APPLICATION_COMMAND_PERMISSIONS_V2"
+	// EnhancedRoleColors is set, if the guild is able to set gradient colors
+	// to roles.
+	EnhancedRoleColors GuildFeature = "ENHANCED_ROLE_COLORS"
+	// GuestsEnabled is set, if the guild has access to guest invites.
+	GuestsEnabled GuildFeature = "GUESTS_ENABLED"
+	// GuildTags is set, if the guild has access to set guild tags.
+	GuildTags GuildFeature = "GUILD_TAGS"
 )
 
+// MutableGuildFeatures are the guild features that can be enabled or disabled
+// through ModifyGuild. Any other feature in ModifyGuildData's Features is
+// ignored by Discord.
+var MutableGuildFeatures = GuildFeatures{
+	Community,
+	Discoverable,
+	InvitesDisabled,
+	RaidAlertsDisabled,
+}
+
+// IsMutable returns true if the feature can be enabled or disabled through
+// ModifyGuild.
+func (f GuildFeature) IsMutable() bool {
+	return MutableGuildFeatures.Has(f)
+}
+
+// GuildFeatures is a set of guild features.
+type GuildFeatures []GuildFeature
+
+// Has returns true if the set contains the given feature.
+func (fs GuildFeatures) Has(feature GuildFeature) bool {
+	for _, f := range fs {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// With returns a copy of the set with the given features added, skipping the
+// ones that are already in the set.
+func (fs GuildFeatures) With(features ...GuildFeature) GuildFeatures {
+	cpy := make(GuildFeatures, len(fs), len(fs)+len(features))
+	copy(cpy, fs)
+
+	for _, f := range features {
+		if !cpy.Has(f) {
+			cpy = append(cpy, f)
+		}
+	}
+
+	return cpy
+}
+
+// Without returns a copy of the set with the given features removed.
+func (fs GuildFeatures) Without(features ...GuildFeature) GuildFeatures {
+	cpy := make(GuildFeatures, 0, len(fs))
+	for _, f := range fs {
+		if !GuildFeatures(features).Has(f) {
+			cpy = append(cpy, f)
+		}
+	}
+	return cpy
+}
+
 // ExplicitFilter is the explicit content filter level of a guild.
 type ExplicitFilter enum.Enum
 
@@ -0,0 +1,240 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestGuildWidgetUnmarshal(t *testing.T) {
+	// https://discord.com/developers/docs/resources/guild#get-guild-widget-example-get-guild-widget
+	const sample = `{
+		"id": "290926798626357999",
+		"name": "Test Server",
+		"instant_invite": "https://discord.com/invite/abcdefg",
+		"channels": [
+			{"id": "705216630279993882", "name": "elephant", "position": 2}
+		],
+		"members": [
+			{
+				"id": "0",
+				"username": "1234",
+				"discriminator": "0000",
+				"avatar": null,
+				"status": "online",
+				"avatar_url": "https://cdn.discordapp.com/widget-avatars/FfvURgcr3Za92K3JtoCppqnYMppMDc5B-Rll74YrGCU/C-1DyBZPQ6t5q2RuATFuMFgq0_uEMZVzd_6LbEfgvt8",
+				"activity": {"name": "Spotify"}
+			}
+		],
+		"presence_count": 1
+	}`
+
+	var w GuildWidget
+	if err := json.Unmarshal([]byte(sample), &w); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	if len(w.Members) != 1 {
+		t.Fatalf("unexpected members: %#v", w.Members)
+	}
+
+	if w.Members[0].Username != "1234" {
+		t.Errorf("unexpected member user: %#v", w.Members[0])
+	}
+
+	if len(w.WidgetMembers) != 1 {
+		t.Fatalf("unexpected widget members: %#v", w.WidgetMembers)
+	}
+
+	m := w.WidgetMembers[0]
+	if m.Username != "1234" || m.Status != OnlineStatus || m.AvatarURL == "" {
+		t.Errorf("unexpected member: %#v", m)
+	}
+	if m.Activity == nil || m.Activity.Name != "Spotify" {
+		t.Errorf("unexpected member activity: %#v", m.Activity)
+	}
+}
+
+func TestPresenceActivities(t *testing.T) {
+	const sample = `{
+		"user": {"id": "1"},
+		"status": "online",
+		"activities": [
+			{
+				"name": "Custom Status",
+				"type": 4,
+				"state": "hi",
+				"emoji": {"name": "👋"}
+			},
+			{
+				"name": "Spotify",
+				"type": 2,
+				"flags": 48,
+				"sync_id": "4cOdK2wGLETKBW3PvgPWqT",
+				"details": "Song",
+				"state": "Artist",
+				"party": {"id": "spotify:1"}
+			},
+			{
+				"name": "Game",
+				"type": 0,
+				"party": {"id": "party", "size": [2, 4]},
+				"secrets": {"join": "secret"},
+				"buttons": ["Join"]
+			}
+		]
+	}`
+
+	var p Presence
+	if err := json.Unmarshal([]byte(sample), &p); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	if p.Streaming() != nil {
+		t.Error("unexpected streaming activity")
+	}
+
+	spotify := p.Spotify()
+	if spotify == nil {
+		t.Fatal("missing Spotify activity")
+	}
+
+	if !spotify.Flags.Has(SyncActivity | PlayActivity) {
+		t.Errorf("unexpected Spotify flags %d", spotify.Flags)
+	}
+
+	if url := spotify.SpotifyTrackURL(); url != "https://open.spotify.com/track/4cOdK2wGLETKBW3PvgPWqT" {
+		t.Errorf("unexpected Spotify track URL %q", url)
+	}
+
+	if emoji := p.Activities[0].Emoji; emoji == nil || emoji.Name != "👋" {
+		t.Errorf("unexpected custom status emoji %v", emoji)
+	}
+
+	game := p.Activities[2]
+	if game.Party.CurrentSize() != 2 || game.Party.MaxSize() != 4 {
+		t.Errorf("unexpected party size %v", game.Party.Size)
+	}
+
+	if game.Secrets == nil || game.Secrets.Join != "secret" {
+		t.Errorf("unexpected secrets %v", game.Secrets)
+	}
+
+	if len(game.Buttons) != 1 || game.Buttons[0] != "Join" {
+		t.Errorf("unexpected buttons %v", game.Buttons)
+	}
+}
+
+func TestMemberTimeoutRemaining(t *testing.T) {
+	now := time.Now()
+
+	m := Member{}
+	if m.IsTimedOut(now) || m.TimeoutRemaining(now) != 0 {
+		t.Error("member without a timeout is timed out")
+	}
+
+	m.CommunicationDisabledUntil = NewTimestamp(now.Add(time.Hour))
+	if !m.IsTimedOut(now) || m.TimeoutRemaining(now) != time.Hour {
+		t.Errorf("unexpected remaining timeout %v", m.TimeoutRemaining(now))
+	}
+
+	m.CommunicationDisabledUntil = NewTimestamp(now.Add(-time.Hour))
+	if m.IsTimedOut(now) {
+		t.Error("member with an expired timeout is timed out")
+	}
+}
+
+func TestGuildFeatures(t *testing.T) {
+	var g Guild
+	if err := json.Unmarshal([]byte(`{"id": "1", "features": ["COMMUNITY", "NEWS"]}`), &g); err != nil {
+		t.Fatal("failed to unmarshal guild:", err)
+	}
+
+	if !g.HasFeature(Community) || !g.HasFeature(News) || g.HasFeature(Discoverable) {
+		t.Fatalf("unexpected features %v", g.Features)
+	}
+
+	with := g.Features.With(Community, InvitesDisabled)
+	if len(with) != 3 || !with.Has(InvitesDisabled) {
+		t.Errorf("unexpected features with InvitesDisabled: %v", with)
+	}
+
+	without := with.Without(Community)
+	if len(without) != 2 || without.Has(Community) {
+		t.Errorf("unexpected features without Community: %v", without)
+	}
+
+	// With and Without don't modify the guild's features.
+	if len(g.Features) != 2 || g.Features.Has(InvitesDisabled) {
+		t.Errorf("guild features were modified: %v", g.Features)
+	}
+
+	if !Community.IsMutable() || News.IsMutable() {
+		t.Error("unexpected mutable features")
+	}
+}
+
+func TestMemberAvatarDecoration(t *testing.T) {
+	m := Member{
+		User: User{
+			ID:               2,
+			AvatarDecoration: &AvatarDecoration{Asset: "user"},
+		},
+		Banner: "a_banner",
+	}
+
+	if url := m.AvatarDecorationURL(); url != "https://cdn.discordapp.com/avatar-decoration-presets/user.png" {
+		t.Errorf("expected the user's avatar decoration, got %q", url)
+	}
+
+	m.AvatarDecoration = &AvatarDecoration{Asset: "member"}
+	if url := m.AvatarDecorationURL(); url != "https://cdn.discordapp.com/avatar-decoration-presets/member.png" {
+		t.Errorf("expected the member's avatar decoration, got %q", url)
+	}
+
+	if url := m.BannerURL(1); url != "https://cdn.discordapp.com/guilds/1/users/2/banners/a_banner.gif" {
+		t.Errorf("unexpected banner URL %q", url)
+	}
+
+	if url := (Member{}).BannerURL(1); url != "" {
+		t.Errorf("unexpected banner URL %q for a member without a banner", url)
+	}
+}
+
+func TestGuildWidgetMarshal(t *testing.T) {
+	w := GuildWidget{
+		ID:      1,
+		Members: []User{{Username: "hime"}},
+	}
+
+	b, err := json.Marshal(w)
+	if err != nil {
+		t.Fatal("failed to marshal:", err)
+	}
+
+	var got GuildWidget
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	if len(got.Members) != 1 || got.Members[0].Username != "hime" {
+		t.Fatalf("unexpected members: %#v", got.Members)
+	}
+
+	w.WidgetMembers = []GuildWidgetMember{{User: User{Username: "arikawa"}, Status: IdleStatus}}
+
+	b, err = json.Marshal(w)
+	if err != nil {
+		t.Fatal("failed to marshal:", err)
+	}
+
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	if len(got.WidgetMembers) != 1 || got.WidgetMembers[0].Status != IdleStatus ||
+		got.Members[0].Username != "arikawa" {
+		t.Fatalf("unexpected widget members: %#v", got.WidgetMembers)
+	}
+}
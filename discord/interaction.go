@@ -44,6 +44,67 @@ type InteractionEvent struct {
 	Locale Language `json:"locale,omitempty"`
 	// GuildLocale is the guild's preferred locale, if invoked in a guild.
 	GuildLocale string `json:"guild_locale,omitempty"`
+
+	// AuthorizingIntegrationOwners maps the installation contexts that the
+	// interaction was authorized for to the IDs of the guilds or users that
+	// installed the application.
+	AuthorizingIntegrationOwners IntegrationOwners `json:"authorizing_integration_owners,omitempty"`
+	// Context is the context where the interaction was triggered from. It is
+	// nil if Discord didn't send it. Use ContextType to get it regardless.
+	Context *InteractionContextType `json:"context,omitempty"`
+	// AppPermissions are the permissions of the application in the channel
+	// of the interaction, including overwrites. If the application is only
+	// installed by the user, such as in DMs and group DMs or in guilds that
+	// its bot isn't in, then they are the permissions given to user-installed
+	// applications rather than the permissions of the bot.
+	AppPermissions Permissions `json:"app_permissions,string,omitempty"`
+}
+
+// IntegrationOwners maps an installation context to the ID of the guild or
+// user that installed the application. The ID is 0 if the interaction is from
+// the bot's DM channel with the user that installed the application to the
+// guild.
+//
+// https://discord.com/developers/docs/interactions/receiving-and-responding#interaction-object-authorizing-integration-owners-object
+type IntegrationOwners map[ApplicationIntegrationType]Snowflake
+
+// GuildID returns the ID of the guild that installed the application, if the
+// interaction was authorized for a guild installation.
+func (o IntegrationOwners) GuildID() (GuildID, bool) {
+	id, ok := o[GuildInstall]
+	return GuildID(id), ok
+}
+
+// UserID returns the ID of the user that installed the application, if the
+// interaction was authorized for a user installation.
+func (o IntegrationOwners) UserID() (UserID, bool) {
+	id, ok := o[UserInstall]
+	return UserID(id), ok
+}
+
+// ContextType returns the context where the interaction was triggered from.
+// If Discord didn't send it, it is guessed from the guild of the interaction,
+// in which case interactions outside guilds are assumed to be in the DM channel
+// with the bot.
+func (e *InteractionEvent) ContextType() InteractionContextType {
+	switch {
+	case e.Context != nil:
+		return *e.Context
+	case e.GuildID.IsValid():
+		return GuildContext
+	default:
+		return BotDMContext
+	}
+}
+
+// IsUserInstallOnly returns true if the interaction was only authorized by a
+// user installation of the application. In that case, the bot of the
+// application may not be in the guild or channel of the interaction, so it
+// shouldn't rely on the gateway or on the bot's access to them.
+func (e *InteractionEvent) IsUserInstallOnly() bool {
+	_, user := e.AuthorizingIntegrationOwners[UserInstall]
+	_, guild := e.AuthorizingIntegrationOwners[GuildInstall]
+	return user && !guild
 }
 
 // Sender returns the sender of this event from either the Member field or the
@@ -188,6 +249,12 @@ type AutocompleteInteraction struct {
 	Options     AutocompleteOptions `json:"options"`
 }
 
+// Focused returns the option that the user is currently focused on. It is a
+// shorthand for a.Options.Focused.
+func (a *AutocompleteInteraction) Focused() AutocompleteOption {
+	return a.Options.Focused()
+}
+
 // Type implements ComponentInteraction.
 func (*AutocompleteInteraction) InteractionType() InteractionDataType {
 	return AutocompleteInteractionType
@@ -208,12 +275,18 @@ func (o AutocompleteOptions) Find(name string) AutocompleteOption {
 	return AutocompleteOption{}
 }
 
-// Focused returns the option that the user is currently focused on.
+// Focused returns the option that the user is currently focused on, searching
+// into the options of subcommands and subcommand groups. Its Name is empty if
+// there is no focused option. The partial value that the user has typed so far
+// can be read using its String method.
 func (o AutocompleteOptions) Focused() AutocompleteOption {
 	for _, opt := range o {
 		if opt.Focused {
 			return opt
 		}
+		if focused := opt.Options.Focused(); focused.Focused {
+			return focused
+		}
 	}
 	return AutocompleteOption{}
 }
@@ -540,16 +613,16 @@ var optionSupportedSnowflakeTypes = map[reflect.Type]CommandOptionType{
 }
 
 var optionKindMap = map[reflect.Kind]CommandOptionType{
-	reflect.Int:     NumberOptionType,
-	reflect.Int8:    NumberOptionType,
-	reflect.Int16:   NumberOptionType,
-	reflect.Int32:   NumberOptionType,
-	reflect.Int64:   NumberOptionType,
-	reflect.Uint:    NumberOptionType,
-	reflect.Uint8:   NumberOptionType,
-	reflect.Uint16:  NumberOptionType,
-	reflect.Uint32:  NumberOptionType,
-	reflect.Uint64:  NumberOptionType,
+	reflect.Int:     IntegerOptionType,
+	reflect.Int8:    IntegerOptionType,
+	reflect.Int16:   IntegerOptionType,
+	reflect.Int32:   IntegerOptionType,
+	reflect.Int64:   IntegerOptionType,
+	reflect.Uint:    IntegerOptionType,
+	reflect.Uint8:   IntegerOptionType,
+	reflect.Uint16:  IntegerOptionType,
+	reflect.Uint32:  IntegerOptionType,
+	reflect.Uint64:  IntegerOptionType,
 	reflect.Float32: NumberOptionType,
 	reflect.Float64: NumberOptionType,
 	reflect.String:  StringOptionType,
@@ -573,8 +646,8 @@ var optionKindMap = map[reflect.Kind]CommandOptionType{
 //   - Snowflake (MentionableOptionType)
 //   - string (StringOptionType)
 //   - bool (BooleanOptionType)
-//   - int* (int, int8, int16, int32, int64) (NumberOptionType)
-//   - uint* (uint, uint8, uint16, uint32, uint64) (NumberOptionType)
+//   - int* (int, int8, int16, int32, int64) (IntegerOptionType, NumberOptionType)
+//   - uint* (uint, uint8, uint16, uint32, uint64) (IntegerOptionType, NumberOptionType)
 //   - float* (float32, float64) (NumberOptionType)
 //   - (any struct and struct pointer) (not Discord-type-checked)
 //
@@ -655,51 +728,94 @@ func unmarshalOptions(find func(string) unmarshalingOption, rv reflect.Value) er
 			return fmt.Errorf("option %q is required but not found", name)
 		}
 
-		if expectType, ok := optionSupportedSnowflakeTypes[fieldt]; ok {
-			if option.Type != expectType {
-				return fmt.Errorf("option %q expecting type %v, got %v", name, expectType, option.Type)
-			}
+		if err := unmarshalOptionValue(name, option, fieldv); err != nil {
+			return err
+		}
+	}
 
-			var snowflake Snowflake
-			if err := option.Value.UnmarshalTo(&snowflake); err != nil {
-				return fmt.Errorf("option %q is not a valid snowflake: %w", name, err)
-			}
+	return nil
+}
 
-			fieldv.Set(reflect.ValueOf(snowflake).Convert(fieldt))
-			continue
+// unmarshalOptionValue unmarshals the value of the found option into v after
+// checking its type.
+func unmarshalOptionValue(name string, option unmarshalingOption, v reflect.Value) error {
+	t := v.Type()
+
+	if expectType, ok := optionSupportedSnowflakeTypes[t]; ok {
+		if option.Type != expectType {
+			return fmt.Errorf("option %q expecting type %v, got %v", name, expectType, option.Type)
 		}
 
-		fieldk := fieldt.Kind()
-		if expectType, ok := optionKindMap[fieldk]; ok {
-			if option.Type != expectType {
-				return fmt.Errorf("option %q expecting type %v, got %v", name, expectType, option.Type)
-			}
+		var snowflake Snowflake
+		if err := option.Value.UnmarshalTo(&snowflake); err != nil {
+			return fmt.Errorf("option %q is not a valid snowflake: %w", name, err)
 		}
 
-		switch fieldk {
-		case reflect.Struct:
-			if err := unmarshalOptions(option.Find, fieldv.Addr()); err != nil {
-				return fmt.Errorf("option %q has invalid suboptions: %w", name, err)
-			}
+		v.Set(reflect.ValueOf(snowflake).Convert(t))
+		return nil
+	}
 
-		case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
-			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	k := t.Kind()
+	if expectType, ok := optionKindMap[k]; ok {
+		// Integer fields also take number options whose values are integers.
+		if option.Type != expectType &&
+			!(expectType == IntegerOptionType && option.Type == NumberOptionType) {
+			return fmt.Errorf("option %q expecting type %v, got %v", name, expectType, option.Type)
+		}
+	}
 
-			v := reflect.New(fieldt)
-			if err := option.Value.UnmarshalTo(v.Interface()); err != nil {
-				return fmt.Errorf("option %q is not a valid %s: %w", name, fieldt, err)
-			}
-			fieldv.Set(v.Elem())
+	switch k {
+	case reflect.Struct:
+		if err := unmarshalOptions(option.Find, v.Addr()); err != nil {
+			return fmt.Errorf("option %q has invalid suboptions: %w", name, err)
+		}
+
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 
-		default:
-			return fmt.Errorf("field %s (%q) has unknown type %s", fieldStruct.Name, name, fieldt)
+		newv := reflect.New(t)
+		if err := option.Value.UnmarshalTo(newv.Interface()); err != nil {
+			return fmt.Errorf("option %q is not a valid %s: %w", name, t, err)
 		}
+		v.Set(newv.Elem())
+
+	default:
+		return fmt.Errorf("option %q has unknown type %s", name, t)
 	}
 
 	return nil
 }
 
+// Option returns the value of the named option as T, which may be any
+// non-pointer type supported by CommandInteractionOptions.Unmarshal. The type
+// of the option is checked against T, and snowflake options are parsed, so
+//
+//	channelID, err := discord.Option[discord.ChannelID](data.Options, "channel")
+//
+// returns an error if the "channel" option is missing or isn't a channel. Use
+// OptionOr for optional options.
+func Option[T any](o CommandInteractionOptions, name string) (T, error) {
+	var v T
+
+	option := o.Find(name).forUnmarshal()
+	if option.Type == 0 {
+		return v, fmt.Errorf("option %q is required but not found", name)
+	}
+
+	err := unmarshalOptionValue(name, option, reflect.ValueOf(&v).Elem())
+	return v, err
+}
+
+// OptionOr is like Option, except it returns def if the named option is
+// missing.
+func OptionOr[T any](o CommandInteractionOptions, name string, def T) (T, error) {
+	if o.Find(name).Type == 0 {
+		return def, nil
+	}
+	return Option[T](o, name)
+}
+
 // Find returns the named command option
 func (o CommandInteractionOptions) Find(name string) CommandInteractionOption {
 	for _, opt := range o {
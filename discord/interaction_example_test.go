@@ -67,6 +67,36 @@ func ExampleCommandInteractionOptions_Unmarshal() {
 	// }
 }
 
+func ExampleOption() {
+	options := discord.CommandInteractionOptions{
+		opt(discord.ChannelOptionType, "channel", "1"),
+		opt(discord.IntegerOptionType, "days", 7),
+	}
+
+	channelID, err := discord.Option[discord.ChannelID](options, "channel")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	days, err := discord.Option[int](options, "days")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	reason, err := discord.OptionOr(options, "reason", "no reason")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	_, err = discord.Option[discord.UserID](options, "channel")
+	fmt.Println(channelID, days, reason)
+	fmt.Println(err)
+
+	// Output:
+	// 1 7 no reason
+	// option "channel" expecting type 6, got 7
+}
+
 func opt(t discord.CommandOptionType, name string, v interface{}) discord.CommandInteractionOption {
 	o := discord.CommandInteractionOption{
 		Type: t,
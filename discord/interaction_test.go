@@ -0,0 +1,81 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestInteractionEventIntegrationOwners(t *testing.T) {
+	const data = `{
+		"id": "1",
+		"application_id": "2",
+		"type": 2,
+		"data": {"id": "3", "name": "ping", "type": 1},
+		"token": "token",
+		"version": 1,
+		"user": {"id": "4", "username": "user"},
+		"authorizing_integration_owners": {"0": "0", "1": "4"},
+		"context": 1
+	}`
+
+	var ev InteractionEvent
+	if err := json.Unmarshal([]byte(data), &ev); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	if ev.Context == nil || *ev.Context != BotDMContext {
+		t.Errorf("expected context %d, got %v", BotDMContext, ev.Context)
+	}
+
+	if id, ok := ev.AuthorizingIntegrationOwners.GuildID(); !ok || id != 0 {
+		t.Errorf("expected guild owner 0, got %d (%v)", id, ok)
+	}
+
+	if id, ok := ev.AuthorizingIntegrationOwners.UserID(); !ok || id != 4 {
+		t.Errorf("expected user owner 4, got %d (%v)", id, ok)
+	}
+}
+
+func TestInteractionEventUserInstall(t *testing.T) {
+	const data = `{
+		"id": "1",
+		"application_id": "2",
+		"type": 2,
+		"data": {"id": "3", "name": "ping", "type": 1},
+		"token": "token",
+		"version": 1,
+		"guild_id": "5",
+		"member": {"user": {"id": "4", "username": "user"}, "roles": [], "joined_at": "2024-01-01T00:00:00Z"},
+		"authorizing_integration_owners": {"1": "4"},
+		"app_permissions": "442368"
+	}`
+
+	var ev InteractionEvent
+	if err := json.Unmarshal([]byte(data), &ev); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	if ev.AppPermissions != 442368 {
+		t.Errorf("unexpected app permissions %d", ev.AppPermissions)
+	}
+
+	if !ev.IsUserInstallOnly() {
+		t.Error("expected a user install only interaction")
+	}
+
+	if ctx := ev.ContextType(); ctx != GuildContext {
+		t.Errorf("expected guessed context %d, got %d", GuildContext, ctx)
+	}
+
+	ev.AuthorizingIntegrationOwners[GuildInstall] = 5
+	if ev.IsUserInstallOnly() {
+		t.Error("unexpected user install only interaction")
+	}
+
+	private := PrivateChannelContext
+	ev.Context = &private
+	if ctx := ev.ContextType(); ctx != PrivateChannelContext {
+		t.Errorf("expected context %d, got %d", PrivateChannelContext, ctx)
+	}
+}
@@ -14,17 +14,29 @@ type Invite struct {
 	// Inviter is the user who created the invite
 	Inviter *User `json:"inviter,omitempty"`
 
-	// Target is the target user for this invite.
+	// TargetType is the type of target for this voice channel invite.
+	TargetType InviteTargetType `json:"target_type,omitempty"`
+	// Target is the user whose stream to display for this voice channel
+	// stream invite.
 	Target *User `json:"target_user,omitempty"`
-	// Target type is the type of user target for this invite.
-	TargetType InviteUserType `json:"target_user_type,omitempty"`
+	// TargetApplication is the partial embedded application to open for this
+	// voice channel embedded application invite.
+	TargetApplication *Application `json:"target_application,omitempty"`
 
-	// ApproximatePresences is the approximate count of online members (only
-	// present when Target is set).
+	// ApproximatePresences is the approximate count of online members. It is
+	// only present when fetching the invite with counts.
 	ApproximatePresences uint `json:"approximate_presence_count,omitempty"`
-	// ApproximateMembers is the approximate count of total members
+	// ApproximateMembers is the approximate count of total members. It is
+	// only present when fetching the invite with counts.
 	ApproximateMembers uint `json:"approximate_member_count,omitempty"`
 
+	// ExpiresAt is the expiration date of this invite. It is invalid if the
+	// invite never expires.
+	ExpiresAt Timestamp `json:"expires_at,omitempty"`
+	// GuildScheduledEvent is the guild scheduled event, if the invite was
+	// fetched with a guild scheduled event ID.
+	GuildScheduledEvent *GuildScheduledEvent `json:"guild_scheduled_event,omitempty"`
+
 	// InviteMetadata contains extra information about the invite.
 	// So far, this field is only available when fetching Channel- or
 	// GuildInvites. Additionally the Uses field is filled when getting the
@@ -42,12 +54,27 @@ func (i Invite) LongURL() string {
 	return "https://discord.com/invite/" + i.Code
 }
 
-// https://discord.com/developers/docs/resources/invite#invite-object-target-user-types
-type InviteUserType uint8
+// https://discord.com/developers/docs/resources/invite#invite-object-invite-target-types
+type InviteTargetType uint8
+
+const (
+	// InviteTargetStream is an invite to a user's stream.
+	InviteTargetStream InviteTargetType = iota + 1
+	// InviteTargetEmbeddedApplication is an invite to an embedded
+	// application, such as a voice activity.
+	InviteTargetEmbeddedApplication
+)
+
+// InviteUserType is the old name of InviteTargetType.
+//
+// Deprecated: Use InviteTargetType instead.
+type InviteUserType = InviteTargetType
 
 const (
-	InviteNormalUser InviteUserType = iota
-	InviteUserStream
+	// Deprecated: Invites without a target have no TargetType.
+	InviteNormalUser InviteTargetType = 0
+	// Deprecated: Use InviteTargetStream instead.
+	InviteUserStream = InviteTargetStream
 )
 
 // Extra information about an invite, will extend the invite object.
@@ -1,7 +1,8 @@
 package discord
 
 import (
-	"fmt"
+	"errors"
+	"net/url"
 	"strings"
 	"time"
 
@@ -113,15 +114,96 @@ type Message struct {
 // URL generates a Discord client URL to the message. If the message doesn't
 // have a GuildID, it will generate a URL with the guild "@me".
 func (m Message) URL() string {
-	var guildID = "@me"
-	if m.GuildID.IsValid() {
-		guildID = m.GuildID.String()
+	return BuildMessageURL(m.GuildID, m.ChannelID, m.ID)
+}
+
+// CanCrosspost returns true if the message can be crossposted to the channels
+// following its channel, which must be of the given type. Only regular messages
+// and replies sent in announcement channels that haven't been crossposted yet
+// can be crossposted.
+func (m Message) CanCrosspost(channelType ChannelType) bool {
+	if channelType != GuildAnnouncement {
+		return false
+	}
+
+	switch m.Type {
+	case DefaultMessage, InlinedReplyMessage:
+	default:
+		return false
+	}
+
+	return m.Flags&(CrosspostedMessage|MessageIsCrosspost|EphemeralMessage) == 0
+}
+
+// ErrInvalidMessageURL is returned by ParseMessageURL if the given string is
+// not a valid Discord message link.
+var ErrInvalidMessageURL = errors.New("invalid message URL")
+
+// BuildMessageURL generates a Discord client URL to the message with the given
+// IDs. If guildID is not valid, then the URL will use the guild "@me", which is
+// what Discord uses for messages in direct messages.
+func BuildMessageURL(guildID GuildID, channelID ChannelID, messageID MessageID) string {
+	const prefix = "https://discord.com/channels/"
+
+	b := make([]byte, 0, len(prefix)+3*21)
+	b = append(b, prefix...)
+	if guildID.IsValid() {
+		b = guildID.Append(b)
+	} else {
+		b = append(b, "@me"...)
+	}
+	b = append(b, '/')
+	b = channelID.Append(b)
+	b = append(b, '/')
+	b = messageID.Append(b)
+
+	return string(b)
+}
+
+// ParseMessageURL parses a Discord client URL to a message, such as one
+// generated by BuildMessageURL, into its guild, channel and message IDs. Links
+// from the canary and PTB clients as well as the legacy discordapp.com domain
+// are also accepted.
+//
+// If the link points to a message in a direct message channel (the guild is
+// "@me"), then the returned GuildID is 0.
+func ParseMessageURL(link string) (GuildID, ChannelID, MessageID, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return 0, 0, 0, ErrInvalidMessageURL
+	}
+
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return 0, 0, 0, ErrInvalidMessageURL
+	}
+
+	switch strings.ToLower(u.Host) {
+	case "discord.com", "www.discord.com",
+		"canary.discord.com", "ptb.discord.com",
+		"discordapp.com", "www.discordapp.com",
+		"canary.discordapp.com", "ptb.discordapp.com":
+	default:
+		return 0, 0, 0, ErrInvalidMessageURL
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 4 || parts[0] != "channels" {
+		return 0, 0, 0, ErrInvalidMessageURL
+	}
+
+	var ids [3]Snowflake
+	for i, part := range parts[1:] {
+		if i == 0 && part == "@me" {
+			continue
+		}
+
+		ids[i], err = ParseSnowflake(part)
+		if err != nil || !ids[i].IsValid() {
+			return 0, 0, 0, ErrInvalidMessageURL
+		}
 	}
 
-	return fmt.Sprintf(
-		"https://discord.com/channels/%s/%s/%s",
-		guildID, m.ChannelID.String(), m.ID.String(),
-	)
+	return GuildID(ids[0]), ChannelID(ids[1]), MessageID(ids[2]), nil
 }
 
 type MessageType uint8
@@ -299,13 +381,39 @@ const (
 
 type StickerFormatType uint8
 
-// https://discord.com/developers/docs/resources/channel#message-object-message-sticker-format-types
+// https://discord.com/developers/docs/resources/sticker#sticker-object-sticker-format-types
 const (
-	StickerFormatPNG    = 1
-	StickerFormatAPNG   = 2
-	StickerFormatLottie = 3
+	StickerFormatPNG    StickerFormatType = 1
+	StickerFormatAPNG   StickerFormatType = 2
+	StickerFormatLottie StickerFormatType = 3
+	StickerFormatGIF    StickerFormatType = 4
 )
 
+// https://discord.com/developers/docs/resources/sticker#sticker-pack-object
+type StickerPack struct {
+	// ID is the ID of the sticker pack.
+	ID StickerPackID `json:"id"`
+	// Stickers are the stickers in the pack.
+	Stickers []Sticker `json:"stickers"`
+	// Name is the name of the sticker pack.
+	Name string `json:"name"`
+	// SKUID is the ID of the pack's SKU.
+	SKUID SKUID `json:"sku_id"`
+	// CoverStickerID is the ID of a sticker in the pack which is shown as the
+	// pack's icon.
+	CoverStickerID StickerID `json:"cover_sticker_id,omitempty"`
+	// Description is the description of the sticker pack.
+	Description string `json:"description"`
+	// BannerAssetID is the ID of the sticker pack's banner image.
+	BannerAssetID Snowflake `json:"banner_asset_id,omitempty"`
+}
+
+// CreatedAt returns a time object representing when the sticker pack was
+// created.
+func (p StickerPack) CreatedAt() time.Time {
+	return p.ID.Time()
+}
+
 // https://discord.com/developers/docs/resources/channel#channel-mention-object
 type ChannelMention struct {
 	// ChannelID is the ID of the channel.
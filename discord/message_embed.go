@@ -2,11 +2,17 @@ package discord
 
 import (
 	"fmt"
+	"image/color"
 	"strconv"
+	"strings"
 )
 
 // Color describes an RGB color (with NO alpha). If a value is -1, then it's
 // marshaled to JSON as null.
+//
+// Note that Discord treats a color of 0 as "no color" for roles and embeds
+// rather than black. To get a color that is displayed as black, use
+// BlackColor. To explicitly reset a color to none, use NullColor.
 type Color int32
 
 // DefaultEmbedColor is the default color to use for an embed.
@@ -15,6 +21,85 @@ var DefaultEmbedColor Color = 0x303030
 // NullColor is a Color that's marshaled to null.
 const NullColor Color = -1
 
+// BlackColor is the closest color to black that Discord will still render as
+// a color. It exists because a Color of 0 is treated as no color.
+const BlackColor Color = 0x010101
+
+var _ color.Color = Color(0)
+
+// NewColor creates a new Color from the given red, green and blue components.
+func NewColor(r, g, b uint8) Color {
+	return Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// NewColorFromImage converts the given image/color.Color into a Color. The
+// alpha channel is discarded. If c is completely transparent, then NullColor
+// is returned.
+func NewColorFromImage(c color.Color) Color {
+	if c, ok := c.(Color); ok {
+		return c
+	}
+
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if nrgba.A == 0 {
+		return NullColor
+	}
+
+	return NewColor(nrgba.R, nrgba.G, nrgba.B)
+}
+
+// ParseColor parses the given hexadecimal color string into a Color. The
+// string may be prefixed with "#" or "0x" and may be in either the full
+// (#RRGGBB) or the shorthand (#RGB) form.
+func ParseColor(hex string) (Color, error) {
+	s := strings.TrimPrefix(hex, "#")
+	if len(s) == len(hex) {
+		s = strings.TrimPrefix(strings.TrimPrefix(hex, "0x"), "0X")
+	}
+
+	switch len(s) {
+	case 3:
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	case 6:
+	default:
+		return NullColor, fmt.Errorf("invalid color %q", hex)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return NullColor, fmt.Errorf("invalid color %q", hex)
+	}
+
+	return Color(v), nil
+}
+
+// IsNull returns true if the color is NullColor or any other negative value.
+func (c Color) IsNull() bool {
+	return c < 0
+}
+
+// IsSet returns true if Discord would display the color, that is, if it is
+// neither null nor 0.
+func (c Color) IsSet() bool {
+	return c > 0
+}
+
+// RGBA implements image/color.Color. A null color is returned as fully
+// transparent; any other color is fully opaque.
+func (c Color) RGBA() (r, g, b, a uint32) {
+	if c.IsNull() {
+		return 0, 0, 0, 0
+	}
+
+	r8, g8, b8 := c.RGB()
+
+	r = uint32(r8) * 0x101
+	g = uint32(g8) * 0x101
+	b = uint32(b8) * 0x101
+	a = 0xFFFF
+	return
+}
+
 // Uint32 returns the color as a Uint32. If the color is null, then 0 is
 // returned.
 func (c Color) Uint32() uint32 {
@@ -0,0 +1,64 @@
+package discord
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		var tests = map[string]Color{
+			"#FF8000":  0xFF8000,
+			"ff8000":   0xFF8000,
+			"0xFF8000": 0xFF8000,
+			"#F80":     0xFF8800,
+		}
+
+		for in, expect := range tests {
+			c, err := ParseColor(in)
+			if err != nil {
+				t.Errorf("failed to parse %q: %v", in, err)
+				continue
+			}
+			if c != expect {
+				t.Errorf("%q: expected %s, got %s", in, expect, c)
+			}
+		}
+
+		for _, in := range []string{"", "#12", "#GGGGGG", "#1234567"} {
+			if _, err := ParseColor(in); err == nil {
+				t.Errorf("expected error parsing %q", in)
+			}
+		}
+	})
+
+	t.Run("rgb", func(t *testing.T) {
+		c := NewColor(0x12, 0x34, 0x56)
+		if c != 0x123456 {
+			t.Fatalf("unexpected color %s", c)
+		}
+
+		if r, g, b := c.RGB(); r != 0x12 || g != 0x34 || b != 0x56 {
+			t.Fatalf("unexpected RGB %d, %d, %d", r, g, b)
+		}
+	})
+
+	t.Run("image", func(t *testing.T) {
+		c := NewColorFromImage(color.RGBA{R: 0xFF, A: 0xFF})
+		if c != 0xFF0000 {
+			t.Fatalf("unexpected color %s", c)
+		}
+
+		if _, _, _, a := NullColor.RGBA(); a != 0 {
+			t.Fatal("NullColor is not transparent")
+		}
+
+		if NewColorFromImage(color.Transparent) != NullColor {
+			t.Fatal("transparent color is not NullColor")
+		}
+
+		if _, _, _, a := Color(0).RGBA(); a != 0xFFFF {
+			t.Fatal("black is not opaque")
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package discord
+
+import "testing"
+
+func TestMessageURL(t *testing.T) {
+	type test struct {
+		name    string
+		url     string
+		guild   GuildID
+		channel ChannelID
+		message MessageID
+	}
+
+	var tests = []test{
+		{
+			name:    "guild",
+			url:     "https://discord.com/channels/1/2/3",
+			guild:   1,
+			channel: 2,
+			message: 3,
+		},
+		{
+			name:    "dm",
+			url:     "https://discord.com/channels/@me/2/3",
+			channel: 2,
+			message: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if url := BuildMessageURL(test.guild, test.channel, test.message); url != test.url {
+				t.Fatalf("unexpected URL: %q", url)
+			}
+
+			g, c, m, err := ParseMessageURL(test.url)
+			if err != nil {
+				t.Fatal("failed to parse URL:", err)
+			}
+
+			if g != test.guild || c != test.channel || m != test.message {
+				t.Fatalf("unexpected IDs: %d/%d/%d", g, c, m)
+			}
+		})
+	}
+
+	t.Run("canary", func(t *testing.T) {
+		_, _, m, err := ParseMessageURL("https://canary.discordapp.com/channels/1/2/3")
+		if err != nil {
+			t.Fatal("failed to parse URL:", err)
+		}
+		if m != 3 {
+			t.Fatal("unexpected message ID:", m)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var invalids = []string{
+			"",
+			"https://example.com/channels/1/2/3",
+			"https://discord.com/channels/1/2",
+			"https://discord.com/channels/1/2/abc",
+			"https://discord.com/channels/1/0/3",
+		}
+
+		for _, invalid := range invalids {
+			if _, _, _, err := ParseMessageURL(invalid); err != ErrInvalidMessageURL {
+				t.Errorf("expected error for %q, got %v", invalid, err)
+			}
+		}
+	})
+}
+
+func TestMessageCanCrosspost(t *testing.T) {
+	tests := []struct {
+		name        string
+		msg         Message
+		channelType ChannelType
+		can         bool
+	}{
+		{"announcement", Message{Type: DefaultMessage}, GuildAnnouncement, true},
+		{"reply", Message{Type: InlinedReplyMessage}, GuildAnnouncement, true},
+		{"text channel", Message{Type: DefaultMessage}, GuildText, false},
+		{"pin notice", Message{Type: ChannelPinnedMessage}, GuildAnnouncement, false},
+		{"crossposted", Message{Flags: CrosspostedMessage}, GuildAnnouncement, false},
+		{"crosspost", Message{Flags: MessageIsCrosspost}, GuildAnnouncement, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if can := test.msg.CanCrosspost(test.channelType); can != test.can {
+				t.Errorf("expected CanCrosspost to return %v, got %v", test.can, can)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package discord
+
+import "time"
+
+// EntitlementType is the type of an entitlement.
+//
+// https://discord.com/developers/docs/resources/entitlement#entitlement-object-entitlement-types
+type EntitlementType int
+
+const (
+	// PurchaseEntitlement means that the entitlement was purchased by the
+	// user.
+	PurchaseEntitlement EntitlementType = iota + 1
+	// PremiumSubscriptionEntitlement means that the entitlement is for a
+	// Discord Nitro subscription.
+	PremiumSubscriptionEntitlement
+	// DeveloperGiftEntitlement means that the entitlement was gifted by the
+	// developer.
+	DeveloperGiftEntitlement
+	// TestModePurchaseEntitlement means that the entitlement was purchased by
+	// a developer in application test mode.
+	TestModePurchaseEntitlement
+	// FreePurchaseEntitlement means that the entitlement was granted when the
+	// SKU was free.
+	FreePurchaseEntitlement
+	// UserGiftEntitlement means that the entitlement was gifted by another
+	// user.
+	UserGiftEntitlement
+	// PremiumPurchaseEntitlement means that the entitlement was claimed by the
+	// user for free as a Nitro subscriber.
+	PremiumPurchaseEntitlement
+	// ApplicationSubscriptionEntitlement means that the entitlement was
+	// purchased as an application subscription.
+	ApplicationSubscriptionEntitlement
+)
+
+// Entitlement represents that a user or guild has access to a premium offering
+// in an application.
+//
+// https://discord.com/developers/docs/resources/entitlement#entitlement-object
+type Entitlement struct {
+	// ID is the ID of the entitlement.
+	ID EntitlementID `json:"id"`
+	// SKUID is the ID of the SKU.
+	SKUID SKUID `json:"sku_id"`
+	// AppID is the ID of the parent application.
+	AppID AppID `json:"application_id"`
+	// UserID is the ID of the user that is granted access to the
+	// entitlement's SKU, if any.
+	UserID UserID `json:"user_id,omitempty"`
+	// GuildID is the ID of the guild that is granted access to the
+	// entitlement's SKU, if any.
+	GuildID GuildID `json:"guild_id,omitempty"`
+	// Type is the type of the entitlement.
+	Type EntitlementType `json:"type"`
+	// Deleted is whether the entitlement was deleted.
+	Deleted bool `json:"deleted"`
+	// StartsAt is the start date at which the entitlement is valid. It is
+	// zero for test entitlements.
+	StartsAt Timestamp `json:"starts_at,omitempty"`
+	// EndsAt is the date at which the entitlement is no longer valid. It is
+	// zero for test entitlements and entitlements that don't expire.
+	EndsAt Timestamp `json:"ends_at,omitempty"`
+	// Consumed is whether the entitlement for a consumable item has been
+	// consumed.
+	Consumed bool `json:"consumed,omitempty"`
+}
+
+// CreatedAt returns a time object representing when the entitlement was
+// created.
+func (e Entitlement) CreatedAt() time.Time {
+	return e.ID.Time()
+}
+
+// IsActive returns true if the entitlement grants access to its SKU at the
+// given time, meaning that it isn't deleted and now is within its validity
+// period, if it has one.
+func (e Entitlement) IsActive(now time.Time) bool {
+	if e.Deleted {
+		return false
+	}
+	if e.StartsAt.IsValid() && now.Before(e.StartsAt.Time()) {
+		return false
+	}
+	if e.EndsAt.IsValid() && !now.Before(e.EndsAt.Time()) {
+		return false
+	}
+	return true
+}
+
+// SubscriptionStatus is the status of a subscription.
+//
+// https://discord.com/developers/docs/resources/subscription#subscription-statuses
+type SubscriptionStatus int
+
+const (
+	// ActiveSubscription means that the subscription is active and scheduled
+	// to renew.
+	ActiveSubscription SubscriptionStatus = iota
+	// EndingSubscription means that the subscription is active but will not
+	// renew.
+	EndingSubscription
+	// InactiveSubscription means that the subscription is inactive and is not
+	// being charged.
+	InactiveSubscription
+)
+
+// Subscription represents a user making recurring payments for at least one
+// SKU over an ongoing period.
+//
+// https://discord.com/developers/docs/resources/subscription#subscription-object
+type Subscription struct {
+	// ID is the ID of the subscription.
+	ID SubscriptionID `json:"id"`
+	// UserID is the ID of the user who is subscribed.
+	UserID UserID `json:"user_id"`
+	// SKUIDs are the SKUs subscribed to.
+	SKUIDs []SKUID `json:"sku_ids"`
+	// EntitlementIDs are the entitlements granted for this subscription.
+	EntitlementIDs []EntitlementID `json:"entitlement_ids"`
+	// RenewalSKUIDs are the SKUs that this user will be subscribed to at
+	// renewal, if they differ from SKUIDs.
+	RenewalSKUIDs []SKUID `json:"renewal_sku_ids,omitempty"`
+	// CurrentPeriodStart is the start of the current subscription period.
+	CurrentPeriodStart Timestamp `json:"current_period_start"`
+	// CurrentPeriodEnd is the end of the current subscription period.
+	CurrentPeriodEnd Timestamp `json:"current_period_end"`
+	// Status is the current status of the subscription.
+	Status SubscriptionStatus `json:"status"`
+	// CanceledAt is when the subscription was canceled, if it was.
+	CanceledAt Timestamp `json:"canceled_at,omitempty"`
+	// Country is the ISO3166-1 alpha-2 country code of the payment source
+	// used to purchase the subscription. It is missing unless queried with a
+	// private OAuth scope.
+	Country string `json:"country,omitempty"`
+}
+
+// CreatedAt returns a time object representing when the subscription was
+// created.
+func (s Subscription) CreatedAt() time.Time {
+	return s.ID.Time()
+}
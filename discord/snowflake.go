@@ -1,8 +1,8 @@
 package discord
 
 import (
+	"math"
 	"strconv"
-	"strings"
 	"time"
 )
 
@@ -15,16 +15,24 @@ func DurationSinceEpoch(t time.Time) time.Duration {
 	return time.Duration(t.UnixNano()) - Epoch
 }
 
-//go:generate go run ../utils/cmd/gensnowflake -o snowflake_types.go AppID AttachmentID AuditLogEntryID ChannelID CommandID EmojiID GuildID IntegrationID InteractionID MessageID RoleID StageID StickerID StickerPackID TagID TeamID UserID WebhookID EventID EntityID
+//go:generate go run ../utils/cmd/gensnowflake -o snowflake_types.go AppID AttachmentID AuditLogEntryID AutoModerationRuleID ChannelID CommandID EmojiID EntitlementID GuildID IntegrationID InteractionID MessageID RoleID StageID SKUID StickerID StickerPackID SubscriptionID TagID TeamID UserID WebhookID EventID EntityID
 
 // Mention generates the mention syntax for this channel ID.
-func (s ChannelID) Mention() string { return "<#" + s.String() + ">" }
+func (s ChannelID) Mention() string { return mention("<#", Snowflake(s)) }
 
 // Mention generates the mention syntax for this role ID.
-func (s RoleID) Mention() string { return "<@&" + s.String() + ">" }
+func (s RoleID) Mention() string { return mention("<@&", Snowflake(s)) }
 
 // Mention generates the mention syntax for this user ID.
-func (s UserID) Mention() string { return "<@" + s.String() + ">" }
+func (s UserID) Mention() string { return mention("<@", Snowflake(s)) }
+
+func mention(prefix string, s Snowflake) string {
+	var buf [32]byte
+	b := append(buf[:0], prefix...)
+	b = s.Append(b)
+	b = append(b, '>')
+	return string(b)
+}
 
 // Snowflake is the format of Discord's ID type. It is a format that can be
 // sorted chronologically.
@@ -53,8 +61,52 @@ func ParseSnowflake(sf string) (Snowflake, error) {
 	return Snowflake(u), nil
 }
 
+// ParseSnowflakeBytes is like ParseSnowflake, except it parses a byte slice.
+// Unlike converting the slice to a string first, it doesn't allocate unless the
+// snowflake is invalid.
+func ParseSnowflakeBytes(sf []byte) (Snowflake, error) {
+	if string(sf) == "null" {
+		return NullSnowflake, nil
+	}
+
+	if len(sf) == 0 {
+		return 0, snowflakeSyntaxError(sf, strconv.ErrSyntax)
+	}
+
+	var u uint64
+	for _, c := range sf {
+		if c < '0' || c > '9' {
+			return 0, snowflakeSyntaxError(sf, strconv.ErrSyntax)
+		}
+
+		d := uint64(c - '0')
+		if u > (math.MaxUint64-d)/10 {
+			return 0, snowflakeSyntaxError(sf, strconv.ErrRange)
+		}
+
+		u = u*10 + d
+	}
+
+	return Snowflake(u), nil
+}
+
+func snowflakeSyntaxError(sf []byte, err error) error {
+	return &strconv.NumError{Func: "ParseUint", Num: string(sf), Err: err}
+}
+
+// AppendSnowflake appends the decimal form of the snowflake to dst and returns
+// the extended buffer. Unlike String, it appends the number even if the
+// snowflake isn't valid.
+func AppendSnowflake(dst []byte, s Snowflake) []byte {
+	return strconv.AppendUint(dst, uint64(s), 10)
+}
+
 func (s *Snowflake) UnmarshalJSON(v []byte) error {
-	p, err := ParseSnowflake(strings.Trim(string(v), `"`))
+	if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' {
+		v = v[1 : len(v)-1]
+	}
+
+	p, err := ParseSnowflakeBytes(v)
 	if err != nil {
 		return err
 	}
@@ -68,9 +120,13 @@ func (s Snowflake) MarshalJSON() ([]byte, error) {
 	// value gets omitted.
 	if !s.IsValid() {
 		return []byte("null"), nil
-	} else {
-		return []byte(`"` + strconv.FormatInt(int64(s), 10) + `"`), nil
 	}
+
+	b := make([]byte, 0, 22) // 20 digits and 2 quotes
+	b = append(b, '"')
+	b = AppendSnowflake(b, s)
+	b = append(b, '"')
+	return b, nil
 }
 
 // String returns the ID, or nothing if the snowflake isn't valid.
@@ -82,6 +138,15 @@ func (s Snowflake) String() string {
 	return strconv.FormatUint(uint64(s), 10)
 }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s Snowflake) Append(b []byte) []byte {
+	if !s.IsValid() {
+		return b
+	}
+	return AppendSnowflake(b, s)
+}
+
 // IsValid returns whether or not the snowflake is valid.
 func (s Snowflake) IsValid() bool {
 	return !(int64(s) == 0 || s == NullSnowflake)
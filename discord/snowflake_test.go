@@ -58,9 +58,83 @@ func TestSnowflake(t *testing.T) {
 		})
 	})
 
+	t.Run("parse bytes", func(t *testing.T) {
+		s, err := ParseSnowflakeBytes([]byte("175928847299117063"))
+		if err != nil {
+			t.Fatal("Failed to parse snowflake:", err)
+		}
+		if s != value {
+			t.Fatal("Unexpected snowflake:", s)
+		}
+
+		if s, _ := ParseSnowflakeBytes([]byte("null")); s != NullSnowflake {
+			t.Fatal("Unexpected null snowflake:", s)
+		}
+
+		for _, invalid := range []string{"", "12a", "-1", "18446744073709551616"} {
+			if _, err := ParseSnowflakeBytes([]byte(invalid)); err == nil {
+				t.Errorf("Expected error parsing %q", invalid)
+			}
+		}
+	})
+
+	t.Run("append", func(t *testing.T) {
+		if b := AppendSnowflake([]byte("id:"), value); string(b) != "id:175928847299117063" {
+			t.Fatal("Unexpected appended snowflake:", string(b))
+		}
+
+		if b := NullSnowflake.Append(nil); len(b) != 0 {
+			t.Fatal("Unexpected appended null snowflake:", string(b))
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := Snowflake(value).MarshalJSON()
+		if err != nil {
+			t.Fatal("Failed to marshal:", err)
+		}
+
+		var s Snowflake
+		if err := s.UnmarshalJSON(b); err != nil {
+			t.Fatal("Failed to unmarshal:", err)
+		}
+		if s != value {
+			t.Fatal("Unexpected unmarshaled snowflake:", s)
+		}
+	})
+
+	t.Run("mention", func(t *testing.T) {
+		if m := UserID(value).Mention(); m != "<@175928847299117063>" {
+			t.Fatal("Unexpected mention:", m)
+		}
+	})
+
 	t.Run("new", func(t *testing.T) {
 		if s := NewSnowflake(expect); !s.Time().Equal(expect) {
 			t.Fatal("Unexpected new snowflake from expected time:", s)
 		}
 	})
 }
+
+func BenchmarkSnowflakeUnmarshalJSON(b *testing.B) {
+	data := []byte(`"175928847299117063"`)
+	b.ReportAllocs()
+
+	var s Snowflake
+	for i := 0; i < b.N; i++ {
+		if err := s.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSnowflakeMarshalJSON(b *testing.B) {
+	s := Snowflake(175928847299117063)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.MarshalJSON(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -16,6 +16,10 @@ func (s *AppID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmarshal
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s AppID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s AppID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s AppID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -40,6 +44,10 @@ func (s *AttachmentID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Un
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s AttachmentID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s AttachmentID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s AttachmentID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -64,6 +72,10 @@ func (s *AuditLogEntryID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s)
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s AuditLogEntryID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s AuditLogEntryID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s AuditLogEntryID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -76,6 +88,34 @@ func (s AuditLogEntryID) Worker() uint8     { return Snowflake(s).Worker() }
 func (s AuditLogEntryID) PID() uint8        { return Snowflake(s).PID() }
 func (s AuditLogEntryID) Increment() uint16 { return Snowflake(s).Increment() }
 
+// AutoModerationRuleID is the snowflake type for a AutoModerationRuleID.
+type AutoModerationRuleID Snowflake
+
+// NullAutoModerationRuleID gets encoded into a null. This is used for optional and nullable snowflake fields.
+const NullAutoModerationRuleID = AutoModerationRuleID(NullSnowflake)
+
+func (s AutoModerationRuleID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *AutoModerationRuleID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s AutoModerationRuleID) String() string { return Snowflake(s).String() }
+
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s AutoModerationRuleID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
+// IsValid returns whether or not the snowflake is valid.
+func (s AutoModerationRuleID) IsValid() bool { return Snowflake(s).IsValid() }
+
+// IsNull returns whether or not the snowflake is null. This method is rarely
+// ever useful; most people should use IsValid instead.
+func (s AutoModerationRuleID) IsNull() bool { return Snowflake(s).IsNull() }
+
+func (s AutoModerationRuleID) Time() time.Time   { return Snowflake(s).Time() }
+func (s AutoModerationRuleID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s AutoModerationRuleID) PID() uint8        { return Snowflake(s).PID() }
+func (s AutoModerationRuleID) Increment() uint16 { return Snowflake(s).Increment() }
+
 // ChannelID is the snowflake type for a ChannelID.
 type ChannelID Snowflake
 
@@ -88,6 +128,10 @@ func (s *ChannelID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmar
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s ChannelID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s ChannelID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s ChannelID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -112,6 +156,10 @@ func (s *CommandID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmar
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s CommandID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s CommandID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s CommandID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -136,6 +184,10 @@ func (s *EmojiID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmarsh
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s EmojiID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s EmojiID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s EmojiID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -148,6 +200,34 @@ func (s EmojiID) Worker() uint8     { return Snowflake(s).Worker() }
 func (s EmojiID) PID() uint8        { return Snowflake(s).PID() }
 func (s EmojiID) Increment() uint16 { return Snowflake(s).Increment() }
 
+// EntitlementID is the snowflake type for a EntitlementID.
+type EntitlementID Snowflake
+
+// NullEntitlementID gets encoded into a null. This is used for optional and nullable snowflake fields.
+const NullEntitlementID = EntitlementID(NullSnowflake)
+
+func (s EntitlementID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *EntitlementID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s EntitlementID) String() string { return Snowflake(s).String() }
+
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s EntitlementID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
+// IsValid returns whether or not the snowflake is valid.
+func (s EntitlementID) IsValid() bool { return Snowflake(s).IsValid() }
+
+// IsNull returns whether or not the snowflake is null. This method is rarely
+// ever useful; most people should use IsValid instead.
+func (s EntitlementID) IsNull() bool { return Snowflake(s).IsNull() }
+
+func (s EntitlementID) Time() time.Time   { return Snowflake(s).Time() }
+func (s EntitlementID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s EntitlementID) PID() uint8        { return Snowflake(s).PID() }
+func (s EntitlementID) Increment() uint16 { return Snowflake(s).Increment() }
+
 // GuildID is the snowflake type for a GuildID.
 type GuildID Snowflake
 
@@ -160,6 +240,10 @@ func (s *GuildID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmarsh
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s GuildID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s GuildID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s GuildID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -184,6 +268,10 @@ func (s *IntegrationID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).U
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s IntegrationID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s IntegrationID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s IntegrationID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -208,6 +296,10 @@ func (s *InteractionID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).U
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s InteractionID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s InteractionID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s InteractionID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -232,6 +324,10 @@ func (s *MessageID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmar
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s MessageID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s MessageID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s MessageID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -256,6 +352,10 @@ func (s *RoleID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmarsha
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s RoleID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s RoleID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s RoleID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -280,6 +380,10 @@ func (s *StageID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmarsh
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s StageID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s StageID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s StageID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -292,6 +396,34 @@ func (s StageID) Worker() uint8     { return Snowflake(s).Worker() }
 func (s StageID) PID() uint8        { return Snowflake(s).PID() }
 func (s StageID) Increment() uint16 { return Snowflake(s).Increment() }
 
+// SKUID is the snowflake type for a SKUID.
+type SKUID Snowflake
+
+// NullSKUID gets encoded into a null. This is used for optional and nullable snowflake fields.
+const NullSKUID = SKUID(NullSnowflake)
+
+func (s SKUID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *SKUID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s SKUID) String() string { return Snowflake(s).String() }
+
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s SKUID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
+// IsValid returns whether or not the snowflake is valid.
+func (s SKUID) IsValid() bool { return Snowflake(s).IsValid() }
+
+// IsNull returns whether or not the snowflake is null. This method is rarely
+// ever useful; most people should use IsValid instead.
+func (s SKUID) IsNull() bool { return Snowflake(s).IsNull() }
+
+func (s SKUID) Time() time.Time   { return Snowflake(s).Time() }
+func (s SKUID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s SKUID) PID() uint8        { return Snowflake(s).PID() }
+func (s SKUID) Increment() uint16 { return Snowflake(s).Increment() }
+
 // StickerID is the snowflake type for a StickerID.
 type StickerID Snowflake
 
@@ -304,6 +436,10 @@ func (s *StickerID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmar
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s StickerID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s StickerID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s StickerID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -328,6 +464,10 @@ func (s *StickerPackID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).U
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s StickerPackID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s StickerPackID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s StickerPackID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -340,6 +480,34 @@ func (s StickerPackID) Worker() uint8     { return Snowflake(s).Worker() }
 func (s StickerPackID) PID() uint8        { return Snowflake(s).PID() }
 func (s StickerPackID) Increment() uint16 { return Snowflake(s).Increment() }
 
+// SubscriptionID is the snowflake type for a SubscriptionID.
+type SubscriptionID Snowflake
+
+// NullSubscriptionID gets encoded into a null. This is used for optional and nullable snowflake fields.
+const NullSubscriptionID = SubscriptionID(NullSnowflake)
+
+func (s SubscriptionID) MarshalJSON() ([]byte, error)  { return Snowflake(s).MarshalJSON() }
+func (s *SubscriptionID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).UnmarshalJSON(v) }
+
+// String returns the ID, or nothing if the snowflake isn't valid.
+func (s SubscriptionID) String() string { return Snowflake(s).String() }
+
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s SubscriptionID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
+// IsValid returns whether or not the snowflake is valid.
+func (s SubscriptionID) IsValid() bool { return Snowflake(s).IsValid() }
+
+// IsNull returns whether or not the snowflake is null. This method is rarely
+// ever useful; most people should use IsValid instead.
+func (s SubscriptionID) IsNull() bool { return Snowflake(s).IsNull() }
+
+func (s SubscriptionID) Time() time.Time   { return Snowflake(s).Time() }
+func (s SubscriptionID) Worker() uint8     { return Snowflake(s).Worker() }
+func (s SubscriptionID) PID() uint8        { return Snowflake(s).PID() }
+func (s SubscriptionID) Increment() uint16 { return Snowflake(s).Increment() }
+
 // TagID is the snowflake type for a TagID.
 type TagID Snowflake
 
@@ -352,6 +520,10 @@ func (s *TagID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmarshal
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s TagID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s TagID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s TagID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -376,6 +548,10 @@ func (s *TeamID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmarsha
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s TeamID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s TeamID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s TeamID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -400,6 +576,10 @@ func (s *UserID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmarsha
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s UserID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s UserID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s UserID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -424,6 +604,10 @@ func (s *WebhookID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmar
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s WebhookID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s WebhookID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s WebhookID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -448,6 +632,10 @@ func (s *EventID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmarsh
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s EventID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s EventID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s EventID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -472,6 +660,10 @@ func (s *EntityID) UnmarshalJSON(v []byte) error { return (*Snowflake)(s).Unmars
 // String returns the ID, or nothing if the snowflake isn't valid.
 func (s EntityID) String() string { return Snowflake(s).String() }
 
+// Append appends the ID to b, or nothing if the snowflake isn't valid. It is
+// the allocation-free equivalent of String.
+func (s EntityID) Append(b []byte) []byte { return Snowflake(s).Append(b) }
+
 // IsValid returns whether or not the snowflake is valid.
 func (s EntityID) IsValid() bool { return Snowflake(s).IsValid() }
 
@@ -1,10 +1,13 @@
 package discord
 
 import (
-	"encoding/json"
+	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
 // Timestamp has a valid zero-value, which can be checked using the IsValid()
@@ -65,6 +68,118 @@ func (t Timestamp) Time() time.Time {
 	return time.Time(t)
 }
 
+// Markdown formats the timestamp into a Discord timestamp markdown with the
+// given style. See TimestampMarkdown.
+func (t Timestamp) Markdown(style TimestampStyle) string {
+	return FormatTimestampMarkdown(t.Time(), style)
+}
+
+// TimestampStyle is the style of a timestamp markdown, which determines how
+// the Discord client displays it.
+//
+// https://discord.com/developers/docs/reference#message-formatting-timestamp-styles
+type TimestampStyle string
+
+const (
+	// DefaultTimestampStyle lets the client choose the style. It is currently
+	// the same as ShortDateTimeStyle.
+	DefaultTimestampStyle TimestampStyle = ""
+	// ShortTimeStyle displays the time as "16:20".
+	ShortTimeStyle TimestampStyle = "t"
+	// LongTimeStyle displays the time as "16:20:30".
+	LongTimeStyle TimestampStyle = "T"
+	// ShortDateStyle displays the time as "20/04/2021".
+	ShortDateStyle TimestampStyle = "d"
+	// LongDateStyle displays the time as "20 April 2021".
+	LongDateStyle TimestampStyle = "D"
+	// ShortDateTimeStyle displays the time as "20 April 2021 16:20".
+	ShortDateTimeStyle TimestampStyle = "f"
+	// LongDateTimeStyle displays the time as "Tuesday, 20 April 2021 16:20".
+	LongDateTimeStyle TimestampStyle = "F"
+	// RelativeTimeStyle displays the time as "2 months ago".
+	RelativeTimeStyle TimestampStyle = "R"
+)
+
+// IsValid returns true if the style is known.
+func (s TimestampStyle) IsValid() bool {
+	switch s {
+	case DefaultTimestampStyle,
+		ShortTimeStyle, LongTimeStyle,
+		ShortDateStyle, LongDateStyle,
+		ShortDateTimeStyle, LongDateTimeStyle,
+		RelativeTimeStyle:
+		return true
+	default:
+		return false
+	}
+}
+
+// TimestampMarkdown is a timestamp markdown in the form of <t:unix:STYLE>,
+// which the Discord client renders in the user's locale and timezone.
+type TimestampMarkdown struct {
+	Time  time.Time
+	Style TimestampStyle
+}
+
+var timestampMarkdownRegex = regexp.MustCompile(`<t:(-?\d+)(?::([tTdDfFR]))?>`)
+
+// FormatTimestampMarkdown formats the given time into a timestamp markdown
+// with the given style.
+func FormatTimestampMarkdown(t time.Time, style TimestampStyle) string {
+	return TimestampMarkdown{t, style}.String()
+}
+
+// ParseTimestampMarkdown parses a single timestamp markdown. The whole string
+// must be the markdown.
+func ParseTimestampMarkdown(markdown string) (TimestampMarkdown, error) {
+	match := timestampMarkdownRegex.FindStringSubmatch(markdown)
+	if match == nil || len(match[0]) != len(markdown) {
+		return TimestampMarkdown{}, fmt.Errorf("invalid timestamp markdown %q", markdown)
+	}
+
+	return newTimestampMarkdown(match)
+}
+
+// FindTimestampMarkdowns finds all timestamp markdowns in the given message
+// content in the order that they appear.
+func FindTimestampMarkdowns(content string) []TimestampMarkdown {
+	matches := timestampMarkdownRegex.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	timestamps := make([]TimestampMarkdown, 0, len(matches))
+	for _, match := range matches {
+		ts, err := newTimestampMarkdown(match)
+		if err == nil {
+			timestamps = append(timestamps, ts)
+		}
+	}
+
+	return timestamps
+}
+
+func newTimestampMarkdown(match []string) (TimestampMarkdown, error) {
+	unix, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return TimestampMarkdown{}, fmt.Errorf("invalid timestamp markdown time: %w", err)
+	}
+
+	return TimestampMarkdown{
+		Time:  time.Unix(unix, 0),
+		Style: TimestampStyle(match[2]),
+	}, nil
+}
+
+// String formats the timestamp into its markdown form.
+func (m TimestampMarkdown) String() string {
+	unix := strconv.FormatInt(m.Time.Unix(), 10)
+	if m.Style == DefaultTimestampStyle {
+		return "<t:" + unix + ">"
+	}
+	return "<t:" + unix + ":" + string(m.Style) + ">"
+}
+
 //
 
 type UnixTimestamp int64
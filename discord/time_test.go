@@ -0,0 +1,38 @@
+package discord
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestampMarkdown(t *testing.T) {
+	ts := time.Unix(1618953630, 0)
+
+	if s := FormatTimestampMarkdown(ts, RelativeTimeStyle); s != "<t:1618953630:R>" {
+		t.Fatalf("unexpected markdown %q", s)
+	}
+
+	if s := FormatTimestampMarkdown(ts, DefaultTimestampStyle); s != "<t:1618953630>" {
+		t.Fatalf("unexpected markdown %q", s)
+	}
+
+	m, err := ParseTimestampMarkdown("<t:1618953630:D>")
+	if err != nil {
+		t.Fatal("failed to parse:", err)
+	}
+	if !m.Time.Equal(ts) || m.Style != LongDateStyle {
+		t.Fatalf("unexpected parsed markdown %#v", m)
+	}
+
+	if _, err := ParseTimestampMarkdown("<t:1618953630:X>"); err == nil {
+		t.Fatal("expected error on invalid style")
+	}
+
+	found := FindTimestampMarkdowns("starts <t:1618953630:R>, ends <t:1618960000>")
+	if len(found) != 2 {
+		t.Fatalf("expected 2 timestamps, got %d", len(found))
+	}
+	if found[0].Style != RelativeTimeStyle || found[1].Style != DefaultTimestampStyle {
+		t.Fatalf("unexpected styles %q, %q", found[0].Style, found[1].Style)
+	}
+}
@@ -2,6 +2,7 @@ package discord
 
 import (
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -29,6 +30,17 @@ type User struct {
 
 	Banner Hash  `json:"banner,omitempty"`
 	Accent Color `json:"accent_color,omitempty"`
+
+	// AvatarDecoration is the data for the user's avatar decoration.
+	AvatarDecoration *AvatarDecoration `json:"avatar_decoration_data,omitempty"`
+	// Collectibles contains the collectibles that the user has equipped.
+	Collectibles *UserCollectibles `json:"collectibles,omitempty"`
+	// PrimaryGuild is the user's primary guild, which determines the guild
+	// tag displayed next to their name.
+	PrimaryGuild *UserPrimaryGuild `json:"primary_guild,omitempty"`
+	// Clan is the legacy name of PrimaryGuild. Discord still sends both
+	// fields with the same data.
+	Clan *UserPrimaryGuild `json:"clan,omitempty"`
 }
 
 // CreatedAt returns a time object representing when the user was created.
@@ -116,6 +128,82 @@ func (u User) BannerURLWithType(t ImageType) string {
 	return "https://cdn.discordapp.com/banners/" + u.ID.String() + "/" + t.format(u.Banner)
 }
 
+// AvatarDecorationURL returns the URL of the user's avatar decoration. If the
+// user has no avatar decoration, an empty string will be returned.
+func (u User) AvatarDecorationURL() string {
+	if u.AvatarDecoration == nil {
+		return ""
+	}
+	return u.AvatarDecoration.URL()
+}
+
+// https://discord.com/developers/docs/resources/user#avatar-decoration-data-object
+type AvatarDecoration struct {
+	// Asset is the avatar decoration hash.
+	Asset Hash `json:"asset"`
+	// SKUID is the ID of the avatar decoration's SKU.
+	SKUID SKUID `json:"sku_id"`
+}
+
+// URL returns the URL of the avatar decoration image. Avatar decorations are
+// always PNGs, which may be animated.
+func (d AvatarDecoration) URL() string {
+	return "https://cdn.discordapp.com/avatar-decoration-presets/" + PNGImage.format(d.Asset)
+}
+
+// https://discord.com/developers/docs/resources/user#collectibles
+type UserCollectibles struct {
+	// Nameplate is the user's nameplate, if any.
+	Nameplate *Nameplate `json:"nameplate,omitempty"`
+}
+
+// https://discord.com/developers/docs/resources/user#nameplate-nameplate-structure
+type Nameplate struct {
+	// SKUID is the ID of the nameplate's SKU.
+	SKUID SKUID `json:"sku_id"`
+	// Asset is the path to the nameplate asset.
+	Asset string `json:"asset"`
+	// Label is the label of this nameplate.
+	Label string `json:"label"`
+	// Palette is the background color of the nameplate, such as "crimson" or
+	// "berry".
+	Palette string `json:"palette"`
+}
+
+// URL returns the URL of the static nameplate image.
+func (n Nameplate) URL() string {
+	return "https://cdn.discordapp.com/assets/collectibles/" + n.Asset + "static.png"
+}
+
+// AnimatedURL returns the URL of the animated nameplate video.
+func (n Nameplate) AnimatedURL() string {
+	return "https://cdn.discordapp.com/assets/collectibles/" + n.Asset + "asset.webm"
+}
+
+// https://discord.com/developers/docs/resources/user#user-object-user-primary-guild
+type UserPrimaryGuild struct {
+	// IdentityGuildID is the ID of the user's primary guild.
+	IdentityGuildID GuildID `json:"identity_guild_id,omitempty"`
+	// IdentityEnabled specifies whether the user is displaying the primary
+	// guild's tag. It is nil if the tag was cleared due to a guild change.
+	IdentityEnabled *bool `json:"identity_enabled,omitempty"`
+	// Tag is the text of the user's guild tag, limited to 4 characters.
+	Tag string `json:"tag,omitempty"`
+	// Badge is the guild tag badge hash.
+	Badge Hash `json:"badge,omitempty"`
+}
+
+// BadgeURL returns the URL of the guild tag badge. If there is no badge, an
+// empty string will be returned.
+func (g UserPrimaryGuild) BadgeURL() string {
+	if g.Badge == "" || !g.IdentityGuildID.IsValid() {
+		return ""
+	}
+
+	return "https://cdn.discordapp.com/guild-tag-badges/" +
+		g.IdentityGuildID.String() + "/" + PNGImage.format(g.Badge)
+}
+
 type UserFlags uint32
 
 const NoFlag UserFlags = 0
@@ -198,11 +286,83 @@ type Activity struct {
 	Assets  *ActivityAssets  `json:"assets,omitempty"`
 	Secrets *ActivitySecrets `json:"secrets,omitempty"`
 
+	// Buttons contains the labels of the custom buttons shown in the rich
+	// presence, up to 2. The URLs of these buttons are in Metadata, which is
+	// only sent to the user themselves.
+	Buttons []string `json:"buttons,omitempty"`
+	// Metadata contains extra non-public data for the activity.
+	Metadata *ActivityMetadata `json:"metadata,omitempty"`
+
+	// Platform is the platform that the activity is running on.
+	Platform ActivityPlatform `json:"platform,omitempty"`
+
 	// Undocumented fields
 	SyncID    string `json:"sync_id,omitempty"`
 	SessionID string `json:"session_id,omitempty"`
 }
 
+// IsStreaming returns true if the activity is a stream, such as one on Twitch
+// or YouTube. The stream's URL is in URL.
+func (a Activity) IsStreaming() bool {
+	return a.Type == StreamingActivity
+}
+
+// IsSpotify returns true if the activity is the user listening to Spotify. The
+// track ID is in SyncID, the title in Details and the artists in State.
+func (a Activity) IsSpotify() bool {
+	if a.Type != ListeningActivity {
+		return false
+	}
+	if a.Party != nil && strings.HasPrefix(a.Party.ID, "spotify:") {
+		return true
+	}
+	return a.Name == "Spotify" && a.SyncID != ""
+}
+
+// SpotifyTrackURL returns the URL of the Spotify track being played, or an
+// empty string if the activity isn't a Spotify activity.
+func (a Activity) SpotifyTrackURL() URL {
+	if !a.IsSpotify() || a.SyncID == "" {
+		return ""
+	}
+	return "https://open.spotify.com/track/" + a.SyncID
+}
+
+// ActivityMetadata contains extra data for an activity.
+type ActivityMetadata struct {
+	// ButtonURLs are the URLs of the activity's buttons, in the same order as
+	// Activity.Buttons.
+	ButtonURLs []URL `json:"button_urls,omitempty"`
+	// ContextURI is the Spotify URI of the album or playlist being played, if
+	// any.
+	ContextURI string `json:"context_uri,omitempty"`
+	// AlbumID is the Spotify ID of the album being played.
+	AlbumID string `json:"album_id,omitempty"`
+	// ArtistIDs are the Spotify IDs of the artists being played.
+	ArtistIDs []string `json:"artist_ids,omitempty"`
+}
+
+// ActivityPlatform is the platform that an activity is running on.
+type ActivityPlatform string
+
+const (
+	DesktopActivityPlatform     ActivityPlatform = "desktop"
+	XboxActivityPlatform        ActivityPlatform = "xbox"
+	SamsungActivityPlatform     ActivityPlatform = "samsung"
+	IOSActivityPlatform         ActivityPlatform = "ios"
+	AndroidActivityPlatform     ActivityPlatform = "android"
+	EmbeddedActivityPlatform    ActivityPlatform = "embedded"
+	PS4ActivityPlatform         ActivityPlatform = "ps4"
+	PS5ActivityPlatform         ActivityPlatform = "ps5"
+	MetaQuestActivityPlatform   ActivityPlatform = "meta_quest"
+	NintendoActivityPlatform    ActivityPlatform = "nintendo"
+	WebActivityPlatform         ActivityPlatform = "web"
+	LinuxActivityPlatform       ActivityPlatform = "linux"
+	MacOSActivityPlatform       ActivityPlatform = "macos"
+	WindowsActivityPlatform     ActivityPlatform = "windows"
+	PlayStationActivityPlatform ActivityPlatform = "playstation"
+)
+
 type ActivityType uint8
 
 const (
@@ -216,6 +376,7 @@ const (
 
 type ActivityFlags uint32
 
+// https://discord.com/developers/docs/topics/gateway-events#activity-object-activity-flags
 const (
 	InstanceActivity ActivityFlags = 1 << iota
 	JoinActivity
@@ -223,8 +384,16 @@ const (
 	JoinRequestActivity
 	SyncActivity
 	PlayActivity
+	PartyPrivacyFriendsActivity
+	PartyPrivacyVoiceChannelActivity
+	EmbeddedActivity
 )
 
+// Has returns true if the flags contain all of the given flags.
+func (f ActivityFlags) Has(flags ActivityFlags) bool {
+	return HasFlag(uint64(f), uint64(flags))
+}
+
 type ActivityTimestamps struct {
 	Start UnixMsTimestamp `json:"start,omitempty"`
 	End   UnixMsTimestamp `json:"end,omitempty"`
@@ -235,6 +404,12 @@ type ActivityParty struct {
 	Size [2]int `json:"size,omitempty"` // [ current, max ]
 }
 
+// CurrentSize returns the current size of the party.
+func (p ActivityParty) CurrentSize() int { return p.Size[0] }
+
+// MaxSize returns the maximum size of the party.
+func (p ActivityParty) MaxSize() int { return p.Size[1] }
+
 type ActivityAssets struct {
 	LargeImage string `json:"large_image,omitempty"` // id
 	LargeText  string `json:"large_text,omitempty"`
@@ -0,0 +1,72 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestUserCollectibles(t *testing.T) {
+	const sample = `{
+		"id": "80351110224678912",
+		"username": "Nelly",
+		"avatar_decoration_data": {"asset": "a_fed43ab12698df65902ba06727e20c0e", "sku_id": "1144058522808614923"},
+		"collectibles": {
+			"nameplate": {
+				"sku_id": "2247558840304243311",
+				"asset": "nameplates/nameplates/twilight/",
+				"label": "",
+				"palette": "cobalt"
+			}
+		},
+		"primary_guild": {
+			"identity_guild_id": "1234647491267808778",
+			"identity_enabled": true,
+			"tag": "DISC",
+			"badge": "7d1734ae5a615e82bc7a4033b98fade8"
+		}
+	}`
+
+	var u User
+	if err := json.Unmarshal([]byte(sample), &u); err != nil {
+		t.Fatal("failed to unmarshal user:", err)
+	}
+
+	const decoration = "https://cdn.discordapp.com/avatar-decoration-presets/a_fed43ab12698df65902ba06727e20c0e.png"
+	if url := u.AvatarDecorationURL(); url != decoration {
+		t.Errorf("unexpected avatar decoration URL %q", url)
+	}
+
+	if u.Collectibles == nil || u.Collectibles.Nameplate == nil {
+		t.Fatalf("missing nameplate in %+v", u.Collectibles)
+	}
+
+	nameplate := u.Collectibles.Nameplate
+	if nameplate.SKUID != 2247558840304243311 || nameplate.Palette != "cobalt" {
+		t.Errorf("unexpected nameplate %+v", nameplate)
+	}
+	if url := nameplate.URL(); url != "https://cdn.discordapp.com/assets/collectibles/nameplates/nameplates/twilight/static.png" {
+		t.Errorf("unexpected nameplate URL %q", url)
+	}
+	if url := nameplate.AnimatedURL(); url != "https://cdn.discordapp.com/assets/collectibles/nameplates/nameplates/twilight/asset.webm" {
+		t.Errorf("unexpected animated nameplate URL %q", url)
+	}
+
+	guild := u.PrimaryGuild
+	if guild == nil || guild.Tag != "DISC" || guild.IdentityEnabled == nil || !*guild.IdentityEnabled {
+		t.Fatalf("unexpected primary guild %+v", guild)
+	}
+
+	const badge = "https://cdn.discordapp.com/guild-tag-badges/1234647491267808778/7d1734ae5a615e82bc7a4033b98fade8.png"
+	if url := guild.BadgeURL(); url != badge {
+		t.Errorf("unexpected badge URL %q", url)
+	}
+
+	// Users without them have no URLs.
+	if url := (User{}).AvatarDecorationURL(); url != "" {
+		t.Errorf("unexpected avatar decoration URL %q for a user without one", url)
+	}
+	if url := (UserPrimaryGuild{Tag: "DISC"}).BadgeURL(); url != "" {
+		t.Errorf("unexpected badge URL %q without a badge", url)
+	}
+}
@@ -35,9 +35,26 @@ func init() {
 		func() ws.Event { return new(GuildBanAddEvent) },
 		func() ws.Event { return new(GuildBanRemoveEvent) },
 		func() ws.Event { return new(GuildEmojisUpdateEvent) },
+		func() ws.Event { return new(GuildStickersUpdateEvent) },
 		func() ws.Event { return new(GuildIntegrationsUpdateEvent) },
+		func() ws.Event { return new(IntegrationCreateEvent) },
+		func() ws.Event { return new(IntegrationUpdateEvent) },
+		func() ws.Event { return new(IntegrationDeleteEvent) },
+		func() ws.Event { return new(AutoModerationRuleCreateEvent) },
+		func() ws.Event { return new(AutoModerationRuleUpdateEvent) },
+		func() ws.Event { return new(AutoModerationRuleDeleteEvent) },
+		func() ws.Event { return new(AutoModerationActionExecutionEvent) },
+		func() ws.Event { return new(EntitlementCreateEvent) },
+		func() ws.Event { return new(EntitlementUpdateEvent) },
+		func() ws.Event { return new(EntitlementDeleteEvent) },
+		func() ws.Event { return new(SubscriptionCreateEvent) },
+		func() ws.Event { return new(SubscriptionUpdateEvent) },
+		func() ws.Event { return new(SubscriptionDeleteEvent) },
 		func() ws.Event { return new(GuildMemberAddEvent) },
 		func() ws.Event { return new(GuildMemberRemoveEvent) },
+		func() ws.Event { return new(GuildJoinRequestCreateEvent) },
+		func() ws.Event { return new(GuildJoinRequestUpdateEvent) },
+		func() ws.Event { return new(GuildJoinRequestDeleteEvent) },
 		func() ws.Event { return new(GuildMemberUpdateEvent) },
 		func() ws.Event { return new(GuildMembersChunkEvent) },
 		func() ws.Event { return new(GuildRoleCreateEvent) },
@@ -53,6 +70,8 @@ func init() {
 		func() ws.Event { return new(MessageReactionRemoveEvent) },
 		func() ws.Event { return new(MessageReactionRemoveAllEvent) },
 		func() ws.Event { return new(MessageReactionRemoveEmojiEvent) },
+		func() ws.Event { return new(MessagePollVoteAddEvent) },
+		func() ws.Event { return new(MessagePollVoteRemoveEvent) },
 		func() ws.Event { return new(MessageAckEvent) },
 		func() ws.Event { return new(PresenceUpdateEvent) },
 		func() ws.Event { return new(PresencesReplaceEvent) },
@@ -61,6 +80,7 @@ func init() {
 		func() ws.Event { return new(UserUpdateEvent) },
 		func() ws.Event { return new(VoiceStateUpdateEvent) },
 		func() ws.Event { return new(VoiceServerUpdateEvent) },
+		func() ws.Event { return new(VoiceChannelEffectSendEvent) },
 		func() ws.Event { return new(WebhooksUpdateEvent) },
 		func() ws.Event { return new(InteractionCreateEvent) },
 		func() ws.Event { return new(UserGuildSettingsUpdateEvent) },
@@ -253,12 +273,98 @@ func (*GuildEmojisUpdateEvent) Op() ws.OpCode { return dispatchOp }
 // EventType implements Event.
 func (*GuildEmojisUpdateEvent) EventType() ws.EventType { return "GUILD_EMOJIS_UPDATE" }
 
+// Op implements Event. It always returns 0.
+func (*GuildStickersUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*GuildStickersUpdateEvent) EventType() ws.EventType { return "GUILD_STICKERS_UPDATE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildIntegrationsUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
 // EventType implements Event.
 func (*GuildIntegrationsUpdateEvent) EventType() ws.EventType { return "GUILD_INTEGRATIONS_UPDATE" }
 
+// Op implements Event. It always returns 0.
+func (*IntegrationCreateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*IntegrationCreateEvent) EventType() ws.EventType { return "INTEGRATION_CREATE" }
+
+// Op implements Event. It always returns 0.
+func (*IntegrationUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*IntegrationUpdateEvent) EventType() ws.EventType { return "INTEGRATION_UPDATE" }
+
+// Op implements Event. It always returns 0.
+func (*IntegrationDeleteEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*IntegrationDeleteEvent) EventType() ws.EventType { return "INTEGRATION_DELETE" }
+
+// Op implements Event. It always returns 0.
+func (*AutoModerationRuleCreateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*AutoModerationRuleCreateEvent) EventType() ws.EventType { return "AUTO_MODERATION_RULE_CREATE" }
+
+// Op implements Event. It always returns 0.
+func (*AutoModerationRuleUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*AutoModerationRuleUpdateEvent) EventType() ws.EventType { return "AUTO_MODERATION_RULE_UPDATE" }
+
+// Op implements Event. It always returns 0.
+func (*AutoModerationRuleDeleteEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*AutoModerationRuleDeleteEvent) EventType() ws.EventType { return "AUTO_MODERATION_RULE_DELETE" }
+
+// Op implements Event. It always returns 0.
+func (*AutoModerationActionExecutionEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*AutoModerationActionExecutionEvent) EventType() ws.EventType {
+	return "AUTO_MODERATION_ACTION_EXECUTION"
+}
+
+// Op implements Event. It always returns 0.
+func (*EntitlementCreateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*EntitlementCreateEvent) EventType() ws.EventType { return "ENTITLEMENT_CREATE" }
+
+// Op implements Event. It always returns 0.
+func (*EntitlementUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*EntitlementUpdateEvent) EventType() ws.EventType { return "ENTITLEMENT_UPDATE" }
+
+// Op implements Event. It always returns 0.
+func (*EntitlementDeleteEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*EntitlementDeleteEvent) EventType() ws.EventType { return "ENTITLEMENT_DELETE" }
+
+// Op implements Event. It always returns 0.
+func (*SubscriptionCreateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*SubscriptionCreateEvent) EventType() ws.EventType { return "SUBSCRIPTION_CREATE" }
+
+// Op implements Event. It always returns 0.
+func (*SubscriptionUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*SubscriptionUpdateEvent) EventType() ws.EventType { return "SUBSCRIPTION_UPDATE" }
+
+// Op implements Event. It always returns 0.
+func (*SubscriptionDeleteEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*SubscriptionDeleteEvent) EventType() ws.EventType { return "SUBSCRIPTION_DELETE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildMemberAddEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -271,6 +377,24 @@ func (*GuildMemberRemoveEvent) Op() ws.OpCode { return dispatchOp }
 // EventType implements Event.
 func (*GuildMemberRemoveEvent) EventType() ws.EventType { return "GUILD_MEMBER_REMOVE" }
 
+// Op implements Event. It always returns 0.
+func (*GuildJoinRequestCreateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*GuildJoinRequestCreateEvent) EventType() ws.EventType { return "GUILD_JOIN_REQUEST_CREATE" }
+
+// Op implements Event. It always returns 0.
+func (*GuildJoinRequestUpdateEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*GuildJoinRequestUpdateEvent) EventType() ws.EventType { return "GUILD_JOIN_REQUEST_UPDATE" }
+
+// Op implements Event. It always returns 0.
+func (*GuildJoinRequestDeleteEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*GuildJoinRequestDeleteEvent) EventType() ws.EventType { return "GUILD_JOIN_REQUEST_DELETE" }
+
 // Op implements Event. It always returns 0.
 func (*GuildMemberUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -363,6 +487,18 @@ func (*MessageReactionRemoveEmojiEvent) EventType() ws.EventType {
 	return "MESSAGE_REACTION_REMOVE_EMOJI"
 }
 
+// Op implements Event. It always returns 0.
+func (*MessagePollVoteAddEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*MessagePollVoteAddEvent) EventType() ws.EventType { return "MESSAGE_POLL_VOTE_ADD" }
+
+// Op implements Event. It always returns 0.
+func (*MessagePollVoteRemoveEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*MessagePollVoteRemoveEvent) EventType() ws.EventType { return "MESSAGE_POLL_VOTE_REMOVE" }
+
 // Op implements Event. It always returns 0.
 func (*MessageAckEvent) Op() ws.OpCode { return dispatchOp }
 
@@ -411,6 +547,12 @@ func (*VoiceServerUpdateEvent) Op() ws.OpCode { return dispatchOp }
 // EventType implements Event.
 func (*VoiceServerUpdateEvent) EventType() ws.EventType { return "VOICE_SERVER_UPDATE" }
 
+// Op implements Event. It always returns 0.
+func (*VoiceChannelEffectSendEvent) Op() ws.OpCode { return dispatchOp }
+
+// EventType implements Event.
+func (*VoiceChannelEffectSendEvent) EventType() ws.EventType { return "VOICE_CHANNEL_EFFECT_SEND" }
+
 // Op implements Event. It always returns 0.
 func (*WebhooksUpdateEvent) Op() ws.OpCode { return dispatchOp }
 
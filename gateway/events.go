@@ -325,6 +325,14 @@ type GuildEmojisUpdateEvent struct {
 	Emojis  []discord.Emoji `json:"emojis"`
 }
 
+// GuildStickersUpdateEvent is a dispatch event.
+//
+// https://discord.com/developers/docs/topics/gateway-events#guild-stickers-update
+type GuildStickersUpdateEvent struct {
+	GuildID  discord.GuildID   `json:"guild_id"`
+	Stickers []discord.Sticker `json:"stickers"`
+}
+
 // GuildIntegrationsUpdateEvent is a dispatch event.
 //
 // https://discord.com/developers/docs/topics/gateway#guilds
@@ -332,6 +340,142 @@ type GuildIntegrationsUpdateEvent struct {
 	GuildID discord.GuildID `json:"guild_id"`
 }
 
+// IntegrationCreateEvent is a dispatch event.
+//
+// https://discord.com/developers/docs/topics/gateway-events#integration-create
+type IntegrationCreateEvent struct {
+	discord.Integration
+	GuildID discord.GuildID `json:"guild_id"`
+}
+
+// IntegrationUpdateEvent is a dispatch event.
+//
+// https://discord.com/developers/docs/topics/gateway-events#integration-update
+type IntegrationUpdateEvent struct {
+	discord.Integration
+	GuildID discord.GuildID `json:"guild_id"`
+}
+
+// IntegrationDeleteEvent is a dispatch event.
+//
+// https://discord.com/developers/docs/topics/gateway-events#integration-delete
+type IntegrationDeleteEvent struct {
+	ID      discord.IntegrationID `json:"id"`
+	GuildID discord.GuildID       `json:"guild_id"`
+	// ApplicationID is the id of the bot/OAuth2 application for this
+	// Discord integration.
+	ApplicationID discord.AppID `json:"application_id,omitempty"`
+}
+
+// AutoModerationRuleCreateEvent is a dispatch event.
+//
+// https://discord.com/developers/docs/topics/gateway-events#auto-moderation-rule-create
+type AutoModerationRuleCreateEvent struct {
+	discord.AutoModerationRule
+}
+
+// AutoModerationRuleUpdateEvent is a dispatch event.
+//
+// https://discord.com/developers/docs/topics/gateway-events#auto-moderation-rule-update
+type AutoModerationRuleUpdateEvent struct {
+	discord.AutoModerationRule
+}
+
+// AutoModerationRuleDeleteEvent is a dispatch event.
+//
+// https://discord.com/developers/docs/topics/gateway-events#auto-moderation-rule-delete
+type AutoModerationRuleDeleteEvent struct {
+	discord.AutoModerationRule
+}
+
+// AutoModerationActionExecutionEvent is a dispatch event. It is sent when a
+// rule is triggered and an action is executed, such as when a message is
+// blocked.
+//
+// https://discord.com/developers/docs/topics/gateway-events#auto-moderation-action-execution
+type AutoModerationActionExecutionEvent struct {
+	GuildID discord.GuildID `json:"guild_id"`
+	// Action is the action which was executed.
+	Action discord.AutoModerationAction `json:"action"`
+	// RuleID is the id of the rule which the action belongs to.
+	RuleID discord.AutoModerationRuleID `json:"rule_id"`
+	// RuleTriggerType is the trigger type of the rule which was triggered.
+	RuleTriggerType discord.AutoModerationTriggerType `json:"rule_trigger_type"`
+	// UserID is the id of the user which generated the content which
+	// triggered the rule.
+	UserID discord.UserID `json:"user_id"`
+	// ChannelID is the id of the channel in which the user content was
+	// posted, if any.
+	ChannelID discord.ChannelID `json:"channel_id,omitempty"`
+	// MessageID is the id of any user message which the content belongs to.
+	// It is not set if the message was blocked or the content was not part of
+	// any message.
+	MessageID discord.MessageID `json:"message_id,omitempty"`
+	// AlertSystemMessageID is the id of any system auto moderation messages
+	// posted as a result of this action.
+	AlertSystemMessageID discord.MessageID `json:"alert_system_message_id,omitempty"`
+	// Content is the user-generated text content. It is empty without the
+	// MESSAGE_CONTENT intent.
+	Content string `json:"content"`
+	// MatchedKeyword is the word or phrase configured in the rule that
+	// triggered it, if any.
+	MatchedKeyword string `json:"matched_keyword,omitempty"`
+	// MatchedContent is the substring in the content that triggered the rule.
+	// It is empty without the MESSAGE_CONTENT intent.
+	MatchedContent string `json:"matched_content,omitempty"`
+}
+
+// EntitlementCreateEvent is a dispatch event. It is sent when a user
+// subscribes to a SKU.
+//
+// https://discord.com/developers/docs/topics/gateway-events#entitlement-create
+type EntitlementCreateEvent struct {
+	discord.Entitlement
+}
+
+// EntitlementUpdateEvent is a dispatch event. It is sent when a user's
+// subscription renews for the next billing period, in which case EndsAt is
+// updated.
+//
+// https://discord.com/developers/docs/topics/gateway-events#entitlement-update
+type EntitlementUpdateEvent struct {
+	discord.Entitlement
+}
+
+// EntitlementDeleteEvent is a dispatch event. It is sent when a user's
+// entitlement is deleted, such as when Discord issues a refund or the
+// entitlement is removed using the API.
+//
+// https://discord.com/developers/docs/topics/gateway-events#entitlement-delete
+type EntitlementDeleteEvent struct {
+	discord.Entitlement
+}
+
+// SubscriptionCreateEvent is a dispatch event. It is sent when a subscription
+// for a SKU is created. Status may be inactive until the payment succeeds, in
+// which case a SubscriptionUpdateEvent follows.
+//
+// https://discord.com/developers/docs/topics/gateway-events#subscription-create
+type SubscriptionCreateEvent struct {
+	discord.Subscription
+}
+
+// SubscriptionUpdateEvent is a dispatch event. It is sent when a subscription
+// is updated, such as when it renews or is canceled.
+//
+// https://discord.com/developers/docs/topics/gateway-events#subscription-update
+type SubscriptionUpdateEvent struct {
+	discord.Subscription
+}
+
+// SubscriptionDeleteEvent is a dispatch event. It is sent when a subscription
+// is deleted.
+//
+// https://discord.com/developers/docs/topics/gateway-events#subscription-delete
+type SubscriptionDeleteEvent struct {
+	discord.Subscription
+}
+
 // GuildMemberAddEvent is a dispatch event.
 //
 // https://discord.com/developers/docs/topics/gateway#guilds
@@ -348,6 +492,32 @@ type GuildMemberRemoveEvent struct {
 	User    discord.User    `json:"user"`
 }
 
+// GuildJoinRequestCreateEvent is a dispatch event. It is sent when a user
+// starts a request to join a guild that has Membership Screening enabled. This
+// event is undocumented.
+type GuildJoinRequestCreateEvent struct {
+	Status  discord.GuildJoinRequestStatus `json:"status"`
+	Request discord.GuildJoinRequest       `json:"request"`
+	GuildID discord.GuildID                `json:"guild_id"`
+}
+
+// GuildJoinRequestUpdateEvent is a dispatch event. It is sent when a request to
+// join a guild is submitted, approved or rejected. This event is undocumented.
+type GuildJoinRequestUpdateEvent struct {
+	Status  discord.GuildJoinRequestStatus `json:"status"`
+	Request discord.GuildJoinRequest       `json:"request"`
+	GuildID discord.GuildID                `json:"guild_id"`
+}
+
+// GuildJoinRequestDeleteEvent is a dispatch event. It is sent when a request to
+// join a guild is deleted, such as when the user leaves before being approved.
+// This event is undocumented.
+type GuildJoinRequestDeleteEvent struct {
+	ID      discord.Snowflake `json:"id"`
+	UserID  discord.UserID    `json:"user_id"`
+	GuildID discord.GuildID   `json:"guild_id"`
+}
+
 // GuildMemberUpdateEvent is a dispatch event.
 //
 // https://discord.com/developers/docs/topics/gateway#guilds
@@ -476,9 +646,10 @@ type InviteCreateEvent struct {
 	GuildID   discord.GuildID   `json:"guild_id,omitempty"`
 
 	// Similar to discord.Invite
-	Inviter    *discord.User          `json:"inviter,omitempty"`
-	Target     *discord.User          `json:"target_user,omitempty"`
-	TargetType discord.InviteUserType `json:"target_user_type,omitempty"`
+	Inviter           *discord.User            `json:"inviter,omitempty"`
+	TargetType        discord.InviteTargetType `json:"target_type,omitempty"`
+	Target            *discord.User            `json:"target_user,omitempty"`
+	TargetApplication *discord.Application     `json:"target_application,omitempty"`
 
 	discord.InviteMetadata
 }
@@ -570,6 +741,32 @@ type MessageReactionRemoveEmojiEvent struct {
 	GuildID   discord.GuildID   `json:"guild_id,omitempty"`
 }
 
+// MessagePollVoteAddEvent is a dispatch event. It is sent when a user votes
+// on a poll. If the poll allows multiple selections, one event is sent per
+// answer.
+//
+// https://discord.com/developers/docs/topics/gateway-events#message-poll-vote-add
+type MessagePollVoteAddEvent struct {
+	UserID    discord.UserID    `json:"user_id"`
+	ChannelID discord.ChannelID `json:"channel_id"`
+	MessageID discord.MessageID `json:"message_id"`
+	GuildID   discord.GuildID   `json:"guild_id,omitempty"`
+	AnswerID  int               `json:"answer_id"`
+}
+
+// MessagePollVoteRemoveEvent is a dispatch event. It is sent when a user
+// removes their vote on a poll. If the poll allows multiple selections, one
+// event is sent per answer.
+//
+// https://discord.com/developers/docs/topics/gateway-events#message-poll-vote-remove
+type MessagePollVoteRemoveEvent struct {
+	UserID    discord.UserID    `json:"user_id"`
+	ChannelID discord.ChannelID `json:"channel_id"`
+	MessageID discord.MessageID `json:"message_id"`
+	GuildID   discord.GuildID   `json:"guild_id,omitempty"`
+	AnswerID  int               `json:"answer_id"`
+}
+
 // MessageAckEvent is a dispatch event.
 type MessageAckEvent struct {
 	MessageID discord.MessageID `json:"message_id"`
@@ -593,7 +790,10 @@ type PresencesReplaceEvent []PresenceUpdateEvent
 // used for current user's presence updates.
 type SessionsReplaceEvent []UserSession
 
-// TypingStartEvent is a dispatch event.
+// TypingStartEvent is a dispatch event. Member is only present if the user
+// started typing in a guild channel.
+//
+// https://discord.com/developers/docs/topics/gateway-events#typing-start
 type TypingStartEvent struct {
 	ChannelID discord.ChannelID     `json:"channel_id"`
 	UserID    discord.UserID        `json:"user_id"`
@@ -624,6 +824,43 @@ type VoiceServerUpdateEvent struct {
 	Endpoint string          `json:"endpoint"`
 }
 
+// VoiceChannelEffectSendEvent is a dispatch event. It is sent when someone
+// sends an effect, such as an emoji reaction or a soundboard sound, in a voice
+// channel that the current user is connected to.
+//
+// https://discord.com/developers/docs/topics/gateway-events#voice-channel-effect-send
+type VoiceChannelEffectSendEvent struct {
+	ChannelID discord.ChannelID `json:"channel_id"`
+	GuildID   discord.GuildID   `json:"guild_id"`
+	UserID    discord.UserID    `json:"user_id"`
+	// Emoji is the emoji sent, for emoji reaction and soundboard effects.
+	Emoji *discord.Emoji `json:"emoji,omitempty"`
+	// AnimationType is the type of emoji animation, for emoji reaction and
+	// soundboard effects.
+	AnimationType *VoiceChannelEffectAnimationType `json:"animation_type,omitempty"`
+	// AnimationID is the ID of the emoji animation, for emoji reaction and
+	// soundboard effects.
+	AnimationID int `json:"animation_id,omitempty"`
+	// SoundID is the ID of the soundboard sound, for soundboard effects.
+	SoundID discord.Snowflake `json:"sound_id,omitempty"`
+	// SoundVolume is the volume of the soundboard sound from 0 to 1, for
+	// soundboard effects.
+	SoundVolume float64 `json:"sound_volume,omitempty"`
+}
+
+// VoiceChannelEffectAnimationType is the type of animation of a voice channel
+// effect.
+//
+// https://discord.com/developers/docs/topics/gateway-events#voice-channel-effect-send-animation-types
+type VoiceChannelEffectAnimationType uint8
+
+const (
+	// PremiumAnimation is a fun animation, sent by a Nitro subscriber.
+	PremiumAnimation VoiceChannelEffectAnimationType = iota
+	// BasicAnimation is the standard animation.
+	BasicAnimation
+)
+
 // WebhooksUpdateEvent is a dispatch event.
 //
 // https://discord.com/developers/docs/topics/gateway#webhooks
@@ -675,6 +912,8 @@ type ReadyEvent struct {
 
 	User      discord.User `json:"user"`
 	SessionID string       `json:"session_id"`
+	// ResumeGatewayURL is the gateway URL to use when resuming the session.
+	ResumeGatewayURL string `json:"resume_gateway_url,omitempty"`
 
 	PrivateChannels []discord.Channel  `json:"private_channels"`
 	Guilds          []GuildCreateEvent `json:"guilds"`
@@ -10,6 +10,7 @@ package gateway
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"net/url"
 	"sync"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/internal/lazytime"
+	"github.com/diamondburned/arikawa/v3/utils/logging"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
 
@@ -71,6 +73,9 @@ type State struct {
 	Identifier Identifier
 	SessionID  string
 	Sequence   int64
+	// ResumeURL is the gateway URL to resume the session on, with the gateway
+	// parameters added. It is given by Discord in the Ready event.
+	ResumeURL string
 }
 
 // Gateway describes an instance that handles the Discord gateway. It is
@@ -86,6 +91,7 @@ type Gateway struct {
 	beatMutex  sync.Mutex
 	sentBeat   time.Time
 	echoBeat   time.Time
+	health     Health // guarded by beatMutex; beat times are filled in Health()
 	retryTimer lazytime.Timer
 }
 
@@ -133,6 +139,20 @@ func NewCustom(gatewayURL, token string) *Gateway {
 	return NewCustomWithIdentifier(gatewayURL, DefaultIdentifier(token), nil)
 }
 
+// EnableLazyEvents, if true, makes new gateways deliver dispatch events as
+// *ws.LazyEvent instead of decoding them right away. When the events are
+// dispatched using ophandler.Loop, as Session does, they're only decoded if
+// there's a handler for their type, so events that nobody handles cost very
+// little. Note that State handles all events, so this is only useful for
+// Sessions without a State. Ready and Resumed events are always decoded.
+var EnableLazyEvents = false
+
+func isLazyEvent(code ws.OpCode, t ws.EventType) bool {
+	return code == dispatchOp &&
+		t != (*ReadyEvent)(nil).EventType() &&
+		t != (*ResumedEvent)(nil).EventType()
+}
+
 // DefaultGatewayOpts contains the default options to be used for connecting to
 // the gateway.
 var DefaultGatewayOpts = ws.GatewayOpts{
@@ -163,16 +183,35 @@ func NewCustomWithIdentifier(gatewayURL string, id Identifier, opts *ws.GatewayO
 }
 
 // NewFromState creates a new gateway from the given state and optionally
-// gateway options. If opts is nil, then DefaultGatewayOpts is used.
+// gateway options. If opts is nil, then DefaultGatewayOpts is used. If the
+// state has a resumable session with a ResumeURL, then the gateway connects to
+// the ResumeURL instead of gatewayURL.
 func NewFromState(gatewayURL string, state State, opts *ws.GatewayOpts) *Gateway {
 	if opts == nil {
 		opts = &DefaultGatewayOpts
 	}
 
-	gw := ws.NewGateway(ws.NewWebsocket(ws.NewCodec(OpUnmarshalers), gatewayURL), opts)
+	if state.ResumeURL != "" && state.ResumeState().Resumable() {
+		gatewayURL = state.ResumeURL
+	}
+
+	if shard := state.Identifier.Shard; shard != nil {
+		cpy := *opts
+		cpy.LogAttrs = append(cpy.LogAttrs[:len(cpy.LogAttrs):len(cpy.LogAttrs)],
+			slog.Int(logging.KeyShard, shard.ShardID()))
+		opts = &cpy
+	}
+
+	codec := ws.NewCodec(OpUnmarshalers)
+	if EnableLazyEvents {
+		codec.Lazy = isLazyEvent
+	}
+
+	gw := ws.NewGateway(ws.NewWebsocket(codec, gatewayURL), opts)
 	return &Gateway{
-		gateway: gw,
-		state:   state,
+		gateway:    gw,
+		state:      state,
+		retryTimer: lazytime.Timer{Clock: opts.Clock},
 	}
 }
 
@@ -229,6 +268,63 @@ func (g *Gateway) Latency() time.Duration {
 	return g.echoBeat.Sub(g.sentBeat)
 }
 
+// Health describes the health of a gateway connection.
+type Health struct {
+	// Connected is true if the gateway has received a Ready or Resumed event
+	// since it last connected.
+	Connected bool
+	// Resumed is true if the current connection resumed an older session
+	// instead of identifying a new one.
+	Resumed bool
+	// Resumable is true if the gateway has a session that it can resume after
+	// it disconnects.
+	Resumable bool
+	// LastHeartbeatAck is the time that the last heartbeat was acknowledged.
+	// It is zero if no heartbeat has been acknowledged yet.
+	LastHeartbeatAck time.Time
+	// Latency is the latency of the last acknowledged heartbeat.
+	Latency time.Duration
+
+	// now is the time that the health was read at, according to the clock
+	// of the gateway.
+	now time.Time
+}
+
+// SinceHeartbeatAck returns the duration between the last heartbeat
+// acknowledgement and the time that the health was read at, according to the
+// clock of the gateway, or 0 if no heartbeat was acknowledged.
+func (h Health) SinceHeartbeatAck() time.Duration {
+	if h.LastHeartbeatAck.IsZero() {
+		return 0
+	}
+	if h.now.IsZero() {
+		return time.Since(h.LastHeartbeatAck)
+	}
+	return h.now.Sub(h.LastHeartbeatAck)
+}
+
+// Health returns the current health of the gateway. It is safe to call while
+// the gateway is running.
+func (g *Gateway) Health() Health {
+	g.beatMutex.Lock()
+	defer g.beatMutex.Unlock()
+
+	health := g.health
+	health.now = g.gateway.Clock().Now()
+	health.LastHeartbeatAck = g.echoBeat
+	if !g.echoBeat.IsZero() {
+		health.Latency = g.echoBeat.Sub(g.sentBeat)
+	}
+
+	return health
+}
+
+func (g *Gateway) setHealth(f func(h *Health)) {
+	g.beatMutex.Lock()
+	f(&g.health)
+	g.beatMutex.Unlock()
+}
+
 // LastError returns the last error that the gateway has received. It only
 // returns a valid error if the gateway's event loop as exited. If the event
 // loop hasn't been started AND stopped, the function will panic.
@@ -317,6 +413,8 @@ type gatewayImpl struct {
 func (g *gatewayImpl) invalidate() {
 	g.state.SessionID = ""
 	g.state.Sequence = 0
+	g.state.ResumeURL = ""
+	g.setHealth(func(h *Health) { h.Resumable = false })
 }
 
 // sendIdentify sends off the Identify command with the Gateway's IdentifyData
@@ -344,6 +442,9 @@ func (g *gatewayImpl) OnOp(ctx context.Context, op ws.Op) bool {
 
 	switch data := op.Data.(type) {
 	case *ws.CloseEvent:
+		g.gateway.Logger().Info("gateway closed, reconnecting", "code", data.Code, logging.KeyError, data.Err)
+		g.setHealth(func(h *Health) { h.Connected = false })
+
 		if data.Code == CodeInvalidSequence {
 			// Invalid sequence.
 			g.invalidate()
@@ -355,12 +456,12 @@ func (g *gatewayImpl) OnOp(ctx context.Context, op ws.Op) bool {
 		g.heartrate = data.HeartbeatInterval.Duration()
 		g.gateway.ResetHeartbeat(g.heartrate)
 
-		now := time.Now()
+		now := g.gateway.Clock().Now()
 
 		g.beatMutex.Lock()
 		// Determine that we shouldn't reconnect if the last time we've received
 		// a heart beat was over (deadbeatDuration) ago.
-		resumable := g.echoBeat.IsZero() || time.Since(g.echoBeat) < deadbeatDuration
+		resumable := g.echoBeat.IsZero() || now.Sub(g.echoBeat) < deadbeatDuration
 		// Reset gateway times.
 		g.echoBeat = time.Time{}
 		g.sentBeat = time.Time{}
@@ -385,6 +486,8 @@ func (g *gatewayImpl) OnOp(ctx context.Context, op ws.Op) bool {
 		}
 
 	case *InvalidSessionEvent:
+		g.gateway.Logger().Warn("gateway session invalidated", "resumable", bool(*data))
+
 		// Wipe the session state.
 		g.invalidate()
 
@@ -416,21 +519,29 @@ func (g *gatewayImpl) OnOp(ctx context.Context, op ws.Op) bool {
 		g.useLastSentBeat()
 
 	case *ReconnectEvent:
+		g.gateway.Logger().Info("gateway requested reconnect")
 		g.gateway.QueueReconnect()
 
 	case *ReadyEvent:
+		g.gateway.Logger().Info("gateway ready", logging.KeyEventType, op.Type)
 		g.state.SessionID = data.SessionID
+		if data.ResumeGatewayURL != "" {
+			g.state.ResumeURL = AddGatewayParams(data.ResumeGatewayURL)
+		}
 		g.useLastSentBeat()
+		g.setHealth(func(h *Health) { *h = Health{Connected: true, Resumable: true} })
 
 	case *ResumedEvent:
+		g.gateway.Logger().Info("gateway resumed", logging.KeyEventType, op.Type)
 		g.useLastSentBeat()
+		g.setHealth(func(h *Health) { *h = Health{Connected: true, Resumed: true, Resumable: true} })
 	}
 
 	return true
 }
 
 func (g *gatewayImpl) useLastSentBeat() {
-	now := time.Now()
+	now := g.gateway.Clock().Now()
 
 	g.beatMutex.Lock()
 	g.sentBeat = g.lastSentBeat
@@ -457,7 +568,7 @@ func (g *gatewayImpl) isDead() bool {
 
 // SendHeartbeat sends a heartbeat with the gateway's current sequence.
 func (g *gatewayImpl) SendHeartbeat(ctx context.Context) {
-	g.lastSentBeat = time.Now()
+	g.lastSentBeat = g.gateway.Clock().Now()
 
 	// TODO: move this to ws.Gateway
 	if g.isDead() {
@@ -477,6 +588,11 @@ func (g *gatewayImpl) SendHeartbeat(ctx context.Context) {
 // Close closes the state.
 func (g *gatewayImpl) Close() error {
 	g.retryTimer.Stop()
-	g.invalidate()
+	// Closing gracefully makes Discord invalidate the session, but it can
+	// still be resumed otherwise.
+	if g.gateway.Opts().AlwaysCloseGracefully {
+		g.invalidate()
+	}
+	g.setHealth(func(h *Health) { h.Connected = false })
 	return nil
 }
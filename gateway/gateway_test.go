@@ -1,8 +1,11 @@
 package gateway
 
 import (
+	"bytes"
 	"context"
 	"log"
+	"log/slog"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -11,6 +14,8 @@ import (
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/internal/testenv"
+	"github.com/diamondburned/arikawa/v3/utils/clock"
+	"github.com/diamondburned/arikawa/v3/utils/logging"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
 
@@ -26,6 +31,117 @@ func doLog() {
 	})
 }
 
+func TestGatewayLogShard(t *testing.T) {
+	var buf bytes.Buffer
+	logging.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer logging.SetLogger(nil)
+
+	id := DefaultIdentifier("")
+	id.SetShard(2, 4)
+
+	g := NewCustomWithIdentifier("", id, nil)
+	g.gateway.Logger().Info("hello")
+
+	if !strings.Contains(buf.String(), "shard=2") {
+		t.Fatalf("log output is missing the shard ID: %q", buf.String())
+	}
+
+	if g.gateway.Logger() != g.gateway.Logger() {
+		t.Fatal("gateway logger is not cached")
+	}
+
+	if len(DefaultGatewayOpts.LogAttrs) != 0 {
+		t.Fatal("DefaultGatewayOpts was modified")
+	}
+}
+
+func TestGatewayHealth(t *testing.T) {
+	g := NewCustomWithIdentifier("", DefaultIdentifier(""), nil)
+	impl := &gatewayImpl{Gateway: g}
+
+	if h := g.Health(); h.Connected || h.Resumable {
+		t.Fatalf("new gateway is unexpectedly healthy: %+v", h)
+	}
+
+	impl.OnOp(context.Background(), ws.Op{Code: dispatchOp, Data: &ReadyEvent{SessionID: "a"}})
+
+	h := g.Health()
+	if !h.Connected || h.Resumed || !h.Resumable {
+		t.Fatalf("unexpected health after Ready: %+v", h)
+	}
+	if h.LastHeartbeatAck.IsZero() {
+		t.Fatal("Ready wasn't counted as a heartbeat acknowledgement")
+	}
+
+	impl.OnOp(context.Background(), ws.Op{Code: dispatchOp, Data: &ResumedEvent{}})
+
+	if h := g.Health(); !h.Connected || !h.Resumed {
+		t.Fatalf("unexpected health after Resumed: %+v", h)
+	}
+
+	impl.Close()
+
+	if h := g.Health(); h.Connected || h.Resumable {
+		t.Fatalf("unexpected health after Close: %+v", h)
+	}
+}
+
+func TestGatewayHealthClock(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1000, 0))
+
+	opts := DefaultGatewayOpts
+	opts.Clock = fake
+
+	g := NewCustomWithIdentifier("", DefaultIdentifier(""), &opts)
+	impl := &gatewayImpl{Gateway: g}
+
+	impl.OnOp(context.Background(), ws.Op{Code: dispatchOp, Data: &ReadyEvent{SessionID: "a"}})
+	fake.Advance(time.Minute)
+
+	if since := g.Health().SinceHeartbeatAck(); since != time.Minute {
+		t.Fatalf("expected a minute since the last acknowledgement, got %v", since)
+	}
+}
+
+func TestFileResumeStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileResumeStore(filepath.Join(t.TempDir(), "resume"))
+
+	rs, err := store.LoadResume(ctx, 1)
+	if err != nil {
+		t.Fatal("failed to load missing resume state:", err)
+	}
+	if rs.Resumable() {
+		t.Fatalf("missing resume state is resumable: %+v", rs)
+	}
+
+	saved := ResumeState{
+		SessionID: "session",
+		Sequence:  42,
+		ResumeURL: AddGatewayParams("wss://resume.discord.gg"),
+	}
+
+	if err := store.SaveResume(ctx, 1, saved); err != nil {
+		t.Fatal("failed to save resume state:", err)
+	}
+
+	if rs, err := store.LoadResume(ctx, 1); err != nil || rs != saved {
+		t.Fatalf("unexpected loaded resume state %+v (err: %v)", rs, err)
+	}
+
+	if rs, _ := store.LoadResume(ctx, 0); rs.Resumable() {
+		t.Fatalf("shard 0 unexpectedly has shard 1's resume state: %+v", rs)
+	}
+
+	if err := store.SaveResume(ctx, 1, ResumeState{}); err != nil {
+		t.Fatal("failed to delete resume state:", err)
+	}
+
+	if rs, _ := store.LoadResume(ctx, 1); rs.Resumable() {
+		t.Fatalf("deleted resume state is still resumable: %+v", rs)
+	}
+}
+
 func TestURL(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	t.Cleanup(cancel)
@@ -0,0 +1,333 @@
+// Package gatewaytest provides a fake Discord gateway server for testing bots
+// without connecting to Discord.
+//
+// The Server speaks the gateway protocol over a real websocket, so a Gateway
+// or a Session can connect to it like it would to Discord. It handles the
+// connection lifecycle on its own: it sends Hello, answers Identify with
+// Ready, Resume with Resumed and heartbeats with acknowledgements. Tests can
+// then inject arbitrary events using Dispatch and assert on the commands that
+// the client sent using Commands and WaitCommand.
+package gatewaytest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/session"
+	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+// ErrNotConnected is returned by Dispatch if no client is connected.
+var ErrNotConnected = errors.New("no client is connected to the gateway")
+
+// DefaultHeartbeatInterval is the default heartbeat interval that the Server
+// tells clients to use.
+var DefaultHeartbeatInterval = 45 * time.Second
+
+// Server is a fake Discord gateway server. All its methods are thread-safe.
+type Server struct {
+	*httptest.Server
+
+	// User is the user sent in the Ready event.
+	User discord.User
+	// SessionID is the session ID sent in the Ready event.
+	SessionID string
+	// HeartbeatInterval is the heartbeat interval sent in the Hello event.
+	HeartbeatInterval time.Duration
+
+	upgrader websocket.Upgrader
+
+	mutex    sync.Mutex
+	conns    map[*serverConn]struct{}
+	sequence int64
+	commands []ws.Op
+	notify   chan struct{} // closed and replaced when a command arrives
+}
+
+type serverConn struct {
+	mutex sync.Mutex
+	conn  *websocket.Conn
+	ready bool // guarded by Server.mutex
+}
+
+func (c *serverConn) write(op ws.Op) error {
+	b, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.conn.WriteMessage(websocket.TextMessage, b)
+}
+
+// NewServer starts a new fake gateway server. The caller should call Close
+// once the server is no longer needed.
+func NewServer() *Server {
+	s := &Server{
+		User: discord.User{
+			ID:       discord.UserID(discord.NewSnowflake(time.Now())),
+			Username: "gatewaytest",
+			Bot:      true,
+		},
+		SessionID:         "gatewaytest",
+		HeartbeatInterval: DefaultHeartbeatInterval,
+		conns:             make(map[*serverConn]struct{}),
+		notify:            make(chan struct{}),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// GatewayURL returns the websocket URL of the server with the gateway
+// parameters added.
+func (s *Server) GatewayURL() string {
+	return gateway.AddGatewayParams("ws" + strings.TrimPrefix(s.URL, "http"))
+}
+
+// Gateway creates a new unopened Gateway with the given token that connects to
+// the server.
+func (s *Server) Gateway(token string) *gateway.Gateway {
+	return gateway.NewCustomWithIdentifier(s.GatewayURL(), gateway.DefaultIdentifier(token), nil)
+}
+
+// Session creates a new unopened Session with the given token whose gateway
+// connects to the server. Its API client still talks to Discord, so it should
+// be replaced if the test makes API calls.
+func (s *Server) Session(token string) *session.Session {
+	return session.NewWithGateway(s.Gateway(token), handler.New())
+}
+
+// Close disconnects all clients and shuts down the server.
+func (s *Server) Close() {
+	s.Disconnect(websocket.CloseGoingAway)
+	s.Server.Close()
+}
+
+// Disconnect closes the connections of all clients with the given websocket
+// close code. Clients will usually try to reconnect and resume.
+func (s *Server) Disconnect(code int) {
+	s.mutex.Lock()
+	conns := s.conns
+	s.conns = make(map[*serverConn]struct{})
+	s.mutex.Unlock()
+
+	msg := websocket.FormatCloseMessage(code, "")
+
+	for conn := range conns {
+		conn.mutex.Lock()
+		conn.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+		conn.mutex.Unlock()
+		conn.conn.Close()
+	}
+}
+
+// Dispatch sends the given dispatch event to all clients that are ready, that
+// is, clients that have identified or resumed.
+func (s *Server) Dispatch(ev ws.Event) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.dispatch(ev)
+}
+
+func (s *Server) dispatch(ev ws.Event) error {
+	op := s.nextDispatch(ev)
+
+	var sent bool
+	for conn := range s.conns {
+		if !conn.ready {
+			continue
+		}
+		if err := conn.write(op); err != nil {
+			return err
+		}
+		sent = true
+	}
+
+	if !sent {
+		return ErrNotConnected
+	}
+
+	return nil
+}
+
+// Commands returns all the commands that clients have sent so far, including
+// identifies, resumes and heartbeats.
+func (s *Server) Commands() []ws.Op {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]ws.Op(nil), s.commands...)
+}
+
+// WaitCommand waits until a client sends a command that fn returns true for,
+// and returns it. Commands that were sent before WaitCommand is called are
+// also checked.
+func (s *Server) WaitCommand(ctx context.Context, fn func(ws.Event) bool) (ws.Event, error) {
+	var checked int
+
+	for {
+		s.mutex.Lock()
+		commands := s.commands[checked:]
+		notify := s.notify
+		s.mutex.Unlock()
+
+		for _, op := range commands {
+			if fn(op.Data) {
+				return op.Data, nil
+			}
+		}
+		checked += len(commands)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	c, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	conn := &serverConn{conn: c}
+	defer c.Close()
+
+	s.mutex.Lock()
+	s.conns[conn] = struct{}{}
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.conns, conn)
+		s.mutex.Unlock()
+	}()
+
+	interval := float64(s.HeartbeatInterval) / float64(time.Millisecond)
+	hello := &gateway.HelloEvent{HeartbeatInterval: discord.Milliseconds(interval)}
+
+	if err := conn.write(ws.Op{Code: hello.Op(), Data: hello}); err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	codec := ws.NewCodec(gateway.OpUnmarshalers)
+	buf := ws.NewDecodeBuffer(1024)
+
+	for {
+		_, r, err := c.NextReader()
+		if err != nil {
+			return
+		}
+
+		// A frame may decode into more than one op, such as when
+		// ws.EnableRawEvents is true, so all of them are drained.
+		ops := make(chan ws.Op)
+		go func() {
+			codec.DecodeInto(ctx, r, &buf, ops)
+			close(ops)
+		}()
+
+		for op := range ops {
+			switch op.Data.(type) {
+			case *ws.RawEvent:
+				continue
+			case *ws.BackgroundErrorEvent:
+				// Discord closes the connection on unknown or undecodable
+				// payloads.
+				s.closeWith(conn, 4002)
+				return
+			}
+
+			if !s.handleCommand(conn, op) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) closeWith(conn *serverConn, code int) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+
+	msg := websocket.FormatCloseMessage(code, "")
+	conn.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+}
+
+// handleCommand records the command and responds to it if needed. False is
+// returned if the connection should be closed.
+func (s *Server) handleCommand(conn *serverConn, op ws.Op) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.commands = append(s.commands, op)
+	close(s.notify)
+	s.notify = make(chan struct{})
+
+	switch data := op.Data.(type) {
+	case *gateway.HeartbeatCommand:
+		ack := &gateway.HeartbeatAckEvent{}
+		return conn.write(ws.Op{Code: ack.Op(), Data: ack}) == nil
+
+	case *gateway.IdentifyCommand:
+		conn.ready = true
+		s.sequence = 0
+
+		ready := &gateway.ReadyEvent{
+			Version:   9,
+			User:      s.User,
+			SessionID: s.SessionID,
+			Shard:     data.Shard,
+		}
+		ready.Application.ID = discord.AppID(s.User.ID)
+
+		return s.dispatchTo(conn, ready) == nil
+
+	case *gateway.ResumeCommand:
+		if data.SessionID != s.SessionID {
+			invalid := gateway.InvalidSessionEvent(false)
+			return conn.write(ws.Op{Code: invalid.Op(), Data: &invalid}) == nil
+		}
+
+		conn.ready = true
+		return s.dispatchTo(conn, &gateway.ResumedEvent{}) == nil
+	}
+
+	return true
+}
+
+// dispatchTo sends the dispatch event to only the given connection.
+func (s *Server) dispatchTo(conn *serverConn, ev ws.Event) error {
+	return conn.write(s.nextDispatch(ev))
+}
+
+// nextDispatch wraps the dispatch event into an Op with the next sequence
+// number.
+func (s *Server) nextDispatch(ev ws.Event) ws.Op {
+	s.sequence++
+	return ws.Op{
+		Code:     ev.Op(),
+		Type:     ev.EventType(),
+		Data:     ev,
+		Sequence: s.sequence,
+	}
+}
@@ -0,0 +1,264 @@
+package gatewaytest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/session"
+	"github.com/diamondburned/arikawa/v3/utils/clock"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func TestServer(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	s := srv.Session("Bot token")
+
+	ready := make(chan *gateway.ReadyEvent, 1)
+	s.AddHandler(ready)
+
+	messages := make(chan *gateway.MessageCreateEvent, 1)
+	s.AddHandler(messages)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.Open(ctx); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+	defer s.Close()
+
+	select {
+	case r := <-ready:
+		if r.User.ID != srv.User.ID || r.SessionID != srv.SessionID {
+			t.Fatalf("unexpected ready event: %+v", r)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for ready")
+	}
+
+	if _, err := srv.WaitCommand(ctx, func(ev ws.Event) bool {
+		identify, ok := ev.(*gateway.IdentifyCommand)
+		return ok && identify.Token == "Bot token"
+	}); err != nil {
+		t.Fatal("identify was not sent:", err)
+	}
+
+	msg := &gateway.MessageCreateEvent{
+		Message: discord.Message{ID: 1, ChannelID: 2, Content: "hime arikawa"},
+	}
+	if err := srv.Dispatch(msg); err != nil {
+		t.Fatal("failed to dispatch:", err)
+	}
+
+	select {
+	case m := <-messages:
+		if m.Content != msg.Content {
+			t.Fatalf("unexpected message: %+v", m)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for message")
+	}
+
+	err := s.SendGateway(ctx, &gateway.RequestGuildMembersCommand{
+		GuildIDs: []discord.GuildID{3},
+		Query:    option.NewString("hime"),
+	})
+	if err != nil {
+		t.Fatal("failed to request guild members:", err)
+	}
+
+	ev, err := srv.WaitCommand(ctx, func(ev ws.Event) bool {
+		_, ok := ev.(*gateway.RequestGuildMembersCommand)
+		return ok
+	})
+	if err != nil {
+		t.Fatal("guild members were not requested:", err)
+	}
+
+	if req := ev.(*gateway.RequestGuildMembersCommand); len(req.GuildIDs) != 1 || req.GuildIDs[0] != 3 {
+		t.Fatalf("unexpected guild members request: %+v", req)
+	}
+}
+
+func TestServerHeartbeatClock(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	fake := clock.NewFake(time.Now())
+
+	opts := gateway.DefaultGatewayOpts
+	opts.Clock = fake
+
+	g := gateway.NewCustomWithIdentifier(srv.GatewayURL(), gateway.DefaultIdentifier("Bot token"), &opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ops := g.Connect(ctx)
+	go func() {
+		for range ops {
+		}
+	}()
+
+	if _, err := srv.WaitCommand(ctx, func(ev ws.Event) bool {
+		_, ok := ev.(*gateway.IdentifyCommand)
+		return ok
+	}); err != nil {
+		t.Fatal("identify was not sent:", err)
+	}
+
+	if err := fake.BlockUntil(ctx, 1); err != nil {
+		t.Fatal("heartbeat ticker was not started:", err)
+	}
+
+	// Skip the heartbeat interval instead of waiting 45 seconds.
+	fake.Advance(srv.HeartbeatInterval)
+
+	if _, err := srv.WaitCommand(ctx, func(ev ws.Event) bool {
+		_, ok := ev.(*gateway.HeartbeatCommand)
+		return ok
+	}); err != nil {
+		t.Fatal("heartbeat was not sent:", err)
+	}
+}
+
+func TestServerSessionResumeStore(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	store := gateway.NewFileResumeStore(t.TempDir())
+
+	err := store.SaveResume(ctx, 0, gateway.ResumeState{
+		SessionID: srv.SessionID,
+		Sequence:  1,
+		ResumeURL: srv.GatewayURL(),
+	})
+	if err != nil {
+		t.Fatal("failed to save resume state:", err)
+	}
+
+	s := session.New("Bot token")
+	s.ResumeStore = store
+
+	if err := s.Open(ctx); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+
+	if _, err := srv.WaitCommand(ctx, func(ev ws.Event) bool {
+		resume, ok := ev.(*gateway.ResumeCommand)
+		return ok && resume.SessionID == srv.SessionID
+	}); err != nil {
+		t.Fatal("resume was not sent:", err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal("failed to close:", err)
+	}
+
+	// The default options close gracefully, which would invalidate the
+	// session, so the saved session must still be resumable.
+	rs, err := store.LoadResume(ctx, 0)
+	if err != nil {
+		t.Fatal("failed to load resume state:", err)
+	}
+
+	if !rs.Resumable() || rs.SessionID != srv.SessionID || rs.ResumeURL != srv.GatewayURL() {
+		t.Fatalf("unexpected saved resume state: %+v", rs)
+	}
+}
+
+func TestServerSessionFetchApplication(t *testing.T) {
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "100", "name": "bot"}`))
+	}))
+	defer apiSrv.Close()
+
+	oldApplications := api.EndpointApplications
+	api.EndpointApplications = apiSrv.URL + "/applications/"
+	defer func() { api.EndpointApplications = oldApplications }()
+
+	srv := NewServer()
+	defer srv.Close()
+
+	s := srv.Session("Bot valid")
+	s.FetchApplication = true
+
+	// Ready handlers run while Open is still waiting for Ready, so reading
+	// the application from them must not block.
+	appIDs := make(chan discord.AppID, 1)
+	s.AddSyncHandler(func(*gateway.ReadyEvent) {
+		appIDs <- s.AppID()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.Open(ctx); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+	defer s.Close()
+
+	select {
+	case id := <-appIDs:
+		if id != 100 {
+			t.Fatalf("unexpected application ID %d in Ready handler", id)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for Ready handler")
+	}
+
+	if app := s.Application(); app == nil || app.Name != "bot" {
+		t.Fatalf("unexpected application %+v", app)
+	}
+}
+
+func TestServerRawEvents(t *testing.T) {
+	ws.EnableRawEvents = true
+	t.Cleanup(func() { ws.EnableRawEvents = false })
+
+	srv := NewServer()
+	defer srv.Close()
+
+	s := srv.Session("Bot token")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.Open(ctx); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+	defer s.Close()
+
+	err := s.SendGateway(ctx, &gateway.RequestGuildMembersCommand{
+		GuildIDs: []discord.GuildID{3},
+	})
+	if err != nil {
+		t.Fatal("failed to request guild members:", err)
+	}
+
+	if _, err := srv.WaitCommand(ctx, func(ev ws.Event) bool {
+		_, ok := ev.(*gateway.RequestGuildMembersCommand)
+		return ok
+	}); err != nil {
+		t.Fatal("guild members were not requested:", err)
+	}
+
+	for _, op := range srv.Commands() {
+		if _, ok := op.Data.(*ws.RawEvent); ok {
+			t.Fatalf("raw event recorded as a command: %+v", op)
+		}
+	}
+}
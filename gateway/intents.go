@@ -1,6 +1,11 @@
 package gateway
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
@@ -13,7 +18,7 @@ const (
 	IntentGuilds Intents = 1 << iota
 	IntentGuildMembers
 	IntentGuildModeration
-	IntentGuildEmojis
+	IntentGuildExpressions
 	IntentGuildIntegrations
 	IntentGuildWebhooks
 	IntentGuildInvites
@@ -27,6 +32,15 @@ const (
 	IntentDirectMessageTyping
 	IntentMessageContent
 	IntentGuildScheduledEvents
+	_
+	_
+	_
+	IntentAutoModerationConfiguration
+	IntentAutoModerationExecution
+	_
+	_
+	IntentGuildMessagePolls
+	IntentDirectMessagePolls
 )
 
 // IntentGuildBans is an alias to IntentGuildModeration.
@@ -34,6 +48,61 @@ const (
 // Deprecated: IntentGuildModeration is the more correct constant to use.
 const IntentGuildBans = IntentGuildModeration
 
+// IntentGuildEmojis is an alias to IntentGuildExpressions.
+//
+// Deprecated: IntentGuildExpressions is the more correct constant to use, since
+// the intent also covers stickers and soundboard sounds.
+const IntentGuildEmojis = IntentGuildExpressions
+
+var intentNames = []struct {
+	intent Intents
+	name   string
+}{
+	{IntentGuilds, "Guilds"},
+	{IntentGuildMembers, "GuildMembers"},
+	{IntentGuildModeration, "GuildModeration"},
+	{IntentGuildExpressions, "GuildExpressions"},
+	{IntentGuildIntegrations, "GuildIntegrations"},
+	{IntentGuildWebhooks, "GuildWebhooks"},
+	{IntentGuildInvites, "GuildInvites"},
+	{IntentGuildVoiceStates, "GuildVoiceStates"},
+	{IntentGuildPresences, "GuildPresences"},
+	{IntentGuildMessages, "GuildMessages"},
+	{IntentGuildMessageReactions, "GuildMessageReactions"},
+	{IntentGuildMessageTyping, "GuildMessageTyping"},
+	{IntentDirectMessages, "DirectMessages"},
+	{IntentDirectMessageReactions, "DirectMessageReactions"},
+	{IntentDirectMessageTyping, "DirectMessageTyping"},
+	{IntentMessageContent, "MessageContent"},
+	{IntentGuildScheduledEvents, "GuildScheduledEvents"},
+	{IntentAutoModerationConfiguration, "AutoModerationConfiguration"},
+	{IntentAutoModerationExecution, "AutoModerationExecution"},
+	{IntentGuildMessagePolls, "GuildMessagePolls"},
+	{IntentDirectMessagePolls, "DirectMessagePolls"},
+}
+
+// String returns the names of the intents joined with "|", such as
+// "GuildMessages|DirectMessages". Unknown bits are formatted in hexadecimal.
+func (i Intents) String() string {
+	if i == 0 {
+		return "0"
+	}
+
+	var names []string
+	for _, intent := range intentNames {
+		if i.Has(intent.intent) {
+			names = append(names, intent.name)
+			i &^= intent.intent
+		}
+	}
+
+	if i != 0 {
+		names = append(names, fmt.Sprintf("%#x", uint32(i)))
+	}
+
+	return strings.Join(names, "|")
+}
+
 // PrivilegedIntents contains a list of privileged intents that Discord requires
 // bots to have these intents explicitly enabled in the Developer Portal.
 var PrivilegedIntents = []Intents{
@@ -70,20 +139,29 @@ var EventIntents = map[ws.EventType]Intents{
 	"GUILD_MEMBER_REMOVE": IntentGuildMembers,
 	"GUILD_MEMBER_UPDATE": IntentGuildMembers,
 
+	"GUILD_JOIN_REQUEST_CREATE": IntentGuildMembers,
+	"GUILD_JOIN_REQUEST_UPDATE": IntentGuildMembers,
+	"GUILD_JOIN_REQUEST_DELETE": IntentGuildMembers,
+
 	"GUILD_AUDIT_LOG_ENTRY_CREATE": IntentGuildModeration,
 	"GUILD_BAN_ADD":                IntentGuildModeration,
 	"GUILD_BAN_REMOVE":             IntentGuildModeration,
 
-	"GUILD_EMOJIS_UPDATE": IntentGuildEmojis,
+	"GUILD_EMOJIS_UPDATE":   IntentGuildExpressions,
+	"GUILD_STICKERS_UPDATE": IntentGuildExpressions,
 
 	"GUILD_INTEGRATIONS_UPDATE": IntentGuildIntegrations,
+	"INTEGRATION_CREATE":        IntentGuildIntegrations,
+	"INTEGRATION_UPDATE":        IntentGuildIntegrations,
+	"INTEGRATION_DELETE":        IntentGuildIntegrations,
 
 	"WEBHOOKS_UPDATE": IntentGuildWebhooks,
 
 	"INVITE_CREATE": IntentGuildInvites,
 	"INVITE_DELETE": IntentGuildInvites,
 
-	"VOICE_STATE_UPDATE": IntentGuildVoiceStates,
+	"VOICE_STATE_UPDATE":        IntentGuildVoiceStates,
+	"VOICE_CHANNEL_EFFECT_SEND": IntentGuildVoiceStates,
 
 	"PRESENCE_UPDATE": IntentGuildPresences,
 
@@ -99,9 +177,60 @@ var EventIntents = map[ws.EventType]Intents{
 
 	"TYPING_START": IntentGuildMessageTyping | IntentDirectMessageTyping,
 
+	"MESSAGE_POLL_VOTE_ADD":    IntentGuildMessagePolls | IntentDirectMessagePolls,
+	"MESSAGE_POLL_VOTE_REMOVE": IntentGuildMessagePolls | IntentDirectMessagePolls,
+
 	"GUILD_SCHEDULED_EVENT_CREATE":      IntentGuildScheduledEvents,
 	"GUILD_SCHEDULED_EVENT_UPDATE":      IntentGuildScheduledEvents,
 	"GUILD_SCHEDULED_EVENT_DELETE":      IntentGuildScheduledEvents,
 	"GUILD_SCHEDULED_EVENT_USER_ADD":    IntentGuildScheduledEvents,
 	"GUILD_SCHEDULED_EVENT_USER_REMOVE": IntentGuildScheduledEvents,
+
+	"AUTO_MODERATION_RULE_CREATE":      IntentAutoModerationConfiguration,
+	"AUTO_MODERATION_RULE_UPDATE":      IntentAutoModerationConfiguration,
+	"AUTO_MODERATION_RULE_DELETE":      IntentAutoModerationConfiguration,
+	"AUTO_MODERATION_ACTION_EXECUTION": IntentAutoModerationExecution,
+}
+
+// Validate checks the intents against the events that are handled, as reported
+// by handles, and returns an error for each problem found. The Handles method
+// of a handler.Handler, which a State embeds, can be given as handles:
+//
+//	for _, err := range intents.Validate(s.Handles) {
+//		log.Println("warning:", err)
+//	}
+//
+// An error is returned for each handled event that would never be received
+// because none of the intents it requires are set, as well as for intents that
+// have no effect given the other intents, such as IntentMessageContent without
+// any intent for message events.
+func (i Intents) Validate(handles func(reflect.Type) bool) []error {
+	var errs []error
+
+	OpUnmarshalers.Each(func(_ ws.OpCode, t ws.EventType, f ws.OpFunc) bool {
+		required, ok := EventIntents[t]
+		if !ok || i&required != 0 {
+			return false
+		}
+
+		if handles(reflect.TypeOf(f())) {
+			errs = append(errs, fmt.Errorf(
+				"event %s is handled but requires intents %v", t, required))
+		}
+
+		return false
+	})
+
+	// Sort the errors, since the order of Each is undefined.
+	sort.Slice(errs, func(a, b int) bool {
+		return errs[a].Error() < errs[b].Error()
+	})
+
+	const messageIntents = IntentGuildMessages | IntentDirectMessages
+	if i.Has(IntentMessageContent) && i&messageIntents == 0 {
+		errs = append(errs, fmt.Errorf(
+			"intent %v has no effect without intents %v", IntentMessageContent, messageIntents))
+	}
+
+	return errs
 }
@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIntentsString(t *testing.T) {
+	tests := []struct {
+		intents Intents
+		expect  string
+	}{
+		{0, "0"},
+		{IntentGuilds, "Guilds"},
+		{IntentGuildMessages | IntentDirectMessages, "GuildMessages|DirectMessages"},
+		{IntentDirectMessagePolls | 1<<30, "DirectMessagePolls|0x40000000"},
+	}
+
+	for _, test := range tests {
+		if got := test.intents.String(); got != test.expect {
+			t.Errorf("expected %q, got %q", test.expect, got)
+		}
+	}
+}
+
+func TestIntentsValidate(t *testing.T) {
+	handled := map[reflect.Type]bool{
+		reflect.TypeOf((*MessageCreateEvent)(nil)):            true,
+		reflect.TypeOf((*MessagePollVoteAddEvent)(nil)):       true,
+		reflect.TypeOf((*GuildStickersUpdateEvent)(nil)):      true,
+		reflect.TypeOf((*InteractionCreateEvent)(nil)):        true,
+		reflect.TypeOf((*AutoModerationRuleCreateEvent)(nil)): false,
+	}
+
+	handles := func(t reflect.Type) bool { return handled[t] }
+
+	errs := (IntentDirectMessages | IntentGuildExpressions).Validate(handles)
+	expect := []string{
+		"event MESSAGE_POLL_VOTE_ADD is handled but requires intents GuildMessagePolls|DirectMessagePolls",
+	}
+
+	assertErrors(t, errs, expect)
+
+	errs = (IntentGuildExpressions | IntentMessageContent).Validate(handles)
+	expect = []string{
+		"event MESSAGE_CREATE is handled but requires intents GuildMessages|DirectMessages",
+		"event MESSAGE_POLL_VOTE_ADD is handled but requires intents GuildMessagePolls|DirectMessagePolls",
+		"intent MessageContent has no effect without intents GuildMessages|DirectMessages",
+	}
+
+	assertErrors(t, errs, expect)
+}
+
+func assertErrors(t *testing.T, errs []error, expect []string) {
+	t.Helper()
+
+	if len(errs) != len(expect) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expect), len(errs), errs)
+	}
+
+	for i, err := range errs {
+		if err.Error() != expect[i] {
+			t.Errorf("error %d: expected %q, got %q", i, expect[i], err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+var eventPooling int32 // atomic
+
+var (
+	messageCreatePool  = sync.Pool{New: func() interface{} { return new(MessageCreateEvent) }}
+	presenceUpdatePool = sync.Pool{New: func() interface{} { return new(PresenceUpdateEvent) }}
+	typingStartPool    = sync.Pool{New: func() interface{} { return new(TypingStartEvent) }}
+)
+
+// EnableEventPooling makes the gateway reuse the event structs of the most
+// frequently received events instead of allocating new ones for each dispatch.
+// This reduces the allocation rate of large bots. The pooled events are:
+//
+//   - MessageCreateEvent
+//   - PresenceUpdateEvent
+//   - TypingStartEvent
+//
+// Pooled events are owned by the gateway: once an event has been dispatched to
+// all synchronous handlers, it is released back into its pool and will be
+// reused for a later event. Handlers that may use the event afterwards, such as
+// asynchronous, ordered and channel handlers, are given their own copy of the
+// event instead (see handler.CallContext), and so is session.WaitForEvent.
+// Synchronous handlers (see handler.AddSyncHandler) must not retain the event
+// pointer after returning. Copying the values out of the event, such as the
+// embedded discord.Message, is safe, since released events are zeroed before
+// they are reused.
+//
+// This function should be called before connecting to the gateway. It affects
+// all gateways, and it cannot be undone.
+func EnableEventPooling() {
+	if !atomic.CompareAndSwapInt32(&eventPooling, 0, 1) {
+		return
+	}
+
+	OpUnmarshalers.Add(
+		func() ws.Event { return messageCreatePool.Get().(*MessageCreateEvent) },
+		func() ws.Event { return presenceUpdatePool.Get().(*PresenceUpdateEvent) },
+		func() ws.Event { return typingStartPool.Get().(*TypingStartEvent) },
+	)
+}
+
+func eventPoolingEnabled() bool {
+	return atomic.LoadInt32(&eventPooling) == 1
+}
+
+// Pooled implements ws.PooledEvent. It returns true if event pooling is
+// enabled.
+func (ev *MessageCreateEvent) Pooled() bool { return eventPoolingEnabled() }
+
+// Release implements ws.PooledEvent. It zeroes the event and puts it back into
+// its pool if event pooling is enabled, otherwise it does nothing. The event
+// must not be used after calling Release.
+func (ev *MessageCreateEvent) Release() {
+	if eventPoolingEnabled() {
+		*ev = MessageCreateEvent{}
+		messageCreatePool.Put(ev)
+	}
+}
+
+// Pooled implements ws.PooledEvent. It returns true if event pooling is
+// enabled.
+func (ev *PresenceUpdateEvent) Pooled() bool { return eventPoolingEnabled() }
+
+// Release implements ws.PooledEvent. It zeroes the event and puts it back into
+// its pool if event pooling is enabled, otherwise it does nothing. The event
+// must not be used after calling Release.
+func (ev *PresenceUpdateEvent) Release() {
+	if eventPoolingEnabled() {
+		*ev = PresenceUpdateEvent{}
+		presenceUpdatePool.Put(ev)
+	}
+}
+
+// Pooled implements ws.PooledEvent. It returns true if event pooling is
+// enabled.
+func (ev *TypingStartEvent) Pooled() bool { return eventPoolingEnabled() }
+
+// Release implements ws.PooledEvent. It zeroes the event and puts it back into
+// its pool if event pooling is enabled, otherwise it does nothing. The event
+// must not be used after calling Release.
+func (ev *TypingStartEvent) Release() {
+	if eventPoolingEnabled() {
+		*ev = TypingStartEvent{}
+		typingStartPool.Put(ev)
+	}
+}
@@ -0,0 +1,144 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// ResumeState is the part of a gateway State that is needed to resume a
+// session. It can be persisted across process restarts using a ResumeStore, so
+// that a restarted bot can resume its previous session instead of identifying
+// again.
+type ResumeState struct {
+	SessionID string `json:"session_id"`
+	Sequence  int64  `json:"seq"`
+	// ResumeURL is the gateway URL that the session should be resumed on. It
+	// already has the gateway parameters added. It may be empty, in which case
+	// the usual gateway URL is used.
+	ResumeURL string `json:"resume_url,omitempty"`
+}
+
+// Resumable returns true if the state can be used to resume a session.
+func (s ResumeState) Resumable() bool {
+	return s.SessionID != "" && s.Sequence != 0
+}
+
+// ResumeState returns the resume state of the gateway state.
+func (s State) ResumeState() ResumeState {
+	return ResumeState{
+		SessionID: s.SessionID,
+		Sequence:  s.Sequence,
+		ResumeURL: s.ResumeURL,
+	}
+}
+
+// SetResumeState sets the session fields of the gateway state to the given
+// resume state.
+func (s *State) SetResumeState(rs ResumeState) {
+	s.SessionID = rs.SessionID
+	s.Sequence = rs.Sequence
+	s.ResumeURL = rs.ResumeURL
+}
+
+// ResumeStore persists the resume states of gateway sessions, keyed by their
+// shard IDs. Non-sharded gateways use shard 0.
+//
+// Note that closing a gateway gracefully invalidates its session, so
+// GatewayOpts.AlwaysCloseGracefully must be false for a saved session to be
+// resumable. session.Session turns it off for the gateways that it creates for
+// a ResumeStore.
+type ResumeStore interface {
+	// LoadResume loads the resume state of the given shard. If there is none,
+	// then a zero-value ResumeState and no error is returned.
+	LoadResume(ctx context.Context, shardID int) (ResumeState, error)
+	// SaveResume saves the resume state of the given shard. Saving a state
+	// that isn't resumable deletes the saved state.
+	SaveResume(ctx context.Context, shardID int, state ResumeState) error
+}
+
+// FileResumeStore is a ResumeStore that saves each shard's resume state as a
+// JSON file in a directory.
+type FileResumeStore struct {
+	// Dir is the directory to save the files in. It is created if it doesn't
+	// exist.
+	Dir string
+}
+
+var _ ResumeStore = FileResumeStore{}
+
+// NewFileResumeStore creates a new FileResumeStore that saves into dir.
+func NewFileResumeStore(dir string) FileResumeStore {
+	return FileResumeStore{Dir: dir}
+}
+
+func (s FileResumeStore) path(shardID int) string {
+	return filepath.Join(s.Dir, "resume-"+strconv.Itoa(shardID)+".json")
+}
+
+// LoadResume implements ResumeStore.
+func (s FileResumeStore) LoadResume(ctx context.Context, shardID int) (ResumeState, error) {
+	var state ResumeState
+
+	b, err := os.ReadFile(s.path(shardID))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return state, nil
+		}
+		return state, fmt.Errorf("failed to read resume state: %w", err)
+	}
+
+	if err := json.Unmarshal(b, &state); err != nil {
+		return state, fmt.Errorf("failed to decode resume state: %w", err)
+	}
+
+	return state, nil
+}
+
+// SaveResume implements ResumeStore. The file is written atomically.
+func (s FileResumeStore) SaveResume(ctx context.Context, shardID int, state ResumeState) error {
+	path := s.path(shardID)
+
+	if !state.Resumable() {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete resume state: %w", err)
+		}
+		return nil
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode resume state: %w", err)
+	}
+
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create resume state directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(s.Dir, ".resume-*")
+	if err != nil {
+		return fmt.Errorf("failed to create resume state file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write resume state: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write resume state: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to save resume state: %w", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,6 @@
 module github.com/diamondburned/arikawa/v3
 
-go 1.16
+go 1.21
 
 require (
 	github.com/gorilla/schema v1.2.0
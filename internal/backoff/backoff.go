@@ -7,6 +7,8 @@ import (
 	"math/rand"
 	"sync/atomic"
 	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/clock"
 )
 
 const (
@@ -21,13 +23,21 @@ func init() {
 // Timer is a backoff timer.
 type Timer struct {
 	backoff Backoff
-	timer   *time.Timer
+	clock   clock.Clock
+	timer   clock.Timer
 }
 
 // NewTimer returns a new uninitialized timer.
 func NewTimer(min, max time.Duration) Timer {
+	return NewTimerWithClock(min, max, nil)
+}
+
+// NewTimerWithClock returns a new uninitialized timer that uses the given
+// clock. If c is nil, then clock.System is used.
+func NewTimerWithClock(min, max time.Duration, c clock.Clock) Timer {
 	return Timer{
 		backoff: NewBackoff(min, max),
+		clock:   clock.OrSystem(c),
 	}
 }
 
@@ -35,13 +45,13 @@ func NewTimer(min, max time.Duration) Timer {
 // when the backoff timeout is reached.
 func (t *Timer) Next() <-chan time.Time {
 	if t.timer == nil {
-		t.timer = time.NewTimer(t.backoff.Next())
+		t.timer = clock.OrSystem(t.clock).NewTimer(t.backoff.Next())
 	} else {
 		t.timer.Stop() // ensure drained
 		t.timer.Reset(t.backoff.Next())
 	}
 
-	return t.timer.C
+	return t.timer.C()
 }
 
 // Stop stops the internal timer and frees its resources. It does nothing if the
@@ -52,7 +62,7 @@ func (t *Timer) Stop() {
 	}
 
 	if !t.timer.Stop() {
-		<-t.timer.C // drain
+		<-t.timer.C() // drain
 	}
 }
 
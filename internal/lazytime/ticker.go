@@ -1,19 +1,27 @@
 package lazytime
 
-import "time"
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/clock"
+)
 
 type Ticker struct {
 	C <-chan time.Time
 
-	ticker *time.Ticker
+	// Clock is the clock used to create the ticker. It is clock.System if nil.
+	// It must be set before the first Reset.
+	Clock clock.Clock
+
+	ticker clock.Ticker
 }
 
 // Reset resets the ticker. If this is the first time calling, then a new timer
 // is created.
 func (t *Ticker) Reset(d time.Duration) {
 	if t.ticker == nil {
-		t.ticker = time.NewTicker(d)
-		t.C = t.ticker.C
+		t.ticker = clock.OrSystem(t.Clock).NewTicker(d)
+		t.C = t.ticker.C()
 	} else {
 		t.ticker.Reset(d)
 	}
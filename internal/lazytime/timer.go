@@ -3,20 +3,26 @@ package lazytime
 import (
 	"context"
 	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/clock"
 )
 
 type Timer struct {
 	C <-chan time.Time
 
-	timer *time.Timer
+	// Clock is the clock used to create the timer. It is clock.System if nil.
+	// It must be set before the first Reset.
+	Clock clock.Clock
+
+	timer clock.Timer
 }
 
 // Reset resets the timer by draining it and resetting the internal channel. If
 // this is the first time calling, then a new timer is created.
 func (t *Timer) Reset(d time.Duration) {
 	if t.timer == nil {
-		t.timer = time.NewTimer(d)
-		t.C = t.timer.C
+		t.timer = clock.OrSystem(t.Clock).NewTimer(d)
+		t.C = t.timer.C()
 		return
 	}
 
@@ -33,7 +39,7 @@ func (t *Timer) Stop() {
 
 	if !t.timer.Stop() {
 		select {
-		case <-t.timer.C:
+		case <-t.C:
 		default:
 		}
 	}
@@ -0,0 +1,64 @@
+// Package singleflight provides a duplicate call suppression mechanism, similar
+// to golang.org/x/sync/singleflight but typed.
+package singleflight
+
+import (
+	"context"
+	"sync"
+)
+
+type call[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// Group coalesces concurrent calls with the same key into one. A zero-value
+// Group is a valid Group.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V]
+}
+
+// Do calls fn and returns its results, making sure that only one call for the
+// given key is in flight at a time. If a duplicate call comes in, then it waits
+// for the original call to finish and receives the same results. shared is
+// true if the results are those of a call made by another caller, in which
+// case the caller must not modify them.
+//
+// fn is called in its own goroutine with a context that has the values of the
+// first caller's ctx but is never cancelled, so that one caller giving up
+// doesn't fail the others. Each caller stops waiting once its own ctx is done,
+// in which case ctx.Err() is returned.
+func (g *Group[K, V]) Do(ctx context.Context, key K, fn func(context.Context) (V, error)) (v V, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*call[V])
+	}
+
+	c, shared := g.calls[key]
+	if !shared {
+		c = &call[V]{done: make(chan struct{})}
+		g.calls[key] = c
+
+		go func() {
+			// Ensure that waiters are released even if fn panics.
+			defer func() {
+				g.mu.Lock()
+				delete(g.calls, key)
+				g.mu.Unlock()
+				close(c.done)
+			}()
+
+			c.val, c.err = fn(context.WithoutCancel(ctx))
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err, shared
+	case <-ctx.Done():
+		return v, ctx.Err(), false
+	}
+}
@@ -0,0 +1,91 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	var g Group[int, string]
+	var calls int32
+
+	start := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			v, err, _ := g.Do(context.Background(), 1, func(context.Context) (string, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return "hime", nil
+			})
+			if err != nil || v != "hime" {
+				t.Errorf("unexpected result %q, %v", v, err)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Fatal("expected 1 call, got", calls)
+	}
+}
+
+func TestGroupCancel(t *testing.T) {
+	var g Group[int, string]
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	leader := make(chan error, 1)
+	go func() {
+		_, err, _ := g.Do(ctx, 1, func(ctx context.Context) (string, error) {
+			close(started)
+			<-release
+			return "hime", ctx.Err()
+		})
+		leader <- err
+	}()
+
+	<-started
+
+	follower := make(chan string, 1)
+	go func() {
+		v, err, shared := g.Do(context.Background(), 1, func(context.Context) (string, error) {
+			t.Error("duplicate call was not coalesced")
+			return "", nil
+		})
+		if err != nil || !shared {
+			t.Errorf("unexpected follower result %q, %v, %v", v, err, shared)
+		}
+		follower <- v
+	}()
+
+	// Give the follower time to join the call.
+	time.Sleep(10 * time.Millisecond)
+
+	// Cancelling the first caller only fails that caller.
+	cancel()
+
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Fatal("expected the cancelled caller to fail, got", err)
+	}
+
+	close(release)
+
+	if v := <-follower; v != "hime" {
+		t.Fatalf("unexpected follower result %q", v)
+	}
+}
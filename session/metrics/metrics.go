@@ -0,0 +1,319 @@
+// Package metrics exposes Prometheus metrics for a Session or State. Metrics are
+// written in the Prometheus text exposition format, so no client library is
+// needed; Metrics itself is an http.Handler that can be mounted on /metrics.
+//
+//	s := state.New("Bot " + token)
+//	m := metrics.AttachState(s)
+//	http.Handle("/metrics", m)
+//
+// The following metrics are exposed:
+//
+//	discord_gateway_events_total{shard,type}      counter
+//	discord_gateway_connected{shard}              gauge
+//	discord_rest_requests_total{route,status}     counter
+//	discord_rate_limit_waits_total{route}         counter
+//	discord_rate_limit_wait_seconds_total{route}  counter
+//	discord_cache_objects{kind}                   gauge
+//
+// Routes are labeled with their template (see rate.RouteTemplate), so IDs and
+// webhook or interaction tokens never appear in the output.
+//
+// Events per second are obtained by applying rate() to the events counter.
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api/rate"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/session"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/state/store"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+// Metrics collects metrics from the sessions attached to it. A single Metrics
+// may be attached to multiple sessions, such as one per shard.
+type Metrics struct {
+	events      *family
+	connected   *family
+	requests    *family
+	waits       *family
+	waitSeconds *family
+
+	cabinet atomic.Value // *store.Cabinet
+}
+
+// New creates a new Metrics that isn't attached to anything.
+func New() *Metrics {
+	return &Metrics{
+		events: newFamily("discord_gateway_events_total", "counter",
+			"Number of gateway events received.", "shard", "type"),
+		connected: newFamily("discord_gateway_connected", "gauge",
+			"Whether the shard is connected to the gateway.", "shard"),
+		requests: newFamily("discord_rest_requests_total", "counter",
+			"Number of REST requests made.", "route", "status"),
+		waits: newFamily("discord_rate_limit_waits_total", "counter",
+			"Number of times a REST request waited for a rate limit.", "route"),
+		waitSeconds: newFamily("discord_rate_limit_wait_seconds_total", "counter",
+			"Total time spent waiting for rate limits.", "route"),
+	}
+}
+
+// Attach creates a new Metrics and attaches it to the given session.
+func Attach(s *session.Session) *Metrics {
+	m := New()
+	m.AttachSession(s)
+	return m
+}
+
+// AttachState creates a new Metrics and attaches it to the given state,
+// including its cache sizes.
+func AttachState(s *state.State) *Metrics {
+	m := New()
+	m.AttachSession(s.Session)
+	m.SetCabinet(s.Cabinet)
+	return m
+}
+
+// AttachSession starts collecting gateway and REST metrics from the given
+// session. It must be called before the session is opened.
+//
+// Since every event is counted, lazy events (see gateway.EnableLazyEvents) are
+// always decoded once AttachSession is called.
+func (m *Metrics) AttachSession(s *session.Session) {
+	var shard atomic.Value // string
+	shard.Store("0")
+
+	s.AddSyncHandler(func(ev ws.Event) {
+		t := ev.EventType()
+		if lazy, ok := ev.(*ws.LazyEvent); ok {
+			t = lazy.OriginalType
+		}
+
+		switch ev := ev.(type) {
+		case *gateway.ReadyEvent:
+			if ev.Shard != nil {
+				shard.Store(strconv.Itoa(ev.Shard.ShardID()))
+			}
+			m.connected.set(1, shard.Load().(string))
+		case *gateway.ResumedEvent:
+			m.connected.set(1, shard.Load().(string))
+		case *ws.CloseEvent:
+			m.connected.set(0, shard.Load().(string))
+			return
+		}
+
+		if ev.Op() >= 0 {
+			m.events.add(1, shard.Load().(string), string(t))
+		}
+	})
+
+	// api.Client has its own OnResponse method, so the field of the underlying
+	// httputil.Client is used.
+	s.Client.Client.OnResponse = append(s.Client.Client.OnResponse,
+		func(r httpdriver.Request, resp httpdriver.Response) error {
+			status := "error"
+			if resp != nil {
+				status = strconv.Itoa(resp.GetStatus())
+			}
+
+			route := strings.TrimPrefix(r.GetPath(), s.Limiter.Prefix)
+			m.requests.add(1, rate.RouteTemplate(route), status)
+			return nil
+		},
+	)
+
+	onEvent := s.Limiter.OnEvent
+	s.Limiter.OnEvent = func(ev rate.Event) {
+		if onEvent != nil {
+			onEvent(ev)
+		}
+		if ev.Type == rate.EventWait {
+			m.waits.add(1, ev.Route)
+			m.waitSeconds.add(int64(ev.Wait), ev.Route)
+		}
+	}
+}
+
+// SetCabinet sets the cabinet whose cache sizes are reported. Shards sharing
+// the same cabinet only need to set it once.
+func (m *Metrics) SetCabinet(cab *store.Cabinet) {
+	m.cabinet.Store(cab)
+}
+
+// ServeHTTP writes all metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	cw := countWriter{w: w}
+	bw := bufio.NewWriter(&cw)
+
+	m.events.write(bw, 1)
+	m.connected.write(bw, 1)
+	m.requests.write(bw, 1)
+	m.waits.write(bw, 1)
+	m.waitSeconds.write(bw, float64(time.Second))
+
+	if cab, ok := m.cabinet.Load().(*store.Cabinet); ok {
+		cache := newFamily("discord_cache_objects", "gauge",
+			"Number of objects in the state cache.", "kind")
+		cacheSizes(cache, cab)
+		cache.write(bw, 1)
+	}
+
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// cacheSizes counts the objects in the cabinet. It is only called when metrics
+// are scraped, so it's fine for it to be somewhat expensive.
+func cacheSizes(f *family, cab *store.Cabinet) {
+	var channels, members, roles, messages int
+
+	guilds, _ := cab.Guilds()
+	for _, guild := range guilds {
+		chs, _ := cab.Channels(guild.ID)
+		channels += len(chs)
+		for _, ch := range chs {
+			msgs, _ := cab.Messages(ch.ID)
+			messages += len(msgs)
+		}
+
+		ms, _ := cab.Members(guild.ID)
+		members += len(ms)
+
+		rs, _ := cab.Roles(guild.ID)
+		roles += len(rs)
+	}
+
+	privates, _ := cab.PrivateChannels()
+	channels += len(privates)
+	for _, ch := range privates {
+		msgs, _ := cab.Messages(ch.ID)
+		messages += len(msgs)
+	}
+
+	f.set(int64(len(guilds)), "guilds")
+	f.set(int64(channels), "channels")
+	f.set(int64(members), "members")
+	f.set(int64(roles), "roles")
+	f.set(int64(messages), "messages")
+}
+
+// family is a metric with a set of labeled series.
+type family struct {
+	name   string
+	typ    string
+	help   string
+	labels []string
+	series sync.Map // string -> *series
+}
+
+type series struct {
+	values []string
+	n      int64 // atomic
+}
+
+func newFamily(name, typ, help string, labels ...string) *family {
+	return &family{
+		name:   name,
+		typ:    typ,
+		help:   help,
+		labels: labels,
+	}
+}
+
+func (f *family) get(values []string) *series {
+	key := strings.Join(values, "\xff")
+
+	if s, ok := f.series.Load(key); ok {
+		return s.(*series)
+	}
+
+	s, _ := f.series.LoadOrStore(key, &series{values: values})
+	return s.(*series)
+}
+
+func (f *family) add(delta int64, values ...string) {
+	atomic.AddInt64(&f.get(values).n, delta)
+}
+
+func (f *family) set(v int64, values ...string) {
+	atomic.StoreInt64(&f.get(values).n, v)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// write writes the family, dividing each value by div.
+func (f *family) write(w *bufio.Writer, div float64) {
+	var all []*series
+	f.series.Range(func(_, v interface{}) bool {
+		all = append(all, v.(*series))
+		return true
+	})
+
+	if len(all) == 0 {
+		return
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		a, b := all[i].values, all[j].values
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+
+	w.WriteString("# HELP " + f.name + " " + f.help + "\n")
+	w.WriteString("# TYPE " + f.name + " " + f.typ + "\n")
+
+	for _, s := range all {
+		w.WriteString(f.name)
+		w.WriteByte('{')
+		for i, label := range f.labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(label)
+			w.WriteString(`="`)
+			labelEscaper.WriteString(w, s.values[i])
+			w.WriteByte('"')
+		}
+		w.WriteString("} ")
+
+		n := atomic.LoadInt64(&s.n)
+		if div == 1 {
+			w.WriteString(strconv.FormatInt(n, 10))
+		} else {
+			w.WriteString(strconv.FormatFloat(float64(n)/div, 'g', -1, 64))
+		}
+		w.WriteByte('\n')
+	}
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	w.n += int64(n)
+	return n, err
+}
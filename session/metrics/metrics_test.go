@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/api/rate"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+func TestMetrics(t *testing.T) {
+	s := state.New("")
+	m := AttachState(s)
+
+	s.Session.Handler.Call(&gateway.ReadyEvent{Shard: &gateway.Shard{1, 2}})
+
+	// Ready resets the cabinet, so fill it afterwards.
+	s.Cabinet.GuildSet(&discord.Guild{ID: 1}, false)
+	s.Cabinet.MemberSet(1, &discord.Member{User: discord.User{ID: 2}}, false)
+	s.Session.Handler.Call(&gateway.MessageCreateEvent{})
+	s.Session.Handler.Call(&gateway.MessageCreateEvent{})
+
+	req := httpdriver.NewMockRequest("GET", api.EndpointChannels+"123/messages", nil, nil)
+	for _, fn := range s.Client.Client.OnResponse {
+		fn(req, httpdriver.NewMockResponse(200, nil, nil))
+	}
+
+	s.Limiter.OnEvent(rate.Event{
+		Type:  rate.EventWait,
+		Route: "/channels/:id",
+		Wait:  1500 * time.Millisecond,
+	})
+	s.Limiter.OnEvent(rate.Event{
+		Type:  rate.EventRateLimited,
+		Route: "/channels/:id",
+		Wait:  time.Second,
+	})
+
+	var b strings.Builder
+	if _, err := m.WriteTo(&b); err != nil {
+		t.Fatal("failed to write metrics:", err)
+	}
+
+	for _, expect := range []string{
+		`discord_gateway_events_total{shard="1",type="MESSAGE_CREATE"} 2`,
+		`discord_gateway_events_total{shard="1",type="READY"} 1`,
+		`discord_gateway_connected{shard="1"} 1`,
+		`discord_rest_requests_total{route="/channels/:id/messages",status="200"} 1`,
+		`discord_rate_limit_waits_total{route="/channels/:id"} 1`,
+		`discord_rate_limit_wait_seconds_total{route="/channels/:id"} 1.5`,
+		`discord_cache_objects{kind="guilds"} 1`,
+		`discord_cache_objects{kind="members"} 1`,
+	} {
+		if !strings.Contains(b.String(), expect) {
+			t.Errorf("missing metric %q in:\n%s", expect, b.String())
+		}
+	}
+
+	s.Session.Handler.Call(&ws.CloseEvent{Code: -1})
+
+	b.Reset()
+	m.WriteTo(&b)
+
+	if !strings.Contains(b.String(), `discord_gateway_connected{shard="1"} 0`) {
+		t.Errorf("shard is still connected after close:\n%s", b.String())
+	}
+}
+
+func TestMetricsRedactTokens(t *testing.T) {
+	const token = "aW50ZXJhY3Rpb246c2VjcmV0"
+
+	s := state.New("")
+	m := AttachState(s)
+
+	for _, path := range []string{
+		api.EndpointWebhooks + "123/" + token + "/messages/@original",
+		api.EndpointInteractions + "456/" + token + "/callback",
+	} {
+		req := httpdriver.NewMockRequest("POST", path, nil, nil)
+		for _, fn := range s.Client.Client.OnResponse {
+			fn(req, httpdriver.NewMockResponse(204, nil, nil))
+		}
+	}
+
+	var b strings.Builder
+	m.WriteTo(&b)
+
+	if strings.Contains(b.String(), token) {
+		t.Fatalf("token found in metrics:\n%s", b.String())
+	}
+
+	for _, expect := range []string{
+		`discord_rest_requests_total{route="/webhooks/:id/:token/messages/@original",status="204"} 1`,
+		`discord_rest_requests_total{route="/interactions/:id/:token/callback",status="204"} 1`,
+	} {
+		if !strings.Contains(b.String(), expect) {
+			t.Errorf("missing metric %q in:\n%s", expect, b.String())
+		}
+	}
+}
+
+var _ http.Handler = (*Metrics)(nil)
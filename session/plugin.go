@@ -0,0 +1,49 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Plugin is a reusable feature that can be added to a Session, such as metrics,
+// paginators or schedulers. A plugin usually adds its handlers and intents in
+// Setup and removes them in Close.
+type Plugin interface {
+	// Setup sets up the plugin for the given Session. It is called once by
+	// AddPlugin.
+	Setup(*Session) error
+	// Close cleans up the plugin. It is called once when the Session is
+	// closed.
+	Close() error
+}
+
+// AddPlugin sets up the plugin and adds it to the session, so that it is closed
+// when the session is closed. Plugins should be added before Open is called,
+// since intents can't be added after that.
+//
+// If Setup fails, then the plugin is not added and the error is returned.
+func (s *Session) AddPlugin(p Plugin) error {
+	if err := p.Setup(s); err != nil {
+		return fmt.Errorf("failed to set up plugin %T: %w", p, err)
+	}
+
+	s.state.Lock()
+	s.state.plugins = append(s.state.plugins, p)
+	s.state.Unlock()
+
+	return nil
+}
+
+// closePlugins closes the plugins in the reverse order that they were added
+// in, so that plugins can depend on plugins added before them.
+func closePlugins(plugins []Plugin) error {
+	var errs []error
+
+	for i := len(plugins) - 1; i >= 0; i-- {
+		if err := plugins[i].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close plugin %T: %w", plugins[i], err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
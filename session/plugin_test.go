@@ -0,0 +1,59 @@
+package session
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testPlugin struct {
+	name     string
+	setupErr error
+	closeErr error
+	calls    *[]string
+}
+
+func (p testPlugin) Setup(*Session) error {
+	*p.calls = append(*p.calls, "setup "+p.name)
+	return p.setupErr
+}
+
+func (p testPlugin) Close() error {
+	*p.calls = append(*p.calls, "close "+p.name)
+	return p.closeErr
+}
+
+func TestPlugins(t *testing.T) {
+	var calls []string
+	errSetup := errors.New("setup failed")
+	errClose := errors.New("close failed")
+
+	s := New("")
+
+	if err := s.AddPlugin(testPlugin{name: "a", calls: &calls}); err != nil {
+		t.Fatal("failed to add plugin a:", err)
+	}
+
+	if err := s.AddPlugin(testPlugin{name: "b", setupErr: errSetup, calls: &calls}); !errors.Is(err, errSetup) {
+		t.Fatal("expected setup error, got", err)
+	}
+
+	if err := s.AddPlugin(testPlugin{name: "c", closeErr: errClose, calls: &calls}); err != nil {
+		t.Fatal("failed to add plugin c:", err)
+	}
+
+	err := s.Close()
+	if !errors.Is(err, ErrClosed) || !errors.Is(err, errClose) {
+		t.Fatal("expected ErrClosed and close error, got", err)
+	}
+
+	expected := []string{"setup a", "setup b", "setup c", "close c", "close a"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("expected calls %q, got %q", expected, calls)
+	}
+
+	// Plugins are removed once they are closed.
+	if err := s.Close(); err != ErrClosed {
+		t.Fatal("expected ErrClosed, got", err)
+	}
+}
@@ -8,12 +8,17 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/api/webhook"
+	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json/option"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 	"github.com/diamondburned/arikawa/v3/utils/ws/ophandler"
@@ -26,6 +31,10 @@ var ErrMFA = errors.New("account has 2FA enabled")
 // closed (and Close is being called again) or it was never started.
 var ErrClosed = errors.New("Session is closed")
 
+// ErrInvalidToken is returned by Open if FetchApplication is true and Discord
+// rejects the token of the Session.
+var ErrInvalidToken = errors.New("invalid token")
+
 // Session manages both the API and Gateway. As such, Session inherits all of
 // API's methods, as well has the Handler used for Gateway.
 type Session struct {
@@ -45,6 +54,23 @@ type Session struct {
 	// this is true, then any event sent by Discord will unblock Open (usually
 	// HELLO).
 	DontWaitForReady bool // false
+
+	// ResumeStore, if not nil, is used to persist the gateway session when the
+	// Session is closed, so that the next Open after a process restart can
+	// resume it instead of identifying again. The session is only loaded when
+	// Open first creates the gateway, and that gateway never closes
+	// gracefully, since that would invalidate the session. Sessions created
+	// with an existing gateway must disable GatewayOpts.AlwaysCloseGracefully
+	// themselves.
+	ResumeStore gateway.ResumeStore
+
+	// FetchApplication makes Open get the application of the bot before
+	// connecting to the gateway, so that an invalid token fails with
+	// ErrInvalidToken instead of a gateway error. The application is cached
+	// and returned by Application and AppID, so the application ID doesn't
+	// have to be configured separately from the token. It only works with bot
+	// tokens.
+	FetchApplication bool // false
 }
 
 type sessionState struct {
@@ -55,6 +81,12 @@ type sessionState struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	doneCh <-chan struct{}
+
+	plugins []Plugin
+
+	// app is not guarded by the mutex, since Open holds it until Ready, and
+	// handlers of the Ready event may need the application.
+	app atomic.Pointer[discord.Application]
 }
 
 // NewWithIntents is similar to New but adds the given intents in during
@@ -234,6 +266,50 @@ func (s *Session) gatewayIsAlive() bool {
 	}
 }
 
+// Health describes the health of a session. It is suitable for liveness and
+// readiness probes.
+type Health struct {
+	gateway.Health
+	// Alive is true if the gateway is open and is either connected or trying
+	// to reconnect. Refer to GatewayIsAlive.
+	Alive bool
+	// Err is the error that the gateway exited with, if it's not alive.
+	Err error
+}
+
+// Healthy returns true if the gateway is alive and connected, and if the last
+// heartbeat was acknowledged within maxAckAge. If maxAckAge is 0, then the
+// heartbeat isn't checked.
+func (h Health) Healthy(maxAckAge time.Duration) bool {
+	if !h.Alive || !h.Connected {
+		return false
+	}
+	return maxAckAge == 0 || h.SinceHeartbeatAck() <= maxAckAge
+}
+
+// Health returns the current health of the session's gateway. If the gateway
+// has never been opened, then a zero-value Health is returned. Like
+// GatewayIsAlive, Health blocks while Open is waiting for the gateway to be
+// ready.
+func (s *Session) Health() Health {
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	if s.state.gateway == nil {
+		return Health{}
+	}
+
+	health := Health{
+		Health: s.state.gateway.Health(),
+		Alive:  s.gatewayIsAlive(),
+	}
+	if !health.Alive && s.state.doneCh != nil {
+		health.Err = s.state.gateway.LastError()
+	}
+
+	return health
+}
+
 // Connect opens the Discord gateway and waits until an unrecoverable error
 // occurs. Always prefer this method over Open. Note that Connect will return
 // when ctx is done or when s.Close is called.
@@ -284,8 +360,16 @@ func (s *Session) Open(ctx context.Context) error {
 		}
 	}
 
+	if s.FetchApplication && s.state.app.Load() == nil {
+		app, err := s.fetchApplication(ctx)
+		if err != nil {
+			return err
+		}
+		s.state.app.Store(app)
+	}
+
 	if s.state.gateway == nil {
-		g, err := gateway.NewWithIdentifier(ctx, s.state.id)
+		g, err := s.newGateway(ctx)
 		if err != nil {
 			return err
 		}
@@ -329,6 +413,83 @@ func (s *Session) Open(ctx context.Context) error {
 	}
 }
 
+// fetchApplication gets the application of the bot.
+func (s *Session) fetchApplication(ctx context.Context) (*discord.Application, error) {
+	app, err := s.Client.WithContext(ctx).CurrentApplication()
+	if err != nil {
+		var httpErr *httputil.HTTPError
+		if errors.As(err, &httpErr) && httpErr.Status == http.StatusUnauthorized {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+		}
+		return nil, fmt.Errorf("failed to get current application: %w", err)
+	}
+	return app, nil
+}
+
+// Application returns the application of the bot fetched by Open if
+// FetchApplication is true, otherwise nil. The returned application must not
+// be modified.
+func (s *Session) Application() *discord.Application {
+	return s.state.app.Load()
+}
+
+// AppID returns the ID of the application of the bot fetched by Open if
+// FetchApplication is true, otherwise an invalid ID.
+func (s *Session) AppID() discord.AppID {
+	if app := s.Application(); app != nil {
+		return app.ID
+	}
+	return 0
+}
+
+// newGateway creates a new gateway. If there is a ResumeStore, then the
+// gateway resumes the session saved in it if there is one, and it never closes
+// gracefully, so that its session stays resumable.
+func (s *Session) newGateway(ctx context.Context) (*gateway.Gateway, error) {
+	if s.ResumeStore == nil {
+		return gateway.NewWithIdentifier(ctx, s.state.id)
+	}
+
+	resume, err := s.ResumeStore.LoadResume(ctx, s.shardID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load resume state: %w", err)
+	}
+
+	id := s.state.id
+	gatewayURL := resume.ResumeURL
+
+	if !resume.Resumable() || gatewayURL == "" {
+		url, err := id.QueryGateway(ctx)
+		if err != nil {
+			return nil, err
+		}
+		gatewayURL = gateway.AddGatewayParams(url)
+	}
+
+	state := gateway.State{Identifier: id}
+	if resume.Resumable() {
+		state.SetResumeState(resume)
+	}
+
+	opts := gateway.DefaultGatewayOpts
+	opts.AlwaysCloseGracefully = false
+
+	return gateway.NewFromState(gatewayURL, state, &opts), nil
+}
+
+// SetResumeStore sets ResumeStore. It must be called before Open. It lets
+// shard.Manager give its ResumeStore to its shards.
+func (s *Session) SetResumeStore(store gateway.ResumeStore) {
+	s.ResumeStore = store
+}
+
+func (s *Session) shardID() int {
+	if s.state.id.Shard == nil {
+		return 0
+	}
+	return s.state.id.Shard.ShardID()
+}
+
 // Wait blocks until either ctx is done or the gateway stumbles on an
 // unrecoverable error.
 func (s *Session) Wait(ctx context.Context) error {
@@ -408,12 +569,26 @@ func (s *Session) SendGateway(ctx context.Context, m ws.Event) error {
 // Close closes the underlying Websocket connection, invalidating the session
 // ID. It will send a closing frame before ending the connection, closing it
 // gracefully. This will cause the bot to appear as offline instantly. To
-// prevent this behavior, change Gateway.AlwaysCloseGracefully.
+// prevent this behavior, change Gateway.AlwaysCloseGracefully. Gateways created
+// for a ResumeStore never close gracefully.
+//
+// Close also closes and removes all plugins added using AddPlugin, even if the
+// session isn't open. They must be added again if the session is reopened.
 func (s *Session) Close() error {
 	s.state.Lock()
-	defer s.state.Unlock()
+	err := s.close()
+	plugins := s.state.plugins
+	s.state.plugins = nil
+	s.state.Unlock()
+
+	if pluginErr := closePlugins(plugins); pluginErr != nil {
+		if err == nil {
+			return pluginErr
+		}
+		return errors.Join(err, pluginErr)
+	}
 
-	return s.close()
+	return err
 }
 
 func (s *Session) close() error {
@@ -428,5 +603,15 @@ func (s *Session) close() error {
 	<-s.state.doneCh
 	s.state.doneCh = nil
 
-	return s.state.gateway.LastError()
+	err := s.state.gateway.LastError()
+
+	if s.ResumeStore != nil {
+		resume := s.state.gateway.State().ResumeState()
+		saveErr := s.ResumeStore.SaveResume(context.Background(), s.shardID(), resume)
+		if saveErr != nil && err == nil {
+			err = fmt.Errorf("failed to save resume state: %w", saveErr)
+		}
+	}
+
+	return err
 }
@@ -2,9 +2,13 @@ package session
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/internal/testenv"
 )
@@ -92,3 +96,74 @@ func TestSessionConnect(t *testing.T) {
 		time.Sleep(time.Second)
 	}
 }
+
+func TestSessionHealthUnopened(t *testing.T) {
+	s := New("Bot token")
+
+	h := s.Health()
+	if h.Alive || h.Connected || h.Err != nil {
+		t.Fatalf("unopened session has unexpected health: %+v", h)
+	}
+	if h.Healthy(0) {
+		t.Fatal("unopened session is unexpectedly healthy")
+	}
+}
+
+func TestSessionFetchApplication(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/applications/@me" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Header.Get("Authorization") != "Bot valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message": "401: Unauthorized", "code": 0}`))
+			return
+		}
+
+		w.Write([]byte(`{
+			"id": "100",
+			"name": "bot",
+			"flags": 8192,
+			"integration_types_config": {
+				"0": {"oauth2_install_params": {"scopes": ["bot"], "permissions": "2048"}}
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	oldApplications := api.EndpointApplications
+	api.EndpointApplications = srv.URL + "/applications/"
+	defer func() { api.EndpointApplications = oldApplications }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := New("Bot invalid")
+	s.FetchApplication = true
+
+	if err := s.Open(ctx); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+	if s.Application() != nil || s.AppID().IsValid() {
+		t.Errorf("unexpected application %+v", s.Application())
+	}
+
+	s = New("Bot valid")
+
+	app, err := s.fetchApplication(ctx)
+	if err != nil {
+		t.Fatal("failed to fetch application:", err)
+	}
+
+	if app.ID != 100 || app.Flags != 8192 {
+		t.Errorf("unexpected application %+v", app)
+	}
+
+	install := app.IntegrationTypesConfig[0].OAuth2InstallParams
+	if install == nil || install.Permissions != 2048 {
+		t.Errorf("unexpected install params %+v", install)
+	}
+}
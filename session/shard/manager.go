@@ -10,6 +10,8 @@ import (
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/internal/backoff"
+	"github.com/diamondburned/arikawa/v3/session"
+	"github.com/diamondburned/arikawa/v3/utils/clock"
 )
 
 func updateIdentifier(ctx context.Context, id *gateway.Identifier) (url string, err error) {
@@ -39,6 +41,16 @@ func updateIdentifier(ctx context.Context, id *gateway.Identifier) (url string,
 // Manager is the manager responsible for handling all sharding on this
 // instance. An instance of Manager must never be copied.
 type Manager struct {
+	// Clock is the clock used to back off between rescaling attempts. It is
+	// clock.System if nil. It must be set before the Manager is used.
+	Clock clock.Clock
+	// ResumeStore, if not nil, is given to every shard that implements
+	// ResumeShard before it is opened, so that the shards can resume their
+	// sessions after a restart. The sessions saved by the old shards are
+	// deleted when rescaling, since they belong to a different number of
+	// shards. It must be set before the Manager is opened.
+	ResumeStore gateway.ResumeStore
+
 	// shards are the *shards.shards managed by this Manager. They are
 	// sorted in ascending order by their shard id.
 	shards     []ShardState
@@ -180,13 +192,90 @@ func (m *Manager) ForEach(f func(shard Shard)) {
 	}
 }
 
+// ShardHealth is the health of a single shard.
+type ShardHealth struct {
+	session.Health
+	// ShardID is the ID of the shard.
+	ShardID int
+	// Opened is true if the Manager has opened the shard.
+	Opened bool
+}
+
+// HealthShard is a Shard that can report its health. Both session.Session and
+// state.State implement it.
+type HealthShard interface {
+	Shard
+	Health() session.Health
+}
+
+// ResumeShard is a Shard that can persist its gateway session in a
+// gateway.ResumeStore. Both session.Session and state.State implement it.
+type ResumeShard interface {
+	Shard
+	SetResumeStore(gateway.ResumeStore)
+}
+
+// Health returns the health of each shard, ordered by shard ID. Shards that
+// don't implement HealthShard only have their ShardID and Opened fields set.
+func (m *Manager) Health() []ShardHealth {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	health := make([]ShardHealth, len(m.shards))
+	for i, shard := range m.shards {
+		health[i].ShardID = shard.ShardID()
+		health[i].Opened = shard.Opened
+
+		if s, ok := shard.Shard.(HealthShard); ok {
+			health[i].Health = s.Health()
+		}
+	}
+
+	return health
+}
+
+// Healthy returns true if all shards are healthy. Refer to
+// session.Health.Healthy.
+func (m *Manager) Healthy(maxAckAge time.Duration) bool {
+	for _, shard := range m.Health() {
+		if !shard.Healthy(maxAckAge) {
+			return false
+		}
+	}
+	return true
+}
+
 // Open opens all gateways handled by this Manager. If an error occurs, Open
 // will attempt to close all previously opened gateways before returning.
 func (m *Manager) Open(ctx context.Context) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	return OpenShards(ctx, m.shards)
+	return m.openShards(ctx, m.shards)
+}
+
+// openShards opens the given shards after giving them the ResumeStore.
+func (m *Manager) openShards(ctx context.Context, shards []ShardState) error {
+	if m.ResumeStore != nil {
+		for _, shard := range shards {
+			if s, ok := shard.Shard.(ResumeShard); ok {
+				s.SetResumeStore(m.ResumeStore)
+			}
+		}
+	}
+
+	return OpenShards(ctx, shards)
+}
+
+// forgetShards deletes the sessions saved by the given shards.
+func (m *Manager) forgetShards(ctx context.Context, shards []ShardState) {
+	if m.ResumeStore == nil {
+		return
+	}
+
+	for _, shard := range shards {
+		m.ResumeStore.SaveResume(ctx, shard.ShardID(), gateway.ResumeState{})
+	}
 }
 
 // Close closes all gateways handled by this Manager; it will stop rescaling if
@@ -240,8 +329,9 @@ func (m *Manager) rescale() {
 	// allows the caller to halt rescaling while we're closing or opening the
 	// shards.
 	CloseShards(oldShards)
+	m.forgetShards(ctx, oldShards)
 
-	backoffT := backoff.NewTimer(time.Second, 15*time.Minute)
+	backoffT := backoff.NewTimerWithClock(time.Second, 15*time.Minute, m.Clock)
 	defer backoffT.Stop()
 
 	for {
@@ -299,7 +389,7 @@ func (m *Manager) tryRescale(ctx context.Context) bool {
 		}
 	}
 
-	if err := OpenShards(ctx, newShards); err != nil {
+	if err := m.openShards(ctx, newShards); err != nil {
 		return false
 	}
 
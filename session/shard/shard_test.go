@@ -63,3 +63,38 @@ func TestSharding(t *testing.T) {
 		}
 	}
 }
+
+type resumeShard struct {
+	store gateway.ResumeStore
+}
+
+func (s *resumeShard) Open(context.Context) error               { return nil }
+func (s *resumeShard) Close() error                             { return nil }
+func (s *resumeShard) SetResumeStore(store gateway.ResumeStore) { s.store = store }
+
+func TestManagerResumeStore(t *testing.T) {
+	data := gateway.DefaultIdentifyCommand("Bot token")
+	data.Shard = &gateway.Shard{0, 2}
+
+	m, err := NewIdentifiedManagerWithURL("wss://gateway.invalid", gateway.NewIdentifier(data),
+		func(m *Manager, id *gateway.Identifier) (Shard, error) {
+			return &resumeShard{}, nil
+		},
+	)
+	if err != nil {
+		t.Fatal("failed to make shard manager:", err)
+	}
+
+	m.ResumeStore = gateway.NewFileResumeStore(t.TempDir())
+
+	if err := m.Open(context.Background()); err != nil {
+		t.Fatal("failed to open:", err)
+	}
+	defer m.Close()
+
+	m.ForEach(func(shard Shard) {
+		if shard.(*resumeShard).store != m.ResumeStore {
+			t.Error("shard was opened without the ResumeStore")
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package session
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+// EventHandler describes a type that event handlers can be added to, such as
+// *Session, *state.State or *handler.Handler.
+type EventHandler interface {
+	AddSyncHandler(handler interface{}) (rm func())
+}
+
+var _ EventHandler = (*Session)(nil)
+
+// WaitForEvent blocks until an event of type T that satisfies filter arrives
+// through h, or until ctx is done, in which case ctx.Err() is returned. If
+// filter is nil, then the first event of type T is returned. T must be either
+// a pointer to an event type or an interface, as with handler.AddHandler.
+//
+// The filter is called synchronously in the order that events arrive, so it
+// should not block. The handler is removed before WaitForEvent returns.
+//
+// For example, to wait for the next message in a channel:
+//
+//	msg, err := session.WaitForEvent(ctx, s, func(ev *gateway.MessageCreateEvent) bool {
+//		return ev.ChannelID == channelID
+//	})
+func WaitForEvent[T any](ctx context.Context, h EventHandler, filter func(T) bool) (T, error) {
+	result := make(chan T, 1)
+
+	rm := h.AddSyncHandler(func(ev T) {
+		if filter != nil && !filter(ev) {
+			return
+		}
+
+		select {
+		case result <- detachEvent(ev):
+		default:
+			// A previous event was already matched.
+		}
+	})
+	defer rm()
+
+	select {
+	case ev := <-result:
+		return ev, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// NextEvent blocks until the next event of type T arrives through h. It is a
+// shorthand for WaitForEvent with a nil filter.
+func NextEvent[T any](ctx context.Context, h EventHandler) (T, error) {
+	return WaitForEvent[T](ctx, h, nil)
+}
+
+// detachEvent returns a shallow copy of ev if it's a ws.PooledEvent, since
+// those are reused once the synchronous handlers return.
+func detachEvent[T any](ev T) T {
+	if p, ok := any(ev).(ws.PooledEvent); !ok || !p.Pooled() {
+		return ev
+	}
+
+	v := reflect.ValueOf(ev)
+	if v.Kind() != reflect.Ptr {
+		return ev
+	}
+
+	cpy := reflect.New(v.Type().Elem())
+	cpy.Elem().Set(v.Elem())
+
+	return cpy.Interface().(T)
+}
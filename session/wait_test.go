@@ -0,0 +1,76 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/handler"
+)
+
+func TestWaitForEvent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	t.Cleanup(cancel)
+
+	h := handler.New()
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		h.Call(&gateway.MessageCreateEvent{Message: discord.Message{ChannelID: 1}})
+		h.Call(&gateway.TypingStartEvent{ChannelID: 2})
+		h.Call(&gateway.MessageCreateEvent{Message: discord.Message{ChannelID: 2}})
+	}()
+
+	msg, err := WaitForEvent(ctx, h, func(ev *gateway.MessageCreateEvent) bool {
+		return ev.ChannelID == 2
+	})
+	if err != nil {
+		t.Fatal("failed to wait for event:", err)
+	}
+
+	if msg.ChannelID != 2 {
+		t.Fatal("unexpected channel ID:", msg.ChannelID)
+	}
+}
+
+type pooledEvent struct{ gateway.MessageCreateEvent }
+
+func (ev *pooledEvent) Pooled() bool { return true }
+func (ev *pooledEvent) Release()     { *ev = pooledEvent{} }
+
+func TestWaitForPooledEvent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	t.Cleanup(cancel)
+
+	h := handler.New()
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+
+		ev := &pooledEvent{}
+		ev.Content = "hime arikawa"
+		h.Call(ev)
+		ev.Release()
+	}()
+
+	ev, err := NextEvent[*pooledEvent](ctx, h)
+	if err != nil {
+		t.Fatal("failed to wait for event:", err)
+	}
+
+	if ev.Content != "hime arikawa" {
+		t.Fatalf("got a released event: %q", ev.Content)
+	}
+}
+
+func TestNextEventTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	t.Cleanup(cancel)
+
+	_, err := NextEvent[*gateway.ReadyEvent](ctx, handler.New())
+	if err != context.DeadlineExceeded {
+		t.Fatal("unexpected error:", err)
+	}
+}
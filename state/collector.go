@@ -0,0 +1,73 @@
+package state
+
+import (
+	"context"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+// ReactionFilter reports whether a reaction should be collected.
+type ReactionFilter func(*gateway.MessageReactionAddEvent) bool
+
+// CollectReactions collects the reactions added to the given message that pass
+// the filter, until max reactions are collected or ctx is done. If max is 0,
+// reactions are collected until ctx is done. If filter is nil, all reactions
+// are collected, including the ones created by the current user.
+//
+// Use context.WithTimeout to stop collecting after some time. If ctx is done
+// before max reactions are collected, then the reactions collected so far are
+// returned along with ctx.Err().
+func (s *State) CollectReactions(
+	ctx context.Context,
+	messageID discord.MessageID, max int, filter ReactionFilter) ([]*gateway.MessageReactionAddEvent, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch := make(chan *gateway.MessageReactionAddEvent)
+
+	rm := s.AddHandler(func(ev *gateway.MessageReactionAddEvent) {
+		if ev.MessageID != messageID || (filter != nil && !filter(ev)) {
+			return
+		}
+
+		select {
+		case ch <- ev:
+		case <-ctx.Done():
+		}
+	})
+	defer rm()
+
+	var reactions []*gateway.MessageReactionAddEvent
+
+	for max == 0 || len(reactions) < max {
+		select {
+		case ev := <-ch:
+			reactions = append(reactions, ev)
+		case <-ctx.Done():
+			return reactions, ctx.Err()
+		}
+	}
+
+	return reactions, nil
+}
+
+// AwaitReaction waits for the first reaction added to the given message that
+// passes the filter, or until ctx is done. It is useful for reaction menus,
+// where a filter can check the user and the emoji:
+//
+//	ev, err := s.AwaitReaction(ctx, msg.ID, func(ev *gateway.MessageReactionAddEvent) bool {
+//		return ev.UserID == userID && ev.Emoji.APIString() == "✅"
+//	})
+func (s *State) AwaitReaction(
+	ctx context.Context,
+	messageID discord.MessageID, filter ReactionFilter) (*gateway.MessageReactionAddEvent, error) {
+
+	reactions, err := s.CollectReactions(ctx, messageID, 1, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return reactions[0], nil
+}
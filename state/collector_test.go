@@ -0,0 +1,56 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+func TestCollectReactions(t *testing.T) {
+	s := New("")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		// Wait for the collector to add its handler.
+		for !s.Handles(reflect.TypeOf((*gateway.MessageReactionAddEvent)(nil))) {
+			time.Sleep(time.Millisecond)
+		}
+
+		s.Call(&gateway.MessageReactionAddEvent{MessageID: 2, UserID: 1})
+		s.Call(&gateway.MessageReactionAddEvent{MessageID: 1, UserID: 2})
+		s.Call(&gateway.MessageReactionAddEvent{MessageID: 1, UserID: 1})
+	}()
+
+	ev, err := s.AwaitReaction(ctx, 1, func(ev *gateway.MessageReactionAddEvent) bool {
+		return ev.UserID == 1
+	})
+	if err != nil {
+		t.Fatal("failed to await reaction:", err)
+	}
+
+	if ev.MessageID != 1 || ev.UserID != 1 {
+		t.Errorf("unexpected reaction %+v", ev)
+	}
+}
+
+func TestCollectReactionsTimeout(t *testing.T) {
+	s := New("")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	reactions, err := s.CollectReactions(ctx, 1, 0, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+
+	if len(reactions) != 0 {
+		t.Errorf("unexpected reactions %v", reactions)
+	}
+}
@@ -1,7 +1,11 @@
 package state
 
 import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state/store"
 )
 
 func (s *State) handleReady(ev *gateway.ReadyEvent) {
@@ -12,27 +16,47 @@ func (s *State) handleReady(ev *gateway.ReadyEvent) {
 		delete(s.fewMessages, chID)
 	}
 
+	// A new Ready event restates all guilds, so any outage will be resolved
+	// through a GuildReadyEvent instead.
+	for guildID := range s.unavailableGuilds {
+		delete(s.unavailableGuilds, guildID)
+	}
+
 	for _, g := range ev.Guilds {
 		s.unreadyGuilds[g.ID] = struct{}{}
 	}
+
+	if s.preloader != nil {
+		s.preloader.cancel()
+		s.preloader = nil
+	}
+
+	if s.PreloadConcurrency > 0 {
+		s.preloader = s.preload(ev)
+	}
 }
 
 func (s *State) handleGuildCreate(ev *gateway.GuildCreateEvent) {
 	s.guildMutex.Lock()
 
 	var derivedEvent interface{}
+	var preloader *preloader
 
 	// The guild was previously announced to us in the ready event, and has now
 	// become available.
 	if _, ok := s.unreadyGuilds[ev.ID]; ok {
 		delete(s.unreadyGuilds, ev.ID)
 		derivedEvent = &GuildReadyEvent{GuildCreateEvent: ev}
+		preloader = s.preloader
 
 		// The guild was previously announced as unavailable through a guild
 		// delete event, and has now become available again.
-	} else if _, ok = s.unavailableGuilds[ev.ID]; ok {
+	} else if since, ok := s.unavailableGuilds[ev.ID]; ok {
 		delete(s.unavailableGuilds, ev.ID)
-		derivedEvent = &GuildAvailableEvent{GuildCreateEvent: ev}
+		derivedEvent = &GuildAvailableEvent{
+			GuildCreateEvent: ev,
+			UnavailableSince: since,
+		}
 
 		// We don't know this guild, hence it's new.
 	} else {
@@ -43,6 +67,10 @@ func (s *State) handleGuildCreate(ev *gateway.GuildCreateEvent) {
 	// long-blocking synchronous handlers.
 	s.guildMutex.Unlock()
 	s.Handler.Call(derivedEvent)
+
+	if preloader != nil {
+		preloader.guild(s, ev)
+	}
 }
 
 func (s *State) handleGuildDelete(ev *gateway.GuildDeleteEvent) {
@@ -51,7 +79,10 @@ func (s *State) handleGuildDelete(ev *gateway.GuildDeleteEvent) {
 	// store this so we can later dispatch a GuildAvailableEvent, once the
 	// guild becomes available again.
 	if ev.Unavailable {
-		s.unavailableGuilds[ev.ID] = struct{}{}
+		// Keep the earliest time if Discord repeats the event.
+		if _, ok := s.unavailableGuilds[ev.ID]; !ok {
+			s.unavailableGuilds[ev.ID] = time.Now()
+		}
 		s.guildMutex.Unlock()
 
 		s.Handler.Call(&GuildUnavailableEvent{GuildDeleteEvent: ev})
@@ -64,3 +95,59 @@ func (s *State) handleGuildDelete(ev *gateway.GuildDeleteEvent) {
 		s.Handler.Call(&GuildLeaveEvent{GuildDeleteEvent: ev})
 	}
 }
+
+// handleThreadListSync replaces the cached threads of the synced channels with
+// the ones in the event, returning the IDs of the threads that were removed.
+func (s *State) handleThreadListSync(ev *gateway.ThreadListSyncEvent) []discord.ChannelID {
+	if syncer, ok := s.Cabinet.ChannelStore.(store.ThreadSyncStore); ok {
+		removed, err := syncer.ThreadsSync(ev.GuildID, ev.ChannelIDs, ev.Threads)
+		if err != nil {
+			s.stateErr(err, "failed to sync threads in state")
+		}
+		return removed
+	}
+
+	synced := make(map[discord.ChannelID]struct{}, len(ev.Threads))
+	for _, thread := range ev.Threads {
+		synced[thread.ID] = struct{}{}
+	}
+
+	parents := make(map[discord.ChannelID]struct{}, len(ev.ChannelIDs))
+	for _, id := range ev.ChannelIDs {
+		parents[id] = struct{}{}
+	}
+
+	var removed []discord.ChannelID
+
+	// An error here likely means that the guild isn't cached, in which case
+	// there's nothing to remove.
+	chs, _ := s.Cabinet.Channels(ev.GuildID)
+	for i, ch := range chs {
+		if !ch.Type.IsThread() {
+			continue
+		}
+		if _, ok := synced[ch.ID]; ok {
+			continue
+		}
+		if _, ok := parents[ch.ParentID]; !ok && ev.ChannelIDs != nil {
+			continue
+		}
+
+		if err := s.Cabinet.ChannelRemove(&chs[i]); err != nil {
+			s.stateErr(err, "failed to remove a stale thread in state sync")
+			continue
+		}
+
+		removed = append(removed, ch.ID)
+	}
+
+	for i := range ev.Threads {
+		ev.Threads[i].GuildID = ev.GuildID
+
+		if err := s.Cabinet.ChannelSet(&ev.Threads[i], true); err != nil {
+			s.stateErr(err, "failed to set a thread in state sync")
+		}
+	}
+
+	return removed
+}
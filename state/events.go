@@ -1,6 +1,11 @@
 package state
 
-import "github.com/diamondburned/arikawa/v3/gateway"
+import (
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
 
 // events that originated from GuildCreate:
 type (
@@ -19,6 +24,8 @@ type (
 	// were already unavailable when connecting to the gateway.
 	GuildAvailableEvent struct {
 		*gateway.GuildCreateEvent
+		// UnavailableSince is the time the guild became unavailable.
+		UnavailableSince time.Time
 	}
 
 	// GuildJoinEvent gets fired if the bot/user joins a guild.
@@ -35,8 +42,35 @@ type (
 		*gateway.GuildDeleteEvent
 	}
 
-	// GuildUnavailableEvent gets fired if a guild becomes unavailable.
+	// GuildUnavailableEvent gets fired if a guild becomes unavailable, such
+	// as during an outage. Unlike GuildLeaveEvent, the bot/user is still in
+	// the guild, and a GuildAvailableEvent will be fired once it becomes
+	// available again. State.UnavailableGuilds returns all guilds that are
+	// currently unavailable.
 	GuildUnavailableEvent struct {
 		*gateway.GuildDeleteEvent
 	}
 )
+
+// ThreadsSyncedEvent gets fired after the State has handled a Thread List Sync
+// event, which happens when the current user gains access to a channel. All
+// cached threads of the synced channels that weren't in the event have been
+// removed by then.
+type ThreadsSyncedEvent struct {
+	*gateway.ThreadListSyncEvent
+	// RemovedThreadIDs are the IDs of the threads that were removed from the
+	// state, since the current user lost access to them.
+	RemovedThreadIDs []discord.ChannelID
+}
+
+// PreloadDoneEvent gets fired once the State has finished preloading the guilds
+// in the Ready event. It is only fired if State.PreloadConcurrency is positive
+// and preloading wasn't interrupted by another Ready event. Since guilds are
+// preloaded after their Guild Create events, unavailable guilds delay it until
+// they become available.
+type PreloadDoneEvent struct {
+	// Guilds is the number of guilds that were preloaded.
+	Guilds int
+	// Errors contains the errors of the requests that failed, if any.
+	Errors []error
+}
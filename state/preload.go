@@ -0,0 +1,150 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+// preloader fills in the guilds of a Ready event as their Guild Create events
+// arrive. Most of a guild is already in its Guild Create event, so only the
+// parts that the event lacks and that the State would cache under its intents
+// are fetched.
+type preloader struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	sema   chan struct{}
+	guilds int
+	// skip is true if there's nothing to preload.
+	skip bool
+
+	mu      sync.Mutex
+	pending int
+	errs    []error
+}
+
+// preload starts preloading the guilds in the given Ready event. It must be
+// called with guildMutex held. The returned preloader must be cancelled once
+// another Ready event arrives.
+func (s *State) preload(ev *gateway.ReadyEvent) *preloader {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p := &preloader{
+		ctx:     ctx,
+		cancel:  cancel,
+		sema:    make(chan struct{}, s.PreloadConcurrency),
+		guilds:  len(ev.Guilds),
+		pending: len(ev.Guilds),
+	}
+
+	// Without the Guilds intent, no Guild Create events arrive, and nothing
+	// that preloading would fetch is cached anyway.
+	if p.pending == 0 || !s.HasIntents(gateway.IntentGuilds) {
+		p.skip = true
+		go p.done(s)
+	}
+
+	return p
+}
+
+// guild preloads the guild of the given Guild Create event in the background.
+// It must be called once for each guild in the Ready event.
+func (p *preloader) guild(s *State, ev *gateway.GuildCreateEvent) {
+	if p.skip {
+		return
+	}
+
+	go func() {
+		var errs []error
+
+		select {
+		case p.sema <- struct{}{}:
+			errs = s.WithContext(p.ctx).preloadGuild(ev)
+			<-p.sema
+		case <-p.ctx.Done():
+			return
+		}
+
+		p.mu.Lock()
+		p.errs = append(p.errs, errs...)
+		p.pending--
+		last := p.pending == 0
+		p.mu.Unlock()
+
+		if last {
+			p.done(s)
+		}
+	}()
+}
+
+func (p *preloader) done(s *State) {
+	defer p.cancel()
+
+	if p.ctx.Err() != nil {
+		// Interrupted by another Ready event.
+		return
+	}
+
+	p.mu.Lock()
+	errs := p.errs
+	p.mu.Unlock()
+
+	s.Handler.Call(&PreloadDoneEvent{
+		Guilds: p.guilds,
+		Errors: errs,
+	})
+}
+
+// preloadGuild fetches the parts of the guild that are missing from its Guild
+// Create event.
+func (s *State) preloadGuild(ev *gateway.GuildCreateEvent) []error {
+	if ev.Unavailable {
+		// The guild's data arrives with a later Guild Create event.
+		return nil
+	}
+
+	var errs []error
+
+	if ev.Channels == nil {
+		if _, err := s.Channels(ev.ID); err != nil {
+			errs = append(errs, preloadErr("channels", ev.ID, err))
+		}
+	}
+
+	if ev.Roles == nil {
+		if err := s.preloadRoles(ev.ID); err != nil {
+			errs = append(errs, preloadErr("roles", ev.ID, err))
+		}
+	}
+
+	if ev.Emojis == nil && s.HasIntents(gateway.IntentGuildExpressions) {
+		if _, err := s.Emojis(ev.ID); err != nil {
+			errs = append(errs, preloadErr("emojis", ev.ID, err))
+		}
+	}
+
+	return errs
+}
+
+// preloadRoles fetches the roles of the guild. Unlike Roles, it doesn't trust
+// the roles of the cached guild, since they came from the same Guild Create
+// event.
+func (s *State) preloadRoles(guildID discord.GuildID) error {
+	rs, err := s.Session.Roles(guildID)
+	if err != nil {
+		return err
+	}
+
+	for i := range rs {
+		s.RoleSet(guildID, &rs[i], false)
+	}
+
+	return nil
+}
+
+func preloadErr(what string, guildID discord.GuildID, err error) error {
+	return fmt.Errorf("failed to preload %s of guild %d: %w", what, guildID, err)
+}
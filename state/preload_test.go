@@ -0,0 +1,131 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+func waitPreload(t *testing.T, done <-chan *PreloadDoneEvent) *PreloadDoneEvent {
+	t.Helper()
+
+	select {
+	case ev := <-done:
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for PreloadDoneEvent")
+		return nil
+	}
+}
+
+func TestPreload(t *testing.T) {
+	s, rc := newRecordState(func(path string) interface{} {
+		return []discord.Channel{{ID: 20, GuildID: 2, Name: "fetched"}}
+	})
+	s.PreloadConcurrency = 2
+
+	done := make(chan *PreloadDoneEvent, 1)
+	s.AddHandler(func(ev *PreloadDoneEvent) { done <- ev })
+
+	s.Session.Handler.Call(&gateway.ReadyEvent{
+		Guilds: []gateway.GuildCreateEvent{
+			{Guild: discord.Guild{ID: 1}, Unavailable: true},
+			{Guild: discord.Guild{ID: 2}, Unavailable: true},
+		},
+	})
+
+	// Nothing is fetched before the guilds' Guild Create events.
+	time.Sleep(10 * time.Millisecond)
+	if paths := rc.Paths(); len(paths) > 0 {
+		t.Fatalf("unexpected requests before Guild Create: %v", paths)
+	}
+
+	// This guild is complete, so nothing needs to be fetched.
+	s.Session.Handler.Call(&gateway.GuildCreateEvent{
+		Guild: discord.Guild{
+			ID:     1,
+			Roles:  []discord.Role{{ID: 1}},
+			Emojis: []discord.Emoji{},
+		},
+		Channels: []discord.Channel{{ID: 10}},
+	})
+
+	// This guild lacks its channels.
+	s.Session.Handler.Call(&gateway.GuildCreateEvent{
+		Guild: discord.Guild{
+			ID:     2,
+			Roles:  []discord.Role{{ID: 2}},
+			Emojis: []discord.Emoji{},
+		},
+	})
+
+	ev := waitPreload(t, done)
+	if ev.Guilds != 2 || len(ev.Errors) > 0 {
+		t.Fatalf("unexpected PreloadDoneEvent: %+v", ev)
+	}
+
+	if paths := rc.Paths(); len(paths) != 1 || paths[0] != "/guilds/2/channels" {
+		t.Fatalf("expected only the missing channels to be fetched, got %v", paths)
+	}
+
+	ch, err := s.Cabinet.Channel(20)
+	if err != nil || ch.Name != "fetched" {
+		t.Fatalf("fetched channel not in state: %v, %v", ch, err)
+	}
+}
+
+func TestPreloadWithoutGuildsIntent(t *testing.T) {
+	s, rc := newRecordState(func(path string) interface{} {
+		return []discord.Channel{}
+	}, gateway.IntentGuildMessages)
+	s.PreloadConcurrency = 2
+
+	done := make(chan *PreloadDoneEvent, 1)
+	s.AddHandler(func(ev *PreloadDoneEvent) { done <- ev })
+
+	s.Session.Handler.Call(&gateway.ReadyEvent{
+		Guilds: []gateway.GuildCreateEvent{
+			{Guild: discord.Guild{ID: 1}, Unavailable: true},
+		},
+	})
+
+	ev := waitPreload(t, done)
+	if ev.Guilds != 1 {
+		t.Fatalf("unexpected PreloadDoneEvent: %+v", ev)
+	}
+
+	if paths := rc.Paths(); len(paths) > 0 {
+		t.Fatalf("unexpected requests without the Guilds intent: %v", paths)
+	}
+}
+
+func TestPreloadInterrupted(t *testing.T) {
+	s, _ := newRecordState(func(path string) interface{} {
+		return []discord.Channel{}
+	})
+	s.PreloadConcurrency = 1
+
+	done := make(chan *PreloadDoneEvent, 2)
+	s.AddHandler(func(ev *PreloadDoneEvent) { done <- ev })
+
+	s.Session.Handler.Call(&gateway.ReadyEvent{
+		Guilds: []gateway.GuildCreateEvent{
+			{Guild: discord.Guild{ID: 1}, Unavailable: true},
+		},
+	})
+
+	// A new Ready event cancels the first preload, whose guild never arrived.
+	s.Session.Handler.Call(&gateway.ReadyEvent{})
+
+	if ev := waitPreload(t, done); ev.Guilds != 0 {
+		t.Fatalf("unexpected PreloadDoneEvent: %+v", ev)
+	}
+
+	select {
+	case ev := <-done:
+		t.Fatalf("interrupted preload finished: %+v", ev)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
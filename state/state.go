@@ -7,15 +7,18 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/internal/singleflight"
 	"github.com/diamondburned/arikawa/v3/session"
 	"github.com/diamondburned/arikawa/v3/session/shard"
 	"github.com/diamondburned/arikawa/v3/state/store"
 	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
 	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
 )
 
 var (
@@ -95,20 +98,49 @@ type State struct {
 	// with the State.
 	*handler.Handler
 
+	// PreloadConcurrency, if positive, makes the State complete every guild
+	// in the Ready event once its Guild Create event arrives, fetching at most
+	// this many guilds at once. Only the channels, roles and emojis that the
+	// Guild Create event lacks and that the State caches under its intents
+	// are fetched, so usually no requests are made at all. A PreloadDoneEvent
+	// is dispatched once every guild has been preloaded.
+	PreloadConcurrency int // default 0, disabled
+
 	// List of channels with few messages, so it doesn't bother hitting the API
 	// again.
 	fewMessages map[discord.ChannelID]struct{}
 	fewMutex    *sync.Mutex
 
-	// unavailableGuilds is a set of discord.GuildIDs of guilds that became
+	// unavailableGuilds maps the discord.GuildIDs of guilds that became
 	// unavailable after connecting to the gateway, i.e. they were sent in a
-	// GuildUnavailableEvent.
-	unavailableGuilds map[discord.GuildID]struct{}
+	// GuildUnavailableEvent, to the time they became unavailable.
+	unavailableGuilds map[discord.GuildID]time.Time
 	// unreadyGuilds is a set of discord.GuildIDs of the guilds received during
 	// the Ready event. After receiving guild create events for those guilds,
 	// they will be removed.
 	unreadyGuilds map[discord.GuildID]struct{}
 	guildMutex    *sync.Mutex
+
+	// preloader is the ongoing preload, if any. It's guarded by guildMutex.
+	preloader *preloader
+
+	// flights coalesces concurrent REST fallbacks for the same item.
+	flights *flights
+}
+
+// flights contains the singleflight groups used to coalesce concurrent cache
+// misses into a single request. The request is detached from the cancellation
+// of the caller that made it, and every other caller gets its own deep copy of
+// the result.
+type flights struct {
+	guilds   singleflight.Group[discord.GuildID, *discord.Guild]
+	channels singleflight.Group[discord.ChannelID, *discord.Channel]
+	members  singleflight.Group[memberKey, *discord.Member]
+}
+
+type memberKey struct {
+	guildID discord.GuildID
+	userID  discord.UserID
 }
 
 // New creates a new state.
@@ -145,9 +177,10 @@ func NewFromSession(s *session.Session, cabinet *store.Cabinet) *State {
 		readyMu:           new(sync.Mutex),
 		fewMessages:       map[discord.ChannelID]struct{}{},
 		fewMutex:          new(sync.Mutex),
-		unavailableGuilds: make(map[discord.GuildID]struct{}),
+		unavailableGuilds: make(map[discord.GuildID]time.Time),
 		unreadyGuilds:     make(map[discord.GuildID]struct{}),
 		guildMutex:        new(sync.Mutex),
+		flights:           new(flights),
 	}
 	state.hookSession()
 	return state
@@ -165,6 +198,7 @@ func NewAPIOnlyState(token string, h *handler.Handler) *State {
 		Handler:  h,
 		Cabinet:  store.NoopCabinet,
 		StateLog: func(err error) {},
+		flights:  new(flights),
 	}
 }
 
@@ -192,6 +226,32 @@ func (s *State) Ready() gateway.ReadyEvent {
 	return r
 }
 
+// UnavailableGuilds returns the IDs of the guilds that are currently
+// unavailable due to an outage, that is, guilds that became unavailable after
+// connecting to the gateway. Guilds that haven't been received yet since the
+// Ready event are not included.
+func (s *State) UnavailableGuilds() []discord.GuildID {
+	s.guildMutex.Lock()
+	defer s.guildMutex.Unlock()
+
+	ids := make([]discord.GuildID, 0, len(s.unavailableGuilds))
+	for id := range s.unavailableGuilds {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// GuildUnavailableSince returns the time the guild with the given ID became
+// unavailable. False is returned if the guild is not currently unavailable.
+func (s *State) GuildUnavailableSince(guildID discord.GuildID) (time.Time, bool) {
+	s.guildMutex.Lock()
+	defer s.guildMutex.Unlock()
+
+	since, ok := s.unavailableGuilds[guildID]
+	return since, ok
+}
+
 //// Helper methods
 
 func (s *State) AuthorDisplayName(message *gateway.MessageCreateEvent) string {
@@ -270,7 +330,7 @@ func MemberColor(m *discord.Member, role func(discord.RoleID) *discord.Role) (di
 
 	for _, roleID := range m.RoleIDs {
 		if r := role(roleID); r != nil {
-			if r.Color > 0 && r.Position > pos {
+			if r.Color.IsSet() && r.Position > pos {
 				c = r.Color
 				pos = r.Position
 			}
@@ -341,6 +401,28 @@ func (s *State) Permissions(
 	return discord.CalcOverwrites(*g, *ch, *m), nil
 }
 
+// AppPermissions returns the permissions of the application in the channel of
+// the interaction. The permissions sent with the interaction are used if there
+// are any, since they are also correct for user-installed applications whose
+// bot isn't in the guild. Otherwise, the permissions of the bot are computed
+// using Permissions.
+func (s *State) AppPermissions(ev *discord.InteractionEvent) (discord.Permissions, error) {
+	if ev.AppPermissions != 0 {
+		return ev.AppPermissions, nil
+	}
+
+	if ev.IsUserInstallOnly() {
+		return 0, errors.New("interaction has no app permissions")
+	}
+
+	me, err := s.Me()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get bot user: %w", err)
+	}
+
+	return s.Permissions(ev.ChannelID, me.ID)
+}
+
 ////
 
 func (s *State) Me() (*discord.User, error) {
@@ -367,16 +449,7 @@ func (s *State) Channel(id discord.ChannelID) (c *discord.Channel, err error) {
 		return
 	}
 
-	c, err = s.Session.Channel(id)
-	if err != nil {
-		return
-	}
-
-	if s.tracksChannel(c) {
-		s.Cabinet.ChannelSet(c, false)
-	}
-
-	return
+	return s.fetchChannel(id)
 }
 
 func (s *State) Channels(guildID discord.GuildID) (cs []discord.Channel, err error) {
@@ -437,12 +510,25 @@ func (s *State) PrivateChannels() ([]discord.Channel, error) {
 	return cs, nil
 }
 
+// SendDM sends a message to the user in a direct message. The DM channel is
+// taken from the cache if possible, so it is only created once per user.
+func (s *State) SendDM(
+	userID discord.UserID, data api.SendMessageData) (*discord.Message, error) {
+
+	ch, err := s.CreatePrivateChannel(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DM channel: %w", err)
+	}
+
+	return s.SendMessageComplex(ch.ID, data)
+}
+
 ////
 
 func (s *State) Emoji(
 	guildID discord.GuildID, emojiID discord.EmojiID) (e *discord.Emoji, err error) {
 
-	if s.HasIntents(gateway.IntentGuildEmojis) {
+	if s.HasIntents(gateway.IntentGuildExpressions) {
 		e, err = s.Cabinet.Emoji(guildID, emojiID)
 		if err == nil {
 			return
@@ -468,7 +554,7 @@ func (s *State) Emoji(
 }
 
 func (s *State) Emojis(guildID discord.GuildID) (es []discord.Emoji, err error) {
-	if s.HasIntents(gateway.IntentGuildEmojis) {
+	if s.HasIntents(gateway.IntentGuildExpressions) {
 		es, err = s.Cabinet.Emojis(guildID)
 		if err == nil {
 			return
@@ -480,7 +566,7 @@ func (s *State) Emojis(guildID discord.GuildID) (es []discord.Emoji, err error)
 		return
 	}
 
-	if s.HasIntents(gateway.IntentGuildEmojis) {
+	if s.HasIntents(gateway.IntentGuildExpressions) {
 		s.Cabinet.EmojiSet(guildID, es, false)
 	}
 
@@ -525,6 +611,10 @@ func (s *State) Guilds() (gs []discord.Guild, err error) {
 
 ////
 
+// Member returns the member from the state, or fetches it if it isn't cached.
+// The state is only used with the Guild Members intent, since members cached
+// from other events, such as Typing Start, are never updated or removed
+// without it.
 func (s *State) Member(guildID discord.GuildID, userID discord.UserID) (*discord.Member, error) {
 	if s.HasIntents(gateway.IntentGuildMembers) {
 		m, err := s.Cabinet.Member(guildID, userID)
@@ -550,9 +640,7 @@ func (s *State) Members(guildID discord.GuildID) (ms []discord.Member, err error
 	}
 
 	if s.HasIntents(gateway.IntentGuildMembers) {
-		for i := range ms {
-			s.Cabinet.MemberSet(guildID, &ms[i], false)
-		}
+		s.Cabinet.MembersSet(guildID, ms, false)
 	}
 
 	return
@@ -690,15 +778,78 @@ func (s *State) Messages(channelID discord.ChannelID, limit uint) ([]discord.Mes
 			i = len(apiMessages)
 		}
 
-		msgs := apiMessages[:i]
-		for i := range msgs {
-			s.Cabinet.MessageSet(&msgs[i], false)
-		}
+		s.Cabinet.MessagesSet(apiMessages[:i], false)
 	}
 
 	return append(storeMessages, apiMessages...), nil
 }
 
+// SendMessageComplex sends a message like api.Client.SendMessageComplex. If
+// the channel and its guild are cached, the attached files are first validated
+// against the guild's upload limit, and an *api.UploadTooLargeError is
+// returned if they are too large.
+//
+// Only SendMessageComplex, EditMessageComplex and NewMessage validate uploads;
+// the other message methods can't attach files. Interaction responses and
+// webhooks aren't validated, since they aren't sent to a known channel, so
+// their files should be checked using api.ValidateUploadSize.
+func (s *State) SendMessageComplex(
+	channelID discord.ChannelID, data api.SendMessageData) (*discord.Message, error) {
+
+	if err := s.validateUpload(channelID, data.Files); err != nil {
+		return nil, err
+	}
+
+	return s.Session.SendMessageComplex(channelID, data)
+}
+
+// EditMessageComplex edits a message like api.Client.EditMessageComplex. The
+// attached files are validated like in SendMessageComplex.
+func (s *State) EditMessageComplex(
+	channelID discord.ChannelID,
+	messageID discord.MessageID, data api.EditMessageData) (*discord.Message, error) {
+
+	if err := s.validateUpload(channelID, data.Files); err != nil {
+		return nil, err
+	}
+
+	return s.Session.EditMessageComplex(channelID, messageID, data)
+}
+
+// NewMessage creates a new api.MessageBuilder that sends a message to the
+// given channel using the state, so that the attached files are validated like
+// in SendMessageComplex.
+func (s *State) NewMessage(channelID discord.ChannelID) *api.MessageBuilder {
+	return api.NewMessageBuilder(channelID, func(ctx context.Context) api.MessageSender {
+		return s.WithContext(ctx)
+	})
+}
+
+// validateUpload validates the size of the files against the upload limit of
+// the channel, if it can be known from the cache.
+func (s *State) validateUpload(channelID discord.ChannelID, files []sendpart.File) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	c, err := s.Cabinet.Channel(channelID)
+	if err != nil {
+		return nil
+	}
+
+	limit := int64(discord.DefaultUploadLimit)
+
+	if c.GuildID.IsValid() {
+		g, err := s.Cabinet.Guild(c.GuildID)
+		if err != nil {
+			return nil
+		}
+		limit = g.NitroBoost.UploadLimit()
+	}
+
+	return api.ValidateUploadSize(files, limit)
+}
+
 ////
 
 // Presence checks the state for user presences. If no guildID is given, it
@@ -784,22 +935,118 @@ func (s *State) Roles(guildID discord.GuildID) ([]discord.Role, error) {
 	return rs, nil
 }
 
-func (s *State) fetchGuild(id discord.GuildID) (g *discord.Guild, err error) {
-	g, err = s.Session.Guild(id)
-	if err == nil && s.HasIntents(gateway.IntentGuilds) {
-		s.Cabinet.GuildSet(g, false)
+func (s *State) fetchGuild(id discord.GuildID) (*discord.Guild, error) {
+	g, err, shared := s.flights.guilds.Do(s.Client.Context(), id,
+		func(ctx context.Context) (*discord.Guild, error) {
+			g, err := s.Session.WithContext(ctx).Guild(id)
+			if err == nil && s.HasIntents(gateway.IntentGuilds) {
+				s.Cabinet.GuildSet(g, false)
+			}
+			return g, err
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return
+	if shared {
+		return copyGuild(g), nil
+	}
+
+	return g, nil
 }
 
-func (s *State) fetchMember(gID discord.GuildID, uID discord.UserID) (m *discord.Member, err error) {
-	m, err = s.Session.Member(gID, uID)
-	if err == nil && s.HasIntents(gateway.IntentGuildMembers) {
-		s.Cabinet.MemberSet(gID, m, false)
+func (s *State) fetchChannel(id discord.ChannelID) (*discord.Channel, error) {
+	c, err, shared := s.flights.channels.Do(s.Client.Context(), id,
+		func(ctx context.Context) (*discord.Channel, error) {
+			c, err := s.Session.WithContext(ctx).Channel(id)
+			if err == nil && s.tracksChannel(c) {
+				s.Cabinet.ChannelSet(c, false)
+			}
+			return c, err
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return
+	if shared {
+		return copyChannel(c), nil
+	}
+
+	return c, nil
+}
+
+func (s *State) fetchMember(gID discord.GuildID, uID discord.UserID) (*discord.Member, error) {
+	key := memberKey{gID, uID}
+
+	m, err, shared := s.flights.members.Do(s.Client.Context(), key,
+		func(ctx context.Context) (*discord.Member, error) {
+			m, err := s.Session.WithContext(ctx).Member(gID, uID)
+			if err == nil && s.HasIntents(gateway.IntentGuildMembers) {
+				s.Cabinet.MemberSet(gID, m, false)
+			}
+			return m, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if shared {
+		return copyMember(m), nil
+	}
+
+	return m, nil
+}
+
+// copyGuild, copyChannel and copyMember copy results shared between coalesced
+// callers, so that no caller can modify another's. Like the stores, they copy
+// the struct along with the slices and pointers that it owns, but not the ones
+// within them.
+
+func copyGuild(g *discord.Guild) *discord.Guild {
+	cpy := *g
+	cpy.Roles = copySlice(g.Roles)
+	cpy.Emojis = copySlice(g.Emojis)
+	cpy.Features = copySlice(g.Features)
+	return &cpy
+}
+
+func copyChannel(c *discord.Channel) *discord.Channel {
+	cpy := *c
+	cpy.Overwrites = copySlice(c.Overwrites)
+	cpy.DMRecipients = copySlice(c.DMRecipients)
+	cpy.AvailableTags = copySlice(c.AvailableTags)
+	cpy.AppliedTags = copySlice(c.AppliedTags)
+	cpy.ThreadMetadata = copyPtr(c.ThreadMetadata)
+	cpy.ThreadMember = copyPtr(c.ThreadMember)
+	cpy.DefaultReactionEmoji = copyPtr(c.DefaultReactionEmoji)
+	cpy.DefaultSoftOrder = copyPtr(c.DefaultSoftOrder)
+	return &cpy
+}
+
+func copyMember(m *discord.Member) *discord.Member {
+	cpy := *m
+	cpy.RoleIDs = copySlice(m.RoleIDs)
+	cpy.AvatarDecoration = copyPtr(m.AvatarDecoration)
+	return &cpy
+}
+
+// copySlice copies the slice, keeping nil slices nil.
+func copySlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
+
+func copyPtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	cpy := *p
+	return &cpy
 }
 
 // tracksMessage reports whether the state would track the passed message and
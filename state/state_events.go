@@ -1,6 +1,7 @@
 package state
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/diamondburned/arikawa/v3/discord"
@@ -9,10 +10,10 @@ import (
 )
 
 func (s *State) hookSession() {
-	s.Session.AddSyncHandler(func(event interface{}) {
+	s.Session.AddSyncHandler(func(ctx context.Context, event interface{}) {
 		// Call the pre-handler before the state handler.
 		if s.PreHandler != nil {
-			s.PreHandler.Call(event)
+			s.PreHandler.CallContext(ctx, event)
 		}
 
 		// Run the state handler.
@@ -20,30 +21,37 @@ func (s *State) hookSession() {
 
 		switch event := event.(type) {
 		case *gateway.ReadyEvent:
-			s.Handler.Call(event)
+			s.Handler.CallContext(ctx, event)
 			s.handleReady(event)
 		case *gateway.GuildCreateEvent:
-			s.Handler.Call(event)
+			s.Handler.CallContext(ctx, event)
 			s.handleGuildCreate(event)
 		case *gateway.GuildDeleteEvent:
-			s.Handler.Call(event)
+			s.Handler.CallContext(ctx, event)
 			s.handleGuildDelete(event)
+		case *gateway.ThreadListSyncEvent:
+			removed := s.handleThreadListSync(event)
+			s.Handler.CallContext(ctx, event)
+			s.Handler.CallContext(ctx, &ThreadsSyncedEvent{
+				ThreadListSyncEvent: event,
+				RemovedThreadIDs:    removed,
+			})
 
 		// https://github.com/discord/discord-api-docs/commit/01665c4
 		case *gateway.MessageCreateEvent:
 			if event.Member != nil {
 				event.Member.User = event.Author
 			}
-			s.Handler.Call(event)
+			s.Handler.CallContext(ctx, event)
 
 		case *gateway.MessageUpdateEvent:
 			if event.Member != nil {
 				event.Member.User = event.Author
 			}
-			s.Handler.Call(event)
+			s.Handler.CallContext(ctx, event)
 
 		default:
-			s.Handler.Call(event)
+			s.Handler.CallContext(ctx, event)
 		}
 	})
 }
@@ -112,10 +120,8 @@ func (s *State) onEvent(iface interface{}) {
 			guild := ready.Guilds[i]
 
 			members := gateway.ConvertSupplementalMembers(ev.MergedMembers[i])
-			for i := range members {
-				if err := s.Cabinet.MemberSet(guild.ID, &members[i], false); err != nil {
-					s.stateErr(err, "failed to set friend presence in Ready Supplemental")
-				}
+			if err := s.Cabinet.MembersSet(guild.ID, members, false); err != nil {
+				s.stateErr(err, "failed to set members in Ready Supplemental")
 			}
 
 			presences := gateway.ConvertSupplementalPresences(ev.MergedPresences.Guilds[i])
@@ -164,10 +170,10 @@ func (s *State) onEvent(iface interface{}) {
 		}
 
 	case *gateway.GuildMembersChunkEvent:
-		for i := range ev.Members {
-			if err := s.Cabinet.MemberSet(ev.GuildID, &ev.Members[i], false); err != nil {
-				s.stateErr(err, "failed to add a member from chunk in state")
-			}
+		// The gateway decodes the whole chunk into ev.Members, since handlers
+		// receive it as well; only the insertion into the store is batched.
+		if err := s.Cabinet.MembersSet(ev.GuildID, ev.Members, false); err != nil {
+			s.stateErr(err, "failed to add members from chunk in state")
 		}
 
 		for i := range ev.Presences {
@@ -176,6 +182,19 @@ func (s *State) onEvent(iface interface{}) {
 			}
 		}
 
+	case *gateway.TypingStartEvent:
+		if ev.Member == nil || !ev.GuildID.IsValid() {
+			break
+		}
+
+		if !ev.Member.User.ID.IsValid() {
+			ev.Member.User.ID = ev.UserID
+		}
+
+		if err := s.Cabinet.MemberSet(ev.GuildID, ev.Member, true); err != nil {
+			s.stateErr(err, "failed to update a typing member in state")
+		}
+
 	case *gateway.GuildRoleCreateEvent:
 		if err := s.Cabinet.RoleSet(ev.GuildID, &ev.Role, false); err != nil {
 			s.stateErr(err, "failed to add a role in state")
@@ -215,11 +234,7 @@ func (s *State) onEvent(iface interface{}) {
 		// not tracked.
 
 	case *gateway.ThreadListSyncEvent:
-		for i := range ev.Threads {
-			if err := s.Cabinet.ChannelSet(&ev.Threads[i], true); err != nil {
-				s.stateErr(err, "failed to set a thread in state sync")
-			}
-		}
+		// Handled in handleThreadListSync.
 
 	case *gateway.ThreadCreateEvent:
 		if err := s.Cabinet.ChannelSet(&ev.Channel, false); err != nil {
@@ -432,11 +447,9 @@ func storeGuildCreate(cab *store.Cabinet, guild *gateway.GuildCreateEvent) []err
 		}
 	}
 
-	// Handle guild member
-	for i := range guild.Members {
-		if err := cab.MemberSet(guild.ID, &guild.Members[i], false); err != nil {
-			errs(err, "failed to set guild member in Ready")
-		}
+	// Handle guild members
+	if err := cab.MembersSet(guild.ID, guild.Members, false); err != nil {
+		errs(err, "failed to set guild members in Ready")
 	}
 
 	// Handle guild channels
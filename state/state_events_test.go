@@ -0,0 +1,59 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+)
+
+func TestTypingStartMember(t *testing.T) {
+	s := New("")
+
+	s.onEvent(&gateway.TypingStartEvent{
+		ChannelID: 1,
+		UserID:    2,
+		GuildID:   3,
+		Member: &discord.Member{
+			User: discord.User{ID: 2, Username: "typer"},
+			Nick: "nick",
+		},
+	})
+
+	m, err := s.Cabinet.Member(3, 2)
+	if err != nil {
+		t.Fatal("member not in state:", err)
+	}
+
+	if m.Nick != "nick" || m.User.Username != "typer" {
+		t.Errorf("unexpected member in state: %+v", m)
+	}
+}
+
+func TestTypingStartMemberWithoutIntent(t *testing.T) {
+	s, rc := newRecordState(func(path string) interface{} {
+		return discord.Member{User: discord.User{ID: 2}, Nick: "fetched"}
+	}, gateway.IntentGuildMessageTyping)
+
+	s.onEvent(&gateway.TypingStartEvent{
+		ChannelID: 1,
+		UserID:    2,
+		GuildID:   3,
+		Member:    &discord.Member{User: discord.User{ID: 2}, Nick: "nick"},
+	})
+
+	m, err := s.Member(3, 2)
+	if err != nil {
+		t.Fatal("failed to get member:", err)
+	}
+
+	// Without the intent, the typing member would never be updated, so it's
+	// fetched instead.
+	if m.Nick != "fetched" {
+		t.Errorf("unexpected member: %+v", m)
+	}
+
+	if paths := rc.Paths(); len(paths) != 1 || paths[0] != "/guilds/3/members/2" {
+		t.Fatalf("typing member was not fetched: %v", paths)
+	}
+}
@@ -0,0 +1,205 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/session"
+	"github.com/diamondburned/arikawa/v3/state/store/defaultstore"
+	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+)
+
+// recordClient is an httpdriver.Client that records the paths of its requests
+// and responds to them with the result of respond. If gate is not nil, then
+// responses are held back until it's closed.
+type recordClient struct {
+	mutex   sync.Mutex
+	paths   []string
+	respond func(path string) interface{}
+	gate    chan struct{}
+}
+
+func (c *recordClient) NewRequest(ctx context.Context, method, url string) (httpdriver.Request, error) {
+	return httpdriver.NewMockRequestWithContext(ctx, method, url, nil, nil), nil
+}
+
+func (c *recordClient) Do(req httpdriver.Request) (httpdriver.Response, error) {
+	path := strings.TrimPrefix(req.GetPath(), api.Path)
+
+	c.mutex.Lock()
+	c.paths = append(c.paths, path)
+	c.mutex.Unlock()
+
+	if c.gate != nil {
+		select {
+		case <-c.gate:
+		case <-req.GetContext().Done():
+			return nil, req.GetContext().Err()
+		}
+	}
+
+	return httpdriver.NewMockResponse(200, nil, c.respond(path)), nil
+}
+
+func (c *recordClient) Paths() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]string(nil), c.paths...)
+}
+
+func newRecordState(respond func(path string) interface{}, intents ...gateway.Intents) (*State, *recordClient) {
+	rc := &recordClient{respond: respond}
+
+	hc := httputil.NewClient()
+	hc.Client = rc
+
+	id := gateway.DefaultIdentifier("")
+	for _, intent := range intents {
+		id.AddIntents(intent)
+	}
+
+	s := session.NewCustom(id, api.NewCustomClient("", hc), handler.New())
+	return NewFromSession(s, defaultstore.New()), rc
+}
+
+func newGatedMemberState() (*State, *recordClient) {
+	s, rc := newRecordState(func(path string) interface{} {
+		return discord.Member{
+			User:    discord.User{ID: 2},
+			RoleIDs: []discord.RoleID{3},
+		}
+	})
+	rc.gate = make(chan struct{})
+	return s, rc
+}
+
+// waitRequests waits until the client has received n requests, then waits a
+// bit more for coalesced callers to join.
+func waitRequests(t *testing.T, rc *recordClient, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(rc.Paths()) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d requests, got %v", n, rc.Paths())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+}
+
+func TestFetchMemberCoalesced(t *testing.T) {
+	s, rc := newGatedMemberState()
+
+	type result struct {
+		m   *discord.Member
+		err error
+	}
+
+	results := make(chan result, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			m, err := s.Member(1, 2)
+			results <- result{m, err}
+		}()
+	}
+
+	waitRequests(t, rc, 1)
+	close(rc.gate)
+
+	r1, r2 := <-results, <-results
+	if r1.err != nil || r2.err != nil {
+		t.Fatalf("unexpected errors: %v, %v", r1.err, r2.err)
+	}
+
+	if paths := rc.Paths(); len(paths) != 1 {
+		t.Fatalf("expected 1 coalesced request, got %v", paths)
+	}
+
+	// Modifying one caller's result must not affect the other's.
+	r1.m.RoleIDs[0] = 4
+	if r2.m.RoleIDs[0] != 3 {
+		t.Fatal("coalesced callers share the same roles slice")
+	}
+}
+
+func TestFetchMemberCoalescedCancel(t *testing.T) {
+	s, rc := newGatedMemberState()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader := make(chan error, 1)
+	go func() {
+		_, err := s.WithContext(ctx).Member(1, 2)
+		leader <- err
+	}()
+
+	waitRequests(t, rc, 1)
+
+	follower := make(chan error, 1)
+	go func() {
+		_, err := s.Member(1, 2)
+		follower <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	// Cancelling the caller that made the request only fails that caller.
+	cancel()
+
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Fatal("expected the cancelled caller to fail, got", err)
+	}
+
+	close(rc.gate)
+
+	if err := <-follower; err != nil {
+		t.Fatal("the other caller failed:", err)
+	}
+
+	if paths := rc.Paths(); len(paths) != 1 {
+		t.Fatalf("expected 1 coalesced request, got %v", paths)
+	}
+}
+
+func TestCopyShared(t *testing.T) {
+	c := &discord.Channel{
+		ID:           1,
+		Overwrites:   []discord.Overwrite{{ID: 2}},
+		AppliedTags:  []discord.TagID{3},
+		ThreadMember: &discord.ThreadMember{UserID: 4},
+	}
+
+	cpy := copyChannel(c)
+	cpy.Overwrites[0].ID = 5
+	cpy.AppliedTags[0] = 6
+	cpy.ThreadMember.UserID = 7
+
+	if c.Overwrites[0].ID != 2 || c.AppliedTags[0] != 3 || c.ThreadMember.UserID != 4 {
+		t.Fatalf("copy shares data with the channel: %+v", c)
+	}
+
+	g := &discord.Guild{ID: 1, Roles: []discord.Role{{ID: 2}}, Emojis: []discord.Emoji{}}
+
+	gcpy := copyGuild(g)
+	gcpy.Roles[0].ID = 3
+
+	if g.Roles[0].ID != 2 {
+		t.Fatal("copy shares roles with the guild")
+	}
+	if gcpy.Emojis == nil || gcpy.Features != nil {
+		t.Fatalf("copy changed nil slices: %+v", gcpy)
+	}
+}
@@ -0,0 +1,208 @@
+package cowstore
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/internal/moreatomic"
+	"github.com/diamondburned/arikawa/v3/state/store"
+)
+
+// Channel is a copy-on-write ChannelStore. Each guild has its own snapshot of
+// channels, and direct message channels are kept in the snapshot of guild 0.
+type Channel struct {
+	state atomic.Value // *channelState
+}
+
+type channelState struct {
+	guilds   moreatomic.Map // discord.GuildID -> *snapshot[discord.ChannelID, discord.Channel]
+	index    sync.Map       // discord.ChannelID -> discord.GuildID
+	privates sync.Map       // discord.UserID -> discord.ChannelID
+}
+
+var _ store.ChannelStore = (*Channel)(nil)
+
+// NewChannel creates a new copy-on-write ChannelStore.
+func NewChannel() *Channel {
+	s := &Channel{}
+	s.Reset()
+	return s
+}
+
+func newChannelState() *channelState {
+	return &channelState{
+		guilds: *moreatomic.NewMap(func() interface{} {
+			return newSnapshot[discord.ChannelID, discord.Channel]()
+		}),
+	}
+}
+
+func (s *Channel) load() *channelState {
+	return s.state.Load().(*channelState)
+}
+
+func (s *Channel) Reset() error {
+	s.state.Store(newChannelState())
+	return nil
+}
+
+func (st *channelState) channels(guildID discord.GuildID) (map[discord.ChannelID]discord.Channel, bool) {
+	iv, ok := st.guilds.Load(guildID)
+	if !ok {
+		return nil, false
+	}
+	return iv.(*snapshot[discord.ChannelID, discord.Channel]).load(), true
+}
+
+func (st *channelState) channel(id discord.ChannelID) (*discord.Channel, error) {
+	guildID, ok := st.index.Load(id)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	chs, _ := st.channels(guildID.(discord.GuildID))
+
+	ch, ok := chs[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return &ch, nil
+}
+
+func (s *Channel) Channel(id discord.ChannelID) (*discord.Channel, error) {
+	return s.load().channel(id)
+}
+
+func (s *Channel) CreatePrivateChannel(recipient discord.UserID) (*discord.Channel, error) {
+	st := s.load()
+
+	id, ok := st.privates.Load(recipient)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	return st.channel(id.(discord.ChannelID))
+}
+
+// Channels returns a list of Guild channels randomly ordered.
+func (s *Channel) Channels(guildID discord.GuildID) ([]discord.Channel, error) {
+	chs, ok := s.load().channels(guildID)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	var channels = make([]discord.Channel, 0, len(chs))
+	for _, ch := range chs {
+		channels = append(channels, ch)
+	}
+
+	return channels, nil
+}
+
+// PrivateChannels returns a list of Direct Message channels randomly ordered.
+func (s *Channel) PrivateChannels() ([]discord.Channel, error) {
+	chs, _ := s.load().channels(0)
+	if len(chs) == 0 {
+		return nil, store.ErrNotFound
+	}
+
+	var channels = make([]discord.Channel, 0, len(chs))
+	for _, ch := range chs {
+		channels = append(channels, ch)
+	}
+
+	return channels, nil
+}
+
+// ChannelSet sets the Direct Message or Guild channel into the state.
+func (s *Channel) ChannelSet(channel *discord.Channel, update bool) error {
+	cpy := *channel
+
+	switch channel.Type {
+	case discord.DirectMessage:
+		// Safety bound check.
+		if len(channel.DMRecipients) != 1 {
+			return fmt.Errorf("DirectMessage channel %d doesn't have 1 recipient", channel.ID)
+		}
+		cpy.GuildID = 0
+	case discord.GroupDM:
+		cpy.GuildID = 0
+	default:
+		// Ensure that if the channel is not a DM or group DM channel, then it
+		// must have a valid guild ID.
+		if !channel.GuildID.IsValid() {
+			return errors.New("invalid guildID for guild channel")
+		}
+	}
+
+	st := s.load()
+
+	// Drop the channel from its previous guild if it moved.
+	if old, ok := st.index.Load(cpy.ID); ok && old.(discord.GuildID) != cpy.GuildID {
+		if !update {
+			return nil
+		}
+		st.removeFrom(old.(discord.GuildID), cpy.ID)
+	}
+
+	iv, _ := st.guilds.LoadOrStore(cpy.GuildID)
+	iv.(*snapshot[discord.ChannelID, discord.Channel]).update(func(m map[discord.ChannelID]discord.Channel) bool {
+		if _, ok := m[cpy.ID]; ok && !update {
+			return false
+		}
+		m[cpy.ID] = cpy
+		return true
+	})
+
+	st.index.Store(cpy.ID, cpy.GuildID)
+	if cpy.Type == discord.DirectMessage {
+		st.privates.Store(cpy.DMRecipients[0].ID, cpy.ID)
+	}
+
+	return nil
+}
+
+func (s *Channel) ChannelRemove(channel *discord.Channel) error {
+	guildID := channel.GuildID
+
+	switch channel.Type {
+	case discord.DirectMessage:
+		// Safety bound check.
+		if len(channel.DMRecipients) != 1 {
+			return fmt.Errorf("DirectMessage channel %d doesn't have 1 recipient", channel.ID)
+		}
+		guildID = 0
+	case discord.GroupDM:
+		guildID = 0
+	}
+
+	st := s.load()
+	st.removeFrom(guildID, channel.ID)
+
+	st.index.Delete(channel.ID)
+	if channel.Type == discord.DirectMessage {
+		st.privates.Delete(channel.DMRecipients[0].ID)
+	}
+
+	return nil
+}
+
+// removeFrom removes the channel from the snapshot of the given guild.
+func (st *channelState) removeFrom(guildID discord.GuildID, id discord.ChannelID) {
+	iv, ok := st.guilds.Load(guildID)
+	if !ok {
+		return
+	}
+
+	iv.(*snapshot[discord.ChannelID, discord.Channel]).update(func(m map[discord.ChannelID]discord.Channel) bool {
+		if _, ok := m[id]; !ok {
+			return false
+		}
+		delete(m, id)
+		return true
+	})
+}
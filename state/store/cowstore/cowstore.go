@@ -0,0 +1,62 @@
+// Package cowstore provides copy-on-write store implementations for guilds,
+// roles and channels. They are meant for read-heavy workloads: reads load an
+// immutable snapshot without acquiring any lock, while writes copy the affected
+// snapshot, modify the copy and swap it in atomically. Writes are therefore
+// more expensive than in defaultstore, which is fine for data that rarely
+// changes after the guilds are loaded.
+//
+// The stores can be swapped into an existing Cabinet:
+//
+//	cabinet := defaultstore.New()
+//	cabinet.GuildStore = cowstore.NewGuild()
+//	cabinet.RoleStore = cowstore.NewRole()
+//	cabinet.ChannelStore = cowstore.NewChannel()
+package cowstore
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// snapshot is a map that is replaced as a whole on every write. The map that is
+// loaded must never be modified.
+type snapshot[K comparable, V any] struct {
+	mut sync.Mutex // serializes writers
+	val atomic.Value
+}
+
+func newSnapshot[K comparable, V any]() *snapshot[K, V] {
+	s := &snapshot[K, V]{}
+	s.val.Store(map[K]V{})
+	return s
+}
+
+// load returns the current map. It must not be modified.
+func (s *snapshot[K, V]) load() map[K]V {
+	return s.val.Load().(map[K]V)
+}
+
+// update copies the current map, calls fn with the copy, then swaps the copy
+// in. If fn returns false, then the copy is dropped.
+func (s *snapshot[K, V]) update(fn func(m map[K]V) bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	old := s.load()
+
+	m := make(map[K]V, len(old)+1)
+	for k, v := range old {
+		m[k] = v
+	}
+
+	if fn(m) {
+		s.val.Store(m)
+	}
+}
+
+// reset swaps in an empty map.
+func (s *snapshot[K, V]) reset() {
+	s.mut.Lock()
+	s.val.Store(map[K]V{})
+	s.mut.Unlock()
+}
@@ -0,0 +1,123 @@
+package cowstore
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestGuild(t *testing.T) {
+	s := NewGuild()
+
+	s.GuildSet(&discord.Guild{ID: 1, Name: "one"}, false)
+	s.GuildSet(&discord.Guild{ID: 2, Name: "two"}, false)
+	s.GuildSet(&discord.Guild{ID: 1, Name: "ignored"}, false)
+
+	g, err := s.Guild(1)
+	if err != nil {
+		t.Fatal("failed to get guild:", err)
+	}
+	if g.Name != "one" {
+		t.Fatalf("guild was overridden without update, got name %q", g.Name)
+	}
+
+	// Modifying the returned guild must not modify the snapshot.
+	g.Name = "modified"
+	if g, _ := s.Guild(1); g.Name != "one" {
+		t.Fatalf("snapshot was modified, got name %q", g.Name)
+	}
+
+	s.GuildSet(&discord.Guild{ID: 1, Name: "updated"}, true)
+	if g, _ := s.Guild(1); g.Name != "updated" {
+		t.Fatalf("guild wasn't updated, got name %q", g.Name)
+	}
+
+	if gs, _ := s.Guilds(); len(gs) != 2 {
+		t.Fatalf("expected 2 guilds, got %d", len(gs))
+	}
+
+	s.GuildRemove(2)
+	if _, err := s.Guild(2); err == nil {
+		t.Fatal("removed guild is still in the store")
+	}
+}
+
+func TestRole(t *testing.T) {
+	s := NewRole()
+
+	s.RoleSet(1, &discord.Role{ID: 1, Name: "a"}, false)
+	s.RoleSet(1, &discord.Role{ID: 2, Name: "b"}, false)
+	s.RoleSet(2, &discord.Role{ID: 3, Name: "c"}, false)
+
+	if rs, _ := s.Roles(1); len(rs) != 2 {
+		t.Fatalf("expected 2 roles, got %d", len(rs))
+	}
+
+	s.RoleRemove(1, 1)
+	if _, err := s.Role(1, 1); err == nil {
+		t.Fatal("removed role is still in the store")
+	}
+
+	if r, err := s.Role(2, 3); err != nil || r.Name != "c" {
+		t.Fatalf("unexpected role %v, error %v", r, err)
+	}
+}
+
+func TestChannel(t *testing.T) {
+	s := NewChannel()
+
+	dm := discord.Channel{
+		ID:           10,
+		Type:         discord.DirectMessage,
+		DMRecipients: []discord.User{{ID: 100}},
+	}
+
+	s.ChannelSet(&discord.Channel{ID: 1, GuildID: 1, Type: discord.GuildText}, false)
+	s.ChannelSet(&discord.Channel{ID: 2, GuildID: 1, Type: discord.GuildVoice}, false)
+	s.ChannelSet(&dm, false)
+
+	if err := s.ChannelSet(&discord.Channel{ID: 3, Type: discord.GuildText}, false); err == nil {
+		t.Fatal("expected error setting guild channel without guild ID")
+	}
+
+	if chs, _ := s.Channels(1); len(chs) != 2 {
+		t.Fatalf("expected 2 channels, got %d", len(chs))
+	}
+
+	if ch, err := s.CreatePrivateChannel(100); err != nil || ch.ID != dm.ID {
+		t.Fatalf("unexpected private channel %v, error %v", ch, err)
+	}
+
+	if chs, _ := s.PrivateChannels(); len(chs) != 1 {
+		t.Fatalf("expected 1 private channel, got %d", len(chs))
+	}
+
+	s.ChannelRemove(&discord.Channel{ID: 1, GuildID: 1})
+	if _, err := s.Channel(1); err == nil {
+		t.Fatal("removed channel is still in the store")
+	}
+
+	s.Reset()
+	if _, err := s.Channel(2); err == nil {
+		t.Fatal("channel is still in the store after reset")
+	}
+}
+
+func TestChannelSetMovedGuild(t *testing.T) {
+	s := NewChannel()
+
+	s.ChannelSet(&discord.Channel{ID: 1, GuildID: 1, Type: discord.GuildText}, false)
+	s.ChannelSet(&discord.Channel{ID: 1, GuildID: 2, Type: discord.GuildText}, true)
+
+	if chs, _ := s.Channels(1); len(chs) != 0 {
+		t.Fatalf("moved channel is still in its previous guild: %v", chs)
+	}
+
+	if chs, _ := s.Channels(2); len(chs) != 1 || chs[0].ID != 1 {
+		t.Fatalf("moved channel is not in its new guild: %v", chs)
+	}
+
+	if ch, err := s.Channel(1); err != nil || ch.GuildID != 2 {
+		t.Fatalf("unexpected channel %v, error %v", ch, err)
+	}
+}
@@ -0,0 +1,90 @@
+package cowstore
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state/store"
+)
+
+// guildShards is the number of snapshots that guilds are spread across, so
+// that adding a guild only copies a fraction of all guilds.
+const guildShards = 32
+
+// Guild is a copy-on-write GuildStore.
+type Guild struct {
+	shards [guildShards]*snapshot[discord.GuildID, *discord.Guild]
+}
+
+var _ store.GuildStore = (*Guild)(nil)
+
+// NewGuild creates a new copy-on-write GuildStore.
+func NewGuild() *Guild {
+	s := &Guild{}
+	for i := range s.shards {
+		s.shards[i] = newSnapshot[discord.GuildID, *discord.Guild]()
+	}
+	return s
+}
+
+func (s *Guild) shard(id discord.GuildID) *snapshot[discord.GuildID, *discord.Guild] {
+	// The lowest bits of a snowflake are its increment, which vary enough to
+	// spread guilds evenly.
+	return s.shards[uint64(id)%guildShards]
+}
+
+func (s *Guild) Reset() error {
+	for _, shard := range s.shards {
+		shard.reset()
+	}
+	return nil
+}
+
+func (s *Guild) Guild(id discord.GuildID) (*discord.Guild, error) {
+	g, ok := s.shard(id).load()[id]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	cpy := *g
+	return &cpy, nil
+}
+
+func (s *Guild) Guilds() ([]discord.Guild, error) {
+	var gs []discord.Guild
+	for _, shard := range s.shards {
+		for _, g := range shard.load() {
+			gs = append(gs, *g)
+		}
+	}
+
+	if len(gs) == 0 {
+		return nil, store.ErrNotFound
+	}
+
+	return gs, nil
+}
+
+func (s *Guild) GuildSet(guild *discord.Guild, update bool) error {
+	cpy := *guild
+
+	s.shard(guild.ID).update(func(m map[discord.GuildID]*discord.Guild) bool {
+		if _, ok := m[guild.ID]; ok && !update {
+			return false
+		}
+		m[guild.ID] = &cpy
+		return true
+	})
+
+	return nil
+}
+
+func (s *Guild) GuildRemove(id discord.GuildID) error {
+	s.shard(id).update(func(m map[discord.GuildID]*discord.Guild) bool {
+		if _, ok := m[id]; !ok {
+			return false
+		}
+		delete(m, id)
+		return true
+	})
+
+	return nil
+}
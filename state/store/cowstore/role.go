@@ -0,0 +1,90 @@
+package cowstore
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/internal/moreatomic"
+	"github.com/diamondburned/arikawa/v3/state/store"
+)
+
+// Role is a copy-on-write RoleStore. Each guild has its own snapshot of roles.
+type Role struct {
+	guilds moreatomic.Map // discord.GuildID -> *snapshot[discord.RoleID, discord.Role]
+}
+
+var _ store.RoleStore = (*Role)(nil)
+
+// NewRole creates a new copy-on-write RoleStore.
+func NewRole() *Role {
+	return &Role{
+		guilds: *moreatomic.NewMap(func() interface{} {
+			return newSnapshot[discord.RoleID, discord.Role]()
+		}),
+	}
+}
+
+func (s *Role) Reset() error {
+	return s.guilds.Reset()
+}
+
+func (s *Role) roles(guildID discord.GuildID) map[discord.RoleID]discord.Role {
+	iv, ok := s.guilds.Load(guildID)
+	if !ok {
+		return nil
+	}
+	return iv.(*snapshot[discord.RoleID, discord.Role]).load()
+}
+
+func (s *Role) Role(guildID discord.GuildID, roleID discord.RoleID) (*discord.Role, error) {
+	r, ok := s.roles(guildID)[roleID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return &r, nil
+}
+
+func (s *Role) Roles(guildID discord.GuildID) ([]discord.Role, error) {
+	iv, ok := s.guilds.Load(guildID)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+
+	rs := iv.(*snapshot[discord.RoleID, discord.Role]).load()
+
+	var roles = make([]discord.Role, 0, len(rs))
+	for _, role := range rs {
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+func (s *Role) RoleSet(guildID discord.GuildID, role *discord.Role, update bool) error {
+	iv, _ := s.guilds.LoadOrStore(guildID)
+
+	iv.(*snapshot[discord.RoleID, discord.Role]).update(func(m map[discord.RoleID]discord.Role) bool {
+		if _, ok := m[role.ID]; ok && !update {
+			return false
+		}
+		m[role.ID] = *role
+		return true
+	})
+
+	return nil
+}
+
+func (s *Role) RoleRemove(guildID discord.GuildID, roleID discord.RoleID) error {
+	iv, ok := s.guilds.Load(guildID)
+	if !ok {
+		return nil
+	}
+
+	iv.(*snapshot[discord.RoleID, discord.Role]).update(func(m map[discord.RoleID]discord.Role) bool {
+		if _, ok := m[roleID]; !ok {
+			return false
+		}
+		delete(m, roleID)
+		return true
+	})
+
+	return nil
+}
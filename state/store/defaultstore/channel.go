@@ -122,6 +122,11 @@ func (s *Channel) ChannelSet(channel *discord.Channel, update bool) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
+	// Drop the channel from its previous guild if it moved.
+	if old, ok := s.channels[channel.ID]; ok && old.GuildID != channel.GuildID {
+		s.guildChs[old.GuildID] = removeChannelID(s.guildChs[old.GuildID], channel.ID)
+	}
+
 	// Update the reference if we can.
 	s.channels[channel.ID] = cpy
 
@@ -173,6 +178,64 @@ func (s *Channel) ChannelRemove(channel *discord.Channel) error {
 	return nil
 }
 
+var _ store.ThreadSyncStore = (*Channel)(nil)
+
+// ThreadsSync replaces all threads of the given parent channels in the guild
+// with the given threads under a single lock.
+func (s *Channel) ThreadsSync(
+	guildID discord.GuildID,
+	parentIDs []discord.ChannelID, threads []discord.Channel) ([]discord.ChannelID, error) {
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	synced := make(map[discord.ChannelID]struct{}, len(threads))
+	for _, thread := range threads {
+		synced[thread.ID] = struct{}{}
+	}
+
+	var removed []discord.ChannelID
+
+	chIDs := s.guildChs[guildID]
+	for i := 0; i < len(chIDs); i++ {
+		ch, ok := s.channels[chIDs[i]]
+		if !ok || !ch.Type.IsThread() || !threadInParents(ch, parentIDs) {
+			continue
+		}
+
+		if _, ok := synced[ch.ID]; ok {
+			continue
+		}
+
+		delete(s.channels, ch.ID)
+		removed = append(removed, ch.ID)
+
+		chIDs = removeChannelID(chIDs, ch.ID)
+		i-- // removeChannelID moved the last channel into i.
+	}
+
+	for _, thread := range threads {
+		thread.GuildID = guildID
+		s.channels[thread.ID] = thread
+		chIDs = addChannelID(chIDs, thread.ID)
+	}
+
+	s.guildChs[guildID] = chIDs
+	return removed, nil
+}
+
+func threadInParents(thread discord.Channel, parentIDs []discord.ChannelID) bool {
+	if parentIDs == nil {
+		return true
+	}
+	for _, id := range parentIDs {
+		if thread.ParentID == id {
+			return true
+		}
+	}
+	return false
+}
+
 func addChannelID(channels []discord.ChannelID, id discord.ChannelID) []discord.ChannelID {
 	for _, ch := range channels {
 		if ch == id {
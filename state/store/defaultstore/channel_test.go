@@ -0,0 +1,73 @@
+package defaultstore
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestThreadsSync(t *testing.T) {
+	store := NewChannel()
+
+	store.ChannelSet(&discord.Channel{ID: 1, GuildID: 1, Type: discord.GuildText}, false)
+	store.ChannelSet(&discord.Channel{ID: 2, GuildID: 1, Type: discord.GuildText}, false)
+	store.ChannelSet(&discord.Channel{ID: 3, GuildID: 1, ParentID: 1, Type: discord.GuildPublicThread}, false)
+	store.ChannelSet(&discord.Channel{ID: 4, GuildID: 1, ParentID: 1, Type: discord.GuildPublicThread}, false)
+	store.ChannelSet(&discord.Channel{ID: 5, GuildID: 1, ParentID: 2, Type: discord.GuildPrivateThread}, false)
+
+	removed, err := store.ThreadsSync(1, []discord.ChannelID{1}, []discord.Channel{
+		{ID: 4, ParentID: 1, Type: discord.GuildPublicThread, Name: "synced"},
+		{ID: 6, ParentID: 1, Type: discord.GuildPublicThread},
+	})
+	if err != nil {
+		t.Fatal("failed to sync threads:", err)
+	}
+
+	if len(removed) != 1 || removed[0] != 3 {
+		t.Errorf("expected thread 3 to be removed, got %v", removed)
+	}
+
+	chs, _ := store.Channels(1)
+
+	got := make(map[discord.ChannelID]discord.Channel, len(chs))
+	for _, ch := range chs {
+		got[ch.ID] = ch
+	}
+
+	for _, id := range []discord.ChannelID{1, 2, 4, 5, 6} {
+		if _, ok := got[id]; !ok {
+			t.Errorf("expected channel %d to be in the store", id)
+		}
+	}
+
+	if _, ok := got[3]; ok {
+		t.Error("stale thread 3 is still in the store")
+	}
+
+	if got[4].Name != "synced" {
+		t.Errorf("thread 4 was not updated, got name %q", got[4].Name)
+	}
+
+	if got[6].GuildID != 1 {
+		t.Errorf("thread 6 has guild ID %d, expected 1", got[6].GuildID)
+	}
+}
+
+func TestChannelSetMovedGuild(t *testing.T) {
+	s := NewChannel()
+
+	s.ChannelSet(&discord.Channel{ID: 1, GuildID: 1, Type: discord.GuildText}, false)
+	s.ChannelSet(&discord.Channel{ID: 1, GuildID: 2, Type: discord.GuildText}, true)
+
+	if chs, _ := s.Channels(1); len(chs) != 0 {
+		t.Fatalf("moved channel is still in its previous guild: %v", chs)
+	}
+
+	if chs, _ := s.Channels(2); len(chs) != 1 || chs[0].ID != 1 {
+		t.Fatalf("moved channel is not in its new guild: %v", chs)
+	}
+
+	if ch, err := s.Channel(1); err != nil || ch.GuildID != 2 {
+		t.Fatalf("unexpected channel %v, error %v", ch, err)
+	}
+}
@@ -84,6 +84,32 @@ func (s *Member) MemberSet(guildID discord.GuildID, m *discord.Member, update bo
 	return nil
 }
 
+var _ store.MemberBatchStore = (*Member)(nil)
+
+// MembersSet sets all the given members of the guild while acquiring the lock
+// only once. If the guild has no members yet, then the member map is sized for
+// the batch upfront to avoid growing it repeatedly.
+func (s *Member) MembersSet(guildID discord.GuildID, members []discord.Member, update bool) error {
+	iv, _ := s.guilds.LoadOrStore(guildID)
+	gm := iv.(*guildMembers)
+
+	gm.mut.Lock()
+	defer gm.mut.Unlock()
+
+	if len(gm.members) == 0 {
+		gm.members = make(map[discord.UserID]discord.Member, len(members))
+	}
+
+	for i := range members {
+		m := &members[i]
+		if _, ok := gm.members[m.User.ID]; !ok || update {
+			gm.members[m.User.ID] = *m
+		}
+	}
+
+	return nil
+}
+
 func (s *Member) MemberRemove(guildID discord.GuildID, userID discord.UserID) error {
 	iv, ok := s.guilds.Load(guildID)
 	if !ok {
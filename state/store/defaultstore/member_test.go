@@ -0,0 +1,90 @@
+package defaultstore
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state/store"
+)
+
+func TestMembersSet(t *testing.T) {
+	s := NewMember()
+
+	s.MemberSet(1, &discord.Member{User: discord.User{ID: 1}, Nick: "old"}, false)
+
+	err := s.MembersSet(1, []discord.Member{
+		{User: discord.User{ID: 1}, Nick: "new"},
+		{User: discord.User{ID: 2}, Nick: "two"},
+		{User: discord.User{ID: 3}, Nick: "three"},
+	}, false)
+	if err != nil {
+		t.Fatal("failed to set members:", err)
+	}
+
+	ms, err := s.Members(1)
+	if err != nil {
+		t.Fatal("failed to get members:", err)
+	}
+	if len(ms) != 3 {
+		t.Fatalf("expected 3 members, got %d", len(ms))
+	}
+
+	// Existing members aren't replaced unless update is true.
+	m, _ := s.Member(1, 1)
+	if m.Nick != "old" {
+		t.Errorf("existing member was replaced without update: %q", m.Nick)
+	}
+
+	if err := s.MembersSet(1, []discord.Member{{User: discord.User{ID: 1}, Nick: "new"}}, true); err != nil {
+		t.Fatal("failed to update members:", err)
+	}
+
+	m, _ = s.Member(1, 1)
+	if m.Nick != "new" {
+		t.Errorf("existing member was not updated: %q", m.Nick)
+	}
+
+	if ms, _ := s.Members(2); len(ms) != 0 {
+		t.Errorf("members leaked into another guild: %v", ms)
+	}
+}
+
+func TestMembersSetNotRetained(t *testing.T) {
+	s := NewMember()
+
+	members := []discord.Member{{User: discord.User{ID: 1}, Nick: "nick"}}
+	s.MembersSet(1, members, false)
+
+	// The store must copy the members rather than keep the slice.
+	members[0].Nick = "changed"
+
+	m, err := s.Member(1, 1)
+	if err != nil {
+		t.Fatal("failed to get member:", err)
+	}
+	if m.Nick != "nick" {
+		t.Fatalf("store retained the members slice: %q", m.Nick)
+	}
+}
+
+func TestCabinetMembersSetFallback(t *testing.T) {
+	// Wrapping hides the MemberBatchStore implementation, so the Cabinet
+	// falls back to MemberSet.
+	var ms struct{ store.MemberStore }
+	ms.MemberStore = NewMember()
+
+	cab := New()
+	cab.MemberStore = ms
+
+	err := cab.MembersSet(1, []discord.Member{
+		{User: discord.User{ID: 1}},
+		{User: discord.User{ID: 2}},
+	}, false)
+	if err != nil {
+		t.Fatal("failed to set members:", err)
+	}
+
+	if got, _ := cab.Members(1); len(got) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(got))
+	}
+}
@@ -1,6 +1,7 @@
 package defaultstore
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/diamondburned/arikawa/v3/discord"
@@ -16,16 +17,14 @@ type Message struct {
 var _ store.MessageStore = (*Message)(nil)
 
 type messages struct {
-	mut      sync.RWMutex
-	messages []discord.Message
+	mut  sync.RWMutex
+	ring messageRing
 }
 
 func NewMessage(maxMsgs int) *Message {
 	return &Message{
 		channels: *moreatomic.NewMap(func() interface{} {
-			return &messages{
-				messages: []discord.Message{}, // never use a nil slice
-			}
+			return &messages{}
 		}),
 		maxMsgs: maxMsgs,
 	}
@@ -46,15 +45,16 @@ func (s *Message) Message(chID discord.ChannelID, mID discord.MessageID) (*disco
 	msgs.mut.RLock()
 	defer msgs.mut.RUnlock()
 
-	for _, m := range msgs.messages {
-		if m.ID == mID {
-			return &m, nil
-		}
+	i, ok := msgs.ring.search(mID)
+	if !ok {
+		return nil, store.ErrNotFound
 	}
 
-	return nil, store.ErrNotFound
+	m := *msgs.ring.at(i)
+	return &m, nil
 }
 
+// Messages returns the messages of the channel, ordered from latest to oldest.
 func (s *Message) Messages(channelID discord.ChannelID) ([]discord.Message, error) {
 	iv, ok := s.channels.Load(channelID)
 	if !ok {
@@ -66,13 +66,23 @@ func (s *Message) Messages(channelID discord.ChannelID) ([]discord.Message, erro
 	msgs.mut.RLock()
 	defer msgs.mut.RUnlock()
 
-	return append([]discord.Message(nil), msgs.messages...), nil
+	messages := make([]discord.Message, msgs.ring.len)
+	for i := range messages {
+		messages[i] = *msgs.ring.at(msgs.ring.len - 1 - i)
+	}
+
+	return messages, nil
 }
 
 func (s *Message) MaxMessages() int {
 	return s.maxMsgs
 }
 
+// MessageSet adds the message into the store. New messages that are later than
+// all stored messages are added in O(1) time, evicting the oldest message if the
+// store is full. Messages older than all stored messages are only added if the
+// store isn't full yet. Messages in between are dropped, since they would
+// disrupt the order.
 func (s *Message) MessageSet(message *discord.Message, update bool) error {
 	if s.maxMsgs <= 0 {
 		return nil
@@ -83,103 +93,60 @@ func (s *Message) MessageSet(message *discord.Message, update bool) error {
 	msgs := iv.(*messages)
 
 	msgs.mut.Lock()
-	defer msgs.mut.Unlock()
+	s.set(&msgs.ring, message, update)
+	msgs.mut.Unlock()
 
-	if update {
-		// Opt for a linear latest-to-oldest search in favor of something like
-		// sort.Search, since more recent messages are more likely to be edited
-		// than older ones.
-		for i, oldMessage := range msgs.messages {
-			// We found a match, update it.
-			if oldMessage.ID == message.ID {
-				DiffMessage(message, &oldMessage)
-				msgs.messages[i] = oldMessage // Now updated.
-				return nil
-			}
-		}
+	return nil
+}
+
+var _ store.MessageBatchStore = (*Message)(nil)
 
+// MessagesSet sets all the given messages, acquiring each channel's lock only
+// once for consecutive messages of the same channel.
+func (s *Message) MessagesSet(batch []discord.Message, update bool) error {
+	if s.maxMsgs <= 0 {
 		return nil
 	}
 
-	if len(msgs.messages) == 0 {
-		msgs.messages = []discord.Message{*message}
-	}
+	for len(batch) > 0 {
+		chID := batch[0].ChannelID
+
+		n := 1
+		for n < len(batch) && batch[n].ChannelID == chID {
+			n++
+		}
 
-	if pos := messageInsertPosition(message, msgs.messages); pos < 0 {
-		// Messages are full, drop the oldest messages to make room.
-		if len(msgs.messages) == s.maxMsgs {
-			copy(msgs.messages[1:], msgs.messages)
-			msgs.messages[0] = *message
-		} else {
-			msgs.messages = append([]discord.Message{*message}, msgs.messages...)
+		iv, _ := s.channels.LoadOrStore(chID)
+		msgs := iv.(*messages)
+
+		msgs.mut.Lock()
+		for i := range batch[:n] {
+			s.set(&msgs.ring, &batch[i], update)
 		}
-	} else if pos > 0 && len(msgs.messages) < s.maxMsgs {
-		msgs.messages = append(msgs.messages, *message)
+		msgs.mut.Unlock()
+
+		batch = batch[n:]
 	}
 
-	// We already have this message or we can't append any more messages.
 	return nil
 }
 
-// messageInsertPosition checks if the message should be appended or prepended
-// into the passed messages, ordered by time of creation from latest to oldest.
-// If the message should be prepended, messageInsertPosition returns -1, and if
-// the message should be appended it returns 1. As a third option it returns 0,
-// if the message should not be added to the slice, because it would disrupt
-// the order.
-//
-// messageInsertPosition is biased as it will recommend adding the message even
-// if timestamps just match, even though the true order cannot be determined in
-// that case.
-func messageInsertPosition(target *discord.Message, messages []discord.Message) int8 {
-	var (
-		targetTime = target.ID.Time()
-		firstTime  = messages[0].ID.Time()
-		lastTime   = messages[len(messages)-1].ID.Time()
-	)
-
-	if targetTime.After(firstTime) {
-		return -1
-	} else if targetTime.Before(lastTime) {
-		return 1
-	}
-
-	// Two cases remain, the timestamp is equal to either the latest or oldest
-	// message, or the message is already contained in message.
-	// So we compare timestamps. If they are equal, make sure messages doesn't
-	// contain a message with the same id, in order to prevent insertion of a
-	// duplicate. If they are not equal, we return 0 as the message would
-	// violate the order of messages.
-	// ID timestamps are used, as they provide millisecond accuracy in contrast
-	// to the second accuracy of discord.Message.Timestamp.
-	if targetTime.Equal(firstTime) {
-		// Only iterate as long as timestamps are equal, or there are no more
-		// messages.
-		for i := 0; i < len(messages) && targetTime.Equal(messages[i].ID.Time()); i++ {
-			// Duplicate, don't insert.
-			if messages[i].ID == target.ID {
-				return 0
-			}
-		}
-
-		// No duplicate of message found, so safe to prepend.
-		return -1
-	} else if targetTime.Equal(lastTime) {
-		// Only iterate as long as timestamps are equal, or there are no more
-		// messages.
-		for i := len(messages) - 1; i >= 0 && targetTime.Equal(messages[i].ID.Time()); i-- {
-			// Duplicate, don't insert.
-			if messages[i].ID == target.ID {
-				return 0
-			}
+func (s *Message) set(ring *messageRing, message *discord.Message, update bool) {
+	if update {
+		if i, ok := ring.search(message.ID); ok {
+			DiffMessage(message, ring.at(i))
 		}
+		return
+	}
 
-		// No duplicate of message found, so safe to append.
-		return 1
+	switch {
+	case ring.len == 0 || message.ID > ring.at(ring.len-1).ID:
+		ring.pushLatest(*message, s.maxMsgs)
+	case message.ID < ring.at(0).ID && ring.len < s.maxMsgs:
+		ring.pushOldest(*message, s.maxMsgs)
 	}
 
-	// Message would violate the order of messages, don't add it.
-	return 0
+	// Otherwise, we already have this message or we can't add it.
 }
 
 // DiffMessage fills non-empty fields from src to dst.
@@ -225,12 +192,90 @@ func (s *Message) MessageRemove(channelID discord.ChannelID, messageID discord.M
 	msgs.mut.Lock()
 	defer msgs.mut.Unlock()
 
-	for i, m := range msgs.messages {
-		if m.ID == messageID {
-			msgs.messages = append(msgs.messages[:i], msgs.messages[i+1:]...)
-			return nil
-		}
+	if i, ok := msgs.ring.search(messageID); ok {
+		msgs.ring.remove(i)
 	}
 
 	return nil
 }
+
+// messageRing is a ring buffer of messages ordered by ID from oldest to latest.
+// Its buffer grows as needed until it holds the maximum number of messages,
+// after which adding the latest message overwrites the oldest one.
+type messageRing struct {
+	buf   []discord.Message
+	start int // index of the oldest message in buf
+	len   int
+}
+
+// at returns the message at the given position, with 0 being the oldest
+// message.
+func (r *messageRing) at(i int) *discord.Message {
+	return &r.buf[(r.start+i)%len(r.buf)]
+}
+
+// search returns the position of the message with the given ID using a binary
+// search.
+func (r *messageRing) search(id discord.MessageID) (int, bool) {
+	i := sort.Search(r.len, func(i int) bool { return r.at(i).ID >= id })
+	return i, i < r.len && r.at(i).ID == id
+}
+
+// pushLatest adds the message as the latest one, evicting the oldest message if
+// the ring already has max messages.
+func (r *messageRing) pushLatest(m discord.Message, max int) {
+	if r.len == len(r.buf) && !r.grow(max) {
+		r.buf[r.start] = m
+		r.start = (r.start + 1) % len(r.buf)
+		return
+	}
+
+	r.len++
+	*r.at(r.len - 1) = m
+}
+
+// pushOldest adds the message as the oldest one. The ring must have less than
+// max messages.
+func (r *messageRing) pushOldest(m discord.Message, max int) {
+	if r.len == len(r.buf) {
+		r.grow(max)
+	}
+
+	r.start = (r.start - 1 + len(r.buf)) % len(r.buf)
+	r.buf[r.start] = m
+	r.len++
+}
+
+// remove removes the message at the given position.
+func (r *messageRing) remove(i int) {
+	for ; i < r.len-1; i++ {
+		*r.at(i) = *r.at(i + 1)
+	}
+	*r.at(r.len - 1) = discord.Message{}
+	r.len--
+}
+
+// grow grows the buffer, up to max messages. It returns false if the buffer
+// already holds max messages.
+func (r *messageRing) grow(max int) bool {
+	if len(r.buf) >= max {
+		return false
+	}
+
+	size := len(r.buf) * 2
+	if size < 4 {
+		size = 4
+	}
+	if size > max {
+		size = max
+	}
+
+	buf := make([]discord.Message, size)
+	for i := 0; i < r.len; i++ {
+		buf[i] = *r.at(i)
+	}
+
+	r.buf = buf
+	r.start = 0
+	return true
+}
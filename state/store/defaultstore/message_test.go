@@ -76,3 +76,46 @@ func TestMessagesUpdate(t *testing.T) {
 		}
 	}
 }
+
+func TestMessageRemove(t *testing.T) {
+	store := populate12Store()
+
+	if err := store.MessageRemove(1, 1<<30); err != nil {
+		t.Fatal("failed to remove message:", err)
+	}
+
+	if _, err := store.Message(1, 1<<30); err == nil {
+		t.Fatal("removed message is still in the store")
+	}
+
+	// The latest and oldest messages should still be reachable.
+	for _, id := range []discord.MessageID{1 << 36, 1 << 27} {
+		if _, err := store.Message(1, id); err != nil {
+			t.Errorf("message %d not found: %v", id, err)
+		}
+	}
+
+	messages, _ := store.Messages(1)
+	if len(messages) != store.MaxMessages()-1 {
+		t.Fatalf("expected %d messages, got %d", store.MaxMessages()-1, len(messages))
+	}
+
+	for i := 1; i < len(messages); i++ {
+		if messages[i-1].ID <= messages[i].ID {
+			t.Errorf("messages at %d and %d are out of order", i-1, i)
+		}
+	}
+}
+
+func BenchmarkMessageSet(b *testing.B) {
+	store := NewMessage(100)
+	msg := discord.Message{ChannelID: 1}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		msg.ID = discord.MessageID(i + 1)
+		store.MessageSet(&msg, false)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 type Presence struct {
 	guilds moreatomic.Map
+	filter func(discord.GuildID) bool
 }
 
 type presences struct {
@@ -29,6 +30,16 @@ func NewPresence() *Presence {
 	}
 }
 
+// NewPresenceFiltered creates a new presence store that only stores the
+// presences of the guilds that filter returns true for. Presences of other
+// guilds are dropped, which saves memory for bots that only need presences in
+// some guilds.
+func NewPresenceFiltered(filter func(discord.GuildID) bool) *Presence {
+	s := NewPresence()
+	s.filter = filter
+	return s
+}
+
 func (s *Presence) Reset() error {
 	return s.guilds.Reset()
 }
@@ -72,6 +83,10 @@ func (s *Presence) Presences(guildID discord.GuildID) ([]discord.Presence, error
 }
 
 func (s *Presence) PresenceSet(guildID discord.GuildID, p *discord.Presence, update bool) error {
+	if s.filter != nil && !s.filter(guildID) {
+		return nil
+	}
+
 	iv, _ := s.guilds.LoadOrStore(guildID)
 
 	ps := iv.(*presences)
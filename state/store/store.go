@@ -60,6 +60,42 @@ type Cabinet struct {
 	VoiceStateStore
 }
 
+// MembersSet sets all the given members of the guild. It uses MemberBatchStore
+// if the MemberStore implements it; otherwise, MemberSet is called for each
+// member, and the first error is returned after all members are tried.
+func (sc *Cabinet) MembersSet(guildID discord.GuildID, members []discord.Member, update bool) error {
+	if batcher, ok := sc.MemberStore.(MemberBatchStore); ok {
+		return batcher.MembersSet(guildID, members, update)
+	}
+
+	var firstErr error
+	for i := range members {
+		if err := sc.MemberSet(guildID, &members[i], update); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// MessagesSet sets all the given messages. It uses MessageBatchStore if the
+// MessageStore implements it; otherwise, MessageSet is called for each message,
+// and the first error is returned after all messages are tried.
+func (sc *Cabinet) MessagesSet(messages []discord.Message, update bool) error {
+	if batcher, ok := sc.MessageStore.(MessageBatchStore); ok {
+		return batcher.MessagesSet(messages, update)
+	}
+
+	var firstErr error
+	for i := range messages {
+		if err := sc.MessageSet(&messages[i], update); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
 // Reset resets everything inside the container.
 func (sc *Cabinet) Reset() error {
 	errors := []error{
@@ -186,6 +222,20 @@ type ChannelStore interface {
 	ChannelRemove(*discord.Channel) error
 }
 
+// ThreadSyncStore is an optional interface that a ChannelStore can implement
+// to handle thread list syncs atomically. If a ChannelStore doesn't implement
+// it, then the State falls back to removing and setting threads one by one.
+type ThreadSyncStore interface {
+	// ThreadsSync replaces all cached threads of the given parent channels in
+	// the guild with the given threads. If parentIDs is nil, then all threads
+	// in the guild are replaced. It returns the IDs of the threads that were
+	// removed.
+	ThreadsSync(
+		guildID discord.GuildID,
+		parentIDs []discord.ChannelID, threads []discord.Channel,
+	) (removed []discord.ChannelID, err error)
+}
+
 var _ ChannelStore = (*noop)(nil)
 
 func (noop) Channel(discord.ChannelID) (*discord.Channel, error) {
@@ -260,6 +310,16 @@ type MemberStore interface {
 	MemberRemove(discord.GuildID, discord.UserID) error
 }
 
+// MemberBatchStore is an optional interface that a MemberStore can implement to
+// insert many members at once, such as the ones in a Guild Members Chunk event.
+// If a MemberStore doesn't implement it, then the State falls back to calling
+// MemberSet for each member.
+type MemberBatchStore interface {
+	// MembersSet sets all the given members of the guild. The members slice
+	// must not be retained after returning.
+	MembersSet(guildID discord.GuildID, members []discord.Member, update bool) error
+}
+
 var _ MemberStore = (*noop)(nil)
 
 func (noop) Member(discord.GuildID, discord.UserID) (*discord.Member, error) {
@@ -299,6 +359,16 @@ type MessageStore interface {
 	MessageRemove(discord.ChannelID, discord.MessageID) error
 }
 
+// MessageBatchStore is an optional interface that a MessageStore can implement
+// to insert many messages at once, such as the ones fetched from the API. If a
+// MessageStore doesn't implement it, then MessageSet is called for each
+// message.
+type MessageBatchStore interface {
+	// MessagesSet behaves as if MessageSet was called for each message in
+	// order. The messages slice must not be retained after returning.
+	MessagesSet(messages []discord.Message, update bool) error
+}
+
 var _ MessageStore = (*noop)(nil)
 
 func (noop) MaxMessages() int {
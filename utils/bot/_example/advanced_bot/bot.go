@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
@@ -113,13 +112,8 @@ func (bot *Bot) Embed(_ *gateway.MessageCreateEvent, f arguments.Flag) (*discord
 		return nil, fmt.Errorf("usage: embed [flags] content...\n" + fs.Usage())
 	}
 
-	// Check if the color string is valid.
-	if !strings.HasPrefix(*color, "#") || len(*color) != 7 {
-		return nil, errors.New("invalid color, format must be #hhhhhh")
-	}
-
-	// Parse the color into decimal numbers.
-	colorHex, err := strconv.ParseInt((*color)[1:], 16, 64)
+	// Parse the color string.
+	embedColor, err := discord.ParseColor(*color)
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +122,7 @@ func (bot *Bot) Embed(_ *gateway.MessageCreateEvent, f arguments.Flag) (*discord
 	embed := discord.Embed{
 		Title:       *title,
 		Description: strings.Join(fs.Args(), " "),
-		Color:       discord.Color(colorHex),
+		Color:       embedColor,
 	}
 
 	if *author != "" {
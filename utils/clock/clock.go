@@ -0,0 +1,83 @@
+// Package clock provides an abstraction over time, so that code that waits,
+// such as rate limiting, backoff and heartbeating, can be driven by a fake
+// clock in tests and simulations instead of actually sleeping.
+package clock
+
+import "time"
+
+// Clock tells the time and creates timers and tickers.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer creates a new Timer that fires once after d.
+	NewTimer(d time.Duration) Timer
+	// NewTicker creates a new Ticker that fires every d.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is a single event timer like time.Timer.
+type Timer interface {
+	// C returns the channel that the time is sent on when the timer fires.
+	C() <-chan time.Time
+	// Stop stops the timer. It returns false if the timer has already fired
+	// or been stopped.
+	Stop() bool
+	// Reset changes the timer to fire after d. It returns true if the timer
+	// was active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker is a periodic timer like time.Ticker.
+type Ticker interface {
+	// C returns the channel that the ticks are sent on.
+	C() <-chan time.Time
+	// Stop stops the ticker.
+	Stop()
+	// Reset stops the ticker and resets its period to d.
+	Reset(d time.Duration)
+}
+
+// System is the Clock backed by the time package.
+var System Clock = systemClock{}
+
+// OrSystem returns c, or System if c is nil. It is meant for optional Clock
+// fields.
+func OrSystem(c Clock) Clock {
+	if c == nil {
+		return System
+	}
+	return c
+}
+
+// Since returns the time elapsed since t according to c.
+func Since(c Clock, t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// After waits for d to elapse according to c, then sends the current time on
+// the returned channel. Unlike time.After, the underlying timer is only freed
+// once it fires, so a Timer should be used instead if the wait may be
+// abandoned.
+func After(c Clock, d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTimer struct{ *time.Timer }
+
+func (t systemTimer) C() <-chan time.Time { return t.Timer.C }
+
+type systemTicker struct{ *time.Ticker }
+
+func (t systemTicker) C() <-chan time.Time { return t.Ticker.C }
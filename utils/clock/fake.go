@@ -0,0 +1,225 @@
+package clock
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when Advance or Set is called. Timers
+// and tickers created from it fire as the time moves past their deadlines, so
+// tests can fast-forward through waits instantly and deterministically. A Fake
+// is thread-safe.
+type Fake struct {
+	mutex   sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+	changed chan struct{} // closed and replaced when waiters change
+}
+
+var _ Clock = (*Fake)(nil)
+
+// NewFake creates a new Fake clock starting at the given time.
+func NewFake(now time.Time) *Fake {
+	return &Fake{
+		now:     now,
+		changed: make(chan struct{}),
+	}
+}
+
+// Now implements Clock.
+func (f *Fake) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.now
+}
+
+// NewTimer implements Clock.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{clock: f, ch: make(chan time.Time, 1)}
+	w.Reset(d)
+	return (*fakeTimer)(w)
+}
+
+// NewTicker implements Clock. It panics if d is not positive, like
+// time.NewTicker.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	w := &fakeWaiter{clock: f, ch: make(chan time.Time, 1)}
+	(*fakeTicker)(w).Reset(d)
+	return (*fakeTicker)(w)
+}
+
+// Advance moves the time forward by d, firing all timers and tickers whose
+// deadlines are reached in order. Like time.Ticker, a ticker that falls
+// behind drops ticks instead of sending them all.
+func (f *Fake) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.setTime(f.now.Add(d))
+}
+
+// Set moves the time to t, firing all timers and tickers whose deadlines are
+// reached. Setting the time backwards fires nothing.
+func (f *Fake) Set(t time.Time) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.setTime(t)
+}
+
+func (f *Fake) setTime(t time.Time) {
+	f.now = t
+
+	sort.SliceStable(f.waiters, func(i, j int) bool {
+		return f.waiters[i].at.Before(f.waiters[j].at)
+	})
+
+	remaining := f.waiters[:0]
+
+	for _, w := range f.waiters {
+		if w.at.After(t) {
+			remaining = append(remaining, w)
+			continue
+		}
+
+		// Drop the time if the last one hasn't been received yet.
+		select {
+		case w.ch <- w.at:
+		default:
+		}
+
+		if w.period > 0 {
+			for !w.at.After(t) {
+				w.at = w.at.Add(w.period)
+			}
+			remaining = append(remaining, w)
+		}
+	}
+
+	f.waiters = remaining
+	f.notify()
+}
+
+// Waiters returns the number of active timers and tickers.
+func (f *Fake) Waiters() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return len(f.waiters)
+}
+
+// BlockUntil blocks until there are at least n active timers and tickers, or
+// until ctx expires. It lets a test wait for the code under test to start
+// waiting before advancing the time.
+func (f *Fake) BlockUntil(ctx context.Context, n int) error {
+	for {
+		f.mutex.Lock()
+		count := len(f.waiters)
+		changed := f.changed
+		f.mutex.Unlock()
+
+		if count >= n {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (f *Fake) notify() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// add adds w into the waiters. f.mutex must be held.
+func (f *Fake) add(w *fakeWaiter) {
+	f.waiters = append(f.waiters, w)
+	f.notify()
+}
+
+// remove removes w from the waiters and returns true if it was active.
+// f.mutex must be held.
+func (f *Fake) remove(w *fakeWaiter) bool {
+	for i, waiter := range f.waiters {
+		if waiter == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			f.notify()
+			return true
+		}
+	}
+	return false
+}
+
+type fakeWaiter struct {
+	clock  *Fake
+	ch     chan time.Time
+	at     time.Time
+	period time.Duration // 0 for timers
+}
+
+type fakeTimer fakeWaiter
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	return t.clock.remove((*fakeWaiter)(t))
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	return (*fakeWaiter)(t).Reset(d)
+}
+
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.clock.mutex.Lock()
+	defer w.clock.mutex.Unlock()
+
+	active := w.clock.remove(w)
+	w.at = w.clock.now.Add(d)
+
+	if d <= 0 {
+		// Fire right away, like time.Timer.
+		select {
+		case w.ch <- w.clock.now:
+		default:
+		}
+		return active
+	}
+
+	w.clock.add(w)
+	return active
+}
+
+type fakeTicker fakeWaiter
+
+func (t *fakeTicker) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTicker) Stop() {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	t.clock.remove((*fakeWaiter)(t))
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for clock.Ticker.Reset")
+	}
+
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	t.clock.remove((*fakeWaiter)(t))
+	t.period = d
+	t.at = t.clock.now.Add(d)
+	t.clock.add((*fakeWaiter)(t))
+}
@@ -0,0 +1,109 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFakeTimer(t *testing.T) {
+	start := time.Unix(0, 0)
+	f := NewFake(start)
+
+	timer := f.NewTimer(time.Second)
+
+	f.Advance(500 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	f.Advance(500 * time.Millisecond)
+	select {
+	case at := <-timer.C():
+		if !at.Equal(start.Add(time.Second)) {
+			t.Fatalf("timer fired with time %v", at)
+		}
+	default:
+		t.Fatal("timer did not fire")
+	}
+
+	if timer.Stop() {
+		t.Fatal("Stop returned true for a fired timer")
+	}
+
+	timer.Reset(time.Second)
+	if !timer.Stop() {
+		t.Fatal("Stop returned false for an active timer")
+	}
+
+	f.Advance(time.Hour)
+	select {
+	case <-timer.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+
+	if n := f.Waiters(); n != 0 {
+		t.Fatalf("clock still has %d waiters", n)
+	}
+}
+
+func TestFakeTicker(t *testing.T) {
+	f := NewFake(time.Unix(0, 0))
+
+	ticker := f.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for i := 0; i < 3; i++ {
+		f.Advance(time.Second)
+		select {
+		case <-ticker.C():
+		default:
+			t.Fatalf("ticker did not tick on tick %d", i)
+		}
+	}
+
+	// Ticks are dropped if the ticker falls behind.
+	f.Advance(10 * time.Second)
+	<-ticker.C()
+
+	select {
+	case <-ticker.C():
+		t.Fatal("ticker did not drop ticks")
+	default:
+	}
+
+	f.Advance(time.Second)
+	select {
+	case <-ticker.C():
+	default:
+		t.Fatal("ticker did not tick after falling behind")
+	}
+}
+
+func TestFakeBlockUntil(t *testing.T) {
+	f := NewFake(time.Unix(0, 0))
+
+	done := make(chan struct{})
+	go func() {
+		<-After(f, time.Minute)
+		close(done)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := f.BlockUntil(ctx, 1); err != nil {
+		t.Fatal("BlockUntil failed:", err)
+	}
+
+	f.Advance(time.Minute)
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the sleeper")
+	}
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"log"
+	"log/slog"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
@@ -9,6 +13,7 @@ import (
 
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/logging"
 )
 
 func newMessage(content string) *gateway.MessageCreateEvent {
@@ -78,7 +83,7 @@ func TestHandler(t *testing.T) {
 		t.Fatal("Event type mismatch")
 	}
 
-	go h.call(msgV)
+	go h.call(context.Background(), msgV)
 
 	if results := <-results; results != result {
 		t.Fatal("Unexpected results:", results)
@@ -103,7 +108,7 @@ func TestHandlerChan(t *testing.T) {
 		t.Fatal("Event type mismatch")
 	}
 
-	go h.call(msgV)
+	go h.call(context.Background(), msgV)
 
 	if results := <-results; results.Content != result {
 		t.Fatal("Unexpected results:", results)
@@ -134,7 +139,7 @@ func TestHandlerChanCancel(t *testing.T) {
 	die := make(chan struct{})
 
 	// Call in a goroutine, which would trigger a close.
-	go func() { h.call(msgV); die <- struct{}{} }()
+	go func() { h.call(context.Background(), msgV); die <- struct{}{} }()
 
 	// Call the cleanup function, which should stop the send.
 	h.cleanup()
@@ -176,7 +181,7 @@ func TestHandlerInterface(t *testing.T) {
 		t.Fatal("Event type mismatch")
 	}
 
-	go h.call(msgV)
+	go h.call(context.Background(), msgV)
 	recv := <-results
 
 	if msg, ok := recv.(*gateway.MessageCreateEvent); ok {
@@ -190,6 +195,67 @@ func TestHandlerInterface(t *testing.T) {
 	t.Fatal("Assertion failed:", recv)
 }
 
+func TestHandlerHandles(t *testing.T) {
+	h := New()
+
+	msgT := reflect.TypeOf((*gateway.MessageCreateEvent)(nil))
+	typingT := reflect.TypeOf((*gateway.TypingStartEvent)(nil))
+
+	if h.Handles(msgT) {
+		t.Fatal("empty handler handles MessageCreateEvent")
+	}
+
+	rm := h.AddHandler(func(*gateway.MessageCreateEvent) {})
+
+	if !h.Handles(msgT) {
+		t.Fatal("handler doesn't handle MessageCreateEvent")
+	}
+	if h.Handles(typingT) {
+		t.Fatal("handler unexpectedly handles TypingStartEvent")
+	}
+
+	rm()
+
+	if h.Handles(msgT) {
+		t.Fatal("handler still handles MessageCreateEvent after removal")
+	}
+
+	h.AddHandler(func(interface{}) {})
+
+	if !h.Handles(typingT) {
+		t.Fatal("interface handler doesn't handle TypingStartEvent")
+	}
+}
+
+func TestHandlerContext(t *testing.T) {
+	type ctxKey struct{}
+
+	h := New()
+
+	got := make(chan interface{}, 1)
+	h.AddSyncHandler(func(ctx context.Context, ev *gateway.MessageCreateEvent) {
+		got <- ctx.Value(ctxKey{})
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "hime")
+	h.CallContext(ctx, newMessage("arikawa"))
+
+	if v := <-got; v != "hime" {
+		t.Fatalf("unexpected context value %v", v)
+	}
+
+	// Call should pass a background context.
+	h.Call(newMessage("arikawa"))
+
+	if v := <-got; v != nil {
+		t.Fatalf("unexpected context value %v", v)
+	}
+
+	if _, err := h.AddHandlerCheck(func(context.Context, string, *gateway.MessageCreateEvent) {}); err == nil {
+		t.Fatal("expected error adding handler with 3 arguments")
+	}
+}
+
 func TestHandlerWaitFor(t *testing.T) {
 	inc := make(chan interface{}, 1)
 
@@ -294,6 +360,316 @@ func BenchmarkReflect(b *testing.B) {
 			b.Fatal("Event type mismatch")
 		}
 
-		h.call(msgV)
+		h.call(context.Background(), msgV)
+	}
+}
+
+func TestAddTypedHandler(t *testing.T) {
+	var results = make(chan string, 2)
+
+	h := New()
+
+	rm := AddTypedSyncHandler(h, func(m *gateway.MessageCreateEvent) {
+		results <- m.Content
+	})
+
+	rmIface := AddTypedSyncHandler(h, func(ev interface{}) {
+		results <- "any"
+	})
+	defer rmIface()
+
+	h.Call(newMessage("hime arikawa"))
+
+	if r := <-results; r != "hime arikawa" {
+		t.Fatal("Returned results is wrong:", r)
+	}
+	if r := <-results; r != "any" {
+		t.Fatal("Returned results is wrong:", r)
+	}
+
+	rm()
+	h.Call(&gateway.TypingStartEvent{})
+
+	if r := <-results; r != "any" {
+		t.Fatal("Returned results is wrong:", r)
+	}
+
+	select {
+	case r := <-results:
+		t.Fatal("Unexpected results:", r)
+	default:
+	}
+}
+
+func BenchmarkTyped(b *testing.B) {
+	h := New()
+	AddTypedSyncHandler(h, func(m *gateway.MessageCreateEvent) {})
+
+	var msg = &gateway.MessageCreateEvent{}
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		h.Call(msg)
+	}
+}
+
+func TestHandlerHooks(t *testing.T) {
+	h := New()
+
+	var results = make(chan string, 1)
+	h.AddSyncHandler(func(m *gateway.MessageCreateEvent) {
+		results <- m.Content
+	})
+
+	rmPre := h.AddPreHook(func(ev interface{}) bool {
+		m, ok := ev.(*gateway.MessageCreateEvent)
+		return !ok || m.Content != "drop"
+	})
+
+	var timed = make(chan time.Duration, 1)
+	h.AddPostHook(func(ev interface{}, elapsed time.Duration) {
+		timed <- elapsed
+	})
+
+	h.Call(newMessage("drop"))
+
+	select {
+	case r := <-results:
+		t.Fatal("Unexpected results:", r)
+	default:
+	}
+
+	h.Call(newMessage("hime arikawa"))
+
+	if r := <-results; r != "hime arikawa" {
+		t.Fatal("Returned results is wrong:", r)
+	}
+
+	select {
+	case <-timed:
+	default:
+		t.Fatal("Post-dispatch hook not called")
+	}
+
+	rmPre()
+	h.Call(newMessage("drop"))
+	<-results
+	<-timed
+}
+
+func TestHandlerPreHookPanic(t *testing.T) {
+	h := New()
+	h.AddSyncHandler(func(m *gateway.MessageCreateEvent) {})
+
+	rmPre := h.AddPreHook(func(ev interface{}) bool {
+		panic("pre-hook panic")
+	})
+
+	func() {
+		defer func() { recover() }()
+		h.Call(newMessage("hime arikawa"))
+	}()
+
+	// The read lock must have been released, or this would deadlock.
+	done := make(chan struct{})
+	go func() {
+		rmPre()
+		h.AddSyncHandler(func(m *gateway.MessageCreateEvent) {})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handler lock was leaked by a panicking pre-hook")
+	}
+}
+
+func TestHandlerPanicLog(t *testing.T) {
+	var buf bytes.Buffer
+	logging.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer logging.SetLogger(nil)
+
+	h := New()
+	h.AddSyncHandler(func(m *gateway.MessageCreateEvent) {
+		panic("oh no")
+	})
+	h.Call(newMessage("hime arikawa"))
+
+	for _, expect := range []string{
+		"level=ERROR",
+		"event_type=*gateway.MessageCreateEvent",
+		`panic="oh no"`,
+		"stack=",
+	} {
+		if !strings.Contains(buf.String(), expect) {
+			t.Errorf("log output is missing %q: %q", expect, buf.String())
+		}
+	}
+}
+
+func TestHandlerPanicLogDefault(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	h := New()
+	h.AddSyncHandler(func(m *gateway.MessageCreateEvent) {
+		panic("oh no")
+	})
+	h.Call(newMessage("hime arikawa"))
+
+	for _, expect := range []string{"*gateway.MessageCreateEvent", "oh no", "goroutine"} {
+		if !strings.Contains(buf.String(), expect) {
+			t.Errorf("log output is missing %q: %q", expect, buf.String())
+		}
+	}
+}
+
+func TestHandlerPanic(t *testing.T) {
+	h := New()
+
+	type panicked struct {
+		ev    interface{}
+		rec   interface{}
+		stack []byte
+	}
+
+	var panics = make(chan panicked, 1)
+	h.SetPanicHook(func(ev, rec interface{}, stack []byte) {
+		panics <- panicked{ev, rec, stack}
+	})
+
+	var results = make(chan string, 1)
+	h.AddSyncHandler(func(m *gateway.MessageCreateEvent) {
+		panic("oops")
+	})
+	h.AddSyncHandler(func(m *gateway.MessageCreateEvent) {
+		results <- m.Content
+	})
+
+	msg := newMessage("hime arikawa")
+	h.Call(msg)
+
+	p := <-panics
+	if p.ev != msg || p.rec != "oops" || len(p.stack) == 0 {
+		t.Fatalf("Unexpected panic report: %+v", p)
+	}
+
+	if r := <-results; r != "hime arikawa" {
+		t.Fatal("Returned results is wrong:", r)
+	}
+
+	h.SetRecoverPanics(false)
+
+	func() {
+		defer func() {
+			if rec := recover(); rec != "oops" {
+				t.Error("Expected panic to propagate, got:", rec)
+			}
+		}()
+		h.Call(msg)
+	}()
+}
+
+func TestHandlerWorkerPool(t *testing.T) {
+	pool := NewWorkerPool(2, 4)
+
+	h := New()
+	h.SetWorkerPool(pool)
+
+	var results = make(chan string, 8)
+	h.AddHandler(func(m *gateway.MessageCreateEvent) {
+		results <- m.Content
+	})
+
+	for i := 0; i < 8; i++ {
+		h.Call(newMessage("hime arikawa"))
+	}
+
+	pool.Close()
+
+	if len(results) != 8 {
+		t.Fatal("Unexpected number of results:", len(results))
+	}
+}
+
+func TestHandlerWorkerPoolAfterClose(t *testing.T) {
+	pool := NewWorkerPool(2, 4)
+	pool.Close()
+
+	h := New()
+	h.SetWorkerPool(pool)
+
+	var results = make(chan string, 8)
+	h.AddHandler(func(m *gateway.MessageCreateEvent) {
+		results <- m.Content
+	})
+
+	// The queue has room, so a racy dispatch could queue these after the
+	// workers have exited.
+	for i := 0; i < 4; i++ {
+		h.Call(newMessage("hime arikawa"))
+	}
+
+	for i := 0; i < 4; i++ {
+		select {
+		case <-results:
+		case <-time.After(time.Second):
+			t.Fatal("handler dispatched after Close was not called")
+		}
+	}
+}
+
+func TestHandlerOrdered(t *testing.T) {
+	h := New()
+
+	var results = make(chan string)
+	rm := h.AddOrderedHandler(func(m *gateway.MessageCreateEvent) {
+		results <- m.Content
+	})
+	defer rm()
+
+	// Call must not block on the handler, since it runs off the calling
+	// goroutine.
+	for _, content := range []string{"1", "2", "3"} {
+		h.Call(newMessage(content))
+	}
+
+	for _, expect := range []string{"1", "2", "3"} {
+		if r := <-results; r != expect {
+			t.Fatalf("Expected %q, got %q", expect, r)
+		}
+	}
+}
+
+func TestHandlerOrderedDrainOnRemove(t *testing.T) {
+	h := New()
+
+	var release = make(chan struct{})
+	var results = make(chan string, 3)
+	rm := h.AddOrderedHandler(func(m *gateway.MessageCreateEvent) {
+		<-release
+		results <- m.Content
+	})
+
+	for _, content := range []string{"1", "2", "3"} {
+		h.Call(newMessage(content))
+	}
+
+	// Removing the handler must not drop the events that are already queued.
+	rm()
+	close(release)
+
+	for _, expect := range []string{"1", "2", "3"} {
+		select {
+		case r := <-results:
+			if r != expect {
+				t.Fatalf("Expected %q, got %q", expect, r)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("queued event %q was dropped", expect)
+		}
 	}
 }
@@ -0,0 +1,86 @@
+package handler
+
+import "time"
+
+// PreHook is a hook that is called before an event is dispatched to any of the
+// handlers. If it returns false, then the event is dropped and no handlers are
+// called.
+//
+// Pre-dispatch hooks are called synchronously in the order that they were
+// added, so they must not block for very long.
+type PreHook func(ev interface{}) bool
+
+// PostHook is a hook that is called after each handler has finished handling
+// an event, with the time that the handler took. It is called once per
+// handler, in the same goroutine as the handler.
+type PostHook func(ev interface{}, elapsed time.Duration)
+
+type hook struct {
+	id   int
+	pre  PreHook
+	post PostHook
+}
+
+type hooks struct {
+	preHooks  []hook
+	postHooks []hook
+	nextID    int
+}
+
+// pre calls all pre-dispatch hooks, returning false if any of them drops the
+// event.
+func (h *hooks) pre(ev interface{}) bool {
+	for _, hook := range h.preHooks {
+		if !hook.pre(ev) {
+			return false
+		}
+	}
+	return true
+}
+
+func callPostHooks(hooks []hook, ev interface{}, elapsed time.Duration) {
+	for _, hook := range hooks {
+		hook.post(ev, elapsed)
+	}
+}
+
+// AddPreHook adds a hook that is called before each event is dispatched,
+// returning a function that would remove this hook when called. A pre-dispatch
+// hook can be used to filter events for all handlers.
+func (h *Handler) AddPreHook(fn PreHook) (rm func()) {
+	return h.addHook(hook{pre: fn}, &h.hooks.preHooks)
+}
+
+// AddPostHook adds a hook that is called after each handler has finished
+// handling an event, returning a function that would remove this hook when
+// called. A post-dispatch hook can be used to time each handler.
+func (h *Handler) AddPostHook(fn PostHook) (rm func()) {
+	return h.addHook(hook{post: fn}, &h.hooks.postHooks)
+}
+
+func (h *Handler) addHook(hk hook, list *[]hook) (rm func()) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	hk.id = h.hooks.nextID
+	h.hooks.nextID++
+
+	// Copy the list on write, since Call hands the post-dispatch hooks to
+	// handler goroutines that may outlive the read lock.
+	hooks := make([]hook, len(*list), len(*list)+1)
+	copy(hooks, *list)
+	*list = append(hooks, hk)
+
+	return func() {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+
+		hooks := make([]hook, 0, len(*list))
+		for _, other := range *list {
+			if other.id != hk.id {
+				hooks = append(hooks, other)
+			}
+		}
+		*list = hooks
+	}
+}
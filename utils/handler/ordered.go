@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// DefaultOrderedQueueSize is the queue size used by AddOrderedHandler.
+const DefaultOrderedQueueSize = 64
+
+type orderedJob struct {
+	handler handler
+	ctx     context.Context
+	event   reflect.Value
+}
+
+// orderedQueue delivers events to a single handler in order from its own
+// goroutine.
+type orderedQueue struct {
+	jobs chan orderedJob
+	done chan struct{}
+	once sync.Once
+}
+
+func newOrderedQueue(size int) *orderedQueue {
+	if size < 0 {
+		size = 0
+	}
+
+	q := &orderedQueue{
+		jobs: make(chan orderedJob, size),
+		done: make(chan struct{}),
+	}
+	go q.work()
+
+	return q
+}
+
+func (q *orderedQueue) work() {
+	for {
+		select {
+		case job := <-q.jobs:
+			job.handler.call(job.ctx, job.event)
+		case <-q.done:
+			// Deliver what's left in the queue before exiting.
+			for {
+				select {
+				case job := <-q.jobs:
+					job.handler.call(job.ctx, job.event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (q *orderedQueue) push(h handler, ctx context.Context, event reflect.Value) {
+	select {
+	case q.jobs <- orderedJob{h, ctx, event}:
+	case <-q.done:
+	}
+}
+
+// stop stops the queue once the events queued so far are delivered. Events
+// pushed after stop are dropped.
+func (q *orderedQueue) stop() {
+	q.once.Do(func() { close(q.done) })
+}
+
+// AddOrderedHandler adds a handler that receives events in the order that they
+// arrive, like AddSyncHandler, but from its own goroutine, so that it doesn't
+// block the Call method or other handlers. Events are buffered up to
+// DefaultOrderedQueueSize; once the queue is full, Call blocks until the
+// handler catches up.
+//
+// This is useful for handlers that mutate state and therefore need ordering,
+// but that are too slow to run synchronously on the gateway's read loop.
+func (h *Handler) AddOrderedHandler(handler interface{}) (rm func()) {
+	rm, err := h.AddOrderedHandlerCheck(handler, DefaultOrderedQueueSize)
+	if err != nil {
+		panic(err)
+	}
+	return rm
+}
+
+// AddOrderedHandlerCheck is the safe-guarded version of AddOrderedHandler that
+// also takes the size of the handler's event queue.
+func (h *Handler) AddOrderedHandlerCheck(handler interface{}, queueSize int) (rm func(), err error) {
+	r, err := newHandler(handler, false)
+	if err != nil {
+		return nil, fmt.Errorf("handler reflect failed: %w", err)
+	}
+
+	r.ordered = newOrderedQueue(queueSize)
+	return h.add(r), nil
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/diamondburned/arikawa/v3/utils/logging"
+)
+
+// PanicHook is a hook that is called when a handler panics. It is given the
+// event that the handler was called with, the value that was recovered and the
+// stack trace of the panicking goroutine. It is called in the same goroutine as
+// the handler.
+type PanicHook func(ev interface{}, rec interface{}, stack []byte)
+
+type panicHandler struct {
+	hook        PanicHook // nil to log
+	dontRecover bool
+}
+
+// SetPanicHook sets the hook that is given the panics recovered from handlers.
+// If fn is nil, which is the default, then the panics are logged at the error
+// level using the logger set with logging.SetLogger. If no logger is set, then
+// they're printed with the standard log package, so that they're never lost.
+func (h *Handler) SetPanicHook(fn PanicHook) {
+	h.mutex.Lock()
+	h.panics.hook = fn
+	h.mutex.Unlock()
+}
+
+// SetRecoverPanics sets whether panics in handlers are recovered. By default,
+// a panicking handler is recovered and reported to the panic hook, so that it
+// doesn't take down the gateway event loop or the other handlers. If recover
+// is false, then panicking handlers crash the program as usual.
+func (h *Handler) SetRecoverPanics(recover bool) {
+	h.mutex.Lock()
+	h.panics.dontRecover = !recover
+	h.mutex.Unlock()
+}
+
+// recover recovers a panic and reports it. It must be deferred directly.
+func (p panicHandler) recover(event interface{}) {
+	if p.dontRecover {
+		return
+	}
+
+	rec := recover()
+	if rec == nil {
+		return
+	}
+
+	stack := debug.Stack()
+
+	if p.hook != nil {
+		p.hook(event, rec, stack)
+		return
+	}
+
+	logger := logging.Logger()
+	if !logger.Enabled(context.Background(), slog.LevelError) {
+		log.Printf("recovered from handler panic for %T: %v\n%s", event, rec, stack)
+		return
+	}
+
+	logger.Error("recovered from handler panic",
+		logging.KeyEventType, fmt.Sprintf("%T", event),
+		"panic", fmt.Sprint(rec),
+		"stack", string(stack),
+	)
+}
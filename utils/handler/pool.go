@@ -0,0 +1,102 @@
+package handler
+
+import "sync"
+
+// WorkerPool is a bounded pool of goroutines that asynchronous handlers can be
+// dispatched to instead of spawning a new goroutine for each call. This keeps
+// the goroutine count predictable when there is a burst of events.
+type WorkerPool struct {
+	jobs chan func()
+	done chan struct{}
+	wg   sync.WaitGroup
+
+	// mutex guards closed. run holds it for reading while queueing, so that
+	// Close can't stop the workers between the check and the queueing.
+	mutex  sync.RWMutex
+	closed bool
+}
+
+// NewWorkerPool creates a new worker pool with the given number of workers and
+// a queue of the given size. Once the queue is full, dispatching an event
+// blocks until a worker is free, which slows down the event source instead of
+// piling up goroutines. workers is at least 1, and queue is at least 0.
+func NewWorkerPool(workers, queue int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queue < 0 {
+		queue = 0
+	}
+
+	p := &WorkerPool{
+		jobs: make(chan func(), queue),
+		done: make(chan struct{}),
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+
+	return p
+}
+
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case job := <-p.jobs:
+			job()
+		case <-p.done:
+			// Drain what's left in the queue before exiting.
+			for {
+				select {
+				case job := <-p.jobs:
+					job()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// run queues the job. If the pool is closed, then the job is run in a new
+// goroutine instead.
+func (p *WorkerPool) run(job func()) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	if p.closed {
+		go job()
+		return
+	}
+
+	p.jobs <- job
+}
+
+// Close stops the workers after they have finished the queued jobs, and waits
+// for them to exit. Handlers dispatched after Close each run in their own
+// goroutine again. Close is safe to call multiple times.
+func (p *WorkerPool) Close() {
+	p.mutex.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+	}
+	p.mutex.Unlock()
+
+	p.wg.Wait()
+}
+
+// SetWorkerPool makes the handler dispatch asynchronous handlers to the given
+// worker pool. Synchronous handlers are unaffected. If pool is nil, then each
+// asynchronous handler is called in its own goroutine, which is the default.
+//
+// The caller is responsible for closing the pool once it is no longer used.
+func (h *Handler) SetWorkerPool(pool *WorkerPool) {
+	h.mutex.Lock()
+	h.pool = pool
+	h.mutex.Unlock()
+}
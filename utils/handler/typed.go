@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"reflect"
+)
+
+// AddTypedHandler adds a handler function for events of type T, returning a
+// function that would remove this handler when called. T must be a pointer
+// type or an interface, as with AddHandler; otherwise, AddTypedHandler
+// panics.
+//
+// Unlike AddHandler, the function given is called directly with the event
+// instead of through reflection, which is both faster and checked at compile
+// time. To add a typed handler to a Session or State, use its Handler field:
+//
+//	handler.AddTypedHandler(s.Handler, func(ev *gateway.MessageCreateEvent) {})
+func AddTypedHandler[T any](h *Handler, fn func(T)) (rm func()) {
+	return h.add(newTypedHandler(fn, false))
+}
+
+// AddTypedSyncHandler is a synchronous variant of AddTypedHandler. Refer to
+// AddSyncHandler for more information.
+func AddTypedSyncHandler[T any](h *Handler, fn func(T)) (rm func()) {
+	return h.add(newTypedHandler(fn, true))
+}
+
+func newTypedHandler[T any](fn func(T), sync bool) handler {
+	if fn == nil {
+		panic("handler: nil typed handler function")
+	}
+
+	event := reflect.TypeOf((*T)(nil)).Elem()
+
+	kind := event.Kind()
+	if kind != reflect.Ptr && kind != reflect.Interface {
+		panic(errors.New("handler reflect failed: first argument is not pointer"))
+	}
+
+	return handler{
+		event: event,
+		direct: func(ev interface{}) {
+			fn(ev.(T))
+		},
+		isIface: kind == reflect.Interface,
+		isSync:  sync,
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
 	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/tracing"
 )
 
 // StatusTooManyRequests is the HTTP status code discord sends on rate-limiting.
@@ -190,7 +191,14 @@ func (c *Client) request(
 
 	var status int
 
-	ctx := c.context
+	ctx, span := tracing.Start(c.context, "discord.rest",
+		tracing.String(tracing.KeyHTTPMethod, method))
+	defer func() {
+		if status != 0 {
+			span.SetAttributes(tracing.Int(tracing.KeyHTTPStatus, status))
+		}
+		span.End(doErr)
+	}()
 
 	if c.Timeout > 0 {
 		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
@@ -217,6 +225,9 @@ func (c *Client) request(
 		}
 
 		r, doErr = c.Client.Do(q)
+		if doErr == nil {
+			status = r.GetStatus()
+		}
 
 		// Call OnResponse() even if the request failed.
 		for _, fn := range c.OnResponse {
@@ -230,7 +241,7 @@ func (c *Client) request(
 			continue
 		}
 
-		if status = r.GetStatus(); status == StatusTooManyRequests || status >= 500 {
+		if status == StatusTooManyRequests || status >= 500 {
 			continue
 		}
 
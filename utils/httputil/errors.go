@@ -1,8 +1,11 @@
 package httputil
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/diamondburned/arikawa/v3/utils/json"
 )
@@ -34,8 +37,19 @@ func (r RequestError) Unwrap() error {
 	return r.err
 }
 
+// ErrRateLimited matches any HTTPError with the 429 Too Many Requests status
+// when used with errors.Is.
+var ErrRateLimited = errors.New("rate limited by Discord")
+
 // HTTPError is returned if the server responds successfully with an error of
 // any kind.
+//
+// HTTPError works with errors.Is: it matches an ErrorCode target if it has the
+// same code, and ErrRateLimited if Discord responded with status 429.
+//
+//	if errors.Is(err, httputil.CodeUnknownMessage) {
+//		// The message was already deleted.
+//	}
 type HTTPError struct {
 	Status int    `json:"-"`
 	Body   []byte `json:"-"`
@@ -43,6 +57,82 @@ type HTTPError struct {
 	Code    ErrorCode `json:"code"`
 	Errors  json.Raw  `json:"errors,omitempty"`
 	Message string    `json:"message,omitempty"`
+
+	// RetryAfter and Global are only set if the request is rate limited.
+	RetryAfter float64 `json:"retry_after,omitempty"`
+	Global     bool    `json:"global,omitempty"`
+}
+
+// Is returns true if target is the error's ErrorCode, or if target is
+// ErrRateLimited and the request was rate limited.
+func (err HTTPError) Is(target error) bool {
+	switch target := target.(type) {
+	case ErrorCode:
+		return err.Code == target
+	default:
+		return target == ErrRateLimited && err.Status == StatusTooManyRequests
+	}
+}
+
+// FieldError is a single error of a field in the request body, such as
+// "embeds.0.description".
+type FieldError struct {
+	// Path is the path of the field, with each key separated by a dot.
+	Path    string `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error formats the field error.
+func (err FieldError) Error() string {
+	return err.Path + ": " + err.Message
+}
+
+// FieldErrors flattens the nested Errors object into a list of field errors,
+// sorted by path. It returns nil if Discord didn't report any field errors.
+func (err HTTPError) FieldErrors() ([]FieldError, error) {
+	if len(err.Errors) == 0 {
+		return nil, nil
+	}
+
+	var fields []FieldError
+	if err := walkFieldErrors(&fields, nil, err.Errors); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Path < fields[j].Path
+	})
+
+	return fields, nil
+}
+
+func walkFieldErrors(dst *[]FieldError, path []string, raw json.Raw) error {
+	var object map[string]json.Raw
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return fmt.Errorf("invalid errors object at %q: %w", strings.Join(path, "."), err)
+	}
+
+	for key, value := range object {
+		if key != "_errors" {
+			if err := walkFieldErrors(dst, append(path, key), value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var errs []FieldError
+		if err := json.Unmarshal(value, &errs); err != nil {
+			return fmt.Errorf("invalid _errors at %q: %w", strings.Join(path, "."), err)
+		}
+
+		for _, fieldErr := range errs {
+			fieldErr.Path = strings.Join(path, ".")
+			*dst = append(*dst, fieldErr)
+		}
+	}
+
+	return nil
 }
 
 func (err HTTPError) Error() string {
@@ -66,4 +156,65 @@ func (err HTTPError) Error() string {
 	}
 }
 
+// ErrorCode is a JSON error code returned by Discord. An ErrorCode is also an
+// error, so that it can be used as a target for errors.Is.
+//
+// https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
 type ErrorCode uint
+
+// Common JSON error codes.
+const (
+	CodeUnknownAccount            ErrorCode = 10001
+	CodeUnknownApplication        ErrorCode = 10002
+	CodeUnknownChannel            ErrorCode = 10003
+	CodeUnknownGuild              ErrorCode = 10004
+	CodeUnknownIntegration        ErrorCode = 10005
+	CodeUnknownInvite             ErrorCode = 10006
+	CodeUnknownMember             ErrorCode = 10007
+	CodeUnknownMessage            ErrorCode = 10008
+	CodeUnknownOverwrite          ErrorCode = 10009
+	CodeUnknownRole               ErrorCode = 10011
+	CodeUnknownToken              ErrorCode = 10012
+	CodeUnknownUser               ErrorCode = 10013
+	CodeUnknownEmoji              ErrorCode = 10014
+	CodeUnknownWebhook            ErrorCode = 10015
+	CodeUnknownBan                ErrorCode = 10026
+	CodeUnknownInteraction        ErrorCode = 10062
+	CodeUnknownApplicationCommand ErrorCode = 10063
+	CodeMaxGuilds                 ErrorCode = 30001
+	CodeMaxPins                   ErrorCode = 30003
+	CodeMaxRoles                  ErrorCode = 30005
+	CodeMaxReactions              ErrorCode = 30010
+	CodeResourceRateLimited       ErrorCode = 31001
+	CodeUnauthorized              ErrorCode = 40001
+	CodeAlreadyCrossposted        ErrorCode = 40033
+	CodeInteractionAcknowledged   ErrorCode = 40060
+	CodeMissingAccess             ErrorCode = 50001
+	CodeInvalidAccountType        ErrorCode = 50002
+	CodeCannotExecuteOnDM         ErrorCode = 50003
+	CodeCannotEditOthersMessage   ErrorCode = 50005
+	CodeCannotSendEmptyMessage    ErrorCode = 50006
+	CodeCannotMessageUser         ErrorCode = 50007
+	CodeCannotSendInVoiceChannel  ErrorCode = 50008
+	CodeMissingPermissions        ErrorCode = 50013
+	CodeInvalidToken              ErrorCode = 50014
+	CodeTooFewOrManyMessages      ErrorCode = 50016
+	CodeMessageTooOldToDelete     ErrorCode = 50034
+	CodeInvalidFormBody           ErrorCode = 50035
+	CodeThreadArchived            ErrorCode = 50083
+)
+
+// Error implements error.
+func (code ErrorCode) Error() string {
+	return "Discord error code " + strconv.FormatUint(uint64(code), 10)
+}
+
+// ErrorCodeOf returns the ErrorCode of the HTTPError in err's chain. False is
+// returned if err doesn't contain an HTTPError.
+func ErrorCodeOf(err error) (ErrorCode, bool) {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code, true
+	}
+	return 0, false
+}
@@ -0,0 +1,77 @@
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+func TestHTTPErrorIs(t *testing.T) {
+	var err error = fmt.Errorf("cannot delete: %w", &HTTPError{
+		Status: 404,
+		Code:   CodeUnknownMessage,
+	})
+
+	if !errors.Is(err, CodeUnknownMessage) {
+		t.Error("error doesn't match CodeUnknownMessage")
+	}
+	if errors.Is(err, CodeMissingAccess) {
+		t.Error("error unexpectedly matches CodeMissingAccess")
+	}
+	if errors.Is(err, ErrRateLimited) {
+		t.Error("error unexpectedly matches ErrRateLimited")
+	}
+
+	if code, ok := ErrorCodeOf(err); !ok || code != CodeUnknownMessage {
+		t.Errorf("unexpected error code %d", code)
+	}
+
+	if !errors.Is(&HTTPError{Status: StatusTooManyRequests}, ErrRateLimited) {
+		t.Error("429 error doesn't match ErrRateLimited")
+	}
+}
+
+func TestHTTPErrorFieldErrors(t *testing.T) {
+	const body = `{
+		"code": 50035,
+		"message": "Invalid Form Body",
+		"errors": {
+			"embeds": {
+				"0": {
+					"description": {
+						"_errors": [{"code": "BASE_TYPE_MAX_LENGTH", "message": "Too long."}]
+					}
+				}
+			},
+			"content": {
+				"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "Required."}]
+			}
+		}
+	}`
+
+	var httpErr HTTPError
+	if err := json.Unmarshal([]byte(body), &httpErr); err != nil {
+		t.Fatal("failed to unmarshal:", err)
+	}
+
+	fields, err := httpErr.FieldErrors()
+	if err != nil {
+		t.Fatal("failed to get field errors:", err)
+	}
+
+	expect := []FieldError{
+		{Path: "content", Code: "BASE_TYPE_REQUIRED", Message: "Required."},
+		{Path: "embeds.0.description", Code: "BASE_TYPE_MAX_LENGTH", Message: "Too long."},
+	}
+
+	if len(fields) != len(expect) {
+		t.Fatalf("expected %d field errors, got %v", len(expect), fields)
+	}
+	for i := range expect {
+		if fields[i] != expect[i] {
+			t.Errorf("field error %d: expected %v, got %v", i, expect[i], fields[i])
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package httpdriver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// RecorderMode is the mode of a Recorder.
+type RecorderMode uint8
+
+const (
+	// ModeReplay makes a Recorder respond to requests using the interactions
+	// in its fixture file without making any real request.
+	ModeReplay RecorderMode = iota
+	// ModeRecord makes a Recorder send requests using the underlying client
+	// and record the interactions, which are written into the fixture file
+	// on Save.
+	ModeRecord
+)
+
+// Redacted replaces the scrubbed secrets in recorded interactions.
+const Redacted = "[REDACTED]"
+
+// DefaultScrubHeaders are the request and response headers whose values are
+// scrubbed from recorded interactions by default.
+var DefaultScrubHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// ErrNoInteraction is returned by a replaying Recorder if the fixture has no
+// unused interaction matching the request.
+var ErrNoInteraction = errors.New("no matching recorded interaction")
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Recorder is a Client that records REST interactions into a fixture file and
+// replays them later, so tests can run against realistic Discord payloads
+// deterministically and without a token.
+//
+// When recording, secrets such as the token are scrubbed from the recorded
+// interactions. Requests are matched to recorded interactions by their method,
+// URL and body, in the order that they were recorded. Multipart bodies aren't
+// compared, since their boundaries are random.
+type Recorder struct {
+	// Client is the underlying client used to make requests when recording.
+	Client Client
+	// Path is the path to the fixture file.
+	Path string
+	// Mode is the mode of the Recorder.
+	Mode RecorderMode
+
+	// ScrubHeaders are the headers whose values are replaced with Redacted.
+	// Values of scrubbed request headers, such as the token in the
+	// Authorization header, are also scrubbed from the rest of the
+	// interaction. It is DefaultScrubHeaders by default.
+	ScrubHeaders []string
+	// Secrets are extra strings to be replaced with Redacted wherever they
+	// appear in the interaction, such as interaction or webhook tokens.
+	Secrets []string
+
+	mutex        sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+var _ Client = (*Recorder)(nil)
+
+// NewRecorder creates a new Recorder that records interactions made using
+// client into the fixture file at path. Save must be called once all requests
+// are done.
+func NewRecorder(path string, client Client) *Recorder {
+	return &Recorder{
+		Client:       client,
+		Path:         path,
+		Mode:         ModeRecord,
+		ScrubHeaders: DefaultScrubHeaders,
+	}
+}
+
+// NewReplayer creates a new Recorder that replays the interactions in the
+// fixture file at path.
+func NewReplayer(path string) (*Recorder, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	r := &Recorder{
+		Path:         path,
+		Mode:         ModeReplay,
+		ScrubHeaders: DefaultScrubHeaders,
+	}
+
+	if err := json.Unmarshal(b, &r.interactions); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture: %w", err)
+	}
+
+	r.used = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+// Interactions returns a copy of the recorded or loaded interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions into the fixture file. It does nothing
+// when replaying.
+func (r *Recorder) Save() error {
+	if r.Mode != ModeRecord {
+		return nil
+	}
+
+	r.mutex.Lock()
+	b, err := json.MarshalIndent(r.interactions, "", "\t")
+	r.mutex.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	if err := os.WriteFile(r.Path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+
+	return nil
+}
+
+// NewRequest implements Client. It always returns a *MockRequest.
+func (r *Recorder) NewRequest(ctx context.Context, method, url string) (Request, error) {
+	req := NewMockRequestWithContext(ctx, method, url, nil, nil)
+	req.Header = make(http.Header)
+	return req, nil
+}
+
+// Do implements Client. The given request must be created using NewRequest.
+func (r *Recorder) Do(req Request) (Response, error) {
+	mock, ok := req.(*MockRequest)
+	if !ok {
+		return nil, fmt.Errorf("recorder: unexpected request type %T", req)
+	}
+
+	if r.Mode == ModeReplay {
+		return r.replay(mock)
+	}
+
+	return r.record(mock)
+}
+
+func (r *Recorder) replay(req *MockRequest) (Response, error) {
+	recorded := r.scrub(RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header,
+		Body:   string(req.Body),
+	}, r.secrets(req.Header))
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+
+		r.used[i] = true
+
+		return &MockResponse{
+			StatusCode: interaction.Response.Status,
+			Header:     interaction.Response.Header,
+			Body:       []byte(interaction.Response.Body),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, recorded.Method, recorded.URL)
+}
+
+func (r *Recorder) record(req *MockRequest) (Response, error) {
+	q, err := r.Client.NewRequest(req.ctx, req.Method, req.URL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	q.AddHeader(req.Header)
+	if req.Body != nil {
+		q.WithBody(io.NopCloser(bytes.NewReader(req.Body)))
+	}
+
+	resp, err := r.Client.Do(q)
+	if err != nil {
+		return nil, err
+	}
+
+	body := resp.GetBody()
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	mockResp := &MockResponse{
+		StatusCode: resp.GetStatus(),
+		Header:     resp.GetHeader(),
+		Body:       b,
+	}
+
+	secrets := r.secrets(req.Header)
+
+	interaction := Interaction{
+		Request: r.scrub(RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header,
+			Body:   string(req.Body),
+		}, secrets),
+		Response: RecordedResponse{
+			Status: mockResp.StatusCode,
+			Header: r.scrubHeader(mockResp.Header, secrets),
+			Body:   scrubString(string(b), secrets),
+		},
+	}
+
+	r.mutex.Lock()
+	r.interactions = append(r.interactions, interaction)
+	r.mutex.Unlock()
+
+	return mockResp, nil
+}
+
+// secrets returns the strings to scrub for a request with the given header.
+func (r *Recorder) secrets(h http.Header) []string {
+	secrets := append([]string(nil), r.Secrets...)
+
+	for _, name := range r.ScrubHeaders {
+		for _, v := range h.Values(name) {
+			if v == "" {
+				continue
+			}
+			secrets = append(secrets, v)
+			// Also scrub the bare token in case it's used elsewhere.
+			if _, token, ok := strings.Cut(v, " "); ok && token != "" {
+				secrets = append(secrets, token)
+			}
+		}
+	}
+
+	return secrets
+}
+
+func (r *Recorder) scrub(req RecordedRequest, secrets []string) RecordedRequest {
+	req.URL = scrubString(req.URL, secrets)
+	req.Header = r.scrubHeader(req.Header, secrets)
+	req.Body = scrubString(req.Body, secrets)
+	return req
+}
+
+func (r *Recorder) scrubHeader(h http.Header, secrets []string) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+
+	scrubbed := make(http.Header, len(h))
+	for k, values := range h {
+		cpy := make([]string, len(values))
+		for i, v := range values {
+			cpy[i] = scrubString(v, secrets)
+		}
+		scrubbed[k] = cpy
+	}
+
+	for _, name := range r.ScrubHeaders {
+		if _, ok := scrubbed[http.CanonicalHeaderKey(name)]; ok {
+			scrubbed[http.CanonicalHeaderKey(name)] = []string{Redacted}
+		}
+	}
+
+	return scrubbed
+}
+
+func scrubString(s string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+	return s
+}
+
+func (req RecordedRequest) matches(other RecordedRequest) bool {
+	if req.Method != other.Method || req.URL != other.URL {
+		return false
+	}
+
+	if strings.HasPrefix(other.Header.Get("Content-Type"), "multipart/") {
+		return true
+	}
+
+	return strings.TrimRight(req.Body, "\n") == strings.TrimRight(other.Body, "\n")
+}
@@ -0,0 +1,83 @@
+package httpdriver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"path":"`+r.URL.Path+`","auth":"`+r.Header.Get("Authorization")+`"}`)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	do := func(c Client, url string) (string, error) {
+		req, err := c.NewRequest(context.Background(), "GET", url)
+		if err != nil {
+			return "", err
+		}
+		req.AddHeader(http.Header{"Authorization": {"Bot secret"}})
+
+		resp, err := c.Do(req)
+		if err != nil {
+			return "", err
+		}
+
+		body := resp.GetBody()
+		defer body.Close()
+
+		b, err := io.ReadAll(body)
+		return string(b), err
+	}
+
+	rec := NewRecorder(path, NewClient())
+	rec.Secrets = []string{"webhooktoken"}
+
+	recorded, err := do(rec, srv.URL+"/webhooks/1/webhooktoken")
+	if err != nil {
+		t.Fatal("failed to record:", err)
+	}
+	if !strings.Contains(recorded, "Bot secret") {
+		t.Fatal("recorded response was scrubbed before being returned:", recorded)
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatal("failed to save:", err)
+	}
+
+	fixture, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal("failed to read fixture:", err)
+	}
+	if strings.Contains(string(fixture), "secret") || strings.Contains(string(fixture), "webhooktoken") {
+		t.Fatal("fixture contains secrets:", string(fixture))
+	}
+
+	rep, err := NewReplayer(path)
+	if err != nil {
+		t.Fatal("failed to create replayer:", err)
+	}
+	rep.Secrets = rec.Secrets
+
+	replayed, err := do(rep, srv.URL+"/webhooks/1/webhooktoken")
+	if err != nil {
+		t.Fatal("failed to replay:", err)
+	}
+	if replayed != `{"path":"/webhooks/1/[REDACTED]","auth":"[REDACTED]"}` {
+		t.Fatal("unexpected replayed response:", replayed)
+	}
+
+	if _, err := do(rep, srv.URL+"/webhooks/1/webhooktoken"); !errors.Is(err, ErrNoInteraction) {
+		t.Fatal("expected used interaction not to be replayed twice, got:", err)
+	}
+}
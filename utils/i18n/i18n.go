@@ -0,0 +1,204 @@
+// Package i18n provides a small localization layer that picks response strings
+// for interactions by the language of the user or the guild.
+//
+// Messages are stored in a Catalog, which maps each language to its messages
+// keyed by an arbitrary key. A Localizer picks the messages for an interaction
+// using its Locale, then its GuildLocale, then the fallbacks of those
+// languages, then the default language:
+//
+//	l := i18n.NewLocalizer(discord.EnglishUS, i18n.Catalog{
+//		discord.EnglishUS: {"pong": "Pong! Latency is %s."},
+//		discord.French:    {"pong": "Pong ! La latence est de %s."},
+//	})
+//
+//	r.AddFunc("ping", func(ctx context.Context, data cmdroute.CommandData) *api.InteractionResponseData {
+//		return l.For(data.Event).Data("pong", latency)
+//	})
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+// Catalog maps languages to their messages, which are keyed by message keys.
+// Messages may contain fmt verbs, which are formatted using the arguments given
+// when the message is printed.
+type Catalog map[discord.Language]map[string]string
+
+// Locales returns the message with the given key in all languages that have it.
+// It is useful for the NameLocalizations and DescriptionLocalizations fields
+// of commands.
+func (c Catalog) Locales(key string) discord.StringLocales {
+	locales := make(discord.StringLocales)
+	for lang, messages := range c {
+		if msg, ok := messages[key]; ok {
+			locales[lang] = msg
+		}
+	}
+	return locales
+}
+
+// Localizer picks messages from a Catalog.
+type Localizer struct {
+	// Catalog contains the messages.
+	Catalog Catalog
+	// Default is the language that is used when a message isn't found in any
+	// other language.
+	Default discord.Language
+	// Fallbacks maps languages to the languages that are tried, in order,
+	// when a message isn't found in them. Regardless of Fallbacks, languages
+	// sharing the same base language are tried next, such that "en-GB" falls
+	// back to "en-US".
+	Fallbacks map[discord.Language][]discord.Language
+}
+
+// NewLocalizer creates a new Localizer with the given default language and
+// catalog.
+func NewLocalizer(def discord.Language, catalog Catalog) *Localizer {
+	return &Localizer{
+		Catalog: catalog,
+		Default: def,
+	}
+}
+
+// For returns a Printer for the given interaction. The language of the user
+// is preferred over the language of the guild.
+func (l *Localizer) For(ev *discord.InteractionEvent) Printer {
+	return l.Printer(ev.Locale, discord.Language(ev.GuildLocale))
+}
+
+// Printer returns a Printer that prefers the given languages in order. Empty
+// languages are ignored.
+func (l *Localizer) Printer(langs ...discord.Language) Printer {
+	return Printer{l: l, chain: l.chain(langs)}
+}
+
+// chain returns the languages to try in order for the given languages.
+func (l *Localizer) chain(langs []discord.Language) []discord.Language {
+	var chain []discord.Language
+	seen := make(map[discord.Language]bool)
+
+	add := func(lang discord.Language) {
+		if lang != "" && !seen[lang] {
+			seen[lang] = true
+			chain = append(chain, lang)
+		}
+	}
+
+	for _, lang := range langs {
+		add(lang)
+		for _, fallback := range l.Fallbacks[lang] {
+			add(fallback)
+		}
+		for _, sibling := range l.siblings(lang) {
+			add(sibling)
+		}
+	}
+
+	add(l.Default)
+	return chain
+}
+
+// siblings returns the languages in the catalog that share the base language
+// of lang, sorted for a stable order.
+func (l *Localizer) siblings(lang discord.Language) []discord.Language {
+	base := baseLanguage(lang)
+	if base == "" {
+		return nil
+	}
+
+	var siblings []discord.Language
+	for other := range l.Catalog {
+		if other != lang && baseLanguage(other) == base {
+			siblings = append(siblings, other)
+		}
+	}
+
+	sort.Slice(siblings, func(i, j int) bool { return siblings[i] < siblings[j] })
+	return siblings
+}
+
+func baseLanguage(lang discord.Language) string {
+	base, _, _ := strings.Cut(string(lang), "-")
+	return base
+}
+
+// Printer prints messages in the first language of its chain that has them.
+// The zero value prints the keys themselves.
+type Printer struct {
+	l     *Localizer
+	chain []discord.Language
+}
+
+// Language returns the preferred language of the printer, which is the first
+// language in its chain that exists in the catalog. If none exists, the
+// default language is returned.
+func (p Printer) Language() discord.Language {
+	if p.l == nil {
+		return ""
+	}
+	for _, lang := range p.chain {
+		if _, ok := p.l.Catalog[lang]; ok {
+			return lang
+		}
+	}
+	return p.l.Default
+}
+
+// Lookup returns the unformatted message with the given key and whether it is
+// found.
+func (p Printer) Lookup(key string) (string, bool) {
+	if p.l == nil {
+		return "", false
+	}
+	for _, lang := range p.chain {
+		if msg, ok := p.l.Catalog[lang][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// Sprintf returns the message with the given key formatted with args. If the
+// message isn't found in any language, then the key is used as the message.
+func (p Printer) Sprintf(key string, args ...interface{}) string {
+	msg, ok := p.Lookup(key)
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Data returns an interaction response data with the formatted message as its
+// content. It can be returned from a cmdroute.CommandHandler.
+func (p Printer) Data(key string, args ...interface{}) *api.InteractionResponseData {
+	return &api.InteractionResponseData{
+		Content: option.NewNullableString(p.Sprintf(key, args...)),
+	}
+}
+
+// EphemeralData is like Data, except the message is only visible to the user
+// who invoked the interaction.
+func (p Printer) EphemeralData(key string, args ...interface{}) *api.InteractionResponseData {
+	data := p.Data(key, args...)
+	data.Flags = discord.EphemeralMessage
+	return data
+}
+
+// Response returns an interaction response that responds with the formatted
+// message. It can be given to api.Client.RespondInteraction.
+func (p Printer) Response(key string, args ...interface{}) api.InteractionResponse {
+	return api.InteractionResponse{
+		Type: api.MessageInteractionWithSource,
+		Data: p.Data(key, args...),
+	}
+}
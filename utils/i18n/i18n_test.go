@@ -0,0 +1,67 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+func TestLocalizer(t *testing.T) {
+	l := NewLocalizer(discord.EnglishUS, Catalog{
+		discord.EnglishUS: {
+			"hello": "Hello, %s!",
+			"bye":   "Goodbye!",
+		},
+		discord.EnglishUK: {
+			"hello": "Hello there, %s!",
+		},
+		discord.French: {
+			"hello": "Bonjour, %s !",
+		},
+	})
+	l.Fallbacks = map[discord.Language][]discord.Language{
+		"fr-CA": {discord.French},
+	}
+
+	tests := []struct {
+		name   string
+		ev     discord.InteractionEvent
+		key    string
+		expect string
+	}{
+		{"user locale", discord.InteractionEvent{Locale: discord.French}, "hello", "Bonjour, Ann !"},
+		{"guild locale", discord.InteractionEvent{Locale: discord.German, GuildLocale: "fr"}, "hello", "Bonjour, Ann !"},
+		{"explicit fallback", discord.InteractionEvent{Locale: "fr-CA"}, "hello", "Bonjour, Ann !"},
+		{"sibling fallback", discord.InteractionEvent{Locale: discord.EnglishUK}, "bye", "Goodbye!"},
+		{"default", discord.InteractionEvent{Locale: discord.German}, "bye", "Goodbye!"},
+		{"missing", discord.InteractionEvent{Locale: discord.French}, "unknown", "unknown"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got string
+			if test.key == "hello" {
+				got = l.For(&test.ev).Sprintf(test.key, "Ann")
+			} else {
+				got = l.For(&test.ev).Sprintf(test.key)
+			}
+			if got != test.expect {
+				t.Errorf("expected %q, got %q", test.expect, got)
+			}
+		})
+	}
+
+	if lang := l.Printer(discord.German).Language(); lang != discord.EnglishUS {
+		t.Errorf("expected default language, got %q", lang)
+	}
+
+	locales := l.Catalog.Locales("bye")
+	if len(locales) != 1 || locales[discord.EnglishUS] != "Goodbye!" {
+		t.Errorf("unexpected locales %v", locales)
+	}
+
+	data := l.Printer(discord.French).EphemeralData("hello", "Ann")
+	if data.Content.Val != "Bonjour, Ann !" || data.Flags != discord.EphemeralMessage {
+		t.Errorf("unexpected response data %+v", data)
+	}
+}
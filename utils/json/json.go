@@ -9,6 +9,9 @@ import (
 	"unsafe"
 )
 
+// Driver is a JSON implementation. All JSON encoding and decoding done by
+// arikawa, including gateway events and REST payloads, goes through the Default
+// driver, so replacing it allows swapping in a faster JSON library.
 type Driver interface {
 	Marshal(v interface{}) ([]byte, error)
 	Unmarshal(data []byte, v interface{}) error
@@ -17,6 +20,7 @@ type Driver interface {
 	EncodeStream(w io.Writer, v interface{}) error
 }
 
+// DefaultDriver is the Driver that uses encoding/json.
 type DefaultDriver struct{}
 
 func (d DefaultDriver) Marshal(v interface{}) ([]byte, error) {
@@ -35,9 +39,62 @@ func (d DefaultDriver) EncodeStream(w io.Writer, v interface{}) error {
 	return json.NewEncoder(w).Encode(v)
 }
 
-// Default is the default JSON driver, which uses encoding/json.
+// Default is the default JSON driver, which uses encoding/json. It may be
+// replaced to use a different JSON library, but only before anything else in
+// arikawa is used, since it is not guarded for concurrent access. For example,
+// to use goccy/go-json:
+//
+//	json.Default = json.NewDriver(gojson.Marshal, gojson.Unmarshal)
 var Default Driver = DefaultDriver{}
 
+// FuncDriver is a Driver made out of a pair of Marshal and Unmarshal functions,
+// which is what most JSON libraries provide. The stream methods read the whole
+// stream before unmarshaling and write the whole encoded value at once.
+type FuncDriver struct {
+	MarshalFunc   func(v interface{}) ([]byte, error)
+	UnmarshalFunc func(data []byte, v interface{}) error
+}
+
+var _ Driver = FuncDriver{}
+
+// NewDriver creates a new FuncDriver from the given functions.
+func NewDriver(
+	marshal func(v interface{}) ([]byte, error),
+	unmarshal func(data []byte, v interface{}) error) FuncDriver {
+
+	return FuncDriver{
+		MarshalFunc:   marshal,
+		UnmarshalFunc: unmarshal,
+	}
+}
+
+func (d FuncDriver) Marshal(v interface{}) ([]byte, error) {
+	return d.MarshalFunc(v)
+}
+
+func (d FuncDriver) Unmarshal(data []byte, v interface{}) error {
+	return d.UnmarshalFunc(data, v)
+}
+
+func (d FuncDriver) DecodeStream(r io.Reader, v interface{}) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return d.UnmarshalFunc(b, v)
+}
+
+func (d FuncDriver) EncodeStream(w io.Writer, v interface{}) error {
+	b, err := d.MarshalFunc(v)
+	if err != nil {
+		return err
+	}
+	// Match encoding/json's Encoder, which terminates each value with a
+	// newline.
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
 // Marshal uses the default driver.
 func Marshal(v interface{}) ([]byte, error) {
 	return Default.Marshal(v)
@@ -0,0 +1,94 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type driverTest struct {
+	Name string `json:"name"`
+}
+
+func TestFuncDriver(t *testing.T) {
+	var marshals, unmarshals int
+
+	d := NewDriver(
+		func(v interface{}) ([]byte, error) {
+			marshals++
+			return json.Marshal(v)
+		},
+		func(data []byte, v interface{}) error {
+			unmarshals++
+			return json.Unmarshal(data, v)
+		},
+	)
+
+	var buf bytes.Buffer
+	if err := d.EncodeStream(&buf, driverTest{"hime"}); err != nil {
+		t.Fatal("failed to encode:", err)
+	}
+
+	// The output must match encoding/json's Encoder.
+	if buf.String() != "{\"name\":\"hime\"}\n" {
+		t.Fatalf("unexpected encoded stream %q", buf.String())
+	}
+
+	var v driverTest
+	if err := d.DecodeStream(&buf, &v); err != nil {
+		t.Fatal("failed to decode:", err)
+	}
+
+	if v.Name != "hime" {
+		t.Fatalf("unexpected decoded value %+v", v)
+	}
+
+	if marshals != 1 || unmarshals != 1 {
+		t.Fatalf("expected 1 marshal and 1 unmarshal, got %d and %d", marshals, unmarshals)
+	}
+}
+
+func TestFuncDriverErrors(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	d := NewDriver(
+		func(v interface{}) ([]byte, error) { return nil, errFailed },
+		func(data []byte, v interface{}) error { return errFailed },
+	)
+
+	var buf bytes.Buffer
+	if err := d.EncodeStream(&buf, driverTest{}); !errors.Is(err, errFailed) {
+		t.Fatal("unexpected encode error:", err)
+	}
+	if buf.Len() > 0 {
+		t.Fatalf("failed encode wrote %q", buf.String())
+	}
+
+	if err := d.DecodeStream(strings.NewReader("{}"), &driverTest{}); !errors.Is(err, errFailed) {
+		t.Fatal("unexpected decode error:", err)
+	}
+}
+
+func TestDefaultDriver(t *testing.T) {
+	var called bool
+
+	old := Default
+	Default = NewDriver(
+		func(v interface{}) ([]byte, error) {
+			called = true
+			return json.Marshal(v)
+		},
+		json.Unmarshal,
+	)
+	defer func() { Default = old }()
+
+	if _, err := Marshal(driverTest{}); err != nil {
+		t.Fatal("failed to marshal:", err)
+	}
+
+	if !called {
+		t.Fatal("Marshal didn't use the Default driver")
+	}
+}
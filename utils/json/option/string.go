@@ -1,8 +1,6 @@
 package option
 
-import (
-	"encoding/json"
-)
+import "github.com/diamondburned/arikawa/v3/utils/json"
 
 // ================================ String ================================
 
@@ -0,0 +1,54 @@
+// Package logging holds the structured logger used by arikawa. By default,
+// nothing is logged; use SetLogger to integrate library logs with an existing
+// *slog.Logger:
+//
+//	logging.SetLogger(slog.Default().With("component", "discord"))
+//
+// Log messages carry structured fields such as the shard ID, event type, REST
+// route and rate limit bucket, using the keys defined in this package.
+//
+// The older ws.WSDebug and ws.WSError hooks still receive the messages that
+// they used to receive.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// Keys of the structured fields used by arikawa.
+const (
+	KeyShard     = "shard"
+	KeyEventType = "event_type"
+	KeyRoute     = "route"
+	KeyBucket    = "bucket"
+	KeyStatus    = "status"
+	KeyError     = "err"
+)
+
+var logger atomic.Pointer[slog.Logger]
+
+var discard = slog.New(discardHandler{})
+
+// SetLogger sets the logger used by arikawa. If l is nil, then logging is
+// disabled, which is the default.
+func SetLogger(l *slog.Logger) {
+	logger.Store(l)
+}
+
+// Logger returns the logger set using SetLogger. If none is set, then a logger
+// that discards everything is returned.
+func Logger() *slog.Logger {
+	if l := logger.Load(); l != nil {
+		return l
+	}
+	return discard
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
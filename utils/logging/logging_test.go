@@ -0,0 +1,31 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	if Logger().Enabled(context.Background(), slog.LevelError) {
+		t.Fatal("default logger isn't discarding")
+	}
+
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	defer SetLogger(nil)
+
+	Logger().Info("hello", KeyShard, 1)
+
+	if !strings.Contains(buf.String(), "msg=hello shard=1") {
+		t.Fatalf("unexpected log output %q", buf.String())
+	}
+
+	SetLogger(nil)
+
+	if Logger().Enabled(context.Background(), slog.LevelError) {
+		t.Fatal("logger isn't discarding after SetLogger(nil)")
+	}
+}
@@ -0,0 +1,154 @@
+package sendpart
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// sniffLen is the number of bytes needed by http.DetectContentType.
+const sniffLen = 512
+
+// ErrFileTooLarge is returned by FromURL if the file is larger than the given
+// maximum size.
+var ErrFileTooLarge = errors.New("file is too large")
+
+// FromPath opens the file at the given path. The returned File's Reader is an
+// *os.File, which the caller must close after the file is sent.
+func FromPath(name string) (File, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return File{}, err
+	}
+
+	contentType, err := detectContentType(filepath.Base(name), f)
+	if err != nil {
+		f.Close()
+		return File{}, err
+	}
+
+	return File{
+		Name:        filepath.Base(name),
+		Reader:      f,
+		ContentType: contentType,
+	}, nil
+}
+
+// FromFS reads the file with the given name in fsys, such as an embed.FS, into
+// memory.
+func FromFS(fsys fs.FS, name string) (File, error) {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{
+		Name:        path.Base(name),
+		Reader:      bytes.NewReader(b),
+		ContentType: contentTypeOf(path.Base(name), b),
+	}, nil
+}
+
+// FromURL downloads the file at the given URL into memory. If maxSize is more
+// than 0 and the file is larger than maxSize bytes, then ErrFileTooLarge is
+// returned.
+//
+// The filename is taken from the URL's path, and the content type from the
+// response if it has one.
+func FromURL(ctx context.Context, fileURL string, maxSize int64) (File, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return File{}, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return File{}, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return File{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return File{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var r io.Reader = resp.Body
+
+	if maxSize > 0 {
+		if resp.ContentLength > maxSize {
+			return File{}, fmt.Errorf("%w: %d bytes, max %d", ErrFileTooLarge, resp.ContentLength, maxSize)
+		}
+		// Read one more byte to know if the body is too large.
+		r = io.LimitReader(r, maxSize+1)
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return File{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if maxSize > 0 && int64(len(b)) > maxSize {
+		return File{}, fmt.Errorf("%w: over %d bytes", ErrFileTooLarge, maxSize)
+	}
+
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		name = "file"
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = contentTypeOf(name, b)
+	}
+
+	return File{
+		Name:        name,
+		Reader:      bytes.NewReader(b),
+		ContentType: contentType,
+	}, nil
+}
+
+// detectContentType detects the content type of the file, then seeks back to
+// where it was.
+func detectContentType(name string, r io.ReadSeeker) (string, error) {
+	if t := mime.TypeByExtension(path.Ext(name)); t != "" {
+		return t, nil
+	}
+
+	pos, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	if _, err := r.Seek(pos, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return http.DetectContentType(head[:n]), nil
+}
+
+func contentTypeOf(name string, b []byte) string {
+	if t := mime.TypeByExtension(path.Ext(name)); t != "" {
+		return t
+	}
+	return http.DetectContentType(b)
+}
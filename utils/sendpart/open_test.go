@@ -0,0 +1,97 @@
+package sendpart
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+func TestFromPath(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "image")
+	if err := os.WriteFile(name, []byte(pngHeader+"data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := FromPath(name)
+	if err != nil {
+		t.Fatal("failed to open:", err)
+	}
+	defer f.Reader.(io.Closer).Close()
+
+	assertFile(t, f, "image", "image/png", pngHeader+"data")
+}
+
+func TestFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"dir/notes.txt": {Data: []byte("hello")},
+	}
+
+	f, err := FromFS(fsys, "dir/notes.txt")
+	if err != nil {
+		t.Fatal("failed to open:", err)
+	}
+
+	assertFile(t, f, "notes.txt", "text/plain; charset=utf-8", "hello")
+}
+
+func TestFromURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image":
+			io.WriteString(w, pngHeader+"data")
+		case "/large":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			io.WriteString(w, strings.Repeat("a", 100))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	f, err := FromURL(ctx, srv.URL+"/image", 1024)
+	if err != nil {
+		t.Fatal("failed to download:", err)
+	}
+
+	assertFile(t, f, "image", "image/png", pngHeader+"data")
+
+	if _, err := FromURL(ctx, srv.URL+"/large", 10); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("expected ErrFileTooLarge, got %v", err)
+	}
+
+	if _, err := FromURL(ctx, srv.URL+"/missing", 0); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func assertFile(t *testing.T, f File, name, contentType, data string) {
+	t.Helper()
+
+	if f.Name != name {
+		t.Errorf("expected name %q, got %q", name, f.Name)
+	}
+
+	if f.ContentType != contentType {
+		t.Errorf("expected content type %q, got %q", contentType, f.ContentType)
+	}
+
+	b, err := io.ReadAll(f.Reader)
+	if err != nil {
+		t.Fatal("failed to read:", err)
+	}
+
+	if string(b) != data {
+		t.Errorf("expected data %q, got %q", data, b)
+	}
+}
@@ -3,18 +3,44 @@ package sendpart
 import (
 	"fmt"
 	"io"
+	"io/fs"
 	"mime/multipart"
+	"net/textproto"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
 	"github.com/diamondburned/arikawa/v3/utils/json"
 )
 
+// SpoilerPrefix is the filename prefix that marks an attachment as a spoiler.
+const SpoilerPrefix = "SPOILER_"
+
 // File represents a file to be uploaded to Discord.
 type File struct {
 	Name   string
 	Reader io.Reader
+
+	// Description is the description (alt text) of the file, up to 1024
+	// characters.
+	Description string
+	// Spoiler marks the file as a spoiler by prefixing its filename with
+	// SpoilerPrefix.
+	Spoiler bool
+	// ContentType is the MIME type of the file. If it's empty, the file is
+	// sent as application/octet-stream, and Discord guesses the type from the
+	// filename.
+	ContentType string
+}
+
+// Filename returns the filename that the file is uploaded as. It is Name,
+// prefixed with SpoilerPrefix if Spoiler is true and Name doesn't have it yet.
+func (f File) Filename() string {
+	if f.Spoiler && !strings.HasPrefix(f.Name, SpoilerPrefix) {
+		return SpoilerPrefix + f.Name
+	}
+	return f.Name
 }
 
 // AttachmentURI returns the file encoded using the attachment URI required for
@@ -22,11 +48,84 @@ type File struct {
 func (f File) AttachmentURI() string {
 	u := url.URL{
 		Scheme: "attachment",
-		Path:   f.Name,
+		Path:   f.Filename(),
 	}
 	return u.String()
 }
 
+// Attachment is a partial attachment object describing a file that is being
+// uploaded. ID is the index of the file in the list of uploaded files.
+//
+// https://discord.com/developers/docs/reference#uploading-files
+type Attachment struct {
+	ID          int    `json:"id"`
+	Filename    string `json:"filename"`
+	Description string `json:"description,omitempty"`
+}
+
+// Attachments returns the partial attachment objects for the given files, in
+// the same order.
+func Attachments(files []File) []Attachment {
+	attachments := make([]Attachment, len(files))
+	for i, file := range files {
+		attachments[i] = Attachment{
+			ID:          i,
+			Filename:    file.Filename(),
+			Description: file.Description,
+		}
+	}
+	return attachments
+}
+
+// Size returns the size of the file in bytes, if it can be known without
+// reading it. This is the case if Reader has a Len method, like
+// *bytes.Reader, a Stat method, like *os.File, or is an io.Seeker.
+func (f File) Size() (int64, bool) {
+	switch r := f.Reader.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len()), true
+	case interface{ Stat() (fs.FileInfo, error) }:
+		s, err := r.Stat()
+		if err != nil || !s.Mode().IsRegular() {
+			return 0, false
+		}
+		// Account for what has been read already if possible.
+		if seeker, ok := r.(io.Seeker); ok {
+			if pos, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+				return s.Size() - pos, true
+			}
+		}
+		return s.Size(), true
+	case io.Seeker:
+		pos, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		end, err := r.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, false
+		}
+		if _, err := r.Seek(pos, io.SeekStart); err != nil {
+			return 0, false
+		}
+		return end - pos, true
+	default:
+		return 0, false
+	}
+}
+
+// TotalSize returns the total size of the files whose sizes are known, as
+// returned by Size.
+func TotalSize(files []File) int64 {
+	var total int64
+	for _, file := range files {
+		if size, ok := file.Size(); ok {
+			total += size
+		}
+	}
+	return total
+}
+
 // DataMultipartWriter is a MultipartWriter that also contains data that's
 // JSON-marshalable.
 type DataMultipartWriter interface {
@@ -76,21 +175,32 @@ func PATCH(c *httputil.Client, data DataMultipartWriter, v interface{}, url stri
 
 // Write writes the item into payload_json and the list of files into the
 // multipart writer. Write does not close the body.
+//
+// If any file has a description, or if the item already has an attachments
+// array, the partial attachment objects of the files are appended to the
+// attachments array of the item. Note that when editing a message, Discord
+// removes the existing attachments that aren't in the array, so the ones to be
+// kept must be listed there.
 func Write(body *multipart.Writer, item interface{}, files []File) error {
+	payload, err := payloadJSON(item, files)
+	if err != nil {
+		return err
+	}
+
 	// Encode the JSON body first
 	w, err := body.CreateFormField("payload_json")
 	if err != nil {
 		return fmt.Errorf("failed to create bodypart for JSON: %w", err)
 	}
 
-	if err := json.EncodeStream(w, item); err != nil {
-		return fmt.Errorf("failed to encode JSON: %w", err)
+	if _, err := w.Write(payload); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
 	}
 
 	for i, file := range files {
 		num := strconv.Itoa(i)
 
-		w, err := body.CreateFormFile("file"+num, file.Name)
+		w, err := createFormFile(body, "files["+num+"]", file)
 		if err != nil {
 			return fmt.Errorf("failed to create bodypart for %q: %w", num, err)
 		}
@@ -102,3 +212,80 @@ func Write(body *multipart.Writer, item interface{}, files []File) error {
 
 	return nil
 }
+
+// payloadJSON encodes the item, adding the attachments of the files into it if
+// needed.
+func payloadJSON(item interface{}, files []File) ([]byte, error) {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	if len(files) == 0 {
+		return b, nil
+	}
+
+	var object map[string]json.Raw
+	if err := json.Unmarshal(b, &object); err != nil {
+		// Not an object, so there's nowhere to put the attachments.
+		return b, nil
+	}
+
+	existing, hasAttachments := object["attachments"]
+	if !hasAttachments && !hasDescription(files) {
+		return b, nil
+	}
+
+	var attachments []json.Raw
+	if hasAttachments && string(existing) != "null" {
+		if err := json.Unmarshal(existing, &attachments); err != nil {
+			return nil, fmt.Errorf("failed to decode attachments: %w", err)
+		}
+	}
+
+	for _, attachment := range Attachments(files) {
+		a, err := json.Marshal(attachment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode attachment: %w", err)
+		}
+		attachments = append(attachments, a)
+	}
+
+	if object["attachments"], err = json.Marshal(attachments); err != nil {
+		return nil, fmt.Errorf("failed to encode attachments: %w", err)
+	}
+
+	if b, err = json.Marshal(object); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	return b, nil
+}
+
+func hasDescription(files []File) bool {
+	for _, file := range files {
+		if file.Description != "" {
+			return true
+		}
+	}
+	return false
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// createFormFile is like (*multipart.Writer).CreateFormFile, except the file's
+// content type is used if it has one.
+func createFormFile(body *multipart.Writer, field string, file File) (io.Writer, error) {
+	if file.ContentType == "" {
+		return body.CreateFormFile(field, file.Filename())
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(
+		`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(field), quoteEscaper.Replace(file.Filename()),
+	))
+	h.Set("Content-Type", file.ContentType)
+
+	return body.CreatePart(h)
+}
@@ -0,0 +1,101 @@
+package sendpart
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteAttachments(t *testing.T) {
+	type payload struct {
+		Content     string        `json:"content"`
+		Attachments *[]Attachment `json:"attachments,omitempty"`
+	}
+
+	tests := []struct {
+		name      string
+		item      payload
+		files     []File
+		json      string
+		filenames []string
+	}{
+		{
+			name: "no description",
+			item: payload{Content: "hi"},
+			files: []File{
+				{Name: "a.png", Reader: strings.NewReader("a")},
+			},
+			json:      `{"content":"hi"}`,
+			filenames: []string{"a.png"},
+		},
+		{
+			name: "description and spoiler",
+			item: payload{Content: "hi"},
+			files: []File{
+				{Name: "a.png", Reader: strings.NewReader("a")},
+				{Name: "b.png", Reader: strings.NewReader("b"), Description: "bee", Spoiler: true},
+			},
+			json: `{"attachments":[` +
+				`{"id":0,"filename":"a.png"},` +
+				`{"id":1,"filename":"SPOILER_b.png","description":"bee"}` +
+				`],"content":"hi"}`,
+			filenames: []string{"a.png", "SPOILER_b.png"},
+		},
+		{
+			name: "existing attachments",
+			item: payload{
+				Content:     "hi",
+				Attachments: &[]Attachment{{ID: 5, Filename: "old.png"}},
+			},
+			files: []File{
+				{Name: "SPOILER_a.png", Reader: strings.NewReader("a"), Spoiler: true},
+			},
+			json: `{"attachments":[` +
+				`{"id":5,"filename":"old.png"},` +
+				`{"id":0,"filename":"SPOILER_a.png"}` +
+				`],"content":"hi"}`,
+			filenames: []string{"SPOILER_a.png"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := multipart.NewWriter(&buf)
+
+			if err := Write(w, test.item, test.files); err != nil {
+				t.Fatal("failed to write:", err)
+			}
+			w.Close()
+
+			r := multipart.NewReader(&buf, w.Boundary())
+
+			part, err := r.NextPart()
+			if err != nil {
+				t.Fatal("failed to read payload_json:", err)
+			}
+
+			b, _ := io.ReadAll(part)
+			if got := strings.TrimSpace(string(b)); got != test.json {
+				t.Errorf("unexpected payload_json\nexpected %s\ngot      %s", test.json, got)
+			}
+
+			for i, filename := range test.filenames {
+				part, err := r.NextPart()
+				if err != nil {
+					t.Fatalf("failed to read file %d: %v", i, err)
+				}
+
+				if part.FormName() != "files["+strconv.Itoa(i)+"]" {
+					t.Errorf("file %d has unexpected form name %q", i, part.FormName())
+				}
+				if part.FileName() != filename {
+					t.Errorf("file %d has unexpected filename %q", i, part.FileName())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package settings
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// File is a Store that keeps the settings in memory and saves them to a JSON
+// file after every change. It is meant for small bots that don't have a
+// database.
+type File struct {
+	mem  Memory
+	path string
+}
+
+var _ Store = (*File)(nil)
+
+// fileScope is a scope and its settings in the JSON file.
+type fileScope struct {
+	Scope
+	Settings map[string]json.Raw `json:"settings"`
+}
+
+// NewFile creates a new File store that saves the settings to the file at the
+// given path. The settings already in the file are loaded. The file doesn't
+// have to exist.
+func NewFile(path string) (*File, error) {
+	f := &File{path: path}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return f, nil
+		}
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	var scopes []fileScope
+	if err := json.Unmarshal(b, &scopes); err != nil {
+		return nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	for _, scope := range scopes {
+		for key, value := range scope.Settings {
+			f.mem.set(scope.Scope, key, value)
+		}
+	}
+
+	return f, nil
+}
+
+// Setting implements Store.
+func (f *File) Setting(ctx context.Context, scope Scope, key string) (json.Raw, error) {
+	return f.mem.Setting(ctx, scope, key)
+}
+
+// SetSetting implements Store.
+func (f *File) SetSetting(ctx context.Context, scope Scope, key string, value json.Raw) error {
+	f.mem.mu.Lock()
+	defer f.mem.mu.Unlock()
+
+	old, had := f.mem.settings[scope][key]
+	f.mem.set(scope, key, value)
+
+	if err := f.save(); err != nil {
+		f.restore(scope, key, old, had)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSetting implements Store.
+func (f *File) DeleteSetting(ctx context.Context, scope Scope, key string) error {
+	f.mem.mu.Lock()
+	defer f.mem.mu.Unlock()
+
+	old, had := f.mem.settings[scope][key]
+	f.mem.delete(scope, key)
+
+	if err := f.save(); err != nil {
+		f.restore(scope, key, old, had)
+		return err
+	}
+
+	return nil
+}
+
+// restore puts back the value that the setting had before a change that
+// failed to save, so that the settings in memory match the file. The caller
+// must hold the lock.
+func (f *File) restore(scope Scope, key string, old json.Raw, had bool) {
+	if had {
+		f.mem.set(scope, key, old)
+	} else {
+		f.mem.delete(scope, key)
+	}
+}
+
+// save writes the settings to a temporary file, then renames it over the
+// settings file, so that the file is never partially written. The caller
+// must hold the lock.
+func (f *File) save() error {
+	scopes := make([]fileScope, 0, len(f.mem.settings))
+	for scope, settings := range f.mem.settings {
+		scopes = append(scopes, fileScope{scope, settings})
+	}
+
+	var buf bytes.Buffer
+	if err := json.EncodeStream(&buf, scopes); err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create settings file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+
+	return nil
+}
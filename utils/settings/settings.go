@@ -0,0 +1,172 @@
+// Package settings provides a key-value store for bot settings that are scoped
+// per guild, per user or per member, such as a guild's prefix or a user's
+// preferred units.
+//
+// Values are stored as JSON, and the Get and Set functions convert them to and
+// from Go values:
+//
+//	prefix, err := settings.Get(ctx, store, settings.GuildScope(guildID), "prefix", "!")
+//
+// Use cmdroute.UseSettings to give the store to command handlers.
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// ErrNotFound is returned by a Store if the setting isn't set.
+var ErrNotFound = errors.New("setting not found")
+
+// Scope is the scope of a setting. A guild scope only has a GuildID, a user
+// scope only has a UserID, and a member scope has both.
+type Scope struct {
+	GuildID discord.GuildID `json:"guild_id,omitempty"`
+	UserID  discord.UserID  `json:"user_id,omitempty"`
+}
+
+// GuildScope returns the scope of the settings of a guild.
+func GuildScope(guildID discord.GuildID) Scope {
+	return Scope{GuildID: guildID}
+}
+
+// UserScope returns the scope of the settings of a user, which apply in all
+// guilds.
+func UserScope(userID discord.UserID) Scope {
+	return Scope{UserID: userID}
+}
+
+// MemberScope returns the scope of the settings of a user in a guild.
+func MemberScope(guildID discord.GuildID, userID discord.UserID) Scope {
+	return Scope{GuildID: guildID, UserID: userID}
+}
+
+// String returns the scope as a string, such as "guild/1/user/2". It is
+// suitable as a key in a database.
+func (s Scope) String() string {
+	switch {
+	case s.GuildID.IsValid() && s.UserID.IsValid():
+		return "guild/" + s.GuildID.String() + "/user/" + s.UserID.String()
+	case s.GuildID.IsValid():
+		return "guild/" + s.GuildID.String()
+	case s.UserID.IsValid():
+		return "user/" + s.UserID.String()
+	default:
+		return "global"
+	}
+}
+
+// Store stores settings as JSON values. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Setting returns the value of the setting, or ErrNotFound if it isn't
+	// set.
+	Setting(ctx context.Context, scope Scope, key string) (json.Raw, error)
+	// SetSetting sets the value of the setting.
+	SetSetting(ctx context.Context, scope Scope, key string, value json.Raw) error
+	// DeleteSetting unsets the setting. Deleting a setting that isn't set is
+	// not an error.
+	DeleteSetting(ctx context.Context, scope Scope, key string) error
+}
+
+// Get returns the value of the setting, or def if the setting isn't set.
+func Get[T any](ctx context.Context, store Store, scope Scope, key string, def T) (T, error) {
+	raw, err := store.Setting(ctx, scope, key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return def, nil
+		}
+		return def, err
+	}
+
+	var v T
+	if err := raw.UnmarshalTo(&v); err != nil {
+		return def, fmt.Errorf("invalid setting %q: %w", key, err)
+	}
+
+	return v, nil
+}
+
+// Set sets the value of the setting.
+func Set[T any](ctx context.Context, store Store, scope Scope, key string, v T) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal setting %q: %w", key, err)
+	}
+	return store.SetSetting(ctx, scope, key, b)
+}
+
+// Memory is a Store that keeps the settings in memory. A zero-value Memory is
+// a valid store.
+type Memory struct {
+	mu       sync.RWMutex
+	settings map[Scope]map[string]json.Raw
+}
+
+var _ Store = (*Memory)(nil)
+
+// NewMemory creates a new Memory store.
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+// Setting implements Store.
+func (m *Memory) Setting(ctx context.Context, scope Scope, key string) (json.Raw, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	v, ok := m.settings[scope][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append(json.Raw(nil), v...), nil
+}
+
+// SetSetting implements Store.
+func (m *Memory) SetSetting(ctx context.Context, scope Scope, key string, value json.Raw) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.set(scope, key, value)
+	return nil
+}
+
+func (m *Memory) set(scope Scope, key string, value json.Raw) {
+	if m.settings == nil {
+		m.settings = make(map[Scope]map[string]json.Raw)
+	}
+
+	settings, ok := m.settings[scope]
+	if !ok {
+		settings = make(map[string]json.Raw)
+		m.settings[scope] = settings
+	}
+
+	settings[key] = append(json.Raw(nil), value...)
+}
+
+// DeleteSetting implements Store.
+func (m *Memory) DeleteSetting(ctx context.Context, scope Scope, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.delete(scope, key)
+	return nil
+}
+
+func (m *Memory) delete(scope Scope, key string) {
+	settings, ok := m.settings[scope]
+	if !ok {
+		return
+	}
+
+	delete(settings, key)
+	if len(settings) == 0 {
+		delete(m.settings, scope)
+	}
+}
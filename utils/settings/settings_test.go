@@ -0,0 +1,152 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testStore(t *testing.T, store Store) {
+	ctx := context.Background()
+	guild := GuildScope(1)
+	member := MemberScope(1, 2)
+
+	prefix, err := Get(ctx, store, guild, "prefix", "!")
+	if err != nil || prefix != "!" {
+		t.Fatalf("expected default prefix, got %q (%v)", prefix, err)
+	}
+
+	if err := Set(ctx, store, guild, "prefix", "?"); err != nil {
+		t.Fatal("failed to set prefix:", err)
+	}
+
+	if err := Set(ctx, store, member, "units", []string{"metric"}); err != nil {
+		t.Fatal("failed to set units:", err)
+	}
+
+	if prefix, _ := Get(ctx, store, guild, "prefix", "!"); prefix != "?" {
+		t.Fatalf("expected prefix %q, got %q", "?", prefix)
+	}
+
+	// Settings of different scopes don't clash.
+	if prefix, _ := Get(ctx, store, member, "prefix", "!"); prefix != "!" {
+		t.Fatalf("member scope returned guild prefix %q", prefix)
+	}
+
+	if _, err := Get(ctx, store, member, "units", 0); err == nil {
+		t.Fatal("expected error reading setting as the wrong type")
+	}
+
+	if err := store.DeleteSetting(ctx, guild, "prefix"); err != nil {
+		t.Fatal("failed to delete prefix:", err)
+	}
+
+	if prefix, _ := Get(ctx, store, guild, "prefix", "!"); prefix != "!" {
+		t.Fatalf("expected deleted prefix, got %q", prefix)
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testStore(t, NewMemory())
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+
+	store, err := NewFile(path)
+	if err != nil {
+		t.Fatal("failed to create file store:", err)
+	}
+
+	testStore(t, store)
+
+	store, err = NewFile(path)
+	if err != nil {
+		t.Fatal("failed to reload file store:", err)
+	}
+
+	units, err := Get(context.Background(), store, MemberScope(1, 2), "units", []string(nil))
+	if err != nil || len(units) != 1 || units[0] != "metric" {
+		t.Fatalf("unexpected units after reload: %q (%v)", units, err)
+	}
+}
+
+func TestFileSaveFailure(t *testing.T) {
+	ctx := context.Background()
+	guild := GuildScope(1)
+	dir := filepath.Join(t.TempDir(), "settings")
+
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal("failed to create settings directory:", err)
+	}
+
+	store, err := NewFile(filepath.Join(dir, "settings.json"))
+	if err != nil {
+		t.Fatal("failed to create file store:", err)
+	}
+
+	if err := Set(ctx, store, guild, "prefix", "!"); err != nil {
+		t.Fatal("failed to set prefix:", err)
+	}
+
+	// Saving fails once the directory is gone.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal("failed to remove settings directory:", err)
+	}
+
+	if err := Set(ctx, store, guild, "prefix", "?"); err == nil {
+		t.Fatal("expected error setting prefix without a directory")
+	}
+
+	if err := Set(ctx, store, guild, "units", "metric"); err == nil {
+		t.Fatal("expected error setting units without a directory")
+	}
+
+	if err := store.DeleteSetting(ctx, guild, "prefix"); err == nil {
+		t.Fatal("expected error deleting prefix without a directory")
+	}
+
+	if prefix, _ := Get(ctx, store, guild, "prefix", ""); prefix != "!" {
+		t.Fatalf("failed save changed prefix to %q", prefix)
+	}
+
+	if _, err := store.Setting(ctx, guild, "units"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("failed save left units behind: %v", err)
+	}
+}
+
+func TestMemorySettingCopy(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+
+	if err := Set(ctx, store, GuildScope(1), "prefix", "!"); err != nil {
+		t.Fatal("failed to set prefix:", err)
+	}
+
+	v, err := store.Setting(ctx, GuildScope(1), "prefix")
+	if err != nil {
+		t.Fatal("failed to get prefix:", err)
+	}
+	v[1] = '?'
+
+	if prefix, _ := Get(ctx, store, GuildScope(1), "prefix", ""); prefix != "!" {
+		t.Fatalf("modifying the returned value changed prefix to %q", prefix)
+	}
+}
+
+func TestScopeString(t *testing.T) {
+	tests := map[Scope]string{
+		GuildScope(1):     "guild/1",
+		UserScope(2):      "user/2",
+		MemberScope(1, 2): "guild/1/user/2",
+		{}:                "global",
+	}
+
+	for scope, expect := range tests {
+		if s := scope.String(); s != expect {
+			t.Errorf("expected %q, got %q", expect, s)
+		}
+	}
+}
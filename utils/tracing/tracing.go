@@ -0,0 +1,134 @@
+// Package tracing provides optional tracing spans around Discord calls. It
+// doesn't depend on any tracing library; instead, a Tracer adapter is set using
+// SetTracer, after which REST requests, gateway event dispatches and voice
+// connections are traced.
+//
+// An adapter for OpenTelemetry could look like this:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string, attrs ...tracing.Attribute) (context.Context, tracing.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name)
+//		s := otelSpan{span}
+//		s.SetAttributes(attrs...)
+//		return ctx, s
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) SetAttributes(attrs ...tracing.Attribute) {
+//		for _, attr := range attrs {
+//			s.Span.SetAttributes(attribute.String(attr.Key, fmt.Sprint(attr.Value)))
+//		}
+//	}
+//
+//	func (s otelSpan) End(err error) {
+//		if err != nil {
+//			s.Span.RecordError(err)
+//			s.Span.SetStatus(codes.Error, err.Error())
+//		}
+//		s.Span.End()
+//	}
+//
+//	tracing.SetTracer(otelTracer{otel.Tracer("arikawa")})
+//
+// Since spans are stored in the context, they propagate into context handlers
+// (see package handler) and into API calls made using (*api.Client).WithContext,
+// so that Discord calls show up inside existing traces.
+package tracing
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Tracer starts spans.
+type Tracer interface {
+	// Start starts a new span as a child of the span in ctx, if any. The
+	// returned context must contain the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single traced operation.
+type Span interface {
+	// SetAttributes sets the given attributes on the span, overriding
+	// attributes with the same key.
+	SetAttributes(attrs ...Attribute)
+	// End ends the span. If err is not nil, then the span is marked as failed.
+	End(err error)
+}
+
+// Attribute is a key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String creates a new string attribute.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int creates a new integer attribute.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Attribute keys used by arikawa.
+const (
+	KeyHTTPMethod    = "http.method"
+	KeyHTTPStatus    = "http.status_code"
+	KeyRoute         = "discord.route"
+	KeyBucket        = "discord.rate_limit.bucket"
+	KeyEventType     = "discord.event.type"
+	KeyEventOp       = "discord.event.op"
+	KeyGuildID       = "discord.guild.id"
+	KeyChannelID     = "discord.channel.id"
+	KeyVoiceEndpoint = "discord.voice.endpoint"
+)
+
+type tracerBox struct{ Tracer }
+
+var tracer atomic.Value // tracerBox
+
+// SetTracer sets the global tracer. If t is nil, then tracing is disabled,
+// which is the default.
+func SetTracer(t Tracer) {
+	tracer.Store(tracerBox{t})
+}
+
+// Enabled returns true if a tracer is set.
+func Enabled() bool {
+	box, _ := tracer.Load().(tracerBox)
+	return box.Tracer != nil
+}
+
+type spanKey struct{}
+
+// Start starts a new span using the global tracer. If tracing is disabled, then
+// ctx is returned as-is along with a no-op span.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	box, _ := tracer.Load().(tracerBox)
+	if box.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	ctx, span := box.Tracer.Start(ctx, name, attrs...)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SpanFromContext returns the span started by Start that is stored in ctx. A
+// no-op span is returned if there's none.
+func SpanFromContext(ctx context.Context) Span {
+	if ctx != nil {
+		if span, ok := ctx.Value(spanKey{}).(Span); ok {
+			return span
+		}
+	}
+	return noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) End(error)                  {}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &testSpan{name: name, attrs: map[string]interface{}{}}
+	span.SetAttributes(attrs...)
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+type testSpan struct {
+	name  string
+	attrs map[string]interface{}
+	ended bool
+	err   error
+}
+
+func (s *testSpan) SetAttributes(attrs ...Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *testSpan) End(err error) {
+	s.ended = true
+	s.err = err
+}
+
+func TestTracing(t *testing.T) {
+	ctx, span := Start(context.Background(), "disabled")
+	if _, ok := span.(noopSpan); !ok {
+		t.Fatalf("expected no-op span while disabled, got %T", span)
+	}
+	if ctx != context.Background() {
+		t.Fatal("context was changed while tracing is disabled")
+	}
+
+	tracer := &testTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	if !Enabled() {
+		t.Fatal("tracing isn't enabled after SetTracer")
+	}
+
+	ctx, span = Start(context.Background(), "enabled", String(KeyRoute, "/channels/1"))
+	SpanFromContext(ctx).SetAttributes(Int(KeyHTTPStatus, 200))
+
+	err := errors.New("failed")
+	span.End(err)
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+	}
+
+	got := tracer.spans[0]
+	if got.attrs[KeyRoute] != "/channels/1" || got.attrs[KeyHTTPStatus] != 200 {
+		t.Fatalf("unexpected span attributes %v", got.attrs)
+	}
+	if !got.ended || got.err != err {
+		t.Fatal("span wasn't ended with the error")
+	}
+
+	SetTracer(nil)
+
+	if Enabled() {
+		t.Fatal("tracing is still enabled after SetTracer(nil)")
+	}
+	if _, ok := SpanFromContext(context.Background()).(noopSpan); !ok {
+		t.Fatal("expected no-op span from empty context")
+	}
+}
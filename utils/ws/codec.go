@@ -1,12 +1,15 @@
 package ws
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 
 	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/logging"
 )
 
 // Codec holds the codec states for Websocket implementations to share with the
@@ -15,6 +18,11 @@ import (
 type Codec struct {
 	Unmarshalers OpUnmarshalers
 	Headers      http.Header
+
+	// Lazy, if not nil, is called for every known event. If it returns true,
+	// then the event is delivered as a *LazyEvent, and its payload is only
+	// decoded when asked to.
+	Lazy func(code OpCode, t EventType) bool
 }
 
 // NewCodec creates a new default Codec instance.
@@ -51,6 +59,22 @@ func NewDecodeBuffer(cap int) DecodeBuffer {
 	}
 }
 
+// readBufferPool pools the buffers that payloads are read into before being
+// decoded.
+var readBufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+func putReadBuffer(b *bytes.Buffer) {
+	// Don't keep around buffers that grew for unusually large payloads, such
+	// as Guild Create events of large guilds.
+	if b.Cap() <= maxReadBufferSize {
+		readBufferPool.Put(b)
+	}
+}
+
+const maxReadBufferSize = 1 << 18 // 256KB
+
 // DecodeInto reads the given reader and decodes it into the Op out channel.
 //
 // buf is optional.
@@ -58,10 +82,18 @@ func (c Codec) DecodeInto(ctx context.Context, r io.Reader, buf *DecodeBuffer, o
 	var op codecOp
 	op.Data = json.Raw(buf.buf)
 
-	if err := json.DecodeStream(r, &op); err != nil {
+	b := readBufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+	defer putReadBuffer(b)
+
+	if _, err := b.ReadFrom(r); err != nil {
 		return c.send(ctx, out, newErrOp(err, "cannot read JSON stream"))
 	}
 
+	if err := json.Unmarshal(b.Bytes(), &op); err != nil {
+		return c.send(ctx, out, newErrOp(err, "cannot decode JSON"))
+	}
+
 	if EnableRawEvents {
 		dt := op.Data
 		op := op.Op
@@ -81,6 +113,8 @@ func (c Codec) DecodeInto(ctx context.Context, r io.Reader, buf *DecodeBuffer, o
 
 	fn := c.Unmarshalers.Lookup(op.Code, op.Type)
 	if fn == nil {
+		logging.Logger().Debug("unknown event", "op", op.Code, logging.KeyEventType, op.Type)
+
 		err := UnknownEventError{
 			Op:   op.Code,
 			Type: op.Type,
@@ -88,6 +122,18 @@ func (c Codec) DecodeInto(ctx context.Context, r io.Reader, buf *DecodeBuffer, o
 		return c.send(ctx, out, newErrOp(err, ""))
 	}
 
+	if c.Lazy != nil && c.Lazy(op.Code, op.Type) {
+		op.Op.Data = &LazyEvent{
+			// The buffer is reused for the next event, so the data has to be
+			// copied.
+			Raw:          append(json.Raw(nil), op.Data...),
+			OriginalCode: op.Code,
+			OriginalType: op.Type,
+			new:          fn,
+		}
+		return c.send(ctx, out, op.Op)
+	}
+
 	op.Op.Data = fn()
 	if err := op.Data.UnmarshalTo(op.Op.Data); err != nil {
 		return c.send(ctx, out, newErrOp(err, "cannot unmarshal JSON data from gateway"))
@@ -0,0 +1,96 @@
+package ws
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+type benchEvent struct {
+	Content string `json:"content"`
+	Author  struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+func (benchEvent) Op() OpCode           { return 0 }
+func (benchEvent) EventType() EventType { return "BENCH" }
+
+var benchPayload = []byte(`{"op":0,"t":"BENCH","s":1,"d":{` +
+	`"content":"` + strings.Repeat("hello world ", 200) + `",` +
+	`"author":{"id":"170132746042081280","username":"arikawa"}}}`)
+
+func TestCodecDecodeInto(t *testing.T) {
+	codec := NewCodec(NewOpUnmarshalers(func() Event { return new(benchEvent) }))
+	buf := NewDecodeBuffer(1 << 14)
+	out := make(chan Op, 1)
+
+	// Decode twice to ensure that reused buffers don't leak into the next
+	// event.
+	for i := 0; i < 2; i++ {
+		err := codec.DecodeInto(context.Background(), bytes.NewReader(benchPayload), &buf, out)
+		if err != nil {
+			t.Fatal("failed to decode:", err)
+		}
+
+		op := <-out
+
+		ev, ok := op.Data.(*benchEvent)
+		if !ok {
+			t.Fatalf("unexpected event %T: %v", op.Data, op.Data)
+		}
+
+		if ev.Author.Username != "arikawa" {
+			t.Fatalf("unexpected author username %q", ev.Author.Username)
+		}
+	}
+}
+
+func BenchmarkCodecDecodeInto(b *testing.B) {
+	codec := NewCodec(NewOpUnmarshalers(func() Event { return new(benchEvent) }))
+	buf := NewDecodeBuffer(1 << 14)
+	out := make(chan Op, 1)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := codec.DecodeInto(ctx, bytes.NewReader(benchPayload), &buf, out); err != nil {
+			b.Fatal(err)
+		}
+		<-out
+	}
+}
+
+func BenchmarkLoopStateDecodeZlib(b *testing.B) {
+	var compressed bytes.Buffer
+	z := zlib.NewWriter(&compressed)
+	z.Write(benchPayload)
+	z.Close()
+
+	state := loopState{
+		codec: NewCodec(NewOpUnmarshalers(func() Event { return new(benchEvent) })),
+		buf:   decodeBufferPool.Get().(*DecodeBuffer),
+	}
+	defer state.release()
+
+	out := make(chan Op, 1)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		r := bytes.NewReader(compressed.Bytes())
+		if err := state.decode(ctx, websocket.BinaryMessage, r, out); err != nil {
+			b.Fatal(err)
+		}
+		<-out
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/diamondburned/arikawa/v3/utils/logging"
 )
 
 const rwBufferSize = 1 << 15 // 32KB
@@ -126,11 +128,11 @@ func (c *Conn) Close(gracefully bool) error {
 
 func (c *connMutex) close(timeout time.Duration, gracefully bool) error {
 	if c == nil || c.Conn == nil {
-		WSDebug("Conn: Close is called on already closed connection")
+		LogDebug("Conn: Close is called on already closed connection")
 		return ErrWebsocketClosed
 	}
 
-	WSDebug("Conn: Close is called; shutting down the Websocket connection.")
+	LogDebug("Conn: Close is called; shutting down the Websocket connection.")
 
 	if gracefully {
 		// Have a deadline before closing.
@@ -144,13 +146,13 @@ func (c *connMutex) close(timeout time.Duration, gracefully bool) error {
 			// Lock acquired. We can now safely set the deadline and write.
 			c.SetWriteDeadline(deadline)
 
-			WSDebug("Conn: Graceful closing requested, sending close frame.")
+			LogDebug("Conn: Graceful closing requested, sending close frame.")
 
 			if err := c.WriteMessage(
 				websocket.CloseMessage,
 				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
 			); err != nil {
-				WSError(err)
+				LogError(err, "Conn: cannot send close frame")
 			}
 
 			// Release the lock.
@@ -166,9 +168,10 @@ func (c *connMutex) close(timeout time.Duration, gracefully bool) error {
 	err := c.Conn.Close()
 
 	if err != nil {
-		WSDebug("Conn: Websocket closed; error:", err)
+		Debug("Conn: Websocket closed; error:", err)
+		logging.Logger().Debug("Conn: Websocket closed with error", logging.KeyError, err)
 	} else {
-		WSDebug("Conn: Websocket closed successfully")
+		LogDebug("Conn: Websocket closed successfully")
 	}
 
 	c.Conn = nil
@@ -217,23 +220,41 @@ type loopState struct {
 	conn  *websocket.Conn
 	codec Codec
 	zlib  io.ReadCloser
-	buf   DecodeBuffer
+	buf   *DecodeBuffer
 }
 
+// zlibPool and decodeBufferPool keep the per-connection resources of closed
+// connections around for new ones, so that reconnecting doesn't allocate them
+// again.
+var (
+	zlibPool         sync.Pool // io.ReadCloser
+	decodeBufferPool = sync.Pool{
+		New: func() interface{} {
+			buf := NewDecodeBuffer(1 << 14) // 16KB
+			return &buf
+		},
+	}
+)
+
 func readLoop(ctx context.Context, conn *websocket.Conn, codec Codec, opCh chan<- Op) {
 	// Clean up the events channel in the end.
 	defer close(opCh)
 
 	// Allocate the read loop its own private resources.
+	buf := decodeBufferPool.Get().(*DecodeBuffer)
+	defer decodeBufferPool.Put(buf)
+
 	state := loopState{
 		conn:  conn,
 		codec: codec,
-		buf:   NewDecodeBuffer(1 << 14), // 16KB
+		buf:   buf,
 	}
+	defer state.release()
 
 	for {
 		if err := state.handle(ctx, opCh); err != nil {
-			WSDebug("Conn: fatal Conn error:", err)
+			Debug("Conn: fatal Conn error:", err)
+			logging.Logger().Debug("Conn: fatal Conn error", logging.KeyError, err)
 
 			closeEv := &CloseEvent{
 				Err:  err,
@@ -264,9 +285,19 @@ func (state *loopState) handle(ctx context.Context, opCh chan<- Op) error {
 		return err
 	}
 
+	return state.decode(ctx, t, r, opCh)
+}
+
+func (state *loopState) decode(ctx context.Context, t int, r io.Reader, opCh chan<- Op) error {
 	if t == websocket.BinaryMessage {
 		// Probably a zlib payload.
 
+		if state.zlib == nil {
+			if z, ok := zlibPool.Get().(io.ReadCloser); ok {
+				state.zlib = z
+			}
+		}
+
 		if state.zlib == nil {
 			z, err := zlib.NewReader(r)
 			if err != nil {
@@ -283,9 +314,17 @@ func (state *loopState) handle(ctx context.Context, opCh chan<- Op) error {
 		r = state.zlib
 	}
 
-	if err := state.codec.DecodeInto(ctx, r, &state.buf, opCh); err != nil {
+	if err := state.codec.DecodeInto(ctx, r, state.buf, opCh); err != nil {
 		return fmt.Errorf("error distributing event: %w", err)
 	}
 
 	return nil
 }
+
+// release puts the zlib reader back into the pool.
+func (state *loopState) release() {
+	if state.zlib != nil {
+		zlibPool.Put(state.zlib)
+		state.zlib = nil
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/diamondburned/arikawa/v3/internal/lazytime"
+	"github.com/diamondburned/arikawa/v3/utils/clock"
 	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/logging"
 )
 
 // ConnectionError is given to the user if the gateway fails to connect to the
@@ -76,6 +80,14 @@ type GatewayOpts struct {
 	// gracefully once the context given to Open is cancelled. It governs the
 	// Close behavior. The default is true.
 	AlwaysCloseGracefully bool
+
+	// LogAttrs are attributes added to every message that the Gateway logs
+	// into the structured logger, such as the shard ID.
+	LogAttrs []slog.Attr
+
+	// Clock is the clock used for heartbeating and reconnect delays. It is
+	// clock.System if nil. Tests may set it to a *clock.Fake to skip waiting.
+	Clock clock.Clock
 }
 
 // DefaultGatewayOpts is the default event loop options.
@@ -118,9 +130,17 @@ type Gateway struct {
 	outer     outerState
 	lastError error
 
+	// logger caches the logger derived from the global one and LogAttrs.
+	logger atomic.Pointer[cachedLogger]
+
 	opts GatewayOpts
 }
 
+type cachedLogger struct {
+	base    *slog.Logger
+	derived *slog.Logger
+}
+
 // outerState holds gateway state that the caller may change concurrently. As
 // such, it holds a mutex to allow that. The main purpose of this
 // synchronization is to allow the caller to use the gateway while the event
@@ -154,8 +174,9 @@ func NewGateway(ws *Websocket, opts *GatewayOpts) *Gateway {
 	}
 
 	return &Gateway{
-		ws:   ws,
-		opts: *opts,
+		ws:    ws,
+		heart: lazytime.Ticker{Clock: opts.Clock},
+		opts:  *opts,
 	}
 }
 
@@ -166,6 +187,29 @@ func (g *Gateway) Opts() *GatewayOpts {
 	return &cpy
 }
 
+// Clock returns the clock that the gateway uses.
+func (g *Gateway) Clock() clock.Clock {
+	return clock.OrSystem(g.opts.Clock)
+}
+
+// Logger returns the structured logger set using logging.SetLogger with the
+// gateway's LogAttrs.
+func (g *Gateway) Logger() *slog.Logger {
+	l := logging.Logger()
+	if len(g.opts.LogAttrs) == 0 {
+		return l
+	}
+
+	// The derived logger is only rebuilt if SetLogger was called since.
+	if c := g.logger.Load(); c != nil && c.base == l {
+		return c.derived
+	}
+
+	derived := slog.New(l.Handler().WithAttrs(g.opts.LogAttrs))
+	g.logger.Store(&cachedLogger{base: l, derived: derived})
+	return derived
+}
+
 // Send is a function to send an Op payload to the Gateway.
 func (g *Gateway) Send(ctx context.Context, data Event) error {
 	op := Op{
@@ -174,7 +218,8 @@ func (g *Gateway) Send(ctx context.Context, data Event) error {
 		Data: data,
 	}
 
-	WSDebug("sending command Op", op.Code, "type", op.Type)
+	Debug("sending command Op", op.Code, "type", op.Type)
+	g.Logger().Debug("sending command", "op", op.Code, logging.KeyEventType, op.Type)
 
 	b, err := json.Marshal(op)
 	if err != nil {
@@ -292,6 +337,8 @@ func (g *Gateway) ResetHeartbeat(d time.Duration) {
 // SendError sends the given error wrapped in a BackgroundErrorEvent into the
 // event channel.
 func (g *Gateway) SendError(err error) {
+	g.Logger().Warn("gateway error", logging.KeyError, err)
+
 	event := &BackgroundErrorEvent{err}
 
 	g.outer.ch <- Op{
@@ -311,7 +358,7 @@ func (g *Gateway) spin(ctx context.Context, h Handler) {
 	// Always close the event channel once we exit.
 	defer g.finalize(h)
 
-	var retryTimer lazytime.Timer
+	retryTimer := lazytime.Timer{Clock: g.opts.Clock}
 	defer retryTimer.Stop()
 
 	g.reconnect = make(chan struct{}, 1)
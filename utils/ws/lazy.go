@@ -0,0 +1,49 @@
+package ws
+
+import (
+	"sync"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+)
+
+// LazyEvent is an event whose payload hasn't been decoded yet. It is generated
+// by the Codec instead of the actual event if Codec.Lazy returns true for it,
+// which avoids the decoding cost for events that nobody handles.
+type LazyEvent struct {
+	// Raw is the raw JSON payload of the event. It must not be modified.
+	Raw          json.Raw
+	OriginalCode OpCode
+	OriginalType EventType
+
+	new  OpFunc
+	once sync.Once
+	ev   Event
+	err  error
+}
+
+var _ Event = (*LazyEvent)(nil)
+
+// Op implements Event. It returns -1.
+func (e *LazyEvent) Op() OpCode { return -1 }
+
+// EventType implements Event. It returns an opaque unique string.
+func (e *LazyEvent) EventType() EventType { return "__ws.LazyEvent" }
+
+// NewEvent returns a new zero-value instance of the underlying event without
+// decoding anything. It is useful for checking the type of the event.
+func (e *LazyEvent) NewEvent() Event { return e.new() }
+
+// Decode decodes the payload into the underlying event. The payload is only
+// decoded the first time; later calls return the same event. It is safe to
+// call Decode concurrently.
+func (e *LazyEvent) Decode() (Event, error) {
+	e.once.Do(func() {
+		ev := e.new()
+		if err := e.Raw.UnmarshalTo(ev); err != nil {
+			e.err = err
+			return
+		}
+		e.ev = ev
+	})
+	return e.ev, e.err
+}
@@ -62,6 +62,17 @@ type Event interface {
 	EventType() EventType
 }
 
+// PooledEvent is an Event that may be reused once it has been dispatched to all
+// synchronous handlers. Event loops such as ophandler.Loop call Release after
+// dispatching the event; after that, the event must not be used anymore.
+type PooledEvent interface {
+	Event
+	// Pooled returns true if the event will be reused once released. Handlers
+	// that may use such an event after the dispatch are given a copy.
+	Pooled() bool
+	Release()
+}
+
 // OpFunc is a constructor function for an Operation.
 type OpFunc func() Event
 
@@ -4,25 +4,86 @@ package ophandler
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
 	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/tracing"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
 
 // Loop starts a background goroutine that starts reading from src and
 // distributes received events into the given handler. It's stopped once src is
 // closed. The returned channel will be closed once src is closed.
+//
+// Events implementing ws.PooledEvent are released once the handler returns.
+// Each ws.LazyEvent is decoded if dst has a handler for its underlying type;
+// otherwise, the ws.LazyEvent itself is dispatched.
+//
+// If tracing is enabled, then each event is dispatched inside a span, which is
+// passed to handlers that take a context. The span ends once all synchronous
+// handlers return.
 func Loop(src <-chan ws.Op, dst *handler.Handler) <-chan struct{} {
 	done := make(chan struct{})
 	go func() {
+		// types caches the underlying types of lazy events.
+		types := make(map[ws.EventType]reflect.Type)
+
 		for op := range src {
-			dst.Call(op.Data)
+			ev := op.Data
+
+			if lazy, ok := ev.(*ws.LazyEvent); ok {
+				ev = decodeIfHandled(dst, lazy, types)
+			}
+
+			if tracing.Enabled() {
+				callTraced(dst, op, ev)
+			} else {
+				dst.Call(ev)
+			}
+
+			if ev, ok := ev.(ws.PooledEvent); ok {
+				ev.Release()
+			}
 		}
 		close(done)
 	}()
 	return done
 }
 
+// callTraced calls dst inside a dispatch span. It's kept separate from Loop so
+// that the attributes aren't allocated when tracing is disabled.
+func callTraced(dst *handler.Handler, op ws.Op, ev ws.Event) {
+	ctx, span := tracing.Start(context.Background(), "discord.gateway.dispatch",
+		tracing.String(tracing.KeyEventType, string(op.Type)),
+		tracing.Int(tracing.KeyEventOp, int(op.Code)),
+	)
+	defer span.End(nil)
+
+	dst.CallContext(ctx, ev)
+}
+
+func decodeIfHandled(dst *handler.Handler, lazy *ws.LazyEvent, types map[ws.EventType]reflect.Type) ws.Event {
+	t, ok := types[lazy.OriginalType]
+	if !ok {
+		t = reflect.TypeOf(lazy.NewEvent())
+		types[lazy.OriginalType] = t
+	}
+
+	if !dst.Handles(t) {
+		return lazy
+	}
+
+	ev, err := lazy.Decode()
+	if err != nil {
+		return &ws.BackgroundErrorEvent{
+			Err: fmt.Errorf("cannot unmarshal lazy event %s: %w", lazy.OriginalType, err),
+		}
+	}
+
+	return ev
+}
+
 // WaitForDone waits for the done channel returned by Loop until the channel is
 // closed or the context expires.
 func WaitForDone(ctx context.Context, done <-chan struct{}) error {
@@ -0,0 +1,76 @@
+package ophandler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+)
+
+var pool = sync.Pool{New: func() interface{} { return new(pooledEvent) }}
+
+type pooledEvent struct {
+	Content string
+}
+
+func (ev *pooledEvent) Op() ws.OpCode           { return 0 }
+func (ev *pooledEvent) EventType() ws.EventType { return "POOLED" }
+func (ev *pooledEvent) Pooled() bool            { return true }
+func (ev *pooledEvent) Release()                { *ev = pooledEvent{}; pool.Put(ev) }
+
+func newPooledEvent(content string) *pooledEvent {
+	ev := pool.Get().(*pooledEvent)
+	ev.Content = content
+	return ev
+}
+
+// TestLoopPooledEvents must be run with -race to catch handlers that use a
+// pooled event after it's released.
+func TestLoopPooledEvents(t *testing.T) {
+	h := handler.New()
+
+	const n = 50
+
+	var wg sync.WaitGroup
+	wg.Add(3 * n)
+
+	check := func(name string) func(*pooledEvent) {
+		return func(ev *pooledEvent) {
+			defer wg.Done()
+			time.Sleep(time.Millisecond)
+			if ev.Content != "hime arikawa" {
+				t.Errorf("%s handler got a released event: %q", name, ev.Content)
+			}
+		}
+	}
+
+	h.AddHandler(check("async"))
+	h.AddOrderedHandler(check("ordered"))
+
+	ch := make(chan *pooledEvent)
+	h.AddHandler(ch)
+	go func() {
+		for i := 0; i < n; i++ {
+			check("channel")(<-ch)
+		}
+	}()
+
+	h.AddSyncHandler(func(ev *pooledEvent) {
+		if ev.Content != "hime arikawa" {
+			t.Errorf("sync handler got a released event: %q", ev.Content)
+		}
+	})
+
+	src := make(chan ws.Op)
+	done := Loop(src, h)
+
+	for i := 0; i < n; i++ {
+		src <- ws.Op{Data: newPooledEvent("hime arikawa")}
+	}
+	close(src)
+	<-done
+
+	wg.Wait()
+}
@@ -9,16 +9,45 @@ import (
 	"sync"
 
 	"golang.org/x/time/rate"
+
+	"github.com/diamondburned/arikawa/v3/utils/logging"
 )
 
 var (
 	// WSError is the default error handler
 	WSError = func(err error) { log.Println("Gateway error:", err) }
 	// WSDebug is used for extra debug logging. This is expected to behave
-	// similarly to log.Println().
-	WSDebug = func(v ...interface{}) {}
+	// similarly to log.Println(). It is nil by default, which disables it;
+	// use Debug to call it.
+	WSDebug func(v ...interface{})
 )
 
+// Debug calls WSDebug with v if WSDebug is set.
+func Debug(v ...interface{}) {
+	if WSDebug != nil {
+		WSDebug(v...)
+	}
+}
+
+// LogDebug logs a debug message into both WSDebug and the structured logger
+// set using logging.SetLogger. Args are alternating keys and values, as with
+// slog.Logger.Debug. WSDebug is given only the message, so messages that have
+// args should call Debug and the logger separately.
+func LogDebug(msg string, args ...any) {
+	if WSDebug != nil {
+		WSDebug(msg)
+	}
+	logging.Logger().Debug(msg, args...)
+}
+
+// LogError logs the error into both WSError and the structured logger set
+// using logging.SetLogger. WSError is given the error as is. Args are
+// alternating keys and values, as with slog.Logger.Error.
+func LogError(err error, msg string, args ...any) {
+	WSError(err)
+	logging.Logger().Error(msg, append([]any{logging.KeyError, err}, args...)...)
+}
+
 // Websocket is a wrapper around a websocket Conn with thread safety and rate
 // limiting for sending and throttling.
 type Websocket struct {
@@ -70,21 +99,21 @@ func (ws *Websocket) Dial(ctx context.Context) (<-chan Op, error) {
 // Send sends b over the Websocket with a deadline. It closes the internal
 // Websocket if the Send method errors out.
 func (ws *Websocket) Send(ctx context.Context, b []byte) error {
-	WSDebug("Acquiring the websocket mutex for sending.")
+	LogDebug("Acquiring the websocket mutex for sending.")
 
 	ws.mutex.Lock()
 	sendLimiter := ws.sendLimiter
 	conn := ws.conn
 	ws.mutex.Unlock()
 
-	WSDebug("Waiting for the send rate limiter...")
+	LogDebug("Waiting for the send rate limiter...")
 
 	if err := sendLimiter.Wait(ctx); err != nil {
-		WSDebug("Send rate limiter timed out.")
+		LogDebug("Send rate limiter timed out.")
 		return fmt.Errorf("SendLimiter failed: %w", err)
 	}
 
-	WSDebug("Send has passed the rate limiting.")
+	LogDebug("Send has passed the rate limiting.")
 
 	return conn.Send(ctx, b)
 }
@@ -93,12 +122,12 @@ func (ws *Websocket) Send(ctx context.Context, b []byte) error {
 // closed even when it returns an error. If the Websocket was already closed
 // before, ErrWebsocketClosed will be returned.
 func (ws *Websocket) Close() error {
-	WSDebug("Conn: Acquiring mutex lock to close...")
+	LogDebug("Conn: Acquiring mutex lock to close...")
 
 	ws.mutex.Lock()
 	defer ws.mutex.Unlock()
 
-	WSDebug("Conn: Write mutex acquired")
+	LogDebug("Conn: Write mutex acquired")
 
 	return ws.conn.Close(false)
 }
@@ -106,12 +135,12 @@ func (ws *Websocket) Close() error {
 // CloseGracefully is similar to Close, but a proper close frame is sent to
 // Discord, invalidating the internal session ID and voiding resumes.
 func (ws *Websocket) CloseGracefully() error {
-	WSDebug("Conn: Acquiring mutex lock to close...")
+	LogDebug("Conn: Acquiring mutex lock to close...")
 
 	ws.mutex.Lock()
 	defer ws.mutex.Unlock()
 
-	WSDebug("Conn: Write mutex acquired")
+	LogDebug("Conn: Write mutex acquired")
 
 	return ws.conn.Close(true)
 }
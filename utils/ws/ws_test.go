@@ -0,0 +1,45 @@
+package ws
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLogHooks(t *testing.T) {
+	var debug [][]interface{}
+	var errs []error
+
+	oldDebug, oldError := WSDebug, WSError
+	defer func() { WSDebug, WSError = oldDebug, oldError }()
+
+	WSDebug = func(v ...interface{}) { debug = append(debug, v) }
+	WSError = func(err error) { errs = append(errs, err) }
+
+	err := errors.New("closed")
+
+	LogDebug("Conn: Write mutex acquired")
+	Debug("Conn: fatal Conn error:", err)
+	LogError(err, "Conn: cannot send close frame")
+
+	expectDebug := [][]interface{}{
+		{"Conn: Write mutex acquired"},
+		{"Conn: fatal Conn error:", err},
+	}
+	if !reflect.DeepEqual(debug, expectDebug) {
+		t.Fatalf("unexpected WSDebug calls %v", debug)
+	}
+
+	if len(errs) != 1 || errs[0] != err {
+		t.Fatalf("unexpected WSError calls %v", errs)
+	}
+}
+
+func TestLogDebugNoHook(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		LogDebug("Send has passed the rate limiting.")
+	})
+	if allocs != 0 {
+		t.Fatalf("LogDebug allocated %v times without hooks", allocs)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/diamondburned/arikawa/v3/session"
 	"github.com/diamondburned/arikawa/v3/state"
 	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/logging"
+	"github.com/diamondburned/arikawa/v3/utils/tracing"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 	"github.com/diamondburned/arikawa/v3/utils/ws/ophandler"
 	"github.com/diamondburned/arikawa/v3/voice/udp"
@@ -82,11 +84,23 @@ type Session struct {
 	// udpManager is the manager for a UDP connection. The user can use this to
 	// plug in a custom UDP dialer.
 	udpManager *udp.Manager
+	// gatewayConn, if not nil, creates the websocket connection of each voice
+	// gateway.
+	gatewayConn func(voicegateway.State) ws.Connection
 
 	gateway  *voicegateway.Gateway
 	gwCancel context.CancelFunc
 	gwDone   <-chan struct{}
 
+	// health is read by Health. It has its own mutex, since mut is held for
+	// as long as joining a channel takes.
+	health struct {
+		sync.Mutex
+		connecting bool
+		gateway    *voicegateway.Gateway
+		gwDone     <-chan struct{}
+	}
+
 	WSTimeout      time.Duration // global WSTimeout
 	WSMaxRetry     int           // 2
 	WSRetryDelay   time.Duration // 2s
@@ -144,6 +158,16 @@ func (s *Session) SetUDPDialer(d udp.DialFunc) {
 	s.udpManager.SetDialer(d)
 }
 
+// SetGatewayConnection sets the function used to create the websocket
+// connection of each voice gateway, which is given the state that the gateway
+// is created with. It should only be called right after construction.
+func (s *Session) SetGatewayConnection(f func(voicegateway.State) ws.Connection) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.gatewayConn = f
+}
+
 func (s *Session) acquireUpdate(f func()) bool {
 	if s.joining.Get() {
 		return false
@@ -212,6 +236,16 @@ type waitEventChs struct {
 
 // JoinChannel joins the given voice channel with the default timeout.
 func (s *Session) JoinChannel(ctx context.Context, chID discord.ChannelID, mute, deaf bool) error {
+	ctx, span := tracing.Start(ctx, "discord.voice.join",
+		tracing.String(tracing.KeyChannelID, chID.String()))
+
+	err := s.joinChannel(ctx, chID, mute, deaf)
+	span.End(err)
+
+	return err
+}
+
+func (s *Session) joinChannel(ctx context.Context, chID discord.ChannelID, mute, deaf bool) error {
 	var ch *discord.Channel
 
 	if chID.IsValid() {
@@ -233,6 +267,9 @@ func (s *Session) JoinChannel(ctx context.Context, chID discord.ChannelID, mute,
 
 	defer s.joining.Set(false)
 
+	s.setConnecting(true)
+	defer s.setConnecting(false)
+
 	// Set the state.
 	if ch != nil {
 		s.state.ChannelID = ch.ID
@@ -377,7 +414,23 @@ func (s *Session) waitForIncoming(ctx context.Context, chs waitEventChs) error {
 // reconnect uses the current state to reconnect to a new gateway and UDP
 // connection.
 func (s *Session) reconnectCtx(ctx context.Context) error {
-	ws.WSDebug("Sending stop handle.")
+	ctx, span := tracing.Start(ctx, "discord.voice.connect",
+		tracing.String(tracing.KeyGuildID, s.state.GuildID.String()),
+		tracing.String(tracing.KeyChannelID, s.state.ChannelID.String()),
+		tracing.String(tracing.KeyVoiceEndpoint, s.state.Endpoint),
+	)
+
+	err := s.reconnect(ctx)
+	span.End(err)
+
+	return err
+}
+
+func (s *Session) reconnect(ctx context.Context) error {
+	ws.LogDebug("Sending stop handle.")
+
+	s.setConnecting(true)
+	defer s.setConnecting(false)
 
 	if err := s.udpManager.Pause(ctx); err != nil {
 		return fmt.Errorf("cannot pause UDP manager: %w", err)
@@ -390,18 +443,23 @@ func (s *Session) reconnectCtx(ctx context.Context) error {
 
 	s.ensureClosed()
 
-	ws.WSDebug("Start gateway.")
-	s.gateway = voicegateway.New(s.state)
+	ws.LogDebug("Start gateway.")
+	if s.gatewayConn != nil {
+		s.gateway = voicegateway.NewWithConnection(s.state, s.gatewayConn(s.state))
+	} else {
+		s.gateway = voicegateway.New(s.state)
+	}
 
 	// Open the voice gateway. The function will block until Ready is received.
 	gwctx, gwcancel := context.WithCancel(context.Background())
 	s.gwCancel = gwcancel
 
 	gwch := s.gateway.Connect(gwctx)
-	ws.WSDebug("Voice Gateway connected")
+	ws.LogDebug("Voice Gateway connected")
 
 	if err := s.spinGateway(ctx, gwch); err != nil {
-		ws.WSDebug("Voice spinGateway error:", err)
+		ws.Debug("Voice spinGateway error:", err)
+		logging.Logger().Debug("Voice spinGateway error", logging.KeyError, err)
 		// Early cancel the gateway.
 		gwcancel()
 		// Nil this so future reconnects don't use the invalid gwDone.
@@ -414,8 +472,9 @@ func (s *Session) reconnectCtx(ctx context.Context) error {
 
 	// Start dispatching.
 	s.gwDone = ophandler.Loop(gwch, s.Handler)
+	s.setHealthGateway(s.gateway, s.gwDone)
 
-	ws.WSDebug("Voice reconnectCtx finished with no error")
+	ws.LogDebug("Voice reconnectCtx finished with no error")
 
 	return nil
 }
@@ -438,7 +497,8 @@ func (s *Session) spinGateway(ctx context.Context, gwch <-chan ws.Op) error {
 				return fmt.Errorf("voice gateway error: %w", err)
 
 			case *voicegateway.ReadyEvent:
-				ws.WSDebug("Got ready from voice gateway, SSRC:", data.SSRC)
+				ws.Debug("Got ready from voice gateway, SSRC:", data.SSRC)
+				logging.Logger().Debug("Got ready from voice gateway", "ssrc", data.SSRC)
 
 				// Prepare the UDP voice connection.
 				conn, err = s.udpManager.Dial(ctx, data.Addr(), data.SSRC)
@@ -462,7 +522,7 @@ func (s *Session) spinGateway(ctx context.Context, gwch <-chan ws.Op) error {
 					return errors.New("server bug: SessionDescription before Ready")
 				}
 
-				ws.WSDebug("Received secret key from voice gateway")
+				ws.LogDebug("Received secret key from voice gateway")
 
 				// We're done.
 				conn.UseSecret(data.SecretKey)
@@ -508,13 +568,85 @@ func (s *Session) ReadPacket() (*udp.Packet, error) {
 	return s.udpManager.ReadPacket()
 }
 
+// Health describes the health of a voice session.
+type Health struct {
+	// Connecting is true if the session is currently joining a channel or
+	// reconnecting. The other fields are zero if this is true.
+	Connecting bool
+	// Connected is true if the voice gateway is running and has received its
+	// Ready event.
+	Connected bool
+	// LastHeartbeatAck is the time that the voice gateway last acknowledged a
+	// heartbeat.
+	LastHeartbeatAck time.Time
+	// Latency is the latency of the last acknowledged heartbeat.
+	Latency time.Duration
+}
+
+// Health returns the current health of the voice session. It doesn't wait
+// for the session to finish joining a channel.
+func (s *Session) Health() Health {
+	s.health.Lock()
+	connecting := s.health.connecting
+	gateway := s.health.gateway
+	gwDone := s.health.gwDone
+	s.health.Unlock()
+
+	if connecting {
+		return Health{Connecting: true}
+	}
+
+	if gateway == nil || gateway.Ready() == nil {
+		return Health{}
+	}
+
+	select {
+	case <-gwDone:
+		return Health{}
+	default:
+	}
+
+	return Health{
+		Connected:        true,
+		LastHeartbeatAck: gateway.EchoBeat(),
+		Latency:          gateway.Latency(),
+	}
+}
+
+func (s *Session) setConnecting(connecting bool) {
+	s.health.Lock()
+	s.health.connecting = connecting
+	if connecting {
+		s.health.gateway = nil
+		s.health.gwDone = nil
+	}
+	s.health.Unlock()
+}
+
+func (s *Session) setHealthGateway(gateway *voicegateway.Gateway, gwDone <-chan struct{}) {
+	s.health.Lock()
+	s.health.gateway = gateway
+	s.health.gwDone = gwDone
+	s.health.Unlock()
+}
+
 // Leave disconnects the current voice session from the currently connected
 // channel.
 func (s *Session) Leave(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "discord.voice.leave")
+
+	err := s.leave(ctx)
+	span.End(err)
+
+	return err
+}
+
+func (s *Session) leave(ctx context.Context) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 
 	s.ensureClosed()
+	s.setHealthGateway(nil, nil)
 
 	// Unbind the handlers.
 	if s.detachReconnect != nil {
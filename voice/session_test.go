@@ -299,3 +299,21 @@ func testReconnect(t *testing.T, interrupt func(*testState)) error {
 
 	return testdata.WriteOpus(interruptWriter, testdata.Nico)
 }
+
+func TestSessionHealthLocked(t *testing.T) {
+	s := NewSessionCustom(nil, 1)
+
+	// Health doesn't guess from a busy mutex that the session is connecting.
+	s.mut.Lock()
+	h := s.Health()
+	s.mut.Unlock()
+
+	if h.Connecting || h.Connected {
+		t.Fatalf("unexpected health of an unjoined session: %+v", h)
+	}
+
+	s.setConnecting(true)
+	if h := s.Health(); !h.Connecting {
+		t.Fatalf("unexpected health while connecting: %+v", h)
+	}
+}
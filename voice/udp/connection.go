@@ -90,13 +90,26 @@ func DialConnectionCustom(
 		return nil, fmt.Errorf("failed to dial host: %w", err)
 	}
 
+	c, err := NewConnection(conn, ssrc)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// NewConnection creates a voice connection over an established connection to
+// the voice server, such as a UDP connection or an in-memory fake. It performs
+// IP discovery using the given SSRC number before returning.
+func NewConnection(conn net.Conn, ssrc uint32) (*Connection, error) {
 	// https://discord.com/developers/docs/topics/voice-connections#ip-discovery
 	var ssrcBuffer [74]byte
 	binary.BigEndian.PutUint16(ssrcBuffer[0:2], 1)
 	binary.BigEndian.PutUint16(ssrcBuffer[2:4], 70)
 	binary.BigEndian.PutUint32(ssrcBuffer[4:8], ssrc)
 
-	_, err = conn.Write(ssrcBuffer[:])
+	_, err := conn.Write(ssrcBuffer[:])
 	if err != nil {
 		return nil, fmt.Errorf("failed to write SSRC buffer: %w", err)
 	}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/diamondburned/arikawa/v3/utils/logging"
 	"github.com/diamondburned/arikawa/v3/utils/ws"
 )
 
@@ -95,11 +96,11 @@ func (m *Manager) Close() (err error) {
 	select {
 	case <-m.stopConn:
 		// m.stopConn already closed
-		ws.WSDebug("UDP manager already closed")
+		ws.LogDebug("UDP manager already closed")
 		return ErrManagerClosed
 	default:
 		close(m.stopConn)
-		ws.WSDebug("UDP manager closed")
+		ws.LogDebug("UDP manager closed")
 	}
 
 	return nil
@@ -114,7 +115,7 @@ func (m *Manager) IsClosed() bool {
 // successfully resumed, then true is returned, otherwise if it's already
 // continued, then false is returned.
 func (m *Manager) Continue() bool {
-	ws.WSDebug("UDP continued")
+	ws.LogDebug("UDP continued")
 
 	if m.prevConn != nil {
 		m.prevConn.Close()
@@ -161,7 +162,8 @@ func (m *Manager) Dial(ctx context.Context, addr string, ssrc uint32) (*Connecti
 	}
 
 	m.stopMu.Lock()
-	ws.WSDebug("setting UDP conn to one w/ gateway address", conn.GatewayIP)
+	ws.Debug("setting UDP conn to one w/ gateway address", conn.GatewayIP)
+	logging.Logger().Debug("setting UDP conn to one w/ gateway address", "address", conn.GatewayIP)
 	m.conn = conn
 	m.stopDial = nil
 	m.stopConn = make(chan struct{})
@@ -220,14 +222,14 @@ func (m *Manager) acquireConn() *Connection {
 
 	select {
 	case <-m.stopConn:
-		ws.WSDebug("UDP acquisition got stopped conn")
+		ws.LogDebug("UDP acquisition got stopped conn")
 		return nil
 	default:
 		// ok
 	}
 
 	if m.conn == nil {
-		ws.WSDebug("UDP acquisition got nil conn")
+		ws.LogDebug("UDP acquisition got nil conn")
 	}
 
 	return m.conn
@@ -44,8 +44,10 @@ type Gateway struct {
 	gateway *ws.Gateway
 	state   State // constant
 
-	mutex sync.RWMutex
-	ready *ReadyEvent
+	mutex    sync.RWMutex
+	ready    *ReadyEvent
+	echoBeat time.Time
+	latency  time.Duration
 }
 
 // DefaultGatewayOpts contains the default options to be used for connecting to
@@ -75,11 +77,18 @@ var DefaultGatewayOpts = ws.GatewayOpts{
 
 // New creates a new voice gateway.
 func New(state State) *Gateway {
+	return NewWithConnection(state, ws.NewConn(ws.NewCodec(OpUnmarshalers)))
+}
+
+// NewWithConnection creates a new voice gateway that uses the given websocket
+// connection. The connection is dialed with the gateway URL of the state's
+// endpoint.
+func NewWithConnection(state State, conn ws.Connection) *Gateway {
 	// https://discord.com/developers/docs/topics/voice-connections#establishing-a-voice-websocket-connection
 	endpoint := "wss://" + strings.TrimSuffix(state.Endpoint, ":80") + "/?v=" + Version
 
 	gw := ws.NewGateway(
-		ws.NewWebsocket(ws.NewCodec(OpUnmarshalers), endpoint),
+		ws.NewCustomWebsocket(conn, endpoint),
 		&DefaultGatewayOpts,
 	)
 
@@ -97,6 +106,23 @@ func (g *Gateway) Ready() *ReadyEvent {
 	return g.ready
 }
 
+// EchoBeat returns the last time that a heartbeat was acknowledged. It is zero
+// if no heartbeat has been acknowledged yet.
+func (g *Gateway) EchoBeat() time.Time {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	return g.echoBeat
+}
+
+// Latency returns the latency of the last acknowledged heartbeat.
+func (g *Gateway) Latency() time.Duration {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	return g.latency
+}
+
 // LastError returns the last error that the gateway has received. It only
 // returns a valid error if the gateway's event loop as exited. If the event
 // loop hasn't been started AND stopped, the function will panic.
@@ -190,6 +216,15 @@ func (g *gatewayImpl) OnOp(ctx context.Context, op ws.Op) bool {
 		g.mutex.Lock()
 		g.ready = data
 		g.mutex.Unlock()
+
+	case *HeartbeatAckEvent:
+		// The heartbeat nonce is the time that it was sent.
+		now := time.Now()
+
+		g.mutex.Lock()
+		g.echoBeat = now
+		g.latency = now.Sub(time.Unix(0, int64(*data)))
+		g.mutex.Unlock()
 	}
 
 	return true
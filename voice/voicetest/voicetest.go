@@ -0,0 +1,444 @@
+// Package voicetest provides an in-memory fake of Discord's voice servers, so
+// that code using voice sessions, such as music bot queueing, pacing and Opus
+// framing, can be tested without network access.
+//
+// A Fake implements voice.MainSession and answers voice state updates itself.
+// Sessions created using Fake.Session then connect to a fake voice gateway and
+// write their audio into a null UDP transport that records every frame:
+//
+//	f := voicetest.New()
+//	v := f.Session()
+//
+//	if err := v.JoinChannelAndSpeak(ctx, f.ChannelID, false, false); err != nil {
+//		return err
+//	}
+//
+//	v.Write(opusFrame)
+//	frames, err := f.WaitFrames(ctx, 1)
+package voicetest
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"golang.org/x/crypto/nacl/secretbox"
+
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/utils/handler"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+	"github.com/diamondburned/arikawa/v3/voice"
+	"github.com/diamondburned/arikawa/v3/voice/udp"
+	"github.com/diamondburned/arikawa/v3/voice/voicegateway"
+)
+
+// Fake voice server parameters.
+const (
+	FakeEndpoint = "voicetest.invalid"
+	FakeIP       = "127.0.0.1"
+	FakePort     = 50000
+)
+
+// Frame is a decrypted audio frame that a session wrote.
+type Frame struct {
+	SSRC      uint32
+	Sequence  uint16
+	Timestamp uint32
+	Opus      []byte
+}
+
+// Fake is a fake main session and voice server. All its methods are
+// thread-safe.
+type Fake struct {
+	*handler.Handler
+
+	// User is the current user.
+	User discord.User
+	// GuildID and ChannelID are the guild and voice channel that sessions can
+	// join. Channel returns a voice channel in GuildID for any channel ID.
+	GuildID   discord.GuildID
+	ChannelID discord.ChannelID
+
+	// SSRC and SecretKey are given to sessions when they connect to the fake
+	// voice gateway.
+	SSRC      uint32
+	SecretKey [32]byte
+
+	mutex    sync.Mutex
+	updates  []*gateway.UpdateVoiceStateCommand
+	commands []ws.Event
+	frames   []Frame
+	notify   chan struct{} // closed and replaced when anything is recorded
+}
+
+var _ voice.MainSession = (*Fake)(nil)
+
+// New creates a new Fake.
+func New() *Fake {
+	f := &Fake{
+		Handler: handler.New(),
+		User: discord.User{
+			ID:       100,
+			Username: "voicetest",
+			Bot:      true,
+		},
+		GuildID:   200,
+		ChannelID: 300,
+		SSRC:      400,
+		notify:    make(chan struct{}),
+	}
+
+	for i := range f.SecretKey {
+		f.SecretKey[i] = byte(i)
+	}
+
+	return f
+}
+
+// Session creates a new voice session that uses the Fake as its main session,
+// voice gateway and UDP transport.
+func (f *Fake) Session() *voice.Session {
+	s := voice.NewSessionCustom(f, f.User.ID)
+	s.SetUDPDialer(f.DialUDP)
+	s.SetGatewayConnection(func(voicegateway.State) ws.Connection {
+		return f.GatewayConnection()
+	})
+	return s
+}
+
+// AddHandler implements voice.MainSession. Handlers are added as synchronous
+// handlers, so that the events sent by the Fake are handled in a
+// deterministic order.
+func (f *Fake) AddHandler(h interface{}) (rm func()) {
+	return f.Handler.AddSyncHandler(h)
+}
+
+// Me implements voice.MainSession.
+func (f *Fake) Me() (*discord.User, error) {
+	u := f.User
+	return &u, nil
+}
+
+// Channel implements voice.MainSession.
+func (f *Fake) Channel(id discord.ChannelID) (*discord.Channel, error) {
+	return &discord.Channel{
+		ID:      id,
+		GuildID: f.GuildID,
+		Type:    discord.GuildVoice,
+		Name:    "voicetest",
+	}, nil
+}
+
+// SendGateway implements voice.MainSession. Voice state updates are recorded
+// and, if they join a channel, answered with the voice state and voice server
+// update events that Discord would send.
+func (f *Fake) SendGateway(ctx context.Context, ev ws.Event) error {
+	cmd, ok := ev.(*gateway.UpdateVoiceStateCommand)
+	if !ok {
+		return nil
+	}
+
+	f.record(func() { f.updates = append(f.updates, cmd) })
+
+	if !cmd.ChannelID.IsValid() {
+		return nil
+	}
+
+	// Discord answers asynchronously.
+	go func() {
+		f.Handler.Call(&gateway.VoiceStateUpdateEvent{
+			VoiceState: discord.VoiceState{
+				GuildID:   cmd.GuildID,
+				ChannelID: cmd.ChannelID,
+				UserID:    f.User.ID,
+				SessionID: "voicetest",
+				SelfMute:  cmd.SelfMute,
+				SelfDeaf:  cmd.SelfDeaf,
+			},
+		})
+		f.Handler.Call(&gateway.VoiceServerUpdateEvent{
+			Token:    "voicetest",
+			GuildID:  cmd.GuildID,
+			Endpoint: FakeEndpoint,
+		})
+	}()
+
+	return nil
+}
+
+// VoiceStateUpdates returns the voice state updates sent by sessions, which
+// includes joining and leaving channels.
+func (f *Fake) VoiceStateUpdates() []*gateway.UpdateVoiceStateCommand {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]*gateway.UpdateVoiceStateCommand(nil), f.updates...)
+}
+
+// Commands returns the commands sent to the fake voice gateway.
+func (f *Fake) Commands() []ws.Event {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]ws.Event(nil), f.commands...)
+}
+
+// Speaking returns the speaking flag of the last Speaking command sent to the
+// fake voice gateway.
+func (f *Fake) Speaking() voicegateway.SpeakingFlag {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for i := len(f.commands) - 1; i >= 0; i-- {
+		if speaking, ok := f.commands[i].(*voicegateway.SpeakingEvent); ok {
+			return speaking.Speaking
+		}
+	}
+
+	return voicegateway.NotSpeaking
+}
+
+// Frames returns the audio frames written so far.
+func (f *Fake) Frames() []Frame {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]Frame(nil), f.frames...)
+}
+
+// WaitFrames waits until at least n audio frames have been written and returns
+// all of them.
+func (f *Fake) WaitFrames(ctx context.Context, n int) ([]Frame, error) {
+	for {
+		f.mutex.Lock()
+		frames := append([]Frame(nil), f.frames...)
+		notify := f.notify
+		f.mutex.Unlock()
+
+		if len(frames) >= n {
+			return frames, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return frames, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+func (f *Fake) record(fn func()) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	fn()
+	close(f.notify)
+	f.notify = make(chan struct{})
+}
+
+// DialUDP is a udp.DialFunc that connects to the fake voice server over an
+// in-memory pipe instead of the network.
+func (f *Fake) DialUDP(ctx context.Context, addr string, ssrc uint32) (*udp.Connection, error) {
+	client, server := net.Pipe()
+	go f.serveUDP(server)
+
+	conn, err := udp.NewConnection(client, ssrc)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (f *Fake) serveUDP(conn net.Conn) {
+	defer conn.Close()
+
+	// https://discord.com/developers/docs/topics/voice-connections#ip-discovery
+	var discovery [74]byte
+	if _, err := io.ReadFull(conn, discovery[:]); err != nil {
+		return
+	}
+
+	binary.BigEndian.PutUint16(discovery[0:2], 2)
+	copy(discovery[8:72], FakeIP)
+	binary.LittleEndian.PutUint16(discovery[72:74], FakePort)
+
+	if _, err := conn.Write(discovery[:]); err != nil {
+		return
+	}
+
+	buf := make([]byte, 1500)
+
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return
+		}
+
+		if n < 12 {
+			continue
+		}
+
+		var nonce [24]byte
+		copy(nonce[:12], buf[:12])
+
+		opus, ok := secretbox.Open(nil, buf[12:n], &nonce, &f.SecretKey)
+		if !ok {
+			continue
+		}
+
+		f.record(func() {
+			f.frames = append(f.frames, Frame{
+				SSRC:      binary.BigEndian.Uint32(buf[8:12]),
+				Sequence:  binary.BigEndian.Uint16(buf[2:4]),
+				Timestamp: binary.BigEndian.Uint32(buf[4:8]),
+				Opus:      opus,
+			})
+		})
+	}
+}
+
+// GatewayConnection returns a new in-memory websocket connection to the fake
+// voice gateway.
+func (f *Fake) GatewayConnection() ws.Connection {
+	return &gatewayConn{
+		fake:  f,
+		codec: ws.NewCodec(voicegateway.OpUnmarshalers),
+	}
+}
+
+var errConnClosed = errors.New("voicetest: connection is closed")
+
+type gatewayConn struct {
+	fake  *Fake
+	codec ws.Codec
+
+	mutex   sync.Mutex
+	ops     chan ws.Op
+	closed  chan struct{}
+	replies *sync.WaitGroup // replies in flight, so ops isn't closed under them
+}
+
+func (c *gatewayConn) Dial(ctx context.Context, addr string) (<-chan ws.Op, error) {
+	c.mutex.Lock()
+	c.ops = make(chan ws.Op, 64)
+	c.closed = make(chan struct{})
+	c.replies = &sync.WaitGroup{}
+	ops := c.ops
+	c.mutex.Unlock()
+
+	c.reply(&voicegateway.HelloEvent{HeartbeatInterval: 41250})
+	return ops, nil
+}
+
+// reply sends ev to the client. c.mutex must not be held, since the send
+// blocks until the client reads it or the connection is closed.
+func (c *gatewayConn) reply(ev ws.Event) {
+	c.mutex.Lock()
+	ops, closed, replies := c.ops, c.closed, c.replies
+	if ops == nil {
+		c.mutex.Unlock()
+		return
+	}
+	replies.Add(1)
+	c.mutex.Unlock()
+
+	defer replies.Done()
+
+	select {
+	case ops <- ws.Op{Code: ev.Op(), Type: ev.EventType(), Data: ev}:
+	case <-closed:
+	}
+}
+
+func (c *gatewayConn) Send(ctx context.Context, b []byte) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	buf := ws.NewDecodeBuffer(len(b))
+
+	// A command may decode into more than one op, such as when
+	// ws.EnableRawEvents is true, so all of them are drained.
+	ops := make(chan ws.Op)
+	go func() {
+		c.codec.DecodeInto(ctx, bytes.NewReader(b), &buf, ops)
+		close(ops)
+	}()
+
+	for op := range ops {
+		switch data := op.Data.(type) {
+		case *ws.RawEvent:
+			continue
+		case *ws.BackgroundErrorEvent:
+			return fmt.Errorf("voicetest: invalid command: %w", data)
+		}
+
+		if err := c.handle(op); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handle records the command and replies to it if needed.
+func (c *gatewayConn) handle(op ws.Op) error {
+	c.mutex.Lock()
+	closed := c.ops == nil
+	c.mutex.Unlock()
+
+	if closed {
+		return errConnClosed
+	}
+
+	c.fake.record(func() { c.fake.commands = append(c.fake.commands, op.Data) })
+
+	switch data := op.Data.(type) {
+	case *voicegateway.IdentifyCommand:
+		c.reply(&voicegateway.ReadyEvent{
+			SSRC:  c.fake.SSRC,
+			IP:    FakeIP,
+			Port:  FakePort,
+			Modes: []string{voice.Protocol},
+		})
+	case *voicegateway.SelectProtocolCommand:
+		c.reply(&voicegateway.SessionDescriptionEvent{
+			Mode:      data.Data.Mode,
+			SecretKey: c.fake.SecretKey,
+		})
+	case *voicegateway.ResumeCommand:
+		c.reply(&voicegateway.ResumedEvent{})
+	case *voicegateway.HeartbeatCommand:
+		ack := voicegateway.HeartbeatAckEvent(*data)
+		c.reply(&ack)
+	}
+
+	return nil
+}
+
+func (c *gatewayConn) Close(gracefully bool) error {
+	c.mutex.Lock()
+
+	if c.ops == nil {
+		c.mutex.Unlock()
+		return ws.ErrWebsocketClosed
+	}
+
+	ops, replies := c.ops, c.replies
+	close(c.closed)
+	c.ops = nil
+	c.mutex.Unlock()
+
+	// Pending replies return once closed is closed.
+	replies.Wait()
+	close(ops)
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package voicetest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/diamondburned/arikawa/v3/utils/json"
+	"github.com/diamondburned/arikawa/v3/utils/ws"
+	"github.com/diamondburned/arikawa/v3/voice/voicegateway"
+)
+
+func TestFake(t *testing.T) {
+	f := New()
+	s := f.Session()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.JoinChannelAndSpeak(ctx, f.ChannelID, false, true); err != nil {
+		t.Fatal("failed to join:", err)
+	}
+
+	if h := s.Health(); !h.Connected || h.Connecting {
+		t.Fatalf("unexpected health after joining: %+v", h)
+	}
+
+	if speaking := f.Speaking(); speaking != voicegateway.Microphone {
+		t.Fatalf("unexpected speaking flag %v", speaking)
+	}
+
+	frames := [][]byte{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+
+	start := time.Now()
+	for _, frame := range frames {
+		if _, err := s.Write(frame); err != nil {
+			t.Fatal("failed to write:", err)
+		}
+	}
+
+	// Writes are paced at 20ms per frame.
+	if elapsed := time.Since(start); elapsed < 2*20*time.Millisecond {
+		t.Errorf("writes were not paced, took %v", elapsed)
+	}
+
+	got, err := f.WaitFrames(ctx, len(frames))
+	if err != nil {
+		t.Fatal("failed to wait for frames:", err)
+	}
+
+	for i, frame := range got {
+		if !bytes.Equal(frame.Opus, frames[i]) {
+			t.Errorf("frame %d has Opus %v, expected %v", i, frame.Opus, frames[i])
+		}
+		if frame.SSRC != f.SSRC {
+			t.Errorf("frame %d has SSRC %d, expected %d", i, frame.SSRC, f.SSRC)
+		}
+		if i > 0 && frame.Timestamp-got[i-1].Timestamp != 960 {
+			t.Errorf("frame %d has timestamp %d after %d", i, frame.Timestamp, got[i-1].Timestamp)
+		}
+	}
+
+	if err := s.Leave(ctx); err != nil {
+		t.Fatal("failed to leave:", err)
+	}
+
+	if h := s.Health(); h.Connected || h.Connecting {
+		t.Fatalf("unexpected health after leaving: %+v", h)
+	}
+
+	updates := f.VoiceStateUpdates()
+	if len(updates) != 2 {
+		t.Fatalf("expected 2 voice state updates, got %d", len(updates))
+	}
+
+	if join := updates[0]; join.ChannelID != f.ChannelID || !join.SelfDeaf {
+		t.Errorf("unexpected join update %+v", join)
+	}
+
+	if leave := updates[1]; leave.ChannelID.IsValid() {
+		t.Errorf("unexpected leave update %+v", leave)
+	}
+}
+
+func TestGatewayConnCloseDuringReply(t *testing.T) {
+	conn := New().GatewayConnection()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := conn.Dial(ctx, ""); err != nil {
+		t.Fatal("failed to dial:", err)
+	}
+
+	heartbeat := voicegateway.HeartbeatCommand(1)
+	b, err := json.Marshal(ws.Op{Code: heartbeat.Op(), Data: &heartbeat})
+	if err != nil {
+		t.Fatal("failed to encode heartbeat:", err)
+	}
+
+	// The client never reads, so the replies fill up the buffer and the last
+	// Send blocks until Close.
+	sent := make(chan error, 1)
+	go func() {
+		for i := 0; i < 100; i++ {
+			if err := conn.Send(ctx, b); err != nil {
+				sent <- err
+				return
+			}
+		}
+		sent <- nil
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan error, 1)
+	go func() { closed <- conn.Close(false) }()
+
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatal("failed to close:", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("Close blocked on a pending reply")
+	}
+
+	select {
+	case err := <-sent:
+		if err != nil && !errors.Is(err, errConnClosed) {
+			t.Fatal("unexpected send error:", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("Send blocked after Close")
+	}
+}